@@ -18,6 +18,24 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	BasicAuthScopes = "basicAuth.Scopes"
+)
+
+// Defines values for AssertionKeyAlg.
+const (
+	ES256 AssertionKeyAlg = "ES256"
+	EdDSA AssertionKeyAlg = "EdDSA"
+	RS256 AssertionKeyAlg = "RS256"
+)
+
+// Defines values for AssertionKeyKty.
+const (
+	EC  AssertionKeyKty = "EC"
+	OKP AssertionKeyKty = "OKP"
+	RSA AssertionKeyKty = "RSA"
+)
+
 // Defines values for AuthExchangeResponseTokenType.
 const (
 	AuthExchangeResponseTokenTypeBearer AuthExchangeResponseTokenType = "Bearer"
@@ -39,16 +57,116 @@ const (
 	Ok HealthResponseStatus = "ok"
 )
 
-// AuthExchangeRequest defines model for AuthExchangeRequest.
-type AuthExchangeRequest struct {
-	// ExternalUserId User ID as known by the customer platform
-	ExternalUserId string `json:"external_user_id"`
+// Defines values for IdentityState.
+const (
+	IdentityStateActive    IdentityState = "active"
+	IdentityStateBanned    IdentityState = "banned"
+	IdentityStateSuspended IdentityState = "suspended"
+)
 
-	// Provider Identifier of the customer platform or auth provider
-	Provider string `json:"provider"`
+// Defines values for IntrospectionResponseSessionStatus.
+const (
+	IntrospectionResponseSessionStatusActive  IntrospectionResponseSessionStatus = "active"
+	IntrospectionResponseSessionStatusRevoked IntrospectionResponseSessionStatus = "revoked"
+	IntrospectionResponseSessionStatusUnknown IntrospectionResponseSessionStatus = "unknown"
+)
 
-	// Tenant Optional tenant context
-	Tenant *string `json:"tenant,omitempty"`
+// Defines values for ProfileAttributeSource.
+const (
+	Api      ProfileAttributeSource = "api"
+	Exchange ProfileAttributeSource = "exchange"
+)
+
+// Defines values for ProfileField.
+const (
+	BirthYear   ProfileField = "birth_year"
+	Country     ProfileField = "country"
+	DisplayName ProfileField = "display_name"
+	Locale      ProfileField = "locale"
+)
+
+// Defines values for ProviderStatus.
+const (
+	ProviderStatusActive   ProviderStatus = "active"
+	ProviderStatusDisabled ProviderStatus = "disabled"
+)
+
+// Defines values for SigningKeyState.
+const (
+	Active   SigningKeyState = "active"
+	Next     SigningKeyState = "next"
+	Retired  SigningKeyState = "retired"
+	Retiring SigningKeyState = "retiring"
+)
+
+// Defines values for StatusReason.
+const (
+	Abuse          StatusReason = "abuse"
+	AppealGranted  StatusReason = "appeal_granted"
+	Chargeback     StatusReason = "chargeback"
+	Cheating       StatusReason = "cheating"
+	Fraud          StatusReason = "fraud"
+	Other          StatusReason = "other"
+	TermsViolation StatusReason = "terms_violation"
+	Underage       StatusReason = "underage"
+)
+
+// Defines values for TokenExchangeResponseTokenType.
+const (
+	TokenExchangeResponseTokenTypeBearer TokenExchangeResponseTokenType = "Bearer"
+)
+
+// AssertionKey Public JWK (OKP Ed25519, EC P-256 or RSA of at least 2048 bits)
+type AssertionKey struct {
+	Alg *AssertionKeyAlg `json:"alg,omitempty"`
+	Crv *string          `json:"crv,omitempty"`
+	E   *string          `json:"e,omitempty"`
+
+	// Kid Required when the provider has several keys
+	Kid *string         `json:"kid,omitempty"`
+	Kty AssertionKeyKty `json:"kty"`
+	N   *string         `json:"n,omitempty"`
+	X   *string         `json:"x,omitempty"`
+	Y   *string         `json:"y,omitempty"`
+}
+
+// AssertionKeyAlg defines model for AssertionKey.Alg.
+type AssertionKeyAlg string
+
+// AssertionKeyKty defines model for AssertionKey.Kty.
+type AssertionKeyKty string
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	// Action identity.merged or identity.erased
+	Action string `json:"action"`
+
+	// Actor Internal caller that made the change
+	Actor          *string            `json:"actor,omitempty"`
+	Details        *map[string]string `json:"details,omitempty"`
+	Id             string             `json:"id"`
+	PlatformUserId openapi_types.UUID `json:"platform_user_id"`
+	RecordedAt     time.Time          `json:"recorded_at"`
+}
+
+// AuthExchangeRequest defines model for AuthExchangeRequest.
+type AuthExchangeRequest struct {
+	// Assertion Compact JWS signed by the customer backend with a key registered
+	// for its provider (EdDSA, ES256 or RS256). Claims: iss (provider),
+	// sub (external user ID), aud (the identity service), iat, exp, jti
+	// and an optional tenant. The lifetime exp - iat is capped and every
+	// jti is accepted only once.
+	Assertion string `json:"assertion"`
+
+	// Profile Optional profile attributes. Supplied with an auth exchange, they
+	// are recorded as of the assertion's iat with source exchange and
+	// only replace exchange values that are not newer.
+	Profile *UserProfile `json:"profile,omitempty"`
+
+	// Scope Optional space-separated subset of the scopes the scope policy
+	// grants. Defaults to every granted scope; requesting a scope that
+	// is not granted fails with INVALID_SCOPE.
+	Scope *string `json:"scope,omitempty"`
 }
 
 // AuthExchangeResponse defines model for AuthExchangeResponse.
@@ -59,18 +177,35 @@ type AuthExchangeResponse struct {
 	ExpiresIn int32 `json:"expires_in"`
 
 	// PlatformUserId Proteon platform user ID (stable across exchanges)
-	PlatformUserId openapi_types.UUID            `json:"platform_user_id"`
-	TokenType      AuthExchangeResponseTokenType `json:"token_type"`
+	PlatformUserId openapi_types.UUID `json:"platform_user_id"`
+
+	// RefreshExpiresIn Refresh token lifetime in seconds
+	RefreshExpiresIn int32 `json:"refresh_expires_in"`
+
+	// RefreshToken Opaque, single-use refresh token for POST /v1/auth/refresh
+	RefreshToken string `json:"refresh_token"`
+
+	// Scope Space-separated scopes granted to the token; omitted when none
+	Scope     *string                       `json:"scope,omitempty"`
+	TokenType AuthExchangeResponseTokenType `json:"token_type"`
 }
 
 // AuthExchangeResponseTokenType defines model for AuthExchangeResponse.TokenType.
 type AuthExchangeResponseTokenType string
 
+// AuthRefreshRequest defines model for AuthRefreshRequest.
+type AuthRefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
 // BackofficeTokenRequest defines model for BackofficeTokenRequest.
 type BackofficeTokenRequest struct {
 	// Audience Optional audience override (defaults to \"backoffice\")
 	Audience *string `json:"audience,omitempty"`
 
+	// Scope Optional space-separated subset of the granted scopes
+	Scope *string `json:"scope,omitempty"`
+
 	// SubjectType Type of backoffice user (operator or tenant_user)
 	SubjectType BackofficeTokenRequestSubjectType `json:"subject_type"`
 
@@ -89,13 +224,25 @@ type BackofficeTokenResponse struct {
 	AccessToken string `json:"access_token"`
 
 	// ExpiresIn Token lifetime in seconds
-	ExpiresIn int32                            `json:"expires_in"`
+	ExpiresIn int32 `json:"expires_in"`
+
+	// Scope Space-separated scopes granted to the token; omitted when none
+	Scope     *string                          `json:"scope,omitempty"`
 	TokenType BackofficeTokenResponseTokenType `json:"token_type"`
 }
 
 // BackofficeTokenResponseTokenType defines model for BackofficeTokenResponse.TokenType.
 type BackofficeTokenResponseTokenType string
 
+// ErasureResponse defines model for ErasureResponse.
+type ErasureResponse struct {
+	ErasedAt time.Time `json:"erased_at"`
+
+	// MergedUserIds Platform user IDs merged into the identity, erased with it
+	MergedUserIds  []openapi_types.UUID `json:"merged_user_ids"`
+	PlatformUserId openapi_types.UUID   `json:"platform_user_id"`
+}
+
 // ErrorBody defines model for ErrorBody.
 type ErrorBody struct {
 	// Code Stable machine-readable error code
@@ -126,6 +273,80 @@ type HealthResponse struct {
 // HealthResponseStatus defines model for HealthResponse.Status.
 type HealthResponseStatus string
 
+// IdentityProfile Profile attributes that are set, each with its source
+type IdentityProfile struct {
+	BirthYear   *ProfileAttribute `json:"birth_year,omitempty"`
+	Country     *ProfileAttribute `json:"country,omitempty"`
+	DisplayName *ProfileAttribute `json:"display_name,omitempty"`
+	Locale      *ProfileAttribute `json:"locale,omitempty"`
+}
+
+// IdentityState defines model for IdentityState.
+type IdentityState string
+
+// IdentityStatus Lifecycle status in effect; a suspension that has ended reads as active
+type IdentityStatus struct {
+	// ChangedAt When the status took effect; absent for identities that were
+	// never suspended or banned
+	ChangedAt *time.Time `json:"changed_at,omitempty"`
+
+	// Reason Reason code of a status change
+	Reason *StatusReason `json:"reason,omitempty"`
+	State  IdentityState `json:"state"`
+
+	// Until End of a suspension
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// IdentityStatusRequest defines model for IdentityStatusRequest.
+type IdentityStatusRequest struct {
+	// Note Free text recorded in the audit log
+	Note *string `json:"note,omitempty"`
+
+	// Reason Reason code of a status change
+	Reason *StatusReason `json:"reason,omitempty"`
+	State  IdentityState `json:"state"`
+
+	// Until End of the suspension; required for suspended, not allowed otherwise
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// IntrospectionRequest defines model for IntrospectionRequest.
+type IntrospectionRequest struct {
+	Token string `json:"token"`
+
+	// TokenTypeHint Ignored; only access tokens can be introspected
+	TokenTypeHint *string `json:"token_type_hint,omitempty"`
+}
+
+// IntrospectionResponse defines model for IntrospectionResponse.
+type IntrospectionResponse struct {
+	// Act act claim of a delegated token (RFC 8693): the service acting for
+	// the subject and, nested, the actor before it.
+	Act    *TokenActor `json:"act,omitempty"`
+	Active bool        `json:"active"`
+
+	// Exp Expiry as seconds since the epoch
+	Exp *int64 `json:"exp,omitempty"`
+	Iat *int64 `json:"iat,omitempty"`
+
+	// Scope Space-separated scopes
+	Scope *string `json:"scope,omitempty"`
+
+	// SessionStatus Revocation state of the token's session. unknown for tokens
+	// without sid or whose session this instance does not know.
+	SessionStatus *IntrospectionResponseSessionStatus `json:"session_status,omitempty"`
+	Sid           *string                             `json:"sid,omitempty"`
+	Sub           *string                             `json:"sub,omitempty"`
+	SubjectType   *string                             `json:"subject_type,omitempty"`
+	Tenant        *string                             `json:"tenant,omitempty"`
+	TokenType     *string                             `json:"token_type,omitempty"`
+}
+
+// IntrospectionResponseSessionStatus Revocation state of the token's session. unknown for tokens
+// without sid or whose session this instance does not know.
+type IntrospectionResponseSessionStatus string
+
 // Jwk defines model for Jwk.
 type Jwk struct {
 	Alg                  *string                `json:"alg,omitempty"`
@@ -140,18 +361,377 @@ type JwksResponse struct {
 	Keys []Jwk `json:"keys"`
 }
 
+// Linkage defines model for Linkage.
+type Linkage struct {
+	ExternalUserId string    `json:"external_user_id"`
+	LinkedAt       time.Time `json:"linked_at"`
+	Provider       string    `json:"provider"`
+
+	// Tenant Tenant asserted when the linkage was made
+	Tenant *string `json:"tenant,omitempty"`
+}
+
+// LinkageRequest defines model for LinkageRequest.
+type LinkageRequest struct {
+	ExternalUserId string `json:"external_user_id"`
+
+	// Provider ID of a registered provider
+	Provider string `json:"provider"`
+
+	// Tenant Tenant of the external identity; defaults to, and must equal,
+	// the tenant of the platform identity. Must be allowed by the
+	// provider when it restricts tenants.
+	Tenant *string `json:"tenant,omitempty"`
+}
+
+// MergeRequest defines model for MergeRequest.
+type MergeRequest struct {
+	// MergedUserId Platform user ID of the duplicate to merge into the user
+	MergedUserId openapi_types.UUID `json:"merged_user_id"`
+
+	// Reason Why the identities are merged; recorded in the audit log
+	Reason *string `json:"reason,omitempty"`
+}
+
+// OpenidConfiguration defines model for OpenidConfiguration.
+type OpenidConfiguration struct {
+	// AccessTokenSigningAlgValuesSupported Algorithms of the published signing keys. Identity issues no ID
+	// tokens, so id_token_signing_alg_values_supported is not
+	// advertised.
+	AccessTokenSigningAlgValuesSupported []string `json:"access_token_signing_alg_values_supported"`
+
+	// AuthorizationEndpoint The backoffice authorization code endpoint
+	// (OAUTH_AUTHORIZATION_ENDPOINT); omitted when not configured
+	AuthorizationEndpoint         *string   `json:"authorization_endpoint,omitempty"`
+	ClaimsSupported               []string  `json:"claims_supported"`
+	CodeChallengeMethodsSupported *[]string `json:"code_challenge_methods_supported,omitempty"`
+
+	// GrantTypesSupported urn:ietf:params:oauth:grant-type:token-exchange, and
+	// authorization_code when the authorization endpoint is configured
+	GrantTypesSupported                       []string `json:"grant_types_supported"`
+	IntrospectionEndpoint                     string   `json:"introspection_endpoint"`
+	IntrospectionEndpointAuthMethodsSupported []string `json:"introspection_endpoint_auth_methods_supported"`
+
+	// Issuer The iss claim of every access token (JWT_ISSUER)
+	Issuer  string `json:"issuer"`
+	JwksUri string `json:"jwks_uri"`
+
+	// ResponseTypesSupported code, when the authorization endpoint is configured; omitted
+	// otherwise
+	ResponseTypesSupported *[]string `json:"response_types_supported,omitempty"`
+
+	// SubjectTypesSupported public, when the authorization endpoint is configured; omitted
+	// otherwise
+	SubjectTypesSupported *[]string `json:"subject_types_supported,omitempty"`
+
+	// TokenEndpoint The backoffice token endpoint (OAUTH_TOKEN_ENDPOINT); omitted
+	// when not configured
+	TokenEndpoint *string `json:"token_endpoint,omitempty"`
+
+	// TokenExchangeEndpoint The RFC 8693 token exchange endpoint for internal services, which
+	// authenticate with their service credentials
+	TokenExchangeEndpoint string `json:"token_exchange_endpoint"`
+}
+
 // PlatformIdentityResponse defines model for PlatformIdentityResponse.
 type PlatformIdentityResponse struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExternalUserId External user ID of the primary linkage
+	// Deprecated: Use linkages, which lists every linked external identity
+	ExternalUserId string `json:"external_user_id"`
+
+	// Linkages Linked external identities, oldest first. The first is the
+	// primary linkage: the one the identity was created from, or the
+	// oldest remaining one.
+	Linkages       []Linkage          `json:"linkages"`
+	PlatformUserId openapi_types.UUID `json:"platform_user_id"`
+
+	// Profile Profile attributes that are set, each with its source
+	Profile *IdentityProfile `json:"profile,omitempty"`
+
+	// Provider Provider of the primary linkage
+	// Deprecated: Use linkages, which lists every linked external identity
+	Provider string `json:"provider"`
+
+	// Status Lifecycle status in effect; a suspension that has ended reads as active
+	Status IdentityStatus `json:"status"`
+
+	// Tenant Tenant the identity was created in
+	Tenant *string `json:"tenant,omitempty"`
+}
+
+// ProfileAttribute defines model for ProfileAttribute.
+type ProfileAttribute struct {
+	// Source exchange for values supplied with an auth exchange, api for
+	// values set through PATCH /v1/users/{userId}
+	Source ProfileAttributeSource `json:"source"`
+
+	// UpdatedAt Assertion iat for exchange values, update time for api values
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Value Attribute value; birth years are decimal
+	Value string `json:"value"`
+}
+
+// ProfileAttributeSource exchange for values supplied with an auth exchange, api for
+// values set through PATCH /v1/users/{userId}
+type ProfileAttributeSource string
+
+// ProfileField defines model for ProfileField.
+type ProfileField string
+
+// Provider defines model for Provider.
+type Provider struct {
+	// AllowedTenants Tenants assertions may carry; empty allows any tenant
+	AllowedTenants []string `json:"allowed_tenants"`
+
+	// AssertionKeys Public keys the provider signs identity assertions with
+	AssertionKeys []AssertionKey `json:"assertion_keys"`
+	CreatedAt     time.Time      `json:"created_at"`
+	DisplayName   string         `json:"display_name"`
+
+	// Id Value of the iss claim of the provider's assertions
+	Id string `json:"id"`
+
+	// Status Defaults to active on create
+	Status        ProviderStatus        `json:"status"`
+	TokenSettings ProviderTokenSettings `json:"token_settings"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+// ProviderCreateRequest defines model for ProviderCreateRequest.
+type ProviderCreateRequest struct {
+	// AllowedTenants Tenants assertions may carry; empty allows any tenant
+	AllowedTenants *[]string `json:"allowed_tenants,omitempty"`
+
+	// AssertionKeys Public keys the provider signs identity assertions with
+	AssertionKeys *[]AssertionKey `json:"assertion_keys,omitempty"`
+	DisplayName   *string         `json:"display_name,omitempty"`
+	Id            string          `json:"id"`
+
+	// Status Defaults to active on create
+	Status        *ProviderStatus        `json:"status,omitempty"`
+	TokenSettings *ProviderTokenSettings `json:"token_settings,omitempty"`
+}
+
+// ProviderListResponse defines model for ProviderListResponse.
+type ProviderListResponse struct {
+	Providers []Provider `json:"providers"`
+}
+
+// ProviderStatus Defaults to active on create
+type ProviderStatus string
+
+// ProviderTokenSettings defines model for ProviderTokenSettings.
+type ProviderTokenSettings struct {
+	// AccessTokenTtl Player access token lifetime in seconds; 0 or omitted uses the
+	// default. At least 60 and at most the longest configured access
+	// token lifetime.
+	AccessTokenTtl *int32 `json:"access_token_ttl,omitempty"`
+
+	// ProfileClaims Profile attributes copied into the provider's player access
+	// tokens when set: display_name as name, locale, country and
+	// birth_year (a number).
+	ProfileClaims *[]ProfileField `json:"profile_claims,omitempty"`
+
+	// RefreshTokenTtl Player refresh token and session lifetime in seconds; 0 or omitted
+	// uses the default. May shorten but not extend REFRESH_TOKEN_TTL.
+	RefreshTokenTtl *int32 `json:"refresh_token_ttl,omitempty"`
+}
+
+// ProviderUpdateRequest defines model for ProviderUpdateRequest.
+type ProviderUpdateRequest struct {
+	// AllowedTenants Tenants assertions may carry; empty allows any tenant
+	AllowedTenants *[]string `json:"allowed_tenants,omitempty"`
+
+	// AssertionKeys Public keys the provider signs identity assertions with
+	AssertionKeys *[]AssertionKey `json:"assertion_keys,omitempty"`
+	DisplayName   *string         `json:"display_name,omitempty"`
+
+	// Status Defaults to active on create
+	Status        *ProviderStatus        `json:"status,omitempty"`
+	TokenSettings *ProviderTokenSettings `json:"token_settings,omitempty"`
+}
+
+// Revocation defines model for Revocation.
+type Revocation struct {
+	// ExpiresAt After this time no access token of the session is valid anyway
+	ExpiresAt      time.Time          `json:"expires_at"`
+	PlatformUserId openapi_types.UUID `json:"platform_user_id"`
+	RevokedAt      time.Time          `json:"revoked_at"`
+	Sid            string             `json:"sid"`
+}
+
+// RevocationListResponse defines model for RevocationListResponse.
+type RevocationListResponse struct {
+	// AsOf Pass as since on the next poll
+	AsOf        time.Time    `json:"as_of"`
+	Revocations []Revocation `json:"revocations"`
+}
+
+// Session defines model for Session.
+type Session struct {
 	CreatedAt      time.Time          `json:"created_at"`
-	ExternalUserId string             `json:"external_user_id"`
+	ExpiresAt      time.Time          `json:"expires_at"`
 	PlatformUserId openapi_types.UUID `json:"platform_user_id"`
-	Provider       string             `json:"provider"`
-	Tenant         *string            `json:"tenant,omitempty"`
+
+	// Provider Provider of a player session
+	Provider     *string    `json:"provider,omitempty"`
+	RevokeReason *string    `json:"revoke_reason,omitempty"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+
+	// Scope Space-separated scopes of the session's tokens
+	Scope *string `json:"scope,omitempty"`
+
+	// SessionId Value of the "sid" claim of the session's access tokens
+	SessionId string `json:"session_id"`
+
+	// SubjectType player, operator or tenant_user
+	SubjectType string  `json:"subject_type"`
+	Tenant      *string `json:"tenant,omitempty"`
+}
+
+// SessionListResponse defines model for SessionListResponse.
+type SessionListResponse struct {
+	Sessions []Session `json:"sessions"`
+}
+
+// SigningKey defines model for SigningKey.
+type SigningKey struct {
+	ActivatedAt *time.Time `json:"activated_at,omitempty"`
+	Alg         string     `json:"alg"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	// Kid RFC 7638 JWK thumbprint of the public key
+	Kid        string          `json:"kid"`
+	RetiredAt  *time.Time      `json:"retired_at,omitempty"`
+	RetiringAt *time.Time      `json:"retiring_at,omitempty"`
+	State      SigningKeyState `json:"state"`
+}
+
+// SigningKeyState defines model for SigningKey.State.
+type SigningKeyState string
+
+// SigningKeyListResponse defines model for SigningKeyListResponse.
+type SigningKeyListResponse struct {
+	Keys []SigningKey `json:"keys"`
+}
+
+// StatusReason Reason code of a status change
+type StatusReason string
+
+// TokenActor act claim of a delegated token (RFC 8693): the service acting for
+// the subject and, nested, the actor before it.
+type TokenActor struct {
+	// Act act claim of a delegated token (RFC 8693): the service acting for
+	// the subject and, nested, the actor before it.
+	Act *TokenActor `json:"act,omitempty"`
+
+	// Sub Service name of the actor
+	Sub string `json:"sub"`
+}
+
+// TokenExchangeRequest defines model for TokenExchangeRequest.
+type TokenExchangeRequest struct {
+	// Audience Audience of the new token
+	Audience string `json:"audience"`
+
+	// GrantType Must be urn:ietf:params:oauth:grant-type:token-exchange
+	GrantType string `json:"grant_type"`
+
+	// RequestedTokenType Optional; only urn:ietf:params:oauth:token-type:access_token is issued
+	RequestedTokenType *string `json:"requested_token_type,omitempty"`
+
+	// Scope Space-separated subset of the allowed scopes
+	Scope *string `json:"scope,omitempty"`
+
+	// SubjectToken Proteon access token of the subject
+	SubjectToken string `json:"subject_token"`
+
+	// SubjectTokenType urn:ietf:params:oauth:token-type:access_token or
+	// urn:ietf:params:oauth:token-type:jwt
+	SubjectTokenType string `json:"subject_token_type"`
+}
+
+// TokenExchangeResponse defines model for TokenExchangeResponse.
+type TokenExchangeResponse struct {
+	AccessToken string `json:"access_token"`
+
+	// ExpiresIn Token lifetime in seconds
+	ExpiresIn       int32  `json:"expires_in"`
+	IssuedTokenType string `json:"issued_token_type"`
+
+	// Scope Space-separated scopes granted to the token; omitted when none
+	Scope     *string                        `json:"scope,omitempty"`
+	TokenType TokenExchangeResponseTokenType `json:"token_type"`
+}
+
+// TokenExchangeResponseTokenType defines model for TokenExchangeResponse.TokenType.
+type TokenExchangeResponseTokenType string
+
+// UserExportResponse defines model for UserExportResponse.
+type UserExportResponse struct {
+	// AuditEntries Audit entries of the user and of the merged IDs, oldest first
+	AuditEntries []AuditEntry `json:"audit_entries"`
+	ExportedAt   time.Time    `json:"exported_at"`
+
+	// MergedUserIds Platform user IDs merged into the user
+	MergedUserIds []openapi_types.UUID `json:"merged_user_ids"`
+
+	// Sessions Sessions of the user and of the merged IDs
+	Sessions []Session                `json:"sessions"`
+	User     PlatformIdentityResponse `json:"user"`
+}
+
+// UserListResponse defines model for UserListResponse.
+type UserListResponse struct {
+	// NextCursor Cursor of the next page; absent on the last page
+	NextCursor *string                    `json:"next_cursor,omitempty"`
+	Users      []PlatformIdentityResponse `json:"users"`
+}
+
+// UserProfile Optional profile attributes. Supplied with an auth exchange, they
+// are recorded as of the assertion's iat with source exchange and
+// only replace exchange values that are not newer.
+type UserProfile struct {
+	BirthYear *int `json:"birth_year,omitempty"`
+
+	// Country ISO 3166-1 alpha-2 country or market
+	Country     *string `json:"country,omitempty"`
+	DisplayName *string `json:"display_name,omitempty"`
+
+	// Locale BCP 47 language tag
+	Locale *string `json:"locale,omitempty"`
+}
+
+// UserProfileUpdateRequest defines model for UserProfileUpdateRequest.
+type UserProfileUpdateRequest struct {
+	BirthYear *int `json:"birth_year,omitempty"`
+
+	// Clear Attributes to remove; they may be set again by exchanges
+	Clear *[]ProfileField `json:"clear,omitempty"`
+
+	// Country ISO 3166-1 alpha-2 country or market
+	Country     *string `json:"country,omitempty"`
+	DisplayName *string `json:"display_name,omitempty"`
+
+	// Locale BCP 47 language tag
+	Locale *string `json:"locale,omitempty"`
 }
 
+// RevokeReason defines model for RevokeReason.
+type RevokeReason = string
+
 // BadRequest defines model for BadRequest.
 type BadRequest = ErrorResponse
 
+// Conflict defines model for Conflict.
+type Conflict = ErrorResponse
+
+// Forbidden defines model for Forbidden.
+type Forbidden = ErrorResponse
+
 // InternalError defines model for InternalError.
 type InternalError = ErrorResponse
 
@@ -164,12 +744,90 @@ type TooManyRequests = ErrorResponse
 // Unauthorized defines model for Unauthorized.
 type Unauthorized = ErrorResponse
 
+// GetInternalV1LinkagesParams defines parameters for GetInternalV1Linkages.
+type GetInternalV1LinkagesParams struct {
+	Provider       string  `form:"provider" json:"provider"`
+	ExternalUserId string  `form:"external_user_id" json:"external_user_id"`
+	Tenant         *string `form:"tenant,omitempty" json:"tenant,omitempty"`
+}
+
+// GetInternalV1RevocationsParams defines parameters for GetInternalV1Revocations.
+type GetInternalV1RevocationsParams struct {
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+}
+
+// GetInternalV1UsersParams defines parameters for GetInternalV1Users.
+type GetInternalV1UsersParams struct {
+	Provider             *string `form:"provider,omitempty" json:"provider,omitempty"`
+	Tenant               *string `form:"tenant,omitempty" json:"tenant,omitempty"`
+	ExternalUserIdPrefix *string `form:"external_user_id_prefix,omitempty" json:"external_user_id_prefix,omitempty"`
+	// CreatedFrom Inclusive lower bound of created_at
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+	// CreatedTo Exclusive upper bound of created_at
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+	// Cursor Opaque next_cursor of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+	// Limit Page size
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// DeleteInternalV1UsersUserIdParams defines parameters for DeleteInternalV1UsersUserId.
+type DeleteInternalV1UsersUserIdParams struct {
+	// Reason Free-form reason recorded in the audit log
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty"`
+}
+
+// DeleteInternalV1UsersUserIdLinkagesParams defines parameters for DeleteInternalV1UsersUserIdLinkages.
+type DeleteInternalV1UsersUserIdLinkagesParams struct {
+	Provider       string `form:"provider" json:"provider"`
+	ExternalUserId string `form:"external_user_id" json:"external_user_id"`
+}
+
+// DeleteInternalV1UsersUserIdSessionsParams defines parameters for DeleteInternalV1UsersUserIdSessions.
+type DeleteInternalV1UsersUserIdSessionsParams struct {
+	// Reason Free-form reason recorded on the session (e.g. banned, offboarded)
+	Reason *RevokeReason `form:"reason,omitempty" json:"reason,omitempty"`
+}
+
+// DeleteInternalV1UsersUserIdSessionsSessionIdParams defines parameters for DeleteInternalV1UsersUserIdSessionsSessionId.
+type DeleteInternalV1UsersUserIdSessionsSessionIdParams struct {
+	// Reason Free-form reason recorded on the session (e.g. banned, offboarded)
+	Reason *RevokeReason `form:"reason,omitempty" json:"reason,omitempty"`
+}
+
 // PostInternalV1BackofficeTokensJSONRequestBody defines body for PostInternalV1BackofficeTokens for application/json ContentType.
 type PostInternalV1BackofficeTokensJSONRequestBody = BackofficeTokenRequest
 
+// PostInternalV1IntrospectFormdataRequestBody defines body for PostInternalV1Introspect for application/x-www-form-urlencoded ContentType.
+type PostInternalV1IntrospectFormdataRequestBody = IntrospectionRequest
+
+// PostInternalV1ProvidersJSONRequestBody defines body for PostInternalV1Providers for application/json ContentType.
+type PostInternalV1ProvidersJSONRequestBody = ProviderCreateRequest
+
+// PutInternalV1ProvidersProviderIdJSONRequestBody defines body for PutInternalV1ProvidersProviderId for application/json ContentType.
+type PutInternalV1ProvidersProviderIdJSONRequestBody = ProviderUpdateRequest
+
+// PostInternalV1TokenExchangeFormdataRequestBody defines body for PostInternalV1TokenExchange for application/x-www-form-urlencoded ContentType.
+type PostInternalV1TokenExchangeFormdataRequestBody = TokenExchangeRequest
+
+// PostInternalV1UsersUserIdLinkagesJSONRequestBody defines body for PostInternalV1UsersUserIdLinkages for application/json ContentType.
+type PostInternalV1UsersUserIdLinkagesJSONRequestBody = LinkageRequest
+
+// PostInternalV1UsersUserIdMergesJSONRequestBody defines body for PostInternalV1UsersUserIdMerges for application/json ContentType.
+type PostInternalV1UsersUserIdMergesJSONRequestBody = MergeRequest
+
+// PutInternalV1UsersUserIdStatusJSONRequestBody defines body for PutInternalV1UsersUserIdStatus for application/json ContentType.
+type PutInternalV1UsersUserIdStatusJSONRequestBody = IdentityStatusRequest
+
 // PostV1AuthExchangeJSONRequestBody defines body for PostV1AuthExchange for application/json ContentType.
 type PostV1AuthExchangeJSONRequestBody = AuthExchangeRequest
 
+// PostV1AuthRefreshJSONRequestBody defines body for PostV1AuthRefresh for application/json ContentType.
+type PostV1AuthRefreshJSONRequestBody = AuthRefreshRequest
+
+// PatchV1UsersUserIdJSONRequestBody defines body for PatchV1UsersUserId for application/json ContentType.
+type PatchV1UsersUserIdJSONRequestBody = UserProfileUpdateRequest
+
 // Getter for additional properties for Jwk. Returns the specified
 // element and whether it was found
 func (a Jwk) Get(fieldName string) (value interface{}, found bool) {
@@ -352,11 +1010,91 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetWellKnownOpenidConfiguration request
+	GetWellKnownOpenidConfiguration(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostInternalV1BackofficeTokensWithBody request with any body
 	PostInternalV1BackofficeTokensWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PostInternalV1BackofficeTokens(ctx context.Context, body PostInternalV1BackofficeTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostInternalV1IntrospectWithBody request with any body
+	PostInternalV1IntrospectWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostInternalV1IntrospectWithFormdataBody(ctx context.Context, body PostInternalV1IntrospectFormdataRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInternalV1Linkages request
+	GetInternalV1Linkages(ctx context.Context, params *GetInternalV1LinkagesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInternalV1Providers request
+	GetInternalV1Providers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostInternalV1ProvidersWithBody request with any body
+	PostInternalV1ProvidersWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostInternalV1Providers(ctx context.Context, body PostInternalV1ProvidersJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteInternalV1ProvidersProviderId request
+	DeleteInternalV1ProvidersProviderId(ctx context.Context, providerId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInternalV1ProvidersProviderId request
+	GetInternalV1ProvidersProviderId(ctx context.Context, providerId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutInternalV1ProvidersProviderIdWithBody request with any body
+	PutInternalV1ProvidersProviderIdWithBody(ctx context.Context, providerId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutInternalV1ProvidersProviderId(ctx context.Context, providerId string, body PutInternalV1ProvidersProviderIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInternalV1Revocations request
+	GetInternalV1Revocations(ctx context.Context, params *GetInternalV1RevocationsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInternalV1SigningKeys request
+	GetInternalV1SigningKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostInternalV1SigningKeysRotate request
+	PostInternalV1SigningKeysRotate(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostInternalV1TokenExchangeWithBody request with any body
+	PostInternalV1TokenExchangeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostInternalV1TokenExchangeWithFormdataBody(ctx context.Context, body PostInternalV1TokenExchangeFormdataRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInternalV1Users request
+	GetInternalV1Users(ctx context.Context, params *GetInternalV1UsersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteInternalV1UsersUserId request
+	DeleteInternalV1UsersUserId(ctx context.Context, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInternalV1UsersUserIdExport request
+	GetInternalV1UsersUserIdExport(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteInternalV1UsersUserIdLinkages request
+	DeleteInternalV1UsersUserIdLinkages(ctx context.Context, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdLinkagesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostInternalV1UsersUserIdLinkagesWithBody request with any body
+	PostInternalV1UsersUserIdLinkagesWithBody(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostInternalV1UsersUserIdLinkages(ctx context.Context, userId openapi_types.UUID, body PostInternalV1UsersUserIdLinkagesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostInternalV1UsersUserIdMergesWithBody request with any body
+	PostInternalV1UsersUserIdMergesWithBody(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostInternalV1UsersUserIdMerges(ctx context.Context, userId openapi_types.UUID, body PostInternalV1UsersUserIdMergesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteInternalV1UsersUserIdSessions request
+	DeleteInternalV1UsersUserIdSessions(ctx context.Context, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdSessionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInternalV1UsersUserIdSessions request
+	GetInternalV1UsersUserIdSessions(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteInternalV1UsersUserIdSessionsSessionId request
+	DeleteInternalV1UsersUserIdSessionsSessionId(ctx context.Context, userId openapi_types.UUID, sessionId string, params *DeleteInternalV1UsersUserIdSessionsSessionIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutInternalV1UsersUserIdStatusWithBody request with any body
+	PutInternalV1UsersUserIdStatusWithBody(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutInternalV1UsersUserIdStatus(ctx context.Context, userId openapi_types.UUID, body PutInternalV1UsersUserIdStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetV1WellKnownJwks request
 	GetV1WellKnownJwks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -365,11 +1103,32 @@ type ClientInterface interface {
 
 	PostV1AuthExchange(ctx context.Context, body PostV1AuthExchangeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostV1AuthRefreshWithBody request with any body
+	PostV1AuthRefreshWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostV1AuthRefresh(ctx context.Context, body PostV1AuthRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetV1Health request
 	GetV1Health(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetV1UsersUserId request
 	GetV1UsersUserId(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// PatchV1UsersUserIdWithBody request with any body
+	PatchV1UsersUserIdWithBody(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchV1UsersUserId(ctx context.Context, userId openapi_types.UUID, body PatchV1UsersUserIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetWellKnownOpenidConfiguration(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWellKnownOpenidConfigurationRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) PostInternalV1BackofficeTokensWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -396,8 +1155,8 @@ func (c *Client) PostInternalV1BackofficeTokens(ctx context.Context, body PostIn
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetV1WellKnownJwks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetV1WellKnownJwksRequest(c.Server)
+func (c *Client) PostInternalV1IntrospectWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostInternalV1IntrospectRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -408,8 +1167,8 @@ func (c *Client) GetV1WellKnownJwks(ctx context.Context, reqEditors ...RequestEd
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostV1AuthExchangeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostV1AuthExchangeRequestWithBody(c.Server, contentType, body)
+func (c *Client) PostInternalV1IntrospectWithFormdataBody(ctx context.Context, body PostInternalV1IntrospectFormdataRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostInternalV1IntrospectRequestWithFormdataBody(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -420,8 +1179,8 @@ func (c *Client) PostV1AuthExchangeWithBody(ctx context.Context, contentType str
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostV1AuthExchange(ctx context.Context, body PostV1AuthExchangeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostV1AuthExchangeRequest(c.Server, body)
+func (c *Client) GetInternalV1Linkages(ctx context.Context, params *GetInternalV1LinkagesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInternalV1LinkagesRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -432,8 +1191,8 @@ func (c *Client) PostV1AuthExchange(ctx context.Context, body PostV1AuthExchange
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetV1Health(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetV1HealthRequest(c.Server)
+func (c *Client) GetInternalV1Providers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInternalV1ProvidersRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -444,8 +1203,8 @@ func (c *Client) GetV1Health(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetV1UsersUserId(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetV1UsersUserIdRequest(c.Server, userId)
+func (c *Client) PostInternalV1ProvidersWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostInternalV1ProvidersRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -456,434 +1215,4173 @@ func (c *Client) GetV1UsersUserId(ctx context.Context, userId openapi_types.UUID
 	return c.Client.Do(req)
 }
 
-// NewPostInternalV1BackofficeTokensRequest calls the generic PostInternalV1BackofficeTokens builder with application/json body
-func NewPostInternalV1BackofficeTokensRequest(server string, body PostInternalV1BackofficeTokensJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) PostInternalV1Providers(ctx context.Context, body PostInternalV1ProvidersJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostInternalV1ProvidersRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostInternalV1BackofficeTokensRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostInternalV1BackofficeTokensRequestWithBody generates requests for PostInternalV1BackofficeTokens with any type of body
-func NewPostInternalV1BackofficeTokensRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) DeleteInternalV1ProvidersProviderId(ctx context.Context, providerId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteInternalV1ProvidersProviderIdRequest(c.Server, providerId)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/internal/v1/backoffice-tokens")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetInternalV1ProvidersProviderId(ctx context.Context, providerId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInternalV1ProvidersProviderIdRequest(c.Server, providerId)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+func (c *Client) PutInternalV1ProvidersProviderIdWithBody(ctx context.Context, providerId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutInternalV1ProvidersProviderIdRequestWithBody(c.Server, providerId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetV1WellKnownJwksRequest generates requests for GetV1WellKnownJwks
-func NewGetV1WellKnownJwksRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PutInternalV1ProvidersProviderId(ctx context.Context, providerId string, body PutInternalV1ProvidersProviderIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutInternalV1ProvidersProviderIdRequest(c.Server, providerId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/v1/.well-known/jwks.json")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetInternalV1Revocations(ctx context.Context, params *GetInternalV1RevocationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInternalV1RevocationsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) GetInternalV1SigningKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInternalV1SigningKeysRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostV1AuthExchangeRequest calls the generic PostV1AuthExchange builder with application/json body
-func NewPostV1AuthExchangeRequest(server string, body PostV1AuthExchangeJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) PostInternalV1SigningKeysRotate(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostInternalV1SigningKeysRotateRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostV1AuthExchangeRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostV1AuthExchangeRequestWithBody generates requests for PostV1AuthExchange with any type of body
-func NewPostV1AuthExchangeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostInternalV1TokenExchangeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostInternalV1TokenExchangeRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/v1/auth/exchange")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostInternalV1TokenExchangeWithFormdataBody(ctx context.Context, body PostInternalV1TokenExchangeFormdataRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostInternalV1TokenExchangeRequestWithFormdataBody(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+func (c *Client) GetInternalV1Users(ctx context.Context, params *GetInternalV1UsersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInternalV1UsersRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetV1HealthRequest generates requests for GetV1Health
-func NewGetV1HealthRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) DeleteInternalV1UsersUserId(ctx context.Context, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteInternalV1UsersUserIdRequest(c.Server, userId, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/v1/health")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetInternalV1UsersUserIdExport(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInternalV1UsersUserIdExportRequest(c.Server, userId)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) DeleteInternalV1UsersUserIdLinkages(ctx context.Context, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdLinkagesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteInternalV1UsersUserIdLinkagesRequest(c.Server, userId, params)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetV1UsersUserIdRequest generates requests for GetV1UsersUserId
-func NewGetV1UsersUserIdRequest(server string, userId openapi_types.UUID) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+func (c *Client) PostInternalV1UsersUserIdLinkagesWithBody(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostInternalV1UsersUserIdLinkagesRequestWithBody(c.Server, userId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) PostInternalV1UsersUserIdLinkages(ctx context.Context, userId openapi_types.UUID, body PostInternalV1UsersUserIdLinkagesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostInternalV1UsersUserIdLinkagesRequest(c.Server, userId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/v1/users/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostInternalV1UsersUserIdMergesWithBody(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostInternalV1UsersUserIdMergesRequestWithBody(c.Server, userId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) PostInternalV1UsersUserIdMerges(ctx context.Context, userId openapi_types.UUID, body PostInternalV1UsersUserIdMergesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostInternalV1UsersUserIdMergesRequest(c.Server, userId, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	return req, nil
+func (c *Client) DeleteInternalV1UsersUserIdSessions(ctx context.Context, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdSessionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteInternalV1UsersUserIdSessionsRequest(c.Server, userId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+func (c *Client) GetInternalV1UsersUserIdSessions(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInternalV1UsersUserIdSessionsRequest(c.Server, userId)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
-	return nil
+	return c.Client.Do(req)
 }
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
+func (c *Client) DeleteInternalV1UsersUserIdSessionsSessionId(ctx context.Context, userId openapi_types.UUID, sessionId string, params *DeleteInternalV1UsersUserIdSessionsSessionIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteInternalV1UsersUserIdSessionsSessionIdRequest(c.Server, userId, sessionId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+func (c *Client) PutInternalV1UsersUserIdStatusWithBody(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutInternalV1UsersUserIdStatusRequestWithBody(c.Server, userId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+func (c *Client) PutInternalV1UsersUserIdStatus(ctx context.Context, userId openapi_types.UUID, body PutInternalV1UsersUserIdStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutInternalV1UsersUserIdStatusRequest(c.Server, userId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// PostInternalV1BackofficeTokensWithBodyWithResponse request with any body
-	PostInternalV1BackofficeTokensWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostInternalV1BackofficeTokensResponse, error)
+func (c *Client) GetV1WellKnownJwks(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetV1WellKnownJwksRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	PostInternalV1BackofficeTokensWithResponse(ctx context.Context, body PostInternalV1BackofficeTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostInternalV1BackofficeTokensResponse, error)
-
-	// GetV1WellKnownJwksWithResponse request
-	GetV1WellKnownJwksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetV1WellKnownJwksResponse, error)
-
-	// PostV1AuthExchangeWithBodyWithResponse request with any body
-	PostV1AuthExchangeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV1AuthExchangeResponse, error)
-
-	PostV1AuthExchangeWithResponse(ctx context.Context, body PostV1AuthExchangeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV1AuthExchangeResponse, error)
-
-	// GetV1HealthWithResponse request
-	GetV1HealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetV1HealthResponse, error)
-
-	// GetV1UsersUserIdWithResponse request
-	GetV1UsersUserIdWithResponse(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetV1UsersUserIdResponse, error)
+func (c *Client) PostV1AuthExchangeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostV1AuthExchangeRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type PostInternalV1BackofficeTokensResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *BackofficeTokenResponse
-	JSON400      *BadRequest
-	JSON500      *InternalError
+func (c *Client) PostV1AuthExchange(ctx context.Context, body PostV1AuthExchangeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostV1AuthExchangeRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r PostInternalV1BackofficeTokensResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) PostV1AuthRefreshWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostV1AuthRefreshRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostInternalV1BackofficeTokensResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) PostV1AuthRefresh(ctx context.Context, body PostV1AuthRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostV1AuthRefreshRequest(c.Server, body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type GetV1WellKnownJwksResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *JwksResponse
-	JSON500      *InternalError
+func (c *Client) GetV1Health(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetV1HealthRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetV1WellKnownJwksResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+func (c *Client) GetV1UsersUserId(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetV1UsersUserIdRequest(c.Server, userId)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
-
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetV1WellKnownJwksResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+func (c *Client) PatchV1UsersUserIdWithBody(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchV1UsersUserIdRequestWithBody(c.Server, userId, contentType, body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-type PostV1AuthExchangeResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *AuthExchangeResponse
-	JSON400      *BadRequest
-	JSON401      *Unauthorized
-	JSON429      *TooManyRequests
-	JSON500      *InternalError
+func (c *Client) PatchV1UsersUserId(ctx context.Context, userId openapi_types.UUID, body PatchV1UsersUserIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchV1UsersUserIdRequest(c.Server, userId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// Status returns HTTPResponse.Status
-func (r PostV1AuthExchangeResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewGetWellKnownOpenidConfigurationRequest generates requests for GetWellKnownOpenidConfiguration
+func NewGetWellKnownOpenidConfigurationRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostV1AuthExchangeResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	operationPath := fmt.Sprintf("/.well-known/openid-configuration")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return 0
-}
 
-type GetV1HealthResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *HealthResponse
-	JSON500      *InternalError
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetV1HealthResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewPostInternalV1BackofficeTokensRequest calls the generic PostInternalV1BackofficeTokens builder with application/json body
+func NewPostInternalV1BackofficeTokensRequest(server string, body PostInternalV1BackofficeTokensJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	bodyReader = bytes.NewReader(buf)
+	return NewPostInternalV1BackofficeTokensRequestWithBody(server, "application/json", bodyReader)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetV1HealthResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// NewPostInternalV1BackofficeTokensRequestWithBody generates requests for PostInternalV1BackofficeTokens with any type of body
+func NewPostInternalV1BackofficeTokensRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+
+	operationPath := fmt.Sprintf("/internal/v1/backoffice-tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
 }
 
-type GetV1UsersUserIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *PlatformIdentityResponse
-	JSON404      *NotFound
-	JSON500      *InternalError
+// NewPostInternalV1IntrospectRequestWithFormdataBody calls the generic PostInternalV1Introspect builder with application/x-www-form-urlencoded body
+func NewPostInternalV1IntrospectRequestWithFormdataBody(server string, body PostInternalV1IntrospectFormdataRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	bodyStr, err := runtime.MarshalForm(body, nil)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = strings.NewReader(bodyStr.Encode())
+	return NewPostInternalV1IntrospectRequestWithBody(server, "application/x-www-form-urlencoded", bodyReader)
 }
 
-// Status returns HTTPResponse.Status
-func (r GetV1UsersUserIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewPostInternalV1IntrospectRequestWithBody generates requests for PostInternalV1Introspect with any type of body
+func NewPostInternalV1IntrospectRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+
+	operationPath := fmt.Sprintf("/internal/v1/introspect")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetV1UsersUserIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// NewGetInternalV1LinkagesRequest generates requests for GetInternalV1Linkages
+func NewGetInternalV1LinkagesRequest(server string, params *GetInternalV1LinkagesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/linkages")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "provider", runtime.ParamLocationQuery, params.Provider); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "external_user_id", runtime.ParamLocationQuery, params.ExternalUserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Tenant != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tenant", runtime.ParamLocationQuery, *params.Tenant); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetInternalV1ProvidersRequest generates requests for GetInternalV1Providers
+func NewGetInternalV1ProvidersRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/providers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostInternalV1ProvidersRequest calls the generic PostInternalV1Providers builder with application/json body
+func NewPostInternalV1ProvidersRequest(server string, body PostInternalV1ProvidersJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostInternalV1ProvidersRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostInternalV1ProvidersRequestWithBody generates requests for PostInternalV1Providers with any type of body
+func NewPostInternalV1ProvidersRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/providers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteInternalV1ProvidersProviderIdRequest generates requests for DeleteInternalV1ProvidersProviderId
+func NewDeleteInternalV1ProvidersProviderIdRequest(server string, providerId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/providers/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetInternalV1ProvidersProviderIdRequest generates requests for GetInternalV1ProvidersProviderId
+func NewGetInternalV1ProvidersProviderIdRequest(server string, providerId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/providers/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutInternalV1ProvidersProviderIdRequest calls the generic PutInternalV1ProvidersProviderId builder with application/json body
+func NewPutInternalV1ProvidersProviderIdRequest(server string, providerId string, body PutInternalV1ProvidersProviderIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutInternalV1ProvidersProviderIdRequestWithBody(server, providerId, "application/json", bodyReader)
+}
+
+// NewPutInternalV1ProvidersProviderIdRequestWithBody generates requests for PutInternalV1ProvidersProviderId with any type of body
+func NewPutInternalV1ProvidersProviderIdRequestWithBody(server string, providerId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "providerId", runtime.ParamLocationPath, providerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/providers/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetInternalV1RevocationsRequest generates requests for GetInternalV1Revocations
+func NewGetInternalV1RevocationsRequest(server string, params *GetInternalV1RevocationsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/revocations")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetInternalV1SigningKeysRequest generates requests for GetInternalV1SigningKeys
+func NewGetInternalV1SigningKeysRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/signing-keys")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostInternalV1SigningKeysRotateRequest generates requests for PostInternalV1SigningKeysRotate
+func NewPostInternalV1SigningKeysRotateRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/signing-keys/rotate")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostInternalV1TokenExchangeRequestWithFormdataBody calls the generic PostInternalV1TokenExchange builder with application/x-www-form-urlencoded body
+func NewPostInternalV1TokenExchangeRequestWithFormdataBody(server string, body PostInternalV1TokenExchangeFormdataRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	bodyStr, err := runtime.MarshalForm(body, nil)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = strings.NewReader(bodyStr.Encode())
+	return NewPostInternalV1TokenExchangeRequestWithBody(server, "application/x-www-form-urlencoded", bodyReader)
+}
+
+// NewPostInternalV1TokenExchangeRequestWithBody generates requests for PostInternalV1TokenExchange with any type of body
+func NewPostInternalV1TokenExchangeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/token-exchange")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetInternalV1UsersRequest generates requests for GetInternalV1Users
+func NewGetInternalV1UsersRequest(server string, params *GetInternalV1UsersParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/users")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Provider != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "provider", runtime.ParamLocationQuery, *params.Provider); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Tenant != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tenant", runtime.ParamLocationQuery, *params.Tenant); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ExternalUserIdPrefix != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "external_user_id_prefix", runtime.ParamLocationQuery, *params.ExternalUserIdPrefix); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CreatedFrom != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "created_from", runtime.ParamLocationQuery, *params.CreatedFrom); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CreatedTo != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "created_to", runtime.ParamLocationQuery, *params.CreatedTo); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteInternalV1UsersUserIdRequest generates requests for DeleteInternalV1UsersUserId
+func NewDeleteInternalV1UsersUserIdRequest(server string, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Reason != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "reason", runtime.ParamLocationQuery, *params.Reason); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetInternalV1UsersUserIdExportRequest generates requests for GetInternalV1UsersUserIdExport
+func NewGetInternalV1UsersUserIdExportRequest(server string, userId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/users/%s/export", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteInternalV1UsersUserIdLinkagesRequest generates requests for DeleteInternalV1UsersUserIdLinkages
+func NewDeleteInternalV1UsersUserIdLinkagesRequest(server string, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdLinkagesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/users/%s/linkages", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "provider", runtime.ParamLocationQuery, params.Provider); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "external_user_id", runtime.ParamLocationQuery, params.ExternalUserId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostInternalV1UsersUserIdLinkagesRequest calls the generic PostInternalV1UsersUserIdLinkages builder with application/json body
+func NewPostInternalV1UsersUserIdLinkagesRequest(server string, userId openapi_types.UUID, body PostInternalV1UsersUserIdLinkagesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostInternalV1UsersUserIdLinkagesRequestWithBody(server, userId, "application/json", bodyReader)
+}
+
+// NewPostInternalV1UsersUserIdLinkagesRequestWithBody generates requests for PostInternalV1UsersUserIdLinkages with any type of body
+func NewPostInternalV1UsersUserIdLinkagesRequestWithBody(server string, userId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/users/%s/linkages", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostInternalV1UsersUserIdMergesRequest calls the generic PostInternalV1UsersUserIdMerges builder with application/json body
+func NewPostInternalV1UsersUserIdMergesRequest(server string, userId openapi_types.UUID, body PostInternalV1UsersUserIdMergesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostInternalV1UsersUserIdMergesRequestWithBody(server, userId, "application/json", bodyReader)
+}
+
+// NewPostInternalV1UsersUserIdMergesRequestWithBody generates requests for PostInternalV1UsersUserIdMerges with any type of body
+func NewPostInternalV1UsersUserIdMergesRequestWithBody(server string, userId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/users/%s/merges", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteInternalV1UsersUserIdSessionsRequest generates requests for DeleteInternalV1UsersUserIdSessions
+func NewDeleteInternalV1UsersUserIdSessionsRequest(server string, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdSessionsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/users/%s/sessions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Reason != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "reason", runtime.ParamLocationQuery, *params.Reason); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetInternalV1UsersUserIdSessionsRequest generates requests for GetInternalV1UsersUserIdSessions
+func NewGetInternalV1UsersUserIdSessionsRequest(server string, userId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/users/%s/sessions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteInternalV1UsersUserIdSessionsSessionIdRequest generates requests for DeleteInternalV1UsersUserIdSessionsSessionId
+func NewDeleteInternalV1UsersUserIdSessionsSessionIdRequest(server string, userId openapi_types.UUID, sessionId string, params *DeleteInternalV1UsersUserIdSessionsSessionIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "sessionId", runtime.ParamLocationPath, sessionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/users/%s/sessions/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Reason != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "reason", runtime.ParamLocationQuery, *params.Reason); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutInternalV1UsersUserIdStatusRequest calls the generic PutInternalV1UsersUserIdStatus builder with application/json body
+func NewPutInternalV1UsersUserIdStatusRequest(server string, userId openapi_types.UUID, body PutInternalV1UsersUserIdStatusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutInternalV1UsersUserIdStatusRequestWithBody(server, userId, "application/json", bodyReader)
+}
+
+// NewPutInternalV1UsersUserIdStatusRequestWithBody generates requests for PutInternalV1UsersUserIdStatus with any type of body
+func NewPutInternalV1UsersUserIdStatusRequestWithBody(server string, userId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/v1/users/%s/status", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetV1WellKnownJwksRequest generates requests for GetV1WellKnownJwks
+func NewGetV1WellKnownJwksRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/.well-known/jwks.json")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostV1AuthExchangeRequest calls the generic PostV1AuthExchange builder with application/json body
+func NewPostV1AuthExchangeRequest(server string, body PostV1AuthExchangeJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostV1AuthExchangeRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostV1AuthExchangeRequestWithBody generates requests for PostV1AuthExchange with any type of body
+func NewPostV1AuthExchangeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/auth/exchange")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostV1AuthRefreshRequest calls the generic PostV1AuthRefresh builder with application/json body
+func NewPostV1AuthRefreshRequest(server string, body PostV1AuthRefreshJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostV1AuthRefreshRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostV1AuthRefreshRequestWithBody generates requests for PostV1AuthRefresh with any type of body
+func NewPostV1AuthRefreshRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/auth/refresh")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetV1HealthRequest generates requests for GetV1Health
+func NewGetV1HealthRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/health")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetV1UsersUserIdRequest generates requests for GetV1UsersUserId
+func NewGetV1UsersUserIdRequest(server string, userId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchV1UsersUserIdRequest calls the generic PatchV1UsersUserId builder with application/json body
+func NewPatchV1UsersUserIdRequest(server string, userId openapi_types.UUID, body PatchV1UsersUserIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchV1UsersUserIdRequestWithBody(server, userId, "application/json", bodyReader)
+}
+
+// NewPatchV1UsersUserIdRequestWithBody generates requests for PatchV1UsersUserId with any type of body
+func NewPatchV1UsersUserIdRequestWithBody(server string, userId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetWellKnownOpenidConfigurationWithResponse request
+	GetWellKnownOpenidConfigurationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWellKnownOpenidConfigurationResponse, error)
+
+	// PostInternalV1BackofficeTokensWithBodyWithResponse request with any body
+	PostInternalV1BackofficeTokensWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostInternalV1BackofficeTokensResponse, error)
+
+	PostInternalV1BackofficeTokensWithResponse(ctx context.Context, body PostInternalV1BackofficeTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostInternalV1BackofficeTokensResponse, error)
+
+	// PostInternalV1IntrospectWithBodyWithResponse request with any body
+	PostInternalV1IntrospectWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostInternalV1IntrospectResponse, error)
+
+	PostInternalV1IntrospectWithFormdataBodyWithResponse(ctx context.Context, body PostInternalV1IntrospectFormdataRequestBody, reqEditors ...RequestEditorFn) (*PostInternalV1IntrospectResponse, error)
+
+	// GetInternalV1LinkagesWithResponse request
+	GetInternalV1LinkagesWithResponse(ctx context.Context, params *GetInternalV1LinkagesParams, reqEditors ...RequestEditorFn) (*GetInternalV1LinkagesResponse, error)
+
+	// GetInternalV1ProvidersWithResponse request
+	GetInternalV1ProvidersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInternalV1ProvidersResponse, error)
+
+	// PostInternalV1ProvidersWithBodyWithResponse request with any body
+	PostInternalV1ProvidersWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostInternalV1ProvidersResponse, error)
+
+	PostInternalV1ProvidersWithResponse(ctx context.Context, body PostInternalV1ProvidersJSONRequestBody, reqEditors ...RequestEditorFn) (*PostInternalV1ProvidersResponse, error)
+
+	// DeleteInternalV1ProvidersProviderIdWithResponse request
+	DeleteInternalV1ProvidersProviderIdWithResponse(ctx context.Context, providerId string, reqEditors ...RequestEditorFn) (*DeleteInternalV1ProvidersProviderIdResponse, error)
+
+	// GetInternalV1ProvidersProviderIdWithResponse request
+	GetInternalV1ProvidersProviderIdWithResponse(ctx context.Context, providerId string, reqEditors ...RequestEditorFn) (*GetInternalV1ProvidersProviderIdResponse, error)
+
+	// PutInternalV1ProvidersProviderIdWithBodyWithResponse request with any body
+	PutInternalV1ProvidersProviderIdWithBodyWithResponse(ctx context.Context, providerId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutInternalV1ProvidersProviderIdResponse, error)
+
+	PutInternalV1ProvidersProviderIdWithResponse(ctx context.Context, providerId string, body PutInternalV1ProvidersProviderIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutInternalV1ProvidersProviderIdResponse, error)
+
+	// GetInternalV1RevocationsWithResponse request
+	GetInternalV1RevocationsWithResponse(ctx context.Context, params *GetInternalV1RevocationsParams, reqEditors ...RequestEditorFn) (*GetInternalV1RevocationsResponse, error)
+
+	// GetInternalV1SigningKeysWithResponse request
+	GetInternalV1SigningKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInternalV1SigningKeysResponse, error)
+
+	// PostInternalV1SigningKeysRotateWithResponse request
+	PostInternalV1SigningKeysRotateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostInternalV1SigningKeysRotateResponse, error)
+
+	// PostInternalV1TokenExchangeWithBodyWithResponse request with any body
+	PostInternalV1TokenExchangeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostInternalV1TokenExchangeResponse, error)
+
+	PostInternalV1TokenExchangeWithFormdataBodyWithResponse(ctx context.Context, body PostInternalV1TokenExchangeFormdataRequestBody, reqEditors ...RequestEditorFn) (*PostInternalV1TokenExchangeResponse, error)
+
+	// GetInternalV1UsersWithResponse request
+	GetInternalV1UsersWithResponse(ctx context.Context, params *GetInternalV1UsersParams, reqEditors ...RequestEditorFn) (*GetInternalV1UsersResponse, error)
+
+	// DeleteInternalV1UsersUserIdWithResponse request
+	DeleteInternalV1UsersUserIdWithResponse(ctx context.Context, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdParams, reqEditors ...RequestEditorFn) (*DeleteInternalV1UsersUserIdResponse, error)
+
+	// GetInternalV1UsersUserIdExportWithResponse request
+	GetInternalV1UsersUserIdExportWithResponse(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetInternalV1UsersUserIdExportResponse, error)
+
+	// DeleteInternalV1UsersUserIdLinkagesWithResponse request
+	DeleteInternalV1UsersUserIdLinkagesWithResponse(ctx context.Context, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdLinkagesParams, reqEditors ...RequestEditorFn) (*DeleteInternalV1UsersUserIdLinkagesResponse, error)
+
+	// PostInternalV1UsersUserIdLinkagesWithBodyWithResponse request with any body
+	PostInternalV1UsersUserIdLinkagesWithBodyWithResponse(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostInternalV1UsersUserIdLinkagesResponse, error)
+
+	PostInternalV1UsersUserIdLinkagesWithResponse(ctx context.Context, userId openapi_types.UUID, body PostInternalV1UsersUserIdLinkagesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostInternalV1UsersUserIdLinkagesResponse, error)
+
+	// PostInternalV1UsersUserIdMergesWithBodyWithResponse request with any body
+	PostInternalV1UsersUserIdMergesWithBodyWithResponse(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostInternalV1UsersUserIdMergesResponse, error)
+
+	PostInternalV1UsersUserIdMergesWithResponse(ctx context.Context, userId openapi_types.UUID, body PostInternalV1UsersUserIdMergesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostInternalV1UsersUserIdMergesResponse, error)
+
+	// DeleteInternalV1UsersUserIdSessionsWithResponse request
+	DeleteInternalV1UsersUserIdSessionsWithResponse(ctx context.Context, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdSessionsParams, reqEditors ...RequestEditorFn) (*DeleteInternalV1UsersUserIdSessionsResponse, error)
+
+	// GetInternalV1UsersUserIdSessionsWithResponse request
+	GetInternalV1UsersUserIdSessionsWithResponse(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetInternalV1UsersUserIdSessionsResponse, error)
+
+	// DeleteInternalV1UsersUserIdSessionsSessionIdWithResponse request
+	DeleteInternalV1UsersUserIdSessionsSessionIdWithResponse(ctx context.Context, userId openapi_types.UUID, sessionId string, params *DeleteInternalV1UsersUserIdSessionsSessionIdParams, reqEditors ...RequestEditorFn) (*DeleteInternalV1UsersUserIdSessionsSessionIdResponse, error)
+
+	// PutInternalV1UsersUserIdStatusWithBodyWithResponse request with any body
+	PutInternalV1UsersUserIdStatusWithBodyWithResponse(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutInternalV1UsersUserIdStatusResponse, error)
+
+	PutInternalV1UsersUserIdStatusWithResponse(ctx context.Context, userId openapi_types.UUID, body PutInternalV1UsersUserIdStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*PutInternalV1UsersUserIdStatusResponse, error)
+
+	// GetV1WellKnownJwksWithResponse request
+	GetV1WellKnownJwksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetV1WellKnownJwksResponse, error)
+
+	// PostV1AuthExchangeWithBodyWithResponse request with any body
+	PostV1AuthExchangeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV1AuthExchangeResponse, error)
+
+	PostV1AuthExchangeWithResponse(ctx context.Context, body PostV1AuthExchangeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV1AuthExchangeResponse, error)
+
+	// PostV1AuthRefreshWithBodyWithResponse request with any body
+	PostV1AuthRefreshWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV1AuthRefreshResponse, error)
+
+	PostV1AuthRefreshWithResponse(ctx context.Context, body PostV1AuthRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV1AuthRefreshResponse, error)
+
+	// GetV1HealthWithResponse request
+	GetV1HealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetV1HealthResponse, error)
+
+	// GetV1UsersUserIdWithResponse request
+	GetV1UsersUserIdWithResponse(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetV1UsersUserIdResponse, error)
+	// PatchV1UsersUserIdWithBodyWithResponse request with any body
+	PatchV1UsersUserIdWithBodyWithResponse(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchV1UsersUserIdResponse, error)
+
+	PatchV1UsersUserIdWithResponse(ctx context.Context, userId openapi_types.UUID, body PatchV1UsersUserIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchV1UsersUserIdResponse, error)
+}
+
+type GetWellKnownOpenidConfigurationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OpenidConfiguration
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWellKnownOpenidConfigurationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWellKnownOpenidConfigurationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostInternalV1BackofficeTokensResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BackofficeTokenResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostInternalV1BackofficeTokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostInternalV1BackofficeTokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostInternalV1IntrospectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *IntrospectionResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostInternalV1IntrospectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostInternalV1IntrospectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInternalV1LinkagesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PlatformIdentityResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInternalV1LinkagesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInternalV1LinkagesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInternalV1ProvidersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ProviderListResponse
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInternalV1ProvidersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInternalV1ProvidersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostInternalV1ProvidersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Provider
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON409      *Conflict
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostInternalV1ProvidersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostInternalV1ProvidersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteInternalV1ProvidersProviderIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteInternalV1ProvidersProviderIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteInternalV1ProvidersProviderIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInternalV1ProvidersProviderIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Provider
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInternalV1ProvidersProviderIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInternalV1ProvidersProviderIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutInternalV1ProvidersProviderIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Provider
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PutInternalV1ProvidersProviderIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutInternalV1ProvidersProviderIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInternalV1RevocationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RevocationListResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInternalV1RevocationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInternalV1RevocationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInternalV1SigningKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SigningKeyListResponse
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInternalV1SigningKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInternalV1SigningKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostInternalV1SigningKeysRotateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SigningKeyListResponse
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON409      *Conflict
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostInternalV1SigningKeysRotateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostInternalV1SigningKeysRotateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostInternalV1TokenExchangeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TokenExchangeResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostInternalV1TokenExchangeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostInternalV1TokenExchangeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInternalV1UsersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UserListResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInternalV1UsersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInternalV1UsersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteInternalV1UsersUserIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ErasureResponse
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON409      *Conflict
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteInternalV1UsersUserIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteInternalV1UsersUserIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInternalV1UsersUserIdExportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UserExportResponse
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInternalV1UsersUserIdExportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInternalV1UsersUserIdExportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteInternalV1UsersUserIdLinkagesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PlatformIdentityResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON409      *Conflict
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteInternalV1UsersUserIdLinkagesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteInternalV1UsersUserIdLinkagesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostInternalV1UsersUserIdLinkagesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PlatformIdentityResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON409      *Conflict
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostInternalV1UsersUserIdLinkagesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostInternalV1UsersUserIdLinkagesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostInternalV1UsersUserIdMergesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PlatformIdentityResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON409      *Conflict
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostInternalV1UsersUserIdMergesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostInternalV1UsersUserIdMergesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteInternalV1UsersUserIdSessionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SessionListResponse
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteInternalV1UsersUserIdSessionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteInternalV1UsersUserIdSessionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInternalV1UsersUserIdSessionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SessionListResponse
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInternalV1UsersUserIdSessionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInternalV1UsersUserIdSessionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteInternalV1UsersUserIdSessionsSessionIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Session
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteInternalV1UsersUserIdSessionsSessionIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteInternalV1UsersUserIdSessionsSessionIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutInternalV1UsersUserIdStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PlatformIdentityResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON409      *Conflict
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PutInternalV1UsersUserIdStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutInternalV1UsersUserIdStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetV1WellKnownJwksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *JwksResponse
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetV1WellKnownJwksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetV1WellKnownJwksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostV1AuthExchangeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuthExchangeResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON409      *Conflict
+	JSON429      *TooManyRequests
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostV1AuthExchangeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostV1AuthExchangeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostV1AuthRefreshResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuthExchangeResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON429      *TooManyRequests
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PostV1AuthRefreshResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostV1AuthRefreshResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetV1HealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthResponse
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetV1HealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetV1HealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetV1UsersUserIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PlatformIdentityResponse
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetV1UsersUserIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetV1UsersUserIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchV1UsersUserIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PlatformIdentityResponse
+	JSON400      *BadRequest
+	JSON403      *Forbidden
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchV1UsersUserIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchV1UsersUserIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetWellKnownOpenidConfigurationWithResponse request returning *GetWellKnownOpenidConfigurationResponse
+func (c *ClientWithResponses) GetWellKnownOpenidConfigurationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWellKnownOpenidConfigurationResponse, error) {
+	rsp, err := c.GetWellKnownOpenidConfiguration(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWellKnownOpenidConfigurationResponse(rsp)
+}
+
+// PostInternalV1BackofficeTokensWithBodyWithResponse request with arbitrary body returning *PostInternalV1BackofficeTokensResponse
+func (c *ClientWithResponses) PostInternalV1BackofficeTokensWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostInternalV1BackofficeTokensResponse, error) {
+	rsp, err := c.PostInternalV1BackofficeTokensWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostInternalV1BackofficeTokensResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostInternalV1BackofficeTokensWithResponse(ctx context.Context, body PostInternalV1BackofficeTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostInternalV1BackofficeTokensResponse, error) {
+	rsp, err := c.PostInternalV1BackofficeTokens(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostInternalV1BackofficeTokensResponse(rsp)
+}
+
+// PostInternalV1IntrospectWithBodyWithResponse request with arbitrary body returning *PostInternalV1IntrospectResponse
+func (c *ClientWithResponses) PostInternalV1IntrospectWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostInternalV1IntrospectResponse, error) {
+	rsp, err := c.PostInternalV1IntrospectWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostInternalV1IntrospectResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostInternalV1IntrospectWithFormdataBodyWithResponse(ctx context.Context, body PostInternalV1IntrospectFormdataRequestBody, reqEditors ...RequestEditorFn) (*PostInternalV1IntrospectResponse, error) {
+	rsp, err := c.PostInternalV1IntrospectWithFormdataBody(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostInternalV1IntrospectResponse(rsp)
+}
+
+// GetInternalV1LinkagesWithResponse request returning *GetInternalV1LinkagesResponse
+func (c *ClientWithResponses) GetInternalV1LinkagesWithResponse(ctx context.Context, params *GetInternalV1LinkagesParams, reqEditors ...RequestEditorFn) (*GetInternalV1LinkagesResponse, error) {
+	rsp, err := c.GetInternalV1Linkages(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInternalV1LinkagesResponse(rsp)
+}
+
+// GetInternalV1ProvidersWithResponse request returning *GetInternalV1ProvidersResponse
+func (c *ClientWithResponses) GetInternalV1ProvidersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInternalV1ProvidersResponse, error) {
+	rsp, err := c.GetInternalV1Providers(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInternalV1ProvidersResponse(rsp)
+}
+
+// PostInternalV1ProvidersWithBodyWithResponse request with arbitrary body returning *PostInternalV1ProvidersResponse
+func (c *ClientWithResponses) PostInternalV1ProvidersWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostInternalV1ProvidersResponse, error) {
+	rsp, err := c.PostInternalV1ProvidersWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostInternalV1ProvidersResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostInternalV1ProvidersWithResponse(ctx context.Context, body PostInternalV1ProvidersJSONRequestBody, reqEditors ...RequestEditorFn) (*PostInternalV1ProvidersResponse, error) {
+	rsp, err := c.PostInternalV1Providers(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostInternalV1ProvidersResponse(rsp)
+}
+
+// DeleteInternalV1ProvidersProviderIdWithResponse request returning *DeleteInternalV1ProvidersProviderIdResponse
+func (c *ClientWithResponses) DeleteInternalV1ProvidersProviderIdWithResponse(ctx context.Context, providerId string, reqEditors ...RequestEditorFn) (*DeleteInternalV1ProvidersProviderIdResponse, error) {
+	rsp, err := c.DeleteInternalV1ProvidersProviderId(ctx, providerId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteInternalV1ProvidersProviderIdResponse(rsp)
+}
+
+// GetInternalV1ProvidersProviderIdWithResponse request returning *GetInternalV1ProvidersProviderIdResponse
+func (c *ClientWithResponses) GetInternalV1ProvidersProviderIdWithResponse(ctx context.Context, providerId string, reqEditors ...RequestEditorFn) (*GetInternalV1ProvidersProviderIdResponse, error) {
+	rsp, err := c.GetInternalV1ProvidersProviderId(ctx, providerId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInternalV1ProvidersProviderIdResponse(rsp)
+}
+
+// PutInternalV1ProvidersProviderIdWithBodyWithResponse request with arbitrary body returning *PutInternalV1ProvidersProviderIdResponse
+func (c *ClientWithResponses) PutInternalV1ProvidersProviderIdWithBodyWithResponse(ctx context.Context, providerId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutInternalV1ProvidersProviderIdResponse, error) {
+	rsp, err := c.PutInternalV1ProvidersProviderIdWithBody(ctx, providerId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutInternalV1ProvidersProviderIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutInternalV1ProvidersProviderIdWithResponse(ctx context.Context, providerId string, body PutInternalV1ProvidersProviderIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutInternalV1ProvidersProviderIdResponse, error) {
+	rsp, err := c.PutInternalV1ProvidersProviderId(ctx, providerId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutInternalV1ProvidersProviderIdResponse(rsp)
+}
+
+// GetInternalV1RevocationsWithResponse request returning *GetInternalV1RevocationsResponse
+func (c *ClientWithResponses) GetInternalV1RevocationsWithResponse(ctx context.Context, params *GetInternalV1RevocationsParams, reqEditors ...RequestEditorFn) (*GetInternalV1RevocationsResponse, error) {
+	rsp, err := c.GetInternalV1Revocations(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInternalV1RevocationsResponse(rsp)
+}
+
+// GetInternalV1SigningKeysWithResponse request returning *GetInternalV1SigningKeysResponse
+func (c *ClientWithResponses) GetInternalV1SigningKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInternalV1SigningKeysResponse, error) {
+	rsp, err := c.GetInternalV1SigningKeys(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInternalV1SigningKeysResponse(rsp)
+}
+
+// PostInternalV1SigningKeysRotateWithResponse request returning *PostInternalV1SigningKeysRotateResponse
+func (c *ClientWithResponses) PostInternalV1SigningKeysRotateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostInternalV1SigningKeysRotateResponse, error) {
+	rsp, err := c.PostInternalV1SigningKeysRotate(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostInternalV1SigningKeysRotateResponse(rsp)
+}
+
+// PostInternalV1TokenExchangeWithBodyWithResponse request with arbitrary body returning *PostInternalV1TokenExchangeResponse
+func (c *ClientWithResponses) PostInternalV1TokenExchangeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostInternalV1TokenExchangeResponse, error) {
+	rsp, err := c.PostInternalV1TokenExchangeWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostInternalV1TokenExchangeResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostInternalV1TokenExchangeWithFormdataBodyWithResponse(ctx context.Context, body PostInternalV1TokenExchangeFormdataRequestBody, reqEditors ...RequestEditorFn) (*PostInternalV1TokenExchangeResponse, error) {
+	rsp, err := c.PostInternalV1TokenExchangeWithFormdataBody(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostInternalV1TokenExchangeResponse(rsp)
+}
+
+// GetInternalV1UsersWithResponse request returning *GetInternalV1UsersResponse
+func (c *ClientWithResponses) GetInternalV1UsersWithResponse(ctx context.Context, params *GetInternalV1UsersParams, reqEditors ...RequestEditorFn) (*GetInternalV1UsersResponse, error) {
+	rsp, err := c.GetInternalV1Users(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInternalV1UsersResponse(rsp)
+}
+
+// DeleteInternalV1UsersUserIdWithResponse request returning *DeleteInternalV1UsersUserIdResponse
+func (c *ClientWithResponses) DeleteInternalV1UsersUserIdWithResponse(ctx context.Context, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdParams, reqEditors ...RequestEditorFn) (*DeleteInternalV1UsersUserIdResponse, error) {
+	rsp, err := c.DeleteInternalV1UsersUserId(ctx, userId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteInternalV1UsersUserIdResponse(rsp)
+}
+
+// GetInternalV1UsersUserIdExportWithResponse request returning *GetInternalV1UsersUserIdExportResponse
+func (c *ClientWithResponses) GetInternalV1UsersUserIdExportWithResponse(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetInternalV1UsersUserIdExportResponse, error) {
+	rsp, err := c.GetInternalV1UsersUserIdExport(ctx, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInternalV1UsersUserIdExportResponse(rsp)
+}
+
+// DeleteInternalV1UsersUserIdLinkagesWithResponse request returning *DeleteInternalV1UsersUserIdLinkagesResponse
+func (c *ClientWithResponses) DeleteInternalV1UsersUserIdLinkagesWithResponse(ctx context.Context, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdLinkagesParams, reqEditors ...RequestEditorFn) (*DeleteInternalV1UsersUserIdLinkagesResponse, error) {
+	rsp, err := c.DeleteInternalV1UsersUserIdLinkages(ctx, userId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteInternalV1UsersUserIdLinkagesResponse(rsp)
+}
+
+// PostInternalV1UsersUserIdLinkagesWithBodyWithResponse request with arbitrary body returning *PostInternalV1UsersUserIdLinkagesResponse
+func (c *ClientWithResponses) PostInternalV1UsersUserIdLinkagesWithBodyWithResponse(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostInternalV1UsersUserIdLinkagesResponse, error) {
+	rsp, err := c.PostInternalV1UsersUserIdLinkagesWithBody(ctx, userId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostInternalV1UsersUserIdLinkagesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostInternalV1UsersUserIdLinkagesWithResponse(ctx context.Context, userId openapi_types.UUID, body PostInternalV1UsersUserIdLinkagesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostInternalV1UsersUserIdLinkagesResponse, error) {
+	rsp, err := c.PostInternalV1UsersUserIdLinkages(ctx, userId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostInternalV1UsersUserIdLinkagesResponse(rsp)
+}
+
+// PostInternalV1UsersUserIdMergesWithBodyWithResponse request with arbitrary body returning *PostInternalV1UsersUserIdMergesResponse
+func (c *ClientWithResponses) PostInternalV1UsersUserIdMergesWithBodyWithResponse(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostInternalV1UsersUserIdMergesResponse, error) {
+	rsp, err := c.PostInternalV1UsersUserIdMergesWithBody(ctx, userId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostInternalV1UsersUserIdMergesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostInternalV1UsersUserIdMergesWithResponse(ctx context.Context, userId openapi_types.UUID, body PostInternalV1UsersUserIdMergesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostInternalV1UsersUserIdMergesResponse, error) {
+	rsp, err := c.PostInternalV1UsersUserIdMerges(ctx, userId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostInternalV1UsersUserIdMergesResponse(rsp)
+}
+
+// DeleteInternalV1UsersUserIdSessionsWithResponse request returning *DeleteInternalV1UsersUserIdSessionsResponse
+func (c *ClientWithResponses) DeleteInternalV1UsersUserIdSessionsWithResponse(ctx context.Context, userId openapi_types.UUID, params *DeleteInternalV1UsersUserIdSessionsParams, reqEditors ...RequestEditorFn) (*DeleteInternalV1UsersUserIdSessionsResponse, error) {
+	rsp, err := c.DeleteInternalV1UsersUserIdSessions(ctx, userId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteInternalV1UsersUserIdSessionsResponse(rsp)
+}
+
+// GetInternalV1UsersUserIdSessionsWithResponse request returning *GetInternalV1UsersUserIdSessionsResponse
+func (c *ClientWithResponses) GetInternalV1UsersUserIdSessionsWithResponse(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetInternalV1UsersUserIdSessionsResponse, error) {
+	rsp, err := c.GetInternalV1UsersUserIdSessions(ctx, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInternalV1UsersUserIdSessionsResponse(rsp)
+}
+
+// DeleteInternalV1UsersUserIdSessionsSessionIdWithResponse request returning *DeleteInternalV1UsersUserIdSessionsSessionIdResponse
+func (c *ClientWithResponses) DeleteInternalV1UsersUserIdSessionsSessionIdWithResponse(ctx context.Context, userId openapi_types.UUID, sessionId string, params *DeleteInternalV1UsersUserIdSessionsSessionIdParams, reqEditors ...RequestEditorFn) (*DeleteInternalV1UsersUserIdSessionsSessionIdResponse, error) {
+	rsp, err := c.DeleteInternalV1UsersUserIdSessionsSessionId(ctx, userId, sessionId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteInternalV1UsersUserIdSessionsSessionIdResponse(rsp)
+}
+
+// PutInternalV1UsersUserIdStatusWithBodyWithResponse request with arbitrary body returning *PutInternalV1UsersUserIdStatusResponse
+func (c *ClientWithResponses) PutInternalV1UsersUserIdStatusWithBodyWithResponse(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutInternalV1UsersUserIdStatusResponse, error) {
+	rsp, err := c.PutInternalV1UsersUserIdStatusWithBody(ctx, userId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutInternalV1UsersUserIdStatusResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutInternalV1UsersUserIdStatusWithResponse(ctx context.Context, userId openapi_types.UUID, body PutInternalV1UsersUserIdStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*PutInternalV1UsersUserIdStatusResponse, error) {
+	rsp, err := c.PutInternalV1UsersUserIdStatus(ctx, userId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutInternalV1UsersUserIdStatusResponse(rsp)
+}
+
+// GetV1WellKnownJwksWithResponse request returning *GetV1WellKnownJwksResponse
+func (c *ClientWithResponses) GetV1WellKnownJwksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetV1WellKnownJwksResponse, error) {
+	rsp, err := c.GetV1WellKnownJwks(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetV1WellKnownJwksResponse(rsp)
+}
+
+// PostV1AuthExchangeWithBodyWithResponse request with arbitrary body returning *PostV1AuthExchangeResponse
+func (c *ClientWithResponses) PostV1AuthExchangeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV1AuthExchangeResponse, error) {
+	rsp, err := c.PostV1AuthExchangeWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostV1AuthExchangeResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostV1AuthExchangeWithResponse(ctx context.Context, body PostV1AuthExchangeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV1AuthExchangeResponse, error) {
+	rsp, err := c.PostV1AuthExchange(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostV1AuthExchangeResponse(rsp)
+}
+
+// PostV1AuthRefreshWithBodyWithResponse request with arbitrary body returning *PostV1AuthRefreshResponse
+func (c *ClientWithResponses) PostV1AuthRefreshWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV1AuthRefreshResponse, error) {
+	rsp, err := c.PostV1AuthRefreshWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostV1AuthRefreshResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostV1AuthRefreshWithResponse(ctx context.Context, body PostV1AuthRefreshJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV1AuthRefreshResponse, error) {
+	rsp, err := c.PostV1AuthRefresh(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostV1AuthRefreshResponse(rsp)
+}
+
+// GetV1HealthWithResponse request returning *GetV1HealthResponse
+func (c *ClientWithResponses) GetV1HealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetV1HealthResponse, error) {
+	rsp, err := c.GetV1Health(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetV1HealthResponse(rsp)
+}
+
+// GetV1UsersUserIdWithResponse request returning *GetV1UsersUserIdResponse
+func (c *ClientWithResponses) GetV1UsersUserIdWithResponse(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetV1UsersUserIdResponse, error) {
+	rsp, err := c.GetV1UsersUserId(ctx, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetV1UsersUserIdResponse(rsp)
+}
+
+// PatchV1UsersUserIdWithBodyWithResponse request with arbitrary body returning *PatchV1UsersUserIdResponse
+func (c *ClientWithResponses) PatchV1UsersUserIdWithBodyWithResponse(ctx context.Context, userId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchV1UsersUserIdResponse, error) {
+	rsp, err := c.PatchV1UsersUserIdWithBody(ctx, userId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchV1UsersUserIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchV1UsersUserIdWithResponse(ctx context.Context, userId openapi_types.UUID, body PatchV1UsersUserIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchV1UsersUserIdResponse, error) {
+	rsp, err := c.PatchV1UsersUserId(ctx, userId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchV1UsersUserIdResponse(rsp)
+}
+
+// ParseGetWellKnownOpenidConfigurationResponse parses an HTTP response from a GetWellKnownOpenidConfigurationWithResponse call
+func ParseGetWellKnownOpenidConfigurationResponse(rsp *http.Response) (*GetWellKnownOpenidConfigurationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWellKnownOpenidConfigurationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OpenidConfiguration
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostInternalV1BackofficeTokensResponse parses an HTTP response from a PostInternalV1BackofficeTokensWithResponse call
+func ParsePostInternalV1BackofficeTokensResponse(rsp *http.Response) (*PostInternalV1BackofficeTokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostInternalV1BackofficeTokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BackofficeTokenResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostInternalV1IntrospectResponse parses an HTTP response from a PostInternalV1IntrospectWithResponse call
+func ParsePostInternalV1IntrospectResponse(rsp *http.Response) (*PostInternalV1IntrospectResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostInternalV1IntrospectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest IntrospectionResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInternalV1LinkagesResponse parses an HTTP response from a GetInternalV1LinkagesWithResponse call
+func ParseGetInternalV1LinkagesResponse(rsp *http.Response) (*GetInternalV1LinkagesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInternalV1LinkagesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PlatformIdentityResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInternalV1ProvidersResponse parses an HTTP response from a GetInternalV1ProvidersWithResponse call
+func ParseGetInternalV1ProvidersResponse(rsp *http.Response) (*GetInternalV1ProvidersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInternalV1ProvidersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ProviderListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostInternalV1ProvidersResponse parses an HTTP response from a PostInternalV1ProvidersWithResponse call
+func ParsePostInternalV1ProvidersResponse(rsp *http.Response) (*PostInternalV1ProvidersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostInternalV1ProvidersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Provider
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteInternalV1ProvidersProviderIdResponse parses an HTTP response from a DeleteInternalV1ProvidersProviderIdWithResponse call
+func ParseDeleteInternalV1ProvidersProviderIdResponse(rsp *http.Response) (*DeleteInternalV1ProvidersProviderIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteInternalV1ProvidersProviderIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInternalV1ProvidersProviderIdResponse parses an HTTP response from a GetInternalV1ProvidersProviderIdWithResponse call
+func ParseGetInternalV1ProvidersProviderIdResponse(rsp *http.Response) (*GetInternalV1ProvidersProviderIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInternalV1ProvidersProviderIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Provider
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutInternalV1ProvidersProviderIdResponse parses an HTTP response from a PutInternalV1ProvidersProviderIdWithResponse call
+func ParsePutInternalV1ProvidersProviderIdResponse(rsp *http.Response) (*PutInternalV1ProvidersProviderIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutInternalV1ProvidersProviderIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Provider
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInternalV1RevocationsResponse parses an HTTP response from a GetInternalV1RevocationsWithResponse call
+func ParseGetInternalV1RevocationsResponse(rsp *http.Response) (*GetInternalV1RevocationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInternalV1RevocationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RevocationListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInternalV1SigningKeysResponse parses an HTTP response from a GetInternalV1SigningKeysWithResponse call
+func ParseGetInternalV1SigningKeysResponse(rsp *http.Response) (*GetInternalV1SigningKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInternalV1SigningKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SigningKeyListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostInternalV1SigningKeysRotateResponse parses an HTTP response from a PostInternalV1SigningKeysRotateWithResponse call
+func ParsePostInternalV1SigningKeysRotateResponse(rsp *http.Response) (*PostInternalV1SigningKeysRotateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostInternalV1SigningKeysRotateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SigningKeyListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostInternalV1TokenExchangeResponse parses an HTTP response from a PostInternalV1TokenExchangeWithResponse call
+func ParsePostInternalV1TokenExchangeResponse(rsp *http.Response) (*PostInternalV1TokenExchangeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostInternalV1TokenExchangeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TokenExchangeResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInternalV1UsersResponse parses an HTTP response from a GetInternalV1UsersWithResponse call
+func ParseGetInternalV1UsersResponse(rsp *http.Response) (*GetInternalV1UsersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInternalV1UsersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UserListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteInternalV1UsersUserIdResponse parses an HTTP response from a DeleteInternalV1UsersUserIdWithResponse call
+func ParseDeleteInternalV1UsersUserIdResponse(rsp *http.Response) (*DeleteInternalV1UsersUserIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteInternalV1UsersUserIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ErasureResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInternalV1UsersUserIdExportResponse parses an HTTP response from a GetInternalV1UsersUserIdExportWithResponse call
+func ParseGetInternalV1UsersUserIdExportResponse(rsp *http.Response) (*GetInternalV1UsersUserIdExportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInternalV1UsersUserIdExportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UserExportResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteInternalV1UsersUserIdLinkagesResponse parses an HTTP response from a DeleteInternalV1UsersUserIdLinkagesWithResponse call
+func ParseDeleteInternalV1UsersUserIdLinkagesResponse(rsp *http.Response) (*DeleteInternalV1UsersUserIdLinkagesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteInternalV1UsersUserIdLinkagesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PlatformIdentityResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostInternalV1UsersUserIdLinkagesResponse parses an HTTP response from a PostInternalV1UsersUserIdLinkagesWithResponse call
+func ParsePostInternalV1UsersUserIdLinkagesResponse(rsp *http.Response) (*PostInternalV1UsersUserIdLinkagesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostInternalV1UsersUserIdLinkagesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PlatformIdentityResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostInternalV1UsersUserIdMergesResponse parses an HTTP response from a PostInternalV1UsersUserIdMergesWithResponse call
+func ParsePostInternalV1UsersUserIdMergesResponse(rsp *http.Response) (*PostInternalV1UsersUserIdMergesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostInternalV1UsersUserIdMergesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PlatformIdentityResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteInternalV1UsersUserIdSessionsResponse parses an HTTP response from a DeleteInternalV1UsersUserIdSessionsWithResponse call
+func ParseDeleteInternalV1UsersUserIdSessionsResponse(rsp *http.Response) (*DeleteInternalV1UsersUserIdSessionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteInternalV1UsersUserIdSessionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SessionListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
-	return 0
-}
 
-// PostInternalV1BackofficeTokensWithBodyWithResponse request with arbitrary body returning *PostInternalV1BackofficeTokensResponse
-func (c *ClientWithResponses) PostInternalV1BackofficeTokensWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostInternalV1BackofficeTokensResponse, error) {
-	rsp, err := c.PostInternalV1BackofficeTokensWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParsePostInternalV1BackofficeTokensResponse(rsp)
+	return response, nil
 }
 
-func (c *ClientWithResponses) PostInternalV1BackofficeTokensWithResponse(ctx context.Context, body PostInternalV1BackofficeTokensJSONRequestBody, reqEditors ...RequestEditorFn) (*PostInternalV1BackofficeTokensResponse, error) {
-	rsp, err := c.PostInternalV1BackofficeTokens(ctx, body, reqEditors...)
+// ParseGetInternalV1UsersUserIdSessionsResponse parses an HTTP response from a GetInternalV1UsersUserIdSessionsWithResponse call
+func ParseGetInternalV1UsersUserIdSessionsResponse(rsp *http.Response) (*GetInternalV1UsersUserIdSessionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostInternalV1BackofficeTokensResponse(rsp)
-}
 
-// GetV1WellKnownJwksWithResponse request returning *GetV1WellKnownJwksResponse
-func (c *ClientWithResponses) GetV1WellKnownJwksWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetV1WellKnownJwksResponse, error) {
-	rsp, err := c.GetV1WellKnownJwks(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetInternalV1UsersUserIdSessionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetV1WellKnownJwksResponse(rsp)
-}
 
-// PostV1AuthExchangeWithBodyWithResponse request with arbitrary body returning *PostV1AuthExchangeResponse
-func (c *ClientWithResponses) PostV1AuthExchangeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostV1AuthExchangeResponse, error) {
-	rsp, err := c.PostV1AuthExchangeWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SessionListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
-	return ParsePostV1AuthExchangeResponse(rsp)
+
+	return response, nil
 }
 
-func (c *ClientWithResponses) PostV1AuthExchangeWithResponse(ctx context.Context, body PostV1AuthExchangeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostV1AuthExchangeResponse, error) {
-	rsp, err := c.PostV1AuthExchange(ctx, body, reqEditors...)
+// ParseDeleteInternalV1UsersUserIdSessionsSessionIdResponse parses an HTTP response from a DeleteInternalV1UsersUserIdSessionsSessionIdWithResponse call
+func ParseDeleteInternalV1UsersUserIdSessionsSessionIdResponse(rsp *http.Response) (*DeleteInternalV1UsersUserIdSessionsSessionIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostV1AuthExchangeResponse(rsp)
-}
 
-// GetV1HealthWithResponse request returning *GetV1HealthResponse
-func (c *ClientWithResponses) GetV1HealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetV1HealthResponse, error) {
-	rsp, err := c.GetV1Health(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &DeleteInternalV1UsersUserIdSessionsSessionIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetV1HealthResponse(rsp)
-}
 
-// GetV1UsersUserIdWithResponse request returning *GetV1UsersUserIdResponse
-func (c *ClientWithResponses) GetV1UsersUserIdWithResponse(ctx context.Context, userId openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetV1UsersUserIdResponse, error) {
-	rsp, err := c.GetV1UsersUserId(ctx, userId, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Session
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
-	return ParseGetV1UsersUserIdResponse(rsp)
+
+	return response, nil
 }
 
-// ParsePostInternalV1BackofficeTokensResponse parses an HTTP response from a PostInternalV1BackofficeTokensWithResponse call
-func ParsePostInternalV1BackofficeTokensResponse(rsp *http.Response) (*PostInternalV1BackofficeTokensResponse, error) {
+// ParsePutInternalV1UsersUserIdStatusResponse parses an HTTP response from a PutInternalV1UsersUserIdStatusWithResponse call
+func ParsePutInternalV1UsersUserIdStatusResponse(rsp *http.Response) (*PutInternalV1UsersUserIdStatusResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostInternalV1BackofficeTokensResponse{
+	response := &PutInternalV1UsersUserIdStatusResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest BackofficeTokenResponse
+		var dest PlatformIdentityResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -896,6 +5394,34 @@ func ParsePostInternalV1BackofficeTokensResponse(rsp *http.Response) (*PostInter
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest InternalError
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -954,6 +5480,74 @@ func ParsePostV1AuthExchangeResponse(rsp *http.Response) (*PostV1AuthExchangeRes
 		HTTPResponse: rsp,
 	}
 
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AuthExchangeResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostV1AuthRefreshResponse parses an HTTP response from a PostV1AuthRefreshWithResponse call
+func ParsePostV1AuthRefreshResponse(rsp *http.Response) (*PostV1AuthRefreshResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostV1AuthRefreshResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
 		var dest AuthExchangeResponse
@@ -1067,3 +5661,57 @@ func ParseGetV1UsersUserIdResponse(rsp *http.Response) (*GetV1UsersUserIdRespons
 
 	return response, nil
 }
+
+// ParsePatchV1UsersUserIdResponse parses an HTTP response from a PatchV1UsersUserIdWithResponse call
+func ParsePatchV1UsersUserIdResponse(rsp *http.Response) (*PatchV1UsersUserIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchV1UsersUserIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PlatformIdentityResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 )
 
 type Config[T any] struct {
@@ -81,6 +82,20 @@ func (Env) String(key, fallback string) string {
 	return envString(key, fallback)
 }
 
+// Duration parses a Go duration (e.g. "10m", "24h"). Invalid values are an
+// error so that services fail fast at startup.
+func (Env) Duration(key string, fallback time.Duration) (time.Duration, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", key, v, err)
+	}
+	return d, nil
+}
+
 func withDefaultOptions(opts LoaderOptions) LoaderOptions {
 	if opts.WorkingDir == "" {
 		opts.WorkingDir = "."
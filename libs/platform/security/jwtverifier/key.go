@@ -0,0 +1,165 @@
+package jwtverifier
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// JWS algorithms the verifier supports. "none" and the HMAC algorithms are
+// never accepted: a public key must not double as a shared secret.
+const (
+	AlgEdDSA = "EdDSA"
+	AlgRS256 = "RS256"
+	AlgES256 = "ES256"
+)
+
+// MinRSAKeyBits is the smallest RSA modulus accepted for RS256.
+const MinRSAKeyBits = 2048
+
+var ErrInvalidKey = errors.New("invalid key")
+
+var supportedAlgorithms = []string{AlgEdDSA, AlgRS256, AlgES256}
+
+// DefaultAlgorithms is the allowlist of a Config without Algorithms.
+var DefaultAlgorithms = []string{AlgEdDSA}
+
+// Supported reports whether alg is a JWS algorithm the verifier supports.
+func Supported(alg string) bool {
+	return slices.Contains(supportedAlgorithms, alg)
+}
+
+// ParseAlgorithms parses a comma-separated algorithm allowlist such as
+// "EdDSA,RS256". Unsupported algorithms, including "none", are rejected.
+func ParseAlgorithms(s string) ([]string, error) {
+	var algs []string
+	for _, alg := range strings.Split(s, ",") {
+		alg = strings.TrimSpace(alg)
+		if alg == "" {
+			continue
+		}
+		if !Supported(alg) {
+			return nil, fmt.Errorf("%w %q, want one of %s", ErrUnsupportedAlg, alg, strings.Join(supportedAlgorithms, ", "))
+		}
+		if !slices.Contains(algs, alg) {
+			algs = append(algs, alg)
+		}
+	}
+	if len(algs) == 0 {
+		return nil, fmt.Errorf("%w: empty algorithm list", ErrUnsupportedAlg)
+	}
+	return algs, nil
+}
+
+// Key is a verification key together with the only JWS algorithm it
+// verifies.
+type Key struct {
+	Algorithm string
+	// PublicKey is an ed25519.PublicKey for EdDSA, an *rsa.PublicKey for
+	// RS256 and an *ecdsa.PublicKey on P-256 for ES256.
+	PublicKey crypto.PublicKey
+}
+
+// Validate checks that the public key has the type, curve and size its
+// algorithm requires.
+func (k Key) Validate() error {
+	switch k.Algorithm {
+	case AlgEdDSA:
+		if pub, ok := k.PublicKey.(ed25519.PublicKey); ok && len(pub) == ed25519.PublicKeySize {
+			return nil
+		}
+	case AlgRS256:
+		if pub, ok := k.PublicKey.(*rsa.PublicKey); ok && pub.N.BitLen() >= MinRSAKeyBits {
+			return nil
+		}
+	case AlgES256:
+		if pub, ok := k.PublicKey.(*ecdsa.PublicKey); ok && pub.Curve == elliptic.P256() {
+			return nil
+		}
+	default:
+		return fmt.Errorf("%w: alg %q", ErrUnsupportedAlg, k.Algorithm)
+	}
+	return fmt.Errorf("%w: %T is no %s key", ErrInvalidKey, k.PublicKey, k.Algorithm)
+}
+
+// JWK is a public JSON Web Key (RFC 7517) as published in a JWKS.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// Key decodes the JWK into a verification key. A JWK without alg is taken
+// to be for the algorithm its key type implies (OKP: EdDSA, RSA: RS256,
+// EC: ES256). The alg must fit the key type.
+func (j JWK) Key() (Key, error) {
+	alg := j.Alg
+	if alg == "" {
+		switch j.Kty {
+		case "OKP":
+			alg = AlgEdDSA
+		case "RSA":
+			alg = AlgRS256
+		case "EC":
+			alg = AlgES256
+		}
+	}
+
+	var key Key
+	switch {
+	case j.Kty == "OKP" && j.Crv == "Ed25519":
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil {
+			return Key{}, fmt.Errorf("%w: x: %v", ErrInvalidKey, err)
+		}
+		key = Key{Algorithm: alg, PublicKey: ed25519.PublicKey(x)}
+	case j.Kty == "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil || len(n) == 0 {
+			return Key{}, fmt.Errorf("%w: invalid n", ErrInvalidKey)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return Key{}, fmt.Errorf("%w: invalid e", ErrInvalidKey)
+		}
+		exp := int(new(big.Int).SetBytes(e).Int64())
+		if exp < 3 || exp%2 == 0 {
+			return Key{}, fmt.Errorf("%w: invalid e", ErrInvalidKey)
+		}
+		key = Key{Algorithm: alg, PublicKey: &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exp}}
+	case j.Kty == "EC" && j.Crv == "P-256":
+		x, errX := base64.RawURLEncoding.DecodeString(j.X)
+		y, errY := base64.RawURLEncoding.DecodeString(j.Y)
+		if errX != nil || errY != nil || len(x) != 32 || len(y) != 32 {
+			return Key{}, fmt.Errorf("%w: invalid P-256 point", ErrInvalidKey)
+		}
+		// Go through the uncompressed encoding so the point is checked to
+		// lie on the curve.
+		pub, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), append(append([]byte{4}, x...), y...))
+		if err != nil {
+			return Key{}, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+		}
+		key = Key{Algorithm: alg, PublicKey: pub}
+	default:
+		return Key{}, fmt.Errorf("%w: kty %q crv %q", ErrInvalidKey, j.Kty, j.Crv)
+	}
+
+	if err := key.Validate(); err != nil {
+		return Key{}, err
+	}
+	return key, nil
+}
@@ -0,0 +1,163 @@
+package jwtverifier
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+)
+
+type testKeys struct {
+	ed    ed25519.PublicKey
+	rsa   *rsa.PublicKey
+	ec    *ecdsa.PublicKey
+	rsa1k *rsa.PublicKey
+	p384  *ecdsa.PublicKey
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+	ed, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsa1k, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ec, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testKeys{ed: ed, rsa: &rsaKey.PublicKey, ec: &ec.PublicKey, rsa1k: &rsa1k.PublicKey, p384: &p384.PublicKey}
+}
+
+func TestKeyValidate(t *testing.T) {
+	keys := newTestKeys(t)
+	tests := []struct {
+		name    string
+		key     Key
+		wantErr error
+	}{
+		{name: "EdDSA", key: Key{Algorithm: AlgEdDSA, PublicKey: keys.ed}},
+		{name: "RS256", key: Key{Algorithm: AlgRS256, PublicKey: keys.rsa}},
+		{name: "ES256", key: Key{Algorithm: AlgES256, PublicKey: keys.ec}},
+		{name: "EdDSA with an RSA key", key: Key{Algorithm: AlgEdDSA, PublicKey: keys.rsa}, wantErr: ErrInvalidKey},
+		{name: "RS256 with an Ed25519 key", key: Key{Algorithm: AlgRS256, PublicKey: keys.ed}, wantErr: ErrInvalidKey},
+		{name: "RS256 with a P-256 key", key: Key{Algorithm: AlgRS256, PublicKey: keys.ec}, wantErr: ErrInvalidKey},
+		{name: "ES256 with an RSA key", key: Key{Algorithm: AlgES256, PublicKey: keys.rsa}, wantErr: ErrInvalidKey},
+		{name: "ES256 with a P-384 key", key: Key{Algorithm: AlgES256, PublicKey: keys.p384}, wantErr: ErrInvalidKey},
+		{name: "RS256 with a 1024-bit key", key: Key{Algorithm: AlgRS256, PublicKey: keys.rsa1k}, wantErr: ErrInvalidKey},
+		{name: "EdDSA with a short key", key: Key{Algorithm: AlgEdDSA, PublicKey: keys.ed[:16]}, wantErr: ErrInvalidKey},
+		{name: "HS256", key: Key{Algorithm: "HS256", PublicKey: []byte("secret")}, wantErr: ErrUnsupportedAlg},
+		{name: "none", key: Key{Algorithm: "none", PublicKey: keys.ed}, wantErr: ErrUnsupportedAlg},
+		{name: "no algorithm", key: Key{PublicKey: keys.ed}, wantErr: ErrUnsupportedAlg},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.key.Validate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJWKKey(t *testing.T) {
+	keys := newTestKeys(t)
+	b64 := base64.RawURLEncoding.EncodeToString
+	okp := JWK{Kty: "OKP", Crv: "Ed25519", X: b64(keys.ed)}
+	rsaJWK := JWK{Kty: "RSA", N: b64(keys.rsa.N.Bytes()), E: b64(big.NewInt(int64(keys.rsa.E)).Bytes())}
+	ec := JWK{Kty: "EC", Crv: "P-256", X: b64(keys.ec.X.FillBytes(make([]byte, 32))), Y: b64(keys.ec.Y.FillBytes(make([]byte, 32)))}
+	with := func(j JWK, edit func(*JWK)) JWK {
+		edit(&j)
+		return j
+	}
+
+	tests := []struct {
+		name    string
+		jwk     JWK
+		wantAlg string
+		wantErr error
+	}{
+		{name: "OKP without alg", jwk: okp, wantAlg: AlgEdDSA},
+		{name: "RSA without alg", jwk: rsaJWK, wantAlg: AlgRS256},
+		{name: "EC without alg", jwk: ec, wantAlg: AlgES256},
+		{name: "EC with alg", jwk: with(ec, func(j *JWK) { j.Alg = AlgES256 }), wantAlg: AlgES256},
+		{name: "OKP with alg RS256", jwk: with(okp, func(j *JWK) { j.Alg = AlgRS256 }), wantErr: ErrInvalidKey},
+		{name: "RSA with alg ES256", jwk: with(rsaJWK, func(j *JWK) { j.Alg = AlgES256 }), wantErr: ErrInvalidKey},
+		{name: "EC with alg EdDSA", jwk: with(ec, func(j *JWK) { j.Alg = AlgEdDSA }), wantErr: ErrInvalidKey},
+		{name: "RSA with alg HS256", jwk: with(rsaJWK, func(j *JWK) { j.Alg = "HS256" }), wantErr: ErrUnsupportedAlg},
+		{name: "OKP with alg none", jwk: with(okp, func(j *JWK) { j.Alg = "none" }), wantErr: ErrUnsupportedAlg},
+		{name: "symmetric key", jwk: JWK{Kty: "oct", Alg: "HS256"}, wantErr: ErrInvalidKey},
+		{name: "X25519", jwk: with(okp, func(j *JWK) { j.Crv = "X25519" }), wantErr: ErrInvalidKey},
+		{name: "P-384", jwk: with(ec, func(j *JWK) { j.Crv = "P-384" }), wantErr: ErrInvalidKey},
+		{name: "point off the curve", jwk: with(ec, func(j *JWK) { j.Y = b64(make([]byte, 32)) }), wantErr: ErrInvalidKey},
+		{name: "1024-bit RSA", jwk: with(rsaJWK, func(j *JWK) { j.N = b64(keys.rsa1k.N.Bytes()) }), wantErr: ErrInvalidKey},
+		{name: "even RSA exponent", jwk: with(rsaJWK, func(j *JWK) { j.E = b64([]byte{2}) }), wantErr: ErrInvalidKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := tt.jwk.Key()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Key() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Key() error = %v", err)
+			}
+			if key.Algorithm != tt.wantAlg {
+				t.Errorf("algorithm = %q, want %q", key.Algorithm, tt.wantAlg)
+			}
+		})
+	}
+}
+
+func TestParseAlgorithms(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{in: "EdDSA", want: []string{AlgEdDSA}},
+		{in: " EdDSA, RS256,EdDSA,", want: []string{AlgEdDSA, AlgRS256}},
+		{in: "ES256", want: []string{AlgES256}},
+		{in: "none", wantErr: true},
+		{in: "EdDSA,none", wantErr: true},
+		{in: "HS256", wantErr: true},
+		{in: "RS384", wantErr: true},
+		{in: "eddsa", wantErr: true},
+		{in: "", wantErr: true},
+		{in: " , ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseAlgorithms(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupportedAlg) {
+					t.Fatalf("ParseAlgorithms(%q) = %v, %v, want %v", tt.in, got, err, ErrUnsupportedAlg)
+				}
+				return
+			}
+			if err != nil || !slices.Equal(got, tt.want) {
+				t.Errorf("ParseAlgorithms(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
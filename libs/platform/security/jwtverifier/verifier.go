@@ -4,6 +4,7 @@ import (
 	"crypto/ed25519"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v5"
@@ -12,6 +13,7 @@ import (
 var (
 	ErrUnauthorized     = errors.New("unauthorized")
 	ErrUnsupportedAlg   = errors.New("unsupported jwt alg")
+	ErrKeyAlgMismatch   = errors.New("key does not match jwt alg")
 	ErrUnknownKeyID     = errors.New("unknown kid")
 	ErrMissingKeyID     = errors.New("missing kid")
 	ErrInvalidToken     = errors.New("invalid token")
@@ -31,29 +33,69 @@ type Config struct {
 
 	// Map of kid -> Ed25519 public key.
 	// This matches how JWT header "kid" selects the verification key.
+	//
+	// Deprecated: Keys only verifies EdDSA tokens. Use KeySet, which
+	// carries the algorithm of every key.
 	Keys map[string]ed25519.PublicKey
 
+	// Map of kid -> verification key and its algorithm. When set, it takes
+	// precedence over Keys.
+	KeySet map[string]Key
+
+	// Optional dynamic key source (e.g. a refreshing JWKS cache).
+	// When set, it takes precedence over KeySet and Keys.
+	KeySource KeySource
+
+	// Allowed JWS algorithms. If empty, DefaultAlgorithms. Algorithms the
+	// verifier does not support are ignored; "none" never verifies.
+	Algorithms []string
+
 	// Optional clock skew to tolerate (e.g. 30s) when validating time claims.
 	Leeway time.Duration
 }
 
+// KeySource resolves verification keys by kid.
+type KeySource interface {
+	Key(kid string) (Key, bool)
+}
+
 type Claims struct {
 	Subject     string
 	Tenant      string
 	SubjectType string
+	Audience    []string
 	Scopes      []string
 	SessionID   string // optional; empty if not present
+	Actor       *Actor // optional; act claim of delegated tokens
 	KeyID       string
 	ExpiresAt   time.Time
 	IssuedAt    time.Time
 }
 
+// Actor is the act claim (RFC 8693 §4.1) of a delegated token: the party
+// acting for the subject and, nested, the actor before it.
+type Actor struct {
+	Subject string
+	Actor   *Actor
+}
+
 type Verifier struct {
-	cfg Config
+	cfg        Config
+	algorithms []string
 }
 
 func New(cfg Config) *Verifier {
-	return &Verifier{cfg: cfg}
+	algs := cfg.Algorithms
+	if len(algs) == 0 {
+		algs = DefaultAlgorithms
+	}
+	allowed := make([]string, 0, len(algs))
+	for _, alg := range algs {
+		if slices.Contains(supportedAlgorithms, alg) && !slices.Contains(allowed, alg) {
+			allowed = append(allowed, alg)
+		}
+	}
+	return &Verifier{cfg: cfg, algorithms: allowed}
 }
 
 // Verify verifies signature + standard claims and extracts your domain claims.
@@ -67,7 +109,7 @@ func (v *Verifier) Verify(rawToken string) (Claims, error) {
 	// but we want to enforce presence early.
 	keyFunc := func(t *jwt.Token) (interface{}, error) {
 		// Enforce algorithm
-		if t.Method == nil || t.Method.Alg() != jwt.SigningMethodEdDSA.Alg() {
+		if t.Method == nil || !slices.Contains(v.algorithms, t.Method.Alg()) {
 			return nil, wrap(ErrUnauthorized, ErrUnsupportedAlg)
 		}
 
@@ -80,16 +122,22 @@ func (v *Verifier) Verify(rawToken string) (Claims, error) {
 			return nil, wrap(ErrUnauthorized, ErrMissingKeyID)
 		}
 
-		pub, ok := v.cfg.Keys[kid]
+		key, ok := v.key(kid)
 		if !ok {
 			return nil, wrap(ErrUnauthorized, ErrUnknownKeyID)
 		}
 
-		return pub, nil
+		// A key only verifies the algorithm it was published for, so a
+		// token cannot pick another algorithm for the same key material.
+		if key.Algorithm != t.Method.Alg() || key.Validate() != nil {
+			return nil, wrap(ErrUnauthorized, ErrKeyAlgMismatch)
+		}
+
+		return key.PublicKey, nil
 	}
 
 	parserOpts := []jwt.ParserOption{
-		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithValidMethods(v.algorithms),
 	}
 	if v.cfg.Leeway > 0 {
 		parserOpts = append(parserOpts, jwt.WithLeeway(v.cfg.Leeway))
@@ -154,6 +202,13 @@ func (v *Verifier) Verify(rawToken string) (Claims, error) {
 	// session id (optional): "sid"
 	sid, _ := claims["sid"].(string)
 
+	// aud (string or array) and act (optional)
+	aud, _ := claims.GetAudience()
+	actor, err := parseActor(claims["act"])
+	if err != nil {
+		return Claims{}, wrap(ErrUnauthorized, err)
+	}
+
 	// exp/iat (optional but useful)
 	var exp time.Time
 	if expF, ok := claims["exp"].(float64); ok && expF > 0 {
@@ -168,14 +223,49 @@ func (v *Verifier) Verify(rawToken string) (Claims, error) {
 		Subject:     sub,
 		Tenant:      tenant,
 		SubjectType: subjectType,
+		Audience:    aud,
 		Scopes:      scopes,
 		SessionID:   sid,
+		Actor:       actor,
 		KeyID:       kid,
 		ExpiresAt:   exp,
 		IssuedAt:    iat,
 	}, nil
 }
 
+func (v *Verifier) key(kid string) (Key, bool) {
+	if v.cfg.KeySource != nil {
+		return v.cfg.KeySource.Key(kid)
+	}
+	if v.cfg.KeySet != nil {
+		key, ok := v.cfg.KeySet[kid]
+		return key, ok
+	}
+	pub, ok := v.cfg.Keys[kid]
+	return Key{Algorithm: AlgEdDSA, PublicKey: pub}, ok
+}
+
+// parseActor reads a nested act claim. Every level must be an object with a
+// non-empty sub.
+func parseActor(v interface{}) (*Actor, error) {
+	if v == nil {
+		return nil, nil
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidClaims
+	}
+	sub, _ := obj["sub"].(string)
+	if sub == "" {
+		return nil, ErrInvalidClaims
+	}
+	inner, err := parseActor(obj["act"])
+	if err != nil {
+		return nil, err
+	}
+	return &Actor{Subject: sub, Actor: inner}, nil
+}
+
 func splitScopes(s string) []string {
 	out := make([]string, 0, 8)
 	start := -1
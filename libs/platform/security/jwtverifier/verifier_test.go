@@ -0,0 +1,202 @@
+package jwtverifier
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+func TestVerifyAlgorithms(t *testing.T) {
+	_, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub := edPriv.Public().(ed25519.PublicKey)
+	rsaDER, err := x509.MarshalPKIXPublicKey(&rsaPriv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := map[string]Key{
+		"ed":  {Algorithm: AlgEdDSA, PublicKey: edPub},
+		"rsa": {Algorithm: AlgRS256, PublicKey: &rsaPriv.PublicKey},
+		"ec":  {Algorithm: AlgES256, PublicKey: &ecPriv.PublicKey},
+		// Published for the wrong algorithm.
+		"rsa-as-es": {Algorithm: AlgES256, PublicKey: &rsaPriv.PublicKey},
+	}
+
+	tests := []struct {
+		name   string
+		method jwt.SigningMethod
+		// key signs the token; kid selects the verification key.
+		key        crypto.PrivateKey
+		kid        string
+		noKid      bool
+		algorithms []string
+		wantErr    bool
+	}{
+		{name: "EdDSA", method: jwt.SigningMethodEdDSA, key: edPriv, kid: "ed"},
+		{name: "RS256", method: jwt.SigningMethodRS256, key: rsaPriv, kid: "rsa", algorithms: []string{AlgRS256}},
+		{name: "ES256", method: jwt.SigningMethodES256, key: ecPriv, kid: "ec", algorithms: []string{AlgES256}},
+		{
+			name:   "RS256 outside the default allowlist",
+			method: jwt.SigningMethodRS256, key: rsaPriv, kid: "rsa",
+			wantErr: true,
+		},
+		{
+			name:   "ES256 outside the allowlist",
+			method: jwt.SigningMethodES256, key: ecPriv, kid: "ec",
+			algorithms: []string{AlgEdDSA, AlgRS256},
+			wantErr:    true,
+		},
+		{
+			name:   "none",
+			method: jwt.SigningMethodNone, key: jwt.UnsafeAllowNoneSignatureType, kid: "ed",
+			algorithms: []string{AlgEdDSA, "none"},
+			wantErr:    true,
+		},
+		{
+			name:   "HS256 keyed with an RSA public key",
+			method: jwt.SigningMethodHS256, key: rsaDER, kid: "rsa",
+			algorithms: []string{AlgRS256, "HS256"},
+			wantErr:    true,
+		},
+		{
+			name:   "HS256 keyed with an Ed25519 public key",
+			method: jwt.SigningMethodHS256, key: []byte(edPub), kid: "ed",
+			algorithms: []string{AlgEdDSA, "HS256"},
+			wantErr:    true,
+		},
+		{
+			name:   "kid of a key for another algorithm",
+			method: jwt.SigningMethodES256, key: ecPriv, kid: "rsa",
+			algorithms: []string{AlgRS256, AlgES256},
+			wantErr:    true,
+		},
+		{
+			name:   "RS256 token for an EdDSA key",
+			method: jwt.SigningMethodRS256, key: rsaPriv, kid: "ed",
+			algorithms: []string{AlgEdDSA, AlgRS256},
+			wantErr:    true,
+		},
+		{
+			name:   "key published for the wrong algorithm",
+			method: jwt.SigningMethodES256, key: ecPriv, kid: "rsa-as-es",
+			algorithms: []string{AlgES256},
+			wantErr:    true,
+		},
+		{
+			name:   "signed by another key",
+			method: jwt.SigningMethodES256, key: mustECKey(t), kid: "ec",
+			algorithms: []string{AlgES256},
+			wantErr:    true,
+		},
+		{name: "unknown kid", method: jwt.SigningMethodEdDSA, key: edPriv, kid: "other", wantErr: true},
+		{name: "no kid", method: jwt.SigningMethodEdDSA, key: edPriv, noKid: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tok := jwt.NewWithClaims(tt.method, jwt.MapClaims{
+				"iss":    "issuer",
+				"aud":    "api",
+				"sub":    "user-1",
+				"tenant": "tenant-a",
+				"iat":    time.Now().Unix(),
+				"exp":    time.Now().Add(time.Minute).Unix(),
+			})
+			if !tt.noKid {
+				tok.Header["kid"] = tt.kid
+			}
+			raw, err := tok.SignedString(tt.key)
+			if err != nil {
+				t.Fatalf("sign: %v", err)
+			}
+
+			v := New(Config{Issuer: "issuer", Audience: "api", KeySet: keys, Algorithms: tt.algorithms})
+			claims, err := v.Verify(raw)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnauthorized) {
+					t.Fatalf("Verify error = %v, want %v", err, ErrUnauthorized)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if claims.Subject != "user-1" || claims.KeyID != tt.kid {
+				t.Errorf("claims = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestVerifyDeprecatedKeys(t *testing.T) {
+	_, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := New(Config{
+		Keys:       map[string]ed25519.PublicKey{"ed": edPriv.Public().(ed25519.PublicKey)},
+		Algorithms: []string{AlgEdDSA, AlgRS256},
+	})
+
+	tests := []struct {
+		name    string
+		method  jwt.SigningMethod
+		key     crypto.PrivateKey
+		kid     string
+		wantErr bool
+	}{
+		{name: "EdDSA", method: jwt.SigningMethodEdDSA, key: edPriv, kid: "ed"},
+		{name: "RS256 token for the Ed25519 key", method: jwt.SigningMethodRS256, key: rsaPriv, kid: "ed", wantErr: true},
+		{name: "unknown kid", method: jwt.SigningMethodEdDSA, key: edPriv, kid: "other", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tok := jwt.NewWithClaims(tt.method, jwt.MapClaims{
+				"sub":    "user-1",
+				"tenant": "tenant-a",
+				"exp":    time.Now().Add(time.Minute).Unix(),
+			})
+			tok.Header["kid"] = tt.kid
+			raw, err := tok.SignedString(tt.key)
+			if err != nil {
+				t.Fatalf("sign: %v", err)
+			}
+			_, err = v.Verify(raw)
+			if tt.wantErr != errors.Is(err, ErrUnauthorized) || !tt.wantErr && err != nil {
+				t.Errorf("Verify error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func mustECKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
@@ -0,0 +1,112 @@
+package serviceauth
+
+import (
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// ClientConfig configures the credentials a caller presents to a service.
+// Either or both of a token key and a client certificate may be set; with
+// neither the client sends no credentials.
+type ClientConfig struct {
+	// Caller names the calling service in its service tokens.
+	Caller string
+	// TokenKeyFile or TokenKey hold the PEM (PKCS#8) Ed25519 key service
+	// tokens addressed to TokenAudience are signed with.
+	TokenKeyFile  string
+	TokenKey      string
+	TokenAudience string
+	// CAFile verifies the server certificate of an https endpoint. Empty
+	// uses the system roots.
+	CAFile string
+	// CertFile and KeyFile are the PEM client certificate and key
+	// presented for mTLS.
+	CertFile string
+	KeyFile  string
+}
+
+// NewHTTPClient returns an HTTP client presenting the configured
+// credentials.
+func NewHTTPClient(cfg ClientConfig, timeout time.Duration) (*http.Client, error) {
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, errors.New("client certificate and key must be set together")
+	}
+	if cfg.TokenKeyFile != "" && cfg.TokenKey != "" {
+		return nil, errors.New("set only one of the service token key file and inline key")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.CAFile != "" || cfg.CertFile != "" {
+		tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+		if cfg.CAFile != "" {
+			pool, err := loadCertPool(cfg.CAFile)
+			if err != nil {
+				return nil, err
+			}
+			tlsCfg.RootCAs = pool
+		}
+		if cfg.CertFile != "" {
+			cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("load client certificate: %w", err)
+			}
+			tlsCfg.Certificates = []tls.Certificate{cert}
+		}
+		transport.TLSClientConfig = tlsCfg
+	}
+
+	var rt http.RoundTripper = transport
+	if cfg.TokenKeyFile != "" || cfg.TokenKey != "" {
+		key, err := cfg.tokenKey()
+		if err != nil {
+			return nil, err
+		}
+		signer := NewSigner(cfg.Caller, cfg.TokenAudience, key, MaxTokenTTL)
+		rt = &tokenTransport{signer: signer, base: transport}
+	}
+	return &http.Client{Transport: rt, Timeout: timeout}, nil
+}
+
+func (cfg ClientConfig) tokenKey() (ed25519.PrivateKey, error) {
+	if cfg.Caller == "" || cfg.TokenAudience == "" {
+		return nil, errors.New("service tokens need a caller and an audience")
+	}
+	if cfg.TokenKeyFile != "" {
+		return LoadPrivateKeyFile(cfg.TokenKeyFile)
+	}
+	return ParsePrivateKey([]byte(cfg.TokenKey))
+}
+
+// tokenTransport adds a service token as bearer credentials.
+type tokenTransport struct {
+	signer *Signer
+	base   http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.signer.Token()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(req)
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read CA file %s: %w", path, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("CA file %s: no certificates", path)
+	}
+	return pool, nil
+}
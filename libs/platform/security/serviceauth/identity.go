@@ -0,0 +1,61 @@
+package serviceauth
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	platformconfig "github.com/woffVienna/proteon-cursor/libs/platform/config"
+)
+
+// identityTimeout bounds calls to the identity service's /internal routes.
+const identityTimeout = 10 * time.Second
+
+// IdentityClientConfig holds the credentials presented on the identity
+// service's /internal routes: a service token key, a client certificate,
+// or both.
+type IdentityClientConfig struct {
+	// InternalURL is the base URL for /internal calls, e.g. identity's
+	// mTLS listener. Defaults to IDENTITY_URL.
+	InternalURL string
+	// TokenKeyFile or TokenKey hold the PEM Ed25519 key service tokens are
+	// signed with; TokenAudience must match identity's
+	// SERVICE_TOKEN_AUDIENCE.
+	TokenKeyFile  string
+	TokenKey      string
+	TokenAudience string
+	CAFile        string
+	CertFile      string
+	KeyFile       string
+}
+
+// IdentityClientConfigFromEnv reads the identity client settings shared by
+// the services calling identity. identityURL is the service's IDENTITY_URL.
+func IdentityClientConfigFromEnv(env platformconfig.Env, identityURL string) IdentityClientConfig {
+	return IdentityClientConfig{
+		InternalURL:   env.String("IDENTITY_INTERNAL_URL", identityURL),
+		TokenKeyFile:  env.String("SERVICE_TOKEN_KEY_FILE", ""),
+		TokenKey:      env.String("SERVICE_TOKEN_KEY", ""),
+		TokenAudience: env.String("IDENTITY_SERVICE_AUDIENCE", "identity-service"),
+		CAFile:        env.String("IDENTITY_CA_FILE", ""),
+		CertFile:      env.String("IDENTITY_CLIENT_CERT_FILE", ""),
+		KeyFile:       env.String("IDENTITY_CLIENT_KEY_FILE", ""),
+	}
+}
+
+// NewIdentityHTTPClient returns the client caller uses for identity's
+// /internal routes.
+func NewIdentityHTTPClient(caller string, cfg IdentityClientConfig) (*http.Client, error) {
+	if cfg.TokenKeyFile == "" && cfg.TokenKey == "" && cfg.CertFile == "" {
+		log.Printf("WARNING: no service credentials configured; identity accepts this only with ENV=dev")
+	}
+	return NewHTTPClient(ClientConfig{
+		Caller:        caller,
+		TokenKeyFile:  cfg.TokenKeyFile,
+		TokenKey:      cfg.TokenKey,
+		TokenAudience: cfg.TokenAudience,
+		CAFile:        cfg.CAFile,
+		CertFile:      cfg.CertFile,
+		KeyFile:       cfg.KeyFile,
+	}, identityTimeout)
+}
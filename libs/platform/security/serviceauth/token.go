@@ -0,0 +1,127 @@
+// Package serviceauth authenticates service-to-service calls, either with
+// short-lived Ed25519 signed service tokens or with mTLS client
+// certificates.
+package serviceauth
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+)
+
+// MaxTokenTTL is the longest lifetime a service token may have.
+const MaxTokenTTL = 5 * time.Minute
+
+var (
+	ErrInvalidServiceToken = errors.New("invalid service token")
+	ErrUnknownCaller       = errors.New("unknown caller")
+)
+
+// Signer issues service tokens for one caller. Tokens are reused until half
+// of their lifetime has passed.
+type Signer struct {
+	caller   string
+	audience string
+	key      ed25519.PrivateKey
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	renewAt time.Time
+}
+
+// NewSigner creates a signer for caller tokens addressed to audience. ttl
+// is capped at MaxTokenTTL.
+func NewSigner(caller, audience string, key ed25519.PrivateKey, ttl time.Duration) *Signer {
+	if ttl <= 0 || ttl > MaxTokenTTL {
+		ttl = MaxTokenTTL
+	}
+	return &Signer{
+		caller:   caller,
+		audience: audience,
+		key:      key,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+// Token returns a valid service token.
+func (s *Signer) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.token != "" && now.Before(s.renewAt) {
+		return s.token, nil
+	}
+
+	t := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.RegisteredClaims{
+		Issuer:    s.caller,
+		Subject:   s.caller,
+		Audience:  jwt.ClaimStrings{s.audience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(s.ttl)),
+	})
+	t.Header["kid"] = s.caller
+	token, err := t.SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("sign service token: %w", err)
+	}
+	s.token = token
+	s.renewAt = now.Add(s.ttl / 2)
+	return token, nil
+}
+
+// Verifier verifies service tokens against the public keys of known callers.
+type Verifier struct {
+	audience string
+	keys     map[string]ed25519.PublicKey
+	leeway   time.Duration
+}
+
+// NewVerifier creates a verifier accepting tokens addressed to audience and
+// signed by one of keys (caller -> public key).
+func NewVerifier(audience string, keys map[string]ed25519.PublicKey, leeway time.Duration) *Verifier {
+	return &Verifier{audience: audience, keys: keys, leeway: leeway}
+}
+
+// Callers returns the number of known callers.
+func (v *Verifier) Callers() int {
+	return len(v.keys)
+}
+
+// Verify returns the caller a token was issued for. Errors wrap
+// ErrInvalidServiceToken.
+func (v *Verifier) Verify(token string) (string, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithAudience(v.audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(v.leeway),
+	)
+
+	var claims jwt.RegisteredClaims
+	_, err := parser.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		key, ok := v.keys[claims.Issuer]
+		if !ok {
+			return nil, ErrUnknownCaller
+		}
+		return key, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidServiceToken, err)
+	}
+	if claims.Subject != claims.Issuer || claims.IssuedAt == nil {
+		return "", fmt.Errorf("%w: sub must equal iss", ErrInvalidServiceToken)
+	}
+	if claims.ExpiresAt.Sub(claims.IssuedAt.Time) > MaxTokenTTL {
+		return "", fmt.Errorf("%w: lifetime exceeds %s", ErrInvalidServiceToken, MaxTokenTTL)
+	}
+	return claims.Issuer, nil
+}
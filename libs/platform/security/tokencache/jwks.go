@@ -0,0 +1,247 @@
+// Package tokencache keeps the signing keys and session revocations of the
+// identity service in memory, for the gateways verifying its access tokens
+// locally.
+package tokencache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
+)
+
+// discoveryPath is where identity serves its OpenID Connect discovery
+// document.
+const discoveryPath = "/.well-known/openid-configuration"
+
+const (
+	defaultJWKSRefreshInterval = 5 * time.Minute
+	minJWKSRefreshInterval     = 10 * time.Second
+	jwksRetryInterval          = 10 * time.Second
+)
+
+// discoveryResponse holds the fields of the discovery document the gateways
+// use.
+type discoveryResponse struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+type jwksResponse struct {
+	Keys []jwtverifier.JWK `json:"keys"`
+}
+
+// Discover fetches the OpenID Connect discovery document of the identity
+// service and returns the token issuer and the URL of the JWKS. The JWKS is
+// fetched from jwks_uri with the scheme and host of identityURL: the
+// document advertises identity's public URL, which the gateways may not be
+// able to reach.
+func Discover(identityURL string) (issuer, jwksURL string, err error) {
+	internal, err := url.Parse(identityURL)
+	if err != nil {
+		return "", "", fmt.Errorf("parse identity URL %q: %w", identityURL, err)
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	resp, err := client.Get(identityURL + discoveryPath)
+	if err != nil {
+		return "", "", fmt.Errorf("fetch discovery document from %s: %w", identityURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("fetch discovery document: unexpected status %d", resp.StatusCode)
+	}
+
+	var doc discoveryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return "", "", fmt.Errorf("decode discovery document: %w", err)
+	}
+	if doc.Issuer == "" || doc.JWKSURI == "" {
+		return "", "", fmt.Errorf("discovery document from %s has no issuer or jwks_uri", identityURL)
+	}
+	jwksURI, err := url.Parse(doc.JWKSURI)
+	if err != nil {
+		return "", "", fmt.Errorf("parse jwks_uri %q: %w", doc.JWKSURI, err)
+	}
+	jwksURI.Scheme, jwksURI.Host = internal.Scheme, internal.Host
+
+	return doc.Issuer, jwksURI.String(), nil
+}
+
+// FetchJWKS fetches the JWKS at jwksURL and returns a map of kid ->
+// verification key together with the Cache-Control max-age of the response
+// (zero if absent). Only signing keys for one of algs are kept; malformed
+// keys and keys whose alg does not fit their key type are logged and
+// skipped. Fails if the JWKS is unreachable or has no usable keys.
+func FetchJWKS(jwksURL string, algs []string) (map[string]jwtverifier.Key, time.Duration, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	resp, err := client.Get(jwksURL)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetch JWKS from %s: %w", jwksURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var jwks jwksResponse
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return nil, 0, fmt.Errorf("decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]jwtverifier.Key)
+	for _, entry := range jwks.Keys {
+		if entry.Kid == "" || (entry.Use != "" && entry.Use != "sig") {
+			continue
+		}
+		if entry.Alg != "" && !slices.Contains(algs, entry.Alg) {
+			continue
+		}
+		key, err := entry.Key()
+		if err != nil {
+			log.Printf("skipping JWK %s from %s: %v", entry.Kid, jwksURL, err)
+			continue
+		}
+		if !slices.Contains(algs, key.Algorithm) {
+			continue
+		}
+
+		keys[entry.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, 0, fmt.Errorf("no %s keys found in JWKS from %s", strings.Join(algs, "/"), jwksURL)
+	}
+
+	return keys, cacheMaxAge(resp.Header.Get("Cache-Control")), nil
+}
+
+// JWKSCache keeps the identity JWKS in memory and refreshes it in the
+// background, honouring the Cache-Control max-age of the JWKS response.
+// Keys published ahead of rotation are therefore known before identity
+// starts signing with them. The JWKS is located through identity's
+// discovery document on every refresh. Implements jwtverifier.KeySource.
+type JWKSCache struct {
+	identityURL string
+	algs        []string
+
+	mu     sync.RWMutex
+	issuer string
+	keys   map[string]jwtverifier.Key
+	maxAge time.Duration
+}
+
+// NewJWKSCache creates an empty cache for the tokens of issuer signed with
+// one of algs; an empty issuer is taken from the discovery document on the
+// first refresh. Call Refresh before serving traffic.
+func NewJWKSCache(identityURL, issuer string, algs []string) *JWKSCache {
+	return &JWKSCache{identityURL: identityURL, issuer: issuer, algs: algs}
+}
+
+// Refresh discovers and fetches the JWKS and replaces the cached keys. A
+// discovery document naming another issuer fails the refresh. On failure
+// the previously cached keys are kept.
+func (c *JWKSCache) Refresh() error {
+	issuer, jwksURL, err := Discover(c.identityURL)
+	if err != nil {
+		return err
+	}
+	if want := c.Issuer(); want != "" && issuer != want {
+		return fmt.Errorf("identity issuer %q does not match %q", issuer, want)
+	}
+	keys, maxAge, err := FetchJWKS(jwksURL, c.algs)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.issuer = issuer
+	c.keys = keys
+	c.maxAge = maxAge
+	c.mu.Unlock()
+	return nil
+}
+
+// Issuer returns the issuer whose tokens the cached keys verify.
+func (c *JWKSCache) Issuer() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.issuer
+}
+
+// Len returns the number of cached keys.
+func (c *JWKSCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.keys)
+}
+
+// Key implements jwtverifier.KeySource.
+func (c *JWKSCache) Key(kid string) (jwtverifier.Key, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	key, ok := c.keys[kid]
+	return key, ok
+}
+
+// Run refreshes the cache until ctx is cancelled.
+func (c *JWKSCache) Run(ctx context.Context) {
+	wait := c.refreshInterval()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		if err := c.Refresh(); err != nil {
+			log.Printf("JWKS refresh failed (keeping %d cached key(s)): %v", c.Len(), err)
+			wait = jwksRetryInterval
+			continue
+		}
+		wait = c.refreshInterval()
+	}
+}
+
+func (c *JWKSCache) refreshInterval() time.Duration {
+	c.mu.RLock()
+	maxAge := c.maxAge
+	c.mu.RUnlock()
+
+	switch {
+	case maxAge <= 0:
+		return defaultJWKSRefreshInterval
+	case maxAge < minJWKSRefreshInterval:
+		return minJWKSRefreshInterval
+	default:
+		return maxAge
+	}
+}
+
+// cacheMaxAge extracts max-age from a Cache-Control header value.
+func cacheMaxAge(header string) time.Duration {
+	for _, directive := range strings.Split(header, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(directive), "=")
+		if !ok || !strings.EqualFold(name, "max-age") {
+			continue
+		}
+		secs, err := strconv.Atoi(strings.Trim(value, `"`))
+		if err != nil || secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	return 0
+}
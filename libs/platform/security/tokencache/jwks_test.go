@@ -0,0 +1,109 @@
+package tokencache
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
+)
+
+func TestDiscover(t *testing.T) {
+	tests := []struct {
+		name string
+		// prefix is the path identity is served under on the internal URL.
+		prefix  string
+		jwksURI string
+		// wantPath is the path and query of the JWKS URL on the internal host.
+		wantPath string
+	}{
+		{name: "public URL", jwksURI: "https://id.example.com/v1/.well-known/jwks.json", wantPath: "/v1/.well-known/jwks.json"},
+		{
+			name: "behind a path prefix", prefix: "/identity",
+			jwksURI: "https://api.example.com/identity/v1/.well-known/jwks.json", wantPath: "/identity/v1/.well-known/jwks.json",
+		},
+		{name: "with a query", jwksURI: "https://id.example.com/keys?v=2", wantPath: "/keys?v=2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.prefix+discoveryPath {
+					http.NotFound(w, r)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string]string{"issuer": "proteon.identity", "jwks_uri": tt.jwksURI})
+			}))
+			defer srv.Close()
+
+			issuer, jwksURL, err := Discover(srv.URL + tt.prefix)
+			if err != nil {
+				t.Fatalf("Discover: %v", err)
+			}
+			if issuer != "proteon.identity" {
+				t.Errorf("issuer = %q, want proteon.identity", issuer)
+			}
+			if want := srv.URL + tt.wantPath; jwksURL != want {
+				t.Errorf("JWKS URL = %q, want %q", jwksURL, want)
+			}
+		})
+	}
+}
+
+func TestFetchJWKS(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := base64.RawURLEncoding.EncodeToString(pub)
+	good := jwtverifier.JWK{Kty: "OKP", Kid: "good", Alg: jwtverifier.AlgEdDSA, Use: "sig", Crv: "Ed25519", X: x}
+	malformed := jwtverifier.JWK{Kty: "OKP", Kid: "malformed", Alg: jwtverifier.AlgEdDSA, Crv: "Ed25519", X: "not base64!"}
+	mismatched := jwtverifier.JWK{Kty: "RSA", Kid: "mismatched", Alg: jwtverifier.AlgEdDSA, N: x, E: "AQAB"}
+	otherAlg := jwtverifier.JWK{Kty: "OKP", Kid: "other-alg", Alg: jwtverifier.AlgRS256, Crv: "Ed25519", X: x}
+	encryption := jwtverifier.JWK{Kty: "OKP", Kid: "encryption", Use: "enc", Crv: "Ed25519", X: x}
+
+	tests := []struct {
+		name     string
+		keys     []jwtverifier.JWK
+		wantKids []string
+		wantErr  bool
+	}{
+		{name: "signing key", keys: []jwtverifier.JWK{good}, wantKids: []string{"good"}},
+		{name: "malformed keys skipped", keys: []jwtverifier.JWK{malformed, mismatched, good}, wantKids: []string{"good"}},
+		{name: "other algorithms and uses skipped", keys: []jwtverifier.JWK{otherAlg, good, encryption}, wantKids: []string{"good"}},
+		{name: "no usable key", keys: []jwtverifier.JWK{malformed, encryption}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Cache-Control", "public, max-age=300")
+				_ = json.NewEncoder(w).Encode(map[string][]jwtverifier.JWK{"keys": tt.keys})
+			}))
+			defer srv.Close()
+
+			keys, maxAge, err := FetchJWKS(srv.URL, []string{jwtverifier.AlgEdDSA})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FetchJWKS error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var kids []string
+			for kid := range keys {
+				kids = append(kids, kid)
+			}
+			slices.Sort(kids)
+			if !slices.Equal(kids, tt.wantKids) {
+				t.Errorf("kids = %v, want %v", kids, tt.wantKids)
+			}
+			if maxAge != 5*time.Minute {
+				t.Errorf("max-age = %v, want 5m", maxAge)
+			}
+		})
+	}
+}
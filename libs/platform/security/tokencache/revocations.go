@@ -0,0 +1,128 @@
+package tokencache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// revocationOverlap is subtracted from the previous as_of when polling so
+// that revocations committed while the previous poll ran are not missed.
+const revocationOverlap = 5 * time.Second
+
+type revocationListResponse struct {
+	Revocations []revocationEntry `json:"revocations"`
+	AsOf        time.Time         `json:"as_of"`
+}
+
+type revocationEntry struct {
+	Sid       string    `json:"sid"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// RevocationCache keeps the session revocation list of the identity service
+// in memory and polls it for changes. Tokens whose sid is listed are
+// rejected although their signature and exp are valid.
+type RevocationCache struct {
+	identityURL string
+	interval    time.Duration
+	client      *http.Client
+
+	mu      sync.RWMutex
+	revoked map[string]time.Time
+	asOf    time.Time
+}
+
+// NewRevocationCache creates an empty cache that polls every interval.
+// client presents the service credentials required on identity's /internal
+// routes. Call Refresh before serving traffic.
+func NewRevocationCache(identityURL string, interval time.Duration, client *http.Client) *RevocationCache {
+	return &RevocationCache{
+		identityURL: identityURL,
+		interval:    interval,
+		client:      client,
+		revoked:     make(map[string]time.Time),
+	}
+}
+
+// Refresh fetches revocations made since the previous refresh (all relevant
+// revocations on the first call) and drops entries whose tokens have expired.
+func (c *RevocationCache) Refresh() error {
+	c.mu.RLock()
+	asOf := c.asOf
+	c.mu.RUnlock()
+
+	endpoint := c.identityURL + "/internal/v1/revocations"
+	if !asOf.IsZero() {
+		endpoint += "?since=" + url.QueryEscape(asOf.Add(-revocationOverlap).Format(time.RFC3339Nano))
+	}
+
+	resp, err := c.client.Get(endpoint)
+	if err != nil {
+		return fmt.Errorf("fetch revocations from %s: %w", c.identityURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch revocations: unexpected status %d", resp.StatusCode)
+	}
+
+	var list revocationListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return fmt.Errorf("decode revocations: %w", err)
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range list.Revocations {
+		if r.Sid != "" {
+			c.revoked[r.Sid] = r.ExpiresAt
+		}
+	}
+	for sid, expiresAt := range c.revoked {
+		if !expiresAt.After(now) {
+			delete(c.revoked, sid)
+		}
+	}
+	c.asOf = list.AsOf
+	return nil
+}
+
+// Len returns the number of cached revocations.
+func (c *RevocationCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.revoked)
+}
+
+// Revoked reports whether the session with the given ID has been revoked.
+func (c *RevocationCache) Revoked(sessionID string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.revoked[sessionID]
+	return ok
+}
+
+// Run polls for revocations until ctx is cancelled. On failure the cached
+// revocations are kept.
+func (c *RevocationCache) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := c.Refresh(); err != nil {
+			log.Printf("revocation refresh failed (keeping %d cached revocation(s)): %v", c.Len(), err)
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
 	"time"
 
 	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
-	"github.com/woffVienna/proteon-cursor/services/api-gateway/internal/adapters/auth"
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/serviceauth"
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/tokencache"
 	httpadapter "github.com/woffVienna/proteon-cursor/services/api-gateway/internal/adapters/http"
 	"github.com/woffVienna/proteon-cursor/services/api-gateway/internal/adapters/http/middleware"
 	"github.com/woffVienna/proteon-cursor/services/api-gateway/internal/adapters/http/proxy"
@@ -18,21 +20,34 @@ func main() {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
-	log.Printf("fetching JWKS from %s", cfg.Service.Upstream.IdentityURL)
-	keys, err := auth.FetchJWKS(cfg.Service.Upstream.IdentityURL)
-	if err != nil {
+	log.Printf("fetching JWKS through the discovery document of %s", cfg.Service.Upstream.IdentityURL)
+	jwks := tokencache.NewJWKSCache(cfg.Service.Upstream.IdentityURL, cfg.Service.JWT.Issuer, cfg.Service.JWT.Algorithms)
+	if err := jwks.Refresh(); err != nil {
 		log.Fatalf("failed to fetch JWKS: %v", err)
 	}
-	log.Printf("loaded %d signing key(s) from identity", len(keys))
+	log.Printf("loaded %d signing key(s) of issuer %s from identity", jwks.Len(), jwks.Issuer())
+	go jwks.Run(context.Background())
+
+	identityHTTP, err := serviceauth.NewIdentityHTTPClient(cfg.ServiceName, cfg.Service.Identity)
+	if err != nil {
+		log.Fatalf("failed to create identity client: %v", err)
+	}
+	revocations := tokencache.NewRevocationCache(cfg.Service.Identity.InternalURL, cfg.Service.JWT.RevocationPollInterval, identityHTTP)
+	if err := revocations.Refresh(); err != nil {
+		log.Fatalf("failed to fetch session revocations: %v", err)
+	}
+	log.Printf("loaded %d session revocation(s) from identity", revocations.Len())
+	go revocations.Run(context.Background())
 
 	verifier := jwtverifier.New(jwtverifier.Config{
-		Issuer:   cfg.Service.JWT.Issuer,
-		Audience: cfg.Service.JWT.Audience,
-		Keys:     keys,
-		Leeway:   30 * time.Second,
+		Issuer:     jwks.Issuer(),
+		Audience:   cfg.Service.JWT.Audience,
+		KeySource:  jwks,
+		Algorithms: cfg.Service.JWT.Algorithms,
+		Leeway:     30 * time.Second,
 	})
 
-	authMW := middleware.Auth(verifier)
+	authMW := middleware.Auth(verifier, revocations)
 
 	identityProxy, err := proxy.New(cfg.Service.Upstream.IdentityURL)
 	if err != nil {
@@ -3,6 +3,7 @@ package middleware
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/woffVienna/proteon-cursor/libs/platform/httpcommon"
 	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
@@ -11,11 +12,18 @@ import (
 const (
 	HeaderPlatformUserID = "X-Platform-User-Id"
 	HeaderPlatformTenant = "X-Platform-Tenant"
+	HeaderPlatformScopes = "X-Platform-Scopes"
 )
 
-// Auth returns a chi middleware that validates JWTs and injects verified
-// identity context into downstream request headers.
-func Auth(verifier *jwtverifier.Verifier) func(http.Handler) http.Handler {
+// RevocationList reports revoked sessions.
+type RevocationList interface {
+	Revoked(sessionID string) bool
+}
+
+// Auth returns a chi middleware that validates JWTs, rejects tokens of
+// revoked sessions and injects verified identity context into downstream
+// request headers.
+func Auth(verifier *jwtverifier.Verifier, revocations RevocationList) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			rawToken, err := httpcommon.ExtractBearer(r.Header.Get("Authorization"))
@@ -29,9 +37,14 @@ func Auth(verifier *jwtverifier.Verifier) func(http.Handler) http.Handler {
 				writeAuthError(w, "UNAUTHORIZED", "invalid token")
 				return
 			}
+			if claims.SessionID != "" && revocations.Revoked(claims.SessionID) {
+				writeAuthError(w, "UNAUTHORIZED", "session revoked")
+				return
+			}
 
 			r.Header.Set(HeaderPlatformUserID, claims.Subject)
 			r.Header.Set(HeaderPlatformTenant, claims.Tenant)
+			r.Header.Set(HeaderPlatformScopes, strings.Join(claims.Scopes, " "))
 
 			next.ServeHTTP(w, r)
 		})
@@ -57,12 +57,15 @@ func (s *Server) Router() http.Handler {
 
 	r.Group(func(r chi.Router) {
 		r.Post("/v1/auth/exchange", s.identityProxy.ServeHTTP)
+		r.Post("/v1/auth/refresh", s.identityProxy.ServeHTTP)
 		r.Get("/v1/.well-known/jwks.json", s.identityProxy.ServeHTTP)
+		r.Get("/.well-known/openid-configuration", s.identityProxy.ServeHTTP)
 	})
 
 	r.Group(func(r chi.Router) {
 		r.Use(s.authMiddleware)
 		r.Get("/v1/users/{userId}", s.identityProxy.ServeHTTP)
+		r.Patch("/v1/users/{userId}", s.identityProxy.ServeHTTP)
 	})
 
 	return r
@@ -1,7 +1,13 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	platformconfig "github.com/woffVienna/proteon-cursor/libs/platform/config"
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/serviceauth"
 )
 
 type Config = platformconfig.Config[ServiceConfig]
@@ -9,11 +15,20 @@ type Config = platformconfig.Config[ServiceConfig]
 type ServiceConfig struct {
 	JWT      JWTConfig
 	Upstream UpstreamConfig
+	Identity serviceauth.IdentityClientConfig
 }
 
 type JWTConfig struct {
+	// Issuer pins the expected token issuer; empty takes it from
+	// identity's discovery document.
 	Issuer   string
 	Audience string
+	// Algorithms is the allowlist of JWS algorithms access tokens may be
+	// signed with; JWKS keys for other algorithms are ignored.
+	Algorithms []string
+	// RevocationPollInterval is how often the session revocation list is
+	// fetched from identity, i.e. how long a revoked token may still pass.
+	RevocationPollInterval time.Duration
 }
 
 type UpstreamConfig struct {
@@ -31,14 +46,30 @@ func Load() (Config, error) {
 	})
 
 	return loader.Load(func(env platformconfig.Env) (ServiceConfig, error) {
+		revocationPollInterval, err := env.Duration("REVOCATION_POLL_INTERVAL", 5*time.Second)
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+		if revocationPollInterval <= 0 {
+			return ServiceConfig{}, errors.New("REVOCATION_POLL_INTERVAL must be positive")
+		}
+		algorithms, err := jwtverifier.ParseAlgorithms(env.String("JWT_ALGORITHMS", jwtverifier.AlgEdDSA))
+		if err != nil {
+			return ServiceConfig{}, fmt.Errorf("invalid JWT_ALGORITHMS: %w", err)
+		}
+
+		identityURL := env.String("IDENTITY_URL", "http://localhost:8081")
 		return ServiceConfig{
 			JWT: JWTConfig{
-				Issuer:   env.String("JWT_ISSUER", "proteon.identity"),
-				Audience: env.String("JWT_AUDIENCE", "proteon-api"),
+				Issuer:                 env.String("JWT_ISSUER", ""),
+				Audience:               env.String("JWT_AUDIENCE", "proteon-api"),
+				Algorithms:             algorithms,
+				RevocationPollInterval: revocationPollInterval,
 			},
 			Upstream: UpstreamConfig{
-				IdentityURL: env.String("IDENTITY_URL", "http://localhost:8081"),
+				IdentityURL: identityURL,
 			},
+			Identity: serviceauth.IdentityClientConfigFromEnv(env, identityURL),
 		}, nil
 	})
 }
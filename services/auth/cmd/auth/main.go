@@ -1,12 +1,17 @@
 package main
 
 import (
+	"bytes"
 	"log"
+	"os"
 
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/serviceauth"
 	"github.com/woffVienna/proteon-cursor/services/auth/internal/adapters/credentials"
 	httpadapter "github.com/woffVienna/proteon-cursor/services/auth/internal/adapters/http"
 	identityadapter "github.com/woffVienna/proteon-cursor/services/auth/internal/adapters/identity"
-	"github.com/woffVienna/proteon-cursor/services/auth/internal/application/login"
+	oauthadapter "github.com/woffVienna/proteon-cursor/services/auth/internal/adapters/oauth"
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/application/authorization"
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/domain"
 	"github.com/woffVienna/proteon-cursor/services/auth/internal/platform/config"
 )
 
@@ -21,10 +26,35 @@ func main() {
 		log.Fatalf("failed to create credential store: %v", err)
 	}
 
-	identityClient := identityadapter.NewClient(cfg.Service.IdentityURL)
+	identityHTTP, err := serviceauth.NewIdentityHTTPClient(cfg.ServiceName, cfg.Service.Identity)
+	if err != nil {
+		log.Fatalf("failed to create identity client: %v", err)
+	}
+	identityClient := identityadapter.NewClient(cfg.Service.Identity.InternalURL, identityHTTP)
+
+	clients := make([]domain.Client, 0, len(cfg.Service.OAuth.Clients))
+	for _, c := range cfg.Service.OAuth.Clients {
+		clients = append(clients, domain.Client{ID: c.ID, RedirectURIs: c.RedirectURIs})
+	}
+	if len(clients) == 0 {
+		log.Printf("WARNING: OAUTH_CLIENTS not set; every authorization request is refused")
+	}
 
-	loginSvc := login.NewService(credStore, identityClient)
-	handler := httpadapter.NewHandler(loginSvc)
+	authzSvc := authorization.NewService(
+		oauthadapter.NewStaticClients(clients),
+		oauthadapter.NewMemoryCodeStore(),
+		credStore,
+		identityClient,
+		cfg.Service.OAuth.CodeTTL,
+	)
+	csrfKey, err := loadCSRFKey(cfg)
+	if err != nil {
+		log.Fatalf("failed to load csrf key: %v", err)
+	}
+	handler, err := httpadapter.NewHandler(authzSvc, csrfKey)
+	if err != nil {
+		log.Fatalf("failed to create HTTP handler: %v", err)
+	}
 
 	httpCfg := httpadapter.Config{
 		Port:              cfg.HTTP.Port,
@@ -44,3 +74,21 @@ func main() {
 		log.Fatalf("server error: %v", err)
 	}
 }
+
+// loadCSRFKey returns the configured login form key, or nil to have one
+// generated.
+func loadCSRFKey(cfg config.Config) ([]byte, error) {
+	oc := cfg.Service.OAuth
+	switch {
+	case oc.CSRFKeyFile != "":
+		key, err := os.ReadFile(oc.CSRFKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.TrimSpace(key), nil
+	case oc.CSRFKey != "":
+		return []byte(oc.CSRFKey), nil
+	}
+	log.Printf("WARNING: CSRF_KEY_FILE not set; login forms are signed with a key generated for this process")
+	return nil, nil
+}
@@ -8,3 +8,5 @@ require (
 	github.com/woffVienna/proteon-cursor/libs/platform v0.0.0-20260310040649-5ee80d1abfcd
 	golang.org/x/crypto v0.32.0
 )
+
+require github.com/golang-jwt/jwt/v5 v5.2.1 // indirect
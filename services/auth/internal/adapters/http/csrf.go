@@ -0,0 +1,120 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/domain"
+)
+
+const (
+	// csrfCookie holds the browser's nonce the login form token is bound to.
+	csrfCookie = "authorize_csrf"
+	// csrfField is the login form field carrying the token.
+	csrfField = "csrf_token"
+)
+
+// csrfGuard protects the login form against cross-site posts. The form
+// carries an HMAC of a per-browser nonce, kept in an HttpOnly cookie, and
+// of the authorization request it was rendered for. Another site can
+// neither read the cookie nor the token, so it cannot post credentials or
+// swap the client, redirect URI or code challenge of a rendered form. The
+// key comes from configuration so forms stay valid across restarts; a
+// generated one only asks open forms to sign in again after a restart.
+type csrfGuard struct {
+	key []byte
+}
+
+// minCSRFKeyLen is the minimum length of a configured key.
+const minCSRFKeyLen = 32
+
+// newCSRFGuard signs form tokens with key, or with a random key when key
+// is empty.
+func newCSRFGuard(key []byte) (*csrfGuard, error) {
+	if len(key) == 0 {
+		key = make([]byte, minCSRFKeyLen)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("generate csrf key: %w", err)
+		}
+	}
+	if len(key) < minCSRFKeyLen {
+		return nil, fmt.Errorf("csrf key must be at least %d bytes, got %d", minCSRFKeyLen, len(key))
+	}
+	return &csrfGuard{key: key}, nil
+}
+
+// issue returns the form token for req, setting the nonce cookie unless
+// the browser already has one.
+func (g *csrfGuard) issue(w http.ResponseWriter, r *http.Request, req domain.AuthorizationRequest) (string, error) {
+	if nonce, ok := requestNonce(r); ok {
+		return g.token(nonce, req), nil
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate csrf nonce: %w", err)
+	}
+	nonce := base64.RawURLEncoding.EncodeToString(b)
+	// No Path: the browser scopes the cookie to the directory of the URL
+	// it sees, which keeps it working behind a gateway path prefix.
+	http.SetCookie(w, &http.Cookie{
+		Name:     csrfCookie,
+		Value:    nonce,
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteStrictMode,
+	})
+	return g.token(nonce, req), nil
+}
+
+// verify reports whether token was issued for req to this browser.
+func (g *csrfGuard) verify(r *http.Request, req domain.AuthorizationRequest, token string) bool {
+	nonce, ok := requestNonce(r)
+	if !ok || token == "" {
+		return false
+	}
+	return hmac.Equal([]byte(token), []byte(g.token(nonce, req)))
+}
+
+func (g *csrfGuard) token(nonce string, req domain.AuthorizationRequest) string {
+	mac := hmac.New(sha256.New, g.key)
+	mac.Write([]byte(nonce))
+	mac.Write([]byte{0})
+	mac.Write([]byte(url.Values{
+		"response_type":         {req.ResponseType},
+		"client_id":             {req.ClientID},
+		"redirect_uri":          {req.RedirectURI},
+		"scope":                 {req.Scope},
+		"state":                 {req.State},
+		"code_challenge":        {req.CodeChallenge},
+		"code_challenge_method": {req.CodeChallengeMethod},
+	}.Encode()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// requestNonce returns the nonce cookie of r if it is well-formed.
+func requestNonce(r *http.Request) (string, bool) {
+	c, err := r.Cookie(csrfCookie)
+	if err != nil {
+		return "", false
+	}
+	if b, err := base64.RawURLEncoding.DecodeString(c.Value); err != nil || len(b) != 32 {
+		return "", false
+	}
+	return c.Value, true
+}
+
+// crossSite reports whether the browser says r was sent by another origin
+// (Fetch Metadata). The login form posts to its own origin; browsers that
+// send no Sec-Fetch-Site are left to the form token.
+func crossSite(r *http.Request) bool {
+	switch r.Header.Get("Sec-Fetch-Site") {
+	case "", "same-origin", "none":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCSRFGuardKey(t *testing.T) {
+	key := []byte(strings.Repeat("k", minCSRFKeyLen))
+	tests := []struct {
+		name string
+		// issuer and verifier are the keys of the guard rendering the form
+		// and of the one receiving the post, as after a restart.
+		issuer, verifier []byte
+		wantErr          bool
+		wantValid        bool
+	}{
+		{name: "same configured key", issuer: key, verifier: key, wantValid: true},
+		{name: "other configured key", issuer: key, verifier: []byte(strings.Repeat("o", minCSRFKeyLen))},
+		{name: "generated keys"},
+		{name: "short key", issuer: []byte("short"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer, err := newCSRFGuard(tt.issuer)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newCSRFGuard error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			verifier, err := newCSRFGuard(tt.verifier)
+			if err != nil {
+				t.Fatalf("newCSRFGuard: %v", err)
+			}
+
+			req := authorizationRequest(testAuthorization)
+			rec := httptest.NewRecorder()
+			token, err := issuer.issue(rec, httptest.NewRequest(http.MethodGet, "/authorize", nil), req)
+			if err != nil {
+				t.Fatalf("issue: %v", err)
+			}
+			r := httptest.NewRequest(http.MethodPost, "/authorize", nil)
+			for _, c := range rec.Result().Cookies() {
+				r.AddCookie(c)
+			}
+			if got := verifier.verify(r, req, token); got != tt.wantValid {
+				t.Errorf("verify = %v, want %v", got, tt.wantValid)
+			}
+		})
+	}
+}
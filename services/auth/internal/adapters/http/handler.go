@@ -2,75 +2,215 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
+	"net/url"
 
-	"github.com/woffVienna/proteon-cursor/services/auth/internal/application/login"
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/application/authorization"
 	"github.com/woffVienna/proteon-cursor/services/auth/internal/domain"
 )
 
 // Handler handles auth HTTP requests.
 type Handler struct {
-	loginSvc *login.Service
+	authzSvc *authorization.Service
+	csrf     *csrfGuard
 }
 
-// NewHandler creates a new Handler.
-func NewHandler(loginSvc *login.Service) *Handler {
-	return &Handler{loginSvc: loginSvc}
-}
-
-type loginRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+// NewHandler creates a new Handler. csrfKey signs the login form tokens;
+// empty generates a key, which holds for this process only.
+func NewHandler(authzSvc *authorization.Service, csrfKey []byte) (*Handler, error) {
+	csrf, err := newCSRFGuard(csrfKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{authzSvc: authzSvc, csrf: csrf}, nil
 }
 
-type loginResponse struct {
+type tokenResponse struct {
 	AccessToken string `json:"access_token"`
 	TokenType   string `json:"token_type"`
 	ExpiresIn   int32  `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// Authorize handles GET /authorize: it validates the authorization request
+// and shows the login page.
+func (h *Handler) Authorize(w http.ResponseWriter, r *http.Request) {
+	req := authorizationRequest(r.URL.Query())
+	if err := h.authzSvc.Validate(r.Context(), req); err != nil {
+		h.authorizationError(w, r, req, err)
+		return
+	}
+	h.renderLoginForm(w, r, http.StatusOK, req, "")
 }
 
-// Login handles POST /v1/login.
+// Login handles POST /authorize, the login form: on valid credentials it
+// redirects to the client with an authorization code. Posts from another
+// site are refused, and a form token that was not issued to this browser
+// for this authorization request gets a fresh form instead of a login.
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
-	var req loginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+	if crossSite(r) {
+		renderErrorPage(w, http.StatusForbidden, "The login form was posted from another site.")
 		return
 	}
-	if req.Username == "" || req.Password == "" {
-		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "username and password are required")
+	if err := r.ParseForm(); err != nil {
+		renderErrorPage(w, http.StatusBadRequest, "The login form could not be read.")
 		return
 	}
-
-	result, err := h.loginSvc.Login(r.Context(), req.Username, req.Password)
-	if err != nil {
-		if err == domain.ErrInvalidCredentials {
-			writeError(w, http.StatusUnauthorized, "INVALID_CREDENTIALS", "invalid username or password")
+	req := authorizationRequest(r.PostForm)
+	token := r.PostForm.Get(csrfField)
+	if !h.csrf.verify(r, req, token) {
+		if err := h.authzSvc.Validate(r.Context(), req); err != nil {
+			h.authorizationError(w, r, req, err)
 			return
 		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
+		h.renderLoginForm(w, r, http.StatusForbidden, req, "The login form has expired. Please sign in again.")
+		return
+	}
+
+	code, err := h.authzSvc.Authorize(r.Context(), req, r.PostForm.Get("username"), r.PostForm.Get("password"))
+	switch {
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		renderLoginPage(w, http.StatusUnauthorized, req, token, "Invalid username or password.")
+		return
+	case err != nil:
+		h.authorizationError(w, r, req, err)
 		return
 	}
 
-	resp := loginResponse{
+	redirect(w, r, req.RedirectURI, url.Values{"code": {code}}, req.State)
+}
+
+// Token handles POST /token, the authorization_code grant.
+func (h *Handler) Token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "invalid form body")
+		return
+	}
+	form := r.PostForm
+	if form.Get("grant_type") != "authorization_code" {
+		writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type", "grant_type must be authorization_code")
+		return
+	}
+	req := domain.TokenRequest{
+		Code:         form.Get("code"),
+		ClientID:     form.Get("client_id"),
+		RedirectURI:  form.Get("redirect_uri"),
+		CodeVerifier: form.Get("code_verifier"),
+	}
+	if req.Code == "" || req.ClientID == "" || req.RedirectURI == "" || req.CodeVerifier == "" {
+		writeOAuthError(w, http.StatusBadRequest, "invalid_request", "code, client_id, redirect_uri and code_verifier are required")
+		return
+	}
+
+	result, err := h.authzSvc.Token(r.Context(), req)
+	switch {
+	case errors.Is(err, domain.ErrUnknownClient):
+		writeOAuthError(w, http.StatusUnauthorized, "invalid_client", "unknown client")
+		return
+	case errors.Is(err, domain.ErrInvalidGrant):
+		writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "invalid, expired or redeemed authorization code")
+		return
+	case errors.Is(err, domain.ErrIdentityInactive), errors.Is(err, domain.ErrIdentityErased):
+		writeOAuthError(w, http.StatusBadRequest, "invalid_grant", "the user may no longer sign in")
+		return
+	case err != nil:
+		log.Printf("token: %v", err)
+		writeOAuthError(w, http.StatusInternalServerError, "server_error", "internal error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(&tokenResponse{
 		AccessToken: result.AccessToken,
 		TokenType:   "Bearer",
 		ExpiresIn:   result.ExpiresIn,
+		Scope:       result.Scope,
+	})
+}
+
+// renderLoginForm shows the login form for req with a form token bound
+// to it.
+func (h *Handler) renderLoginForm(w http.ResponseWriter, r *http.Request, status int, req domain.AuthorizationRequest, message string) {
+	token, err := h.csrf.issue(w, r, req)
+	if err != nil {
+		log.Printf("authorize: %v", err)
+		renderErrorPage(w, http.StatusInternalServerError, "Sign-in is unavailable. Please try again later.")
+		return
 	}
+	renderLoginPage(w, status, req, token, message)
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(&resp); err != nil {
-		// best-effort; nothing else to do
-		_ = err
+// authorizationError reports a refused authorization request: on the page
+// while the client or redirect URI is in doubt, otherwise to the client at
+// its redirect URI (RFC 6749, section 4.1.2.1).
+func (h *Handler) authorizationError(w http.ResponseWriter, r *http.Request, req domain.AuthorizationRequest, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUnknownClient):
+		renderErrorPage(w, http.StatusBadRequest, "Unknown client.")
+		return
+	case errors.Is(err, domain.ErrRedirectURINotRegistered):
+		renderErrorPage(w, http.StatusBadRequest, "The redirect URI is not registered for this client.")
+		return
+	}
+
+	code := "server_error"
+	switch {
+	case errors.Is(err, domain.ErrUnsupportedResponseType):
+		code = "unsupported_response_type"
+	case errors.Is(err, domain.ErrInvalidAuthorizationParams):
+		code = "invalid_request"
+	default:
+		log.Printf("authorize: %v", err)
+	}
+	params := url.Values{"error": {code}}
+	if code != "server_error" {
+		params.Set("error_description", err.Error())
+	}
+	redirect(w, r, req.RedirectURI, params, req.State)
+}
+
+// authorizationRequest reads the authorization request parameters.
+func authorizationRequest(v url.Values) domain.AuthorizationRequest {
+	return domain.AuthorizationRequest{
+		ResponseType:        v.Get("response_type"),
+		ClientID:            v.Get("client_id"),
+		RedirectURI:         v.Get("redirect_uri"),
+		Scope:               v.Get("scope"),
+		State:               v.Get("state"),
+		CodeChallenge:       v.Get("code_challenge"),
+		CodeChallengeMethod: v.Get("code_challenge_method"),
+	}
+}
+
+// redirect sends the browser to a registered redirect URI with params and
+// state added to its query.
+func redirect(w http.ResponseWriter, r *http.Request, redirectURI string, params url.Values, state string) {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		renderErrorPage(w, http.StatusBadRequest, "The redirect URI is invalid.")
+		return
+	}
+	q := u.Query()
+	for k, v := range params {
+		q[k] = v
+	}
+	if state != "" {
+		q.Set("state", state)
 	}
+	u.RawQuery = q.Encode()
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, u.String(), http.StatusSeeOther)
 }
 
-func writeError(w http.ResponseWriter, status int, code, message string) {
+func writeOAuthError(w http.ResponseWriter, status int, code, description string) {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
 	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(map[string]map[string]string{
-		"error": {
-			"code":    code,
-			"message": message,
-		},
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error":             code,
+		"error_description": description,
 	})
 }
@@ -0,0 +1,296 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/adapters/credentials"
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/adapters/oauth"
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/application/authorization"
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/domain"
+)
+
+var csrfTokenInput = regexp.MustCompile(`name="csrf_token" value="([^"]*)"`)
+
+// stubIdentity answers every token request with a token or err.
+type stubIdentity struct {
+	err error
+}
+
+func (s stubIdentity) IssueBackofficeToken(context.Context, string, string, string, string) (domain.LoginResult, error) {
+	if s.err != nil {
+		return domain.LoginResult{}, s.err
+	}
+	return domain.LoginResult{AccessToken: "access-token", ExpiresIn: 900}, nil
+}
+
+func newTestHandler(t *testing.T, idAuth interfaces.IdentityTokenClient) *Handler {
+	t.Helper()
+	creds, err := credentials.NewMemoryStore()
+	if err != nil {
+		t.Fatalf("credential store: %v", err)
+	}
+	clients := oauth.NewStaticClients([]domain.Client{{
+		ID:           "backoffice",
+		RedirectURIs: []string{"https://backoffice.test/callback", "https://backoffice.test/other"},
+	}})
+	h, err := NewHandler(authorization.NewService(clients, oauth.NewMemoryCodeStore(), creds, idAuth, time.Minute), nil)
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+	return h
+}
+
+// authorize opens the login page for params and returns the nonce cookie
+// and the form token.
+func authorize(t *testing.T, h *Handler, params url.Values) (*http.Cookie, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.Authorize(rec, httptest.NewRequest(http.MethodGet, "/authorize?"+params.Encode(), nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /authorize status = %d, want 200", rec.Code)
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != csrfCookie || !cookies[0].HttpOnly || cookies[0].SameSite != http.SameSiteStrictMode {
+		t.Fatalf("GET /authorize cookies = %+v, want an HttpOnly, SameSite=Strict %s cookie", cookies, csrfCookie)
+	}
+	m := csrfTokenInput.FindStringSubmatch(rec.Body.String())
+	if m == nil || m[1] == "" {
+		t.Fatal("login page has no csrf token")
+	}
+	return cookies[0], m[1]
+}
+
+// testAuthorization is a valid authorization request; testVerifier is the
+// code verifier of its code challenge (RFC 7636, appendix B).
+var testAuthorization = url.Values{
+	"response_type":         {"code"},
+	"client_id":             {"backoffice"},
+	"redirect_uri":          {"https://backoffice.test/callback"},
+	"state":                 {"xyz"},
+	"code_challenge":        {"E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"},
+	"code_challenge_method": {"S256"},
+}
+
+const testVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+func TestLoginCSRF(t *testing.T) {
+	params := testAuthorization
+	tests := []struct {
+		name string
+		// edit changes the posted form, which starts as the authorization
+		// request, its token and valid credentials.
+		edit func(form url.Values)
+		// cookie replaces the nonce cookie; a nil result sends none.
+		cookie     func(c *http.Cookie) *http.Cookie
+		header     http.Header
+		wantStatus int
+		// wantForm expects a login form with a fresh token.
+		wantForm bool
+	}{
+		{
+			name:       "valid token",
+			wantStatus: http.StatusSeeOther,
+		},
+		{
+			name:       "same-origin fetch metadata",
+			header:     http.Header{"Sec-Fetch-Site": {"same-origin"}},
+			wantStatus: http.StatusSeeOther,
+		},
+		{
+			name:       "cross-site post",
+			header:     http.Header{"Sec-Fetch-Site": {"cross-site"}},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "same-site post",
+			header:     http.Header{"Sec-Fetch-Site": {"same-site"}},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "no token",
+			edit:       func(form url.Values) { form.Del(csrfField) },
+			wantStatus: http.StatusForbidden,
+			wantForm:   true,
+		},
+		{
+			name:       "forged token",
+			edit:       func(form url.Values) { form.Set(csrfField, strings.Repeat("A", 43)) },
+			wantStatus: http.StatusForbidden,
+			wantForm:   true,
+		},
+		{
+			name:       "no cookie",
+			cookie:     func(*http.Cookie) *http.Cookie { return nil },
+			wantStatus: http.StatusForbidden,
+			wantForm:   true,
+		},
+		{
+			name: "another browser's cookie",
+			cookie: func(c *http.Cookie) *http.Cookie {
+				return &http.Cookie{Name: c.Name, Value: strings.Repeat("B", 43)}
+			},
+			wantStatus: http.StatusForbidden,
+			wantForm:   true,
+		},
+		{
+			name:       "token of another redirect URI",
+			edit:       func(form url.Values) { form.Set("redirect_uri", "https://backoffice.test/other") },
+			wantStatus: http.StatusForbidden,
+			wantForm:   true,
+		},
+		{
+			name:       "token of another code challenge",
+			edit:       func(form url.Values) { form.Set("code_challenge", strings.Repeat("c", 43)) },
+			wantStatus: http.StatusForbidden,
+			wantForm:   true,
+		},
+		{
+			name:       "token of another state",
+			edit:       func(form url.Values) { form.Set("state", "abc") },
+			wantStatus: http.StatusForbidden,
+			wantForm:   true,
+		},
+		{
+			name:       "wrong password keeps the token",
+			edit:       func(form url.Values) { form.Set("password", "wrong") },
+			wantStatus: http.StatusUnauthorized,
+			wantForm:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler(t, nil)
+			cookie, token := authorize(t, h, params)
+
+			form := url.Values{csrfField: {token}, "username": {"robert"}, "password": {"proteon"}}
+			for k, v := range params {
+				form[k] = v
+			}
+			if tt.edit != nil {
+				tt.edit(form)
+			}
+			r := httptest.NewRequest(http.MethodPost, "/authorize", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			for k, v := range tt.header {
+				r.Header[k] = v
+			}
+			if tt.cookie != nil {
+				cookie = tt.cookie(cookie)
+			}
+			if cookie != nil {
+				r.AddCookie(cookie)
+			}
+
+			rec := httptest.NewRecorder()
+			h.Login(rec, r)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("POST /authorize status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if rec.Code == http.StatusSeeOther {
+				loc, err := url.Parse(rec.Header().Get("Location"))
+				if err != nil || loc.Query().Get("code") == "" || loc.Query().Get("state") != "xyz" {
+					t.Errorf("redirect = %q, want a code and the state", rec.Header().Get("Location"))
+				}
+				return
+			}
+			m := csrfTokenInput.FindStringSubmatch(rec.Body.String())
+			if !tt.wantForm {
+				if m != nil {
+					t.Error("refused post shows a login form")
+				}
+				return
+			}
+			if m == nil || m[1] == "" {
+				t.Fatal("response has no login form with a token")
+			}
+			if m[1] == form.Get(csrfField) && tt.wantStatus == http.StatusForbidden {
+				t.Error("refused token is shown again")
+			}
+		})
+	}
+}
+
+func TestToken(t *testing.T) {
+	tests := []struct {
+		name string
+		// identityErr is the error identity's token request fails with.
+		identityErr error
+		verifier    string
+		wantStatus  int
+		wantError   string
+	}{
+		{name: "issued", verifier: testVerifier, wantStatus: http.StatusOK},
+		{name: "wrong code verifier", verifier: strings.Repeat("v", 43), wantStatus: http.StatusBadRequest, wantError: "invalid_grant"},
+		{
+			name:        "suspended or banned identity",
+			identityErr: fmt.Errorf("%w: IDENTITY_BANNED", domain.ErrIdentityInactive),
+			verifier:    testVerifier, wantStatus: http.StatusBadRequest, wantError: "invalid_grant",
+		},
+		{
+			name:        "erased identity",
+			identityErr: domain.ErrIdentityErased,
+			verifier:    testVerifier, wantStatus: http.StatusBadRequest, wantError: "invalid_grant",
+		},
+		{
+			name:        "identity unavailable",
+			identityErr: errors.New("call identity: connection refused"),
+			verifier:    testVerifier, wantStatus: http.StatusInternalServerError, wantError: "server_error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler(t, stubIdentity{err: tt.identityErr})
+			cookie, token := authorize(t, h, testAuthorization)
+
+			form := url.Values{csrfField: {token}, "username": {"robert"}, "password": {"proteon"}}
+			for k, v := range testAuthorization {
+				form[k] = v
+			}
+			r := httptest.NewRequest(http.MethodPost, "/authorize", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.AddCookie(cookie)
+			rec := httptest.NewRecorder()
+			h.Login(rec, r)
+			loc, err := url.Parse(rec.Header().Get("Location"))
+			if rec.Code != http.StatusSeeOther || err != nil || loc.Query().Get("code") == "" {
+				t.Fatalf("POST /authorize status = %d, location %q, want a redirect with a code", rec.Code, rec.Header().Get("Location"))
+			}
+
+			form = url.Values{
+				"grant_type":    {"authorization_code"},
+				"code":          {loc.Query().Get("code")},
+				"client_id":     {"backoffice"},
+				"redirect_uri":  {"https://backoffice.test/callback"},
+				"code_verifier": {tt.verifier},
+			}
+			r = httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec = httptest.NewRecorder()
+			h.Token(rec, r)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("POST /token status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			var body struct {
+				AccessToken string `json:"access_token"`
+				Error       string `json:"error"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if body.Error != tt.wantError || (tt.wantError == "") != (body.AccessToken != "") {
+				t.Errorf("POST /token response = %+v, want error %q", body, tt.wantError)
+			}
+		})
+	}
+}
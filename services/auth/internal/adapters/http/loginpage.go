@@ -0,0 +1,78 @@
+package http
+
+import (
+	"html/template"
+	"log"
+	"net/http"
+
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/domain"
+)
+
+// loginPage is the login form of the authorization endpoint. It posts the
+// authorization request and its form token back along with the
+// credentials; the relative action keeps it working behind a gateway path
+// prefix.
+var loginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Proteon Backoffice - Sign in</title>
+<style>
+body { font-family: system-ui, sans-serif; background: #f4f5f7; margin: 0; }
+main { max-width: 22rem; margin: 10vh auto; background: #fff; padding: 2rem; border-radius: 8px; box-shadow: 0 1px 4px rgba(0,0,0,.1); }
+label { display: block; margin-top: 1rem; }
+input { width: 100%; box-sizing: border-box; padding: .5rem; margin-top: .25rem; }
+button { margin-top: 1.5rem; width: 100%; padding: .6rem; }
+.error { color: #b00020; }
+</style>
+</head>
+<body>
+<main>
+<h1>Sign in</h1>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+{{if .Request}}<form method="post" action="authorize">
+{{with .Request}}<input type="hidden" name="response_type" value="{{.ResponseType}}">
+<input type="hidden" name="client_id" value="{{.ClientID}}">
+<input type="hidden" name="redirect_uri" value="{{.RedirectURI}}">
+<input type="hidden" name="scope" value="{{.Scope}}">
+<input type="hidden" name="state" value="{{.State}}">
+<input type="hidden" name="code_challenge" value="{{.CodeChallenge}}">
+<input type="hidden" name="code_challenge_method" value="{{.CodeChallengeMethod}}">{{end}}
+<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+<label>Username <input name="username" autocomplete="username" required autofocus></label>
+<label>Password <input name="password" type="password" autocomplete="current-password" required></label>
+<button type="submit">Sign in</button>
+</form>{{end}}
+</main>
+</body>
+</html>
+`))
+
+type loginPageData struct {
+	Request   *domain.AuthorizationRequest
+	CSRFToken string
+	Error     string
+}
+
+// renderLoginPage shows the login form for req and its form token, with an
+// optional error.
+func renderLoginPage(w http.ResponseWriter, status int, req domain.AuthorizationRequest, csrfToken, message string) {
+	renderPage(w, status, loginPageData{Request: &req, CSRFToken: csrfToken, Error: message})
+}
+
+// renderErrorPage shows an error without a form.
+func renderErrorPage(w http.ResponseWriter, status int, message string) {
+	renderPage(w, status, loginPageData{Error: message})
+}
+
+func renderPage(w http.ResponseWriter, status int, data loginPageData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors 'none'")
+	w.WriteHeader(status)
+	if err := loginPage.Execute(w, data); err != nil {
+		log.Printf("render login page: %v", err)
+	}
+}
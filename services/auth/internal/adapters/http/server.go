@@ -49,7 +49,9 @@ func (s *Server) Router() http.Handler {
 		HealthRoute:  "/v1/health",
 	})
 
-	r.Post("/v1/login", s.handler.Login)
+	r.Get("/authorize", s.handler.Authorize)
+	r.Post("/authorize", s.handler.Login)
+	r.Post("/token", s.handler.Token)
 
 	return r
 }
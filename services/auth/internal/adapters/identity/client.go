@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -20,11 +19,12 @@ type Client struct {
 	client  *http.Client
 }
 
-// NewClient creates a new identity client.
-func NewClient(baseURL string) *Client {
+// NewClient creates a new identity client. httpClient presents the
+// service credentials required on identity's /internal routes.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
 	return &Client{
 		baseURL: strings.TrimRight(baseURL, "/"),
-		client:  &http.Client{Timeout: 10 * time.Second},
+		client:  httpClient,
 	}
 }
 
@@ -33,16 +33,24 @@ type backofficeTokenRequest struct {
 	SubjectType string    `json:"subject_type"`
 	TenantID    *string   `json:"tenant_id,omitempty"`
 	Audience    string    `json:"audience"`
+	Scope       string    `json:"scope,omitempty"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Code string `json:"code"`
+	} `json:"error"`
 }
 
 type backofficeTokenResponse struct {
 	AccessToken string `json:"access_token"`
 	ExpiresIn   int32  `json:"expires_in"`
 	TokenType   string `json:"token_type"`
+	Scope       string `json:"scope"`
 }
 
 // IssueBackofficeToken calls the identity internal endpoint and returns the result.
-func (c *Client) IssueBackofficeToken(ctx context.Context, userID, subjectType, tenant string) (domain.LoginResult, error) {
+func (c *Client) IssueBackofficeToken(ctx context.Context, userID, subjectType, tenant, scope string) (domain.LoginResult, error) {
 	u, err := uuid.Parse(userID)
 	if err != nil {
 		return domain.LoginResult{}, fmt.Errorf("parse user id: %w", err)
@@ -52,6 +60,7 @@ func (c *Client) IssueBackofficeToken(ctx context.Context, userID, subjectType,
 		UserID:      u,
 		SubjectType: subjectType,
 		Audience:    "backoffice",
+		Scope:       scope,
 	}
 	if tenant != "" {
 		reqBody.TenantID = &tenant
@@ -75,7 +84,7 @@ func (c *Client) IssueBackofficeToken(ctx context.Context, userID, subjectType,
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return domain.LoginResult{}, fmt.Errorf("identity returned status %d", resp.StatusCode)
+		return domain.LoginResult{}, statusError(resp)
 	}
 
 	var out backofficeTokenResponse
@@ -86,5 +95,24 @@ func (c *Client) IssueBackofficeToken(ctx context.Context, userID, subjectType,
 	return domain.LoginResult{
 		AccessToken: out.AccessToken,
 		ExpiresIn:   out.ExpiresIn,
+		Scope:       out.Scope,
 	}, nil
 }
+
+// statusError maps a refusal by identity to a domain error: suspended or
+// banned identities to domain.ErrIdentityInactive and erased ones to
+// domain.ErrIdentityErased. Anything else, such as identity refusing the
+// caller itself, is an error of the call.
+func statusError(resp *http.Response) error {
+	var body errorResponse
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	switch {
+	case resp.StatusCode == http.StatusForbidden && (body.Error.Code == "IDENTITY_SUSPENDED" || body.Error.Code == "IDENTITY_BANNED"):
+		return fmt.Errorf("%w: %s", domain.ErrIdentityInactive, body.Error.Code)
+	case resp.StatusCode == http.StatusNotFound && body.Error.Code == "IDENTITY_ERASED":
+		return domain.ErrIdentityErased
+	case body.Error.Code != "":
+		return fmt.Errorf("identity returned status %d %s", resp.StatusCode, body.Error.Code)
+	}
+	return fmt.Errorf("identity returned status %d", resp.StatusCode)
+}
@@ -0,0 +1,70 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/domain"
+)
+
+func TestIssueBackofficeTokenErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{name: "issued", status: http.StatusOK, body: `{"access_token":"token","expires_in":900,"token_type":"Bearer"}`},
+		{
+			name: "suspended", status: http.StatusForbidden,
+			body:    `{"error":{"code":"IDENTITY_SUSPENDED","message":"platform identity is suspended"}}`,
+			wantErr: domain.ErrIdentityInactive,
+		},
+		{
+			name: "banned", status: http.StatusForbidden,
+			body:    `{"error":{"code":"IDENTITY_BANNED","message":"platform identity is banned"}}`,
+			wantErr: domain.ErrIdentityInactive,
+		},
+		{
+			name: "erased", status: http.StatusNotFound,
+			body:    `{"error":{"code":"IDENTITY_ERASED","message":"platform identity was erased"}}`,
+			wantErr: domain.ErrIdentityErased,
+		},
+		{
+			name: "caller not allowed", status: http.StatusForbidden,
+			body: `{"error":{"code":"CALLER_NOT_ALLOWED","message":"caller is not allowed to call this operation"}}`,
+		},
+		{name: "server error", status: http.StatusInternalServerError, body: `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			c := NewClient(srv.URL, srv.Client())
+			_, err := c.IssueBackofficeToken(context.Background(), "00000000-0000-0000-0000-000000000001", "operator", "", "")
+			if tt.status == http.StatusOK {
+				if err != nil {
+					t.Fatalf("IssueBackofficeToken: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("IssueBackofficeToken succeeded, want an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("IssueBackofficeToken error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (errors.Is(err, domain.ErrIdentityInactive) || errors.Is(err, domain.ErrIdentityErased)) {
+				t.Errorf("IssueBackofficeToken error = %v, want an error of the call", err)
+			}
+		})
+	}
+}
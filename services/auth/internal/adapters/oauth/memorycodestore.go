@@ -0,0 +1,58 @@
+package oauth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/domain"
+)
+
+// MemoryCodeStore keeps authorization codes in memory. Codes are not shared
+// between replicas, so the code must be redeemed at the replica that issued
+// it.
+type MemoryCodeStore struct {
+	mu    sync.Mutex
+	codes map[string]domain.AuthorizationCode
+	now   func() time.Time
+}
+
+// NewMemoryCodeStore creates an empty code store.
+func NewMemoryCodeStore() *MemoryCodeStore {
+	return &MemoryCodeStore{
+		codes: make(map[string]domain.AuthorizationCode),
+		now:   time.Now,
+	}
+}
+
+// Save implements interfaces.AuthorizationCodeStore. Expired codes are
+// dropped on the way.
+func (s *MemoryCodeStore) Save(_ context.Context, code domain.AuthorizationCode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for k, c := range s.codes {
+		if !now.Before(c.ExpiresAt) {
+			delete(s.codes, k)
+		}
+	}
+	s.codes[code.Code] = code
+	return nil
+}
+
+// Consume implements interfaces.AuthorizationCodeStore.
+func (s *MemoryCodeStore) Consume(_ context.Context, code string) (domain.AuthorizationCode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.codes[code]
+	if !ok {
+		return domain.AuthorizationCode{}, domain.ErrInvalidGrant
+	}
+	delete(s.codes, code)
+	if !s.now().Before(c.ExpiresAt) {
+		return domain.AuthorizationCode{}, domain.ErrInvalidGrant
+	}
+	return c, nil
+}
@@ -0,0 +1,32 @@
+// Package oauth holds the registered OAuth clients and the authorization
+// codes issued to them.
+package oauth
+
+import (
+	"context"
+
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/domain"
+)
+
+// StaticClients is a client registry loaded from configuration.
+type StaticClients struct {
+	clients map[string]domain.Client
+}
+
+// NewStaticClients creates a registry of the given clients.
+func NewStaticClients(clients []domain.Client) *StaticClients {
+	m := make(map[string]domain.Client, len(clients))
+	for _, c := range clients {
+		m[c.ID] = c
+	}
+	return &StaticClients{clients: m}
+}
+
+// Client implements interfaces.ClientRegistry.
+func (s *StaticClients) Client(_ context.Context, clientID string) (domain.Client, error) {
+	c, ok := s.clients[clientID]
+	if !ok {
+		return domain.Client{}, domain.ErrUnknownClient
+	}
+	return c, nil
+}
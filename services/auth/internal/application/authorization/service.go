@@ -0,0 +1,146 @@
+// Package authorization implements the OAuth 2.1 authorization code flow
+// with PKCE through which first-party clients such as the backoffice SPA
+// obtain backoffice tokens.
+package authorization
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/auth/internal/domain"
+)
+
+// Service authorizes clients on behalf of backoffice users and redeems the
+// authorization codes it issues.
+type Service struct {
+	clients interfaces.ClientRegistry
+	codes   interfaces.AuthorizationCodeStore
+	creds   interfaces.CredentialStore
+	idAuth  interfaces.IdentityTokenClient
+	codeTTL time.Duration
+	now     func() time.Time
+}
+
+// NewService creates an authorization service. Authorization codes expire
+// codeTTL after they are issued.
+func NewService(
+	clients interfaces.ClientRegistry,
+	codes interfaces.AuthorizationCodeStore,
+	creds interfaces.CredentialStore,
+	idAuth interfaces.IdentityTokenClient,
+	codeTTL time.Duration,
+) *Service {
+	return &Service{
+		clients: clients,
+		codes:   codes,
+		creds:   creds,
+		idAuth:  idAuth,
+		codeTTL: codeTTL,
+		now:     time.Now,
+	}
+}
+
+// CheckRedirect checks that the client is registered and the redirect URI
+// is one of its own. Until it passes, errors are shown to the user; the
+// redirect URI must not be used. Returns domain.ErrUnknownClient or
+// domain.ErrRedirectURINotRegistered.
+func (s *Service) CheckRedirect(ctx context.Context, req domain.AuthorizationRequest) error {
+	client, err := s.clients.Client(ctx, req.ClientID)
+	if err != nil {
+		return err
+	}
+	if !client.AllowsRedirect(req.RedirectURI) {
+		return domain.ErrRedirectURINotRegistered
+	}
+	return nil
+}
+
+// Validate checks an authorization request: the redirect as in
+// CheckRedirect, response_type code and an S256 code challenge. Errors
+// after the redirect check are domain.ErrUnsupportedResponseType or wrap
+// domain.ErrInvalidAuthorizationParams and are reported to the client.
+func (s *Service) Validate(ctx context.Context, req domain.AuthorizationRequest) error {
+	if err := s.CheckRedirect(ctx, req); err != nil {
+		return err
+	}
+	if req.ResponseType != "code" {
+		return domain.ErrUnsupportedResponseType
+	}
+	if req.CodeChallengeMethod != domain.CodeChallengeS256 {
+		return fmt.Errorf("%w: code_challenge_method must be %s", domain.ErrInvalidAuthorizationParams, domain.CodeChallengeS256)
+	}
+	if !domain.ValidCodeChallenge(req.CodeChallenge) {
+		return fmt.Errorf("%w: invalid code_challenge", domain.ErrInvalidAuthorizationParams)
+	}
+	return nil
+}
+
+// Authorize validates the request, logs the user in and returns a
+// single-use authorization code for the client. Wrong credentials return
+// domain.ErrInvalidCredentials.
+func (s *Service) Authorize(ctx context.Context, req domain.AuthorizationRequest, username, password string) (string, error) {
+	if err := s.Validate(ctx, req); err != nil {
+		return "", err
+	}
+	userID, subjectType, tenant, err := s.creds.Validate(ctx, username, password)
+	if err != nil {
+		return "", err
+	}
+
+	code, err := newCode()
+	if err != nil {
+		return "", err
+	}
+	if err := s.codes.Save(ctx, domain.AuthorizationCode{
+		Code:          code,
+		ClientID:      req.ClientID,
+		RedirectURI:   req.RedirectURI,
+		CodeChallenge: req.CodeChallenge,
+		Scope:         req.Scope,
+		UserID:        userID,
+		SubjectType:   subjectType,
+		Tenant:        tenant,
+		ExpiresAt:     s.now().Add(s.codeTTL),
+	}); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// Token redeems an authorization code for a backoffice access token. The
+// code is consumed even when the request is refused, so a code can never be
+// tried twice. Codes that are unknown, expired, issued to another client or
+// redirect URI, or presented with a wrong code verifier return
+// domain.ErrInvalidGrant; unregistered clients domain.ErrUnknownClient.
+// Users whose platform identity was suspended, banned or erased since they
+// logged in return domain.ErrIdentityInactive or domain.ErrIdentityErased.
+func (s *Service) Token(ctx context.Context, req domain.TokenRequest) (domain.LoginResult, error) {
+	code, err := s.codes.Consume(ctx, req.Code)
+	if err != nil {
+		return domain.LoginResult{}, err
+	}
+	if _, err := s.clients.Client(ctx, req.ClientID); err != nil {
+		return domain.LoginResult{}, err
+	}
+	if !s.now().Before(code.ExpiresAt) ||
+		code.ClientID != req.ClientID ||
+		code.RedirectURI != req.RedirectURI ||
+		!code.VerifyCodeVerifier(req.CodeVerifier) {
+		return domain.LoginResult{}, domain.ErrInvalidGrant
+	}
+
+	return s.idAuth.IssueBackofficeToken(ctx, code.UserID, code.SubjectType, code.Tenant, code.Scope)
+}
+
+// newCode returns a random authorization code with 256 bits of entropy.
+func newCode() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate authorization code: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
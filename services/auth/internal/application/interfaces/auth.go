@@ -12,6 +12,22 @@ type CredentialStore interface {
 }
 
 // IdentityTokenClient issues backoffice tokens via the identity service.
+// scope is an optional space-separated subset of the granted scopes.
 type IdentityTokenClient interface {
-	IssueBackofficeToken(ctx context.Context, userID, subjectType, tenant string) (domain.LoginResult, error)
+	IssueBackofficeToken(ctx context.Context, userID, subjectType, tenant, scope string) (domain.LoginResult, error)
+}
+
+// ClientRegistry looks up registered OAuth clients.
+type ClientRegistry interface {
+	// Client returns domain.ErrUnknownClient for unregistered IDs.
+	Client(ctx context.Context, clientID string) (domain.Client, error)
+}
+
+// AuthorizationCodeStore holds issued authorization codes until they are
+// redeemed.
+type AuthorizationCodeStore interface {
+	Save(ctx context.Context, code domain.AuthorizationCode) error
+	// Consume removes and returns a code, so it can be redeemed only once.
+	// Unknown, redeemed and expired codes return domain.ErrInvalidGrant.
+	Consume(ctx context.Context, code string) (domain.AuthorizationCode, error)
 }
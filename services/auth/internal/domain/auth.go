@@ -4,10 +4,19 @@ import "errors"
 
 var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrIdentityInactive is returned when identity refuses a token because
+	// the user's platform identity is suspended or banned.
+	ErrIdentityInactive = errors.New("platform identity is suspended or banned")
+	// ErrIdentityErased is returned when identity refuses a token because the
+	// user's platform identity was erased.
+	ErrIdentityErased = errors.New("platform identity was erased")
 )
 
-// LoginResult is the result of a successful login.
+// LoginResult is the backoffice access token issued after a login.
 type LoginResult struct {
 	AccessToken string
 	ExpiresIn   int32
+	// Scope is the space-separated list of granted scopes; empty when the
+	// token carries none.
+	Scope string
 }
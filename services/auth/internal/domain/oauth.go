@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"time"
+)
+
+var (
+	ErrUnknownClient              = errors.New("unknown client")
+	ErrRedirectURINotRegistered   = errors.New("redirect uri is not registered for the client")
+	ErrUnsupportedResponseType    = errors.New("unsupported response type")
+	ErrInvalidAuthorizationParams = errors.New("invalid authorization request")
+	// ErrInvalidGrant is returned for unknown, expired or already redeemed
+	// authorization codes and for codes presented with the wrong client,
+	// redirect URI or code verifier.
+	ErrInvalidGrant = errors.New("invalid grant")
+)
+
+// CodeChallengeS256 is the only PKCE method accepted (RFC 7636).
+const CodeChallengeS256 = "S256"
+
+// Client is a first-party OAuth client such as the backoffice SPA. Clients
+// are public: they hold no secret and prove that an authorization code is
+// theirs with PKCE.
+type Client struct {
+	ID string
+	// RedirectURIs are the registered redirect URIs; the redirect_uri of a
+	// request must equal one of them.
+	RedirectURIs []string
+}
+
+// AllowsRedirect reports whether uri is registered for the client.
+func (c Client) AllowsRedirect(uri string) bool {
+	return slices.Contains(c.RedirectURIs, uri)
+}
+
+// AuthorizationRequest holds the parameters of an authorization request.
+type AuthorizationRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// AuthorizationCode is issued to a client after a successful login and
+// redeemed once for an access token.
+type AuthorizationCode struct {
+	Code          string
+	ClientID      string
+	RedirectURI   string
+	CodeChallenge string
+	Scope         string
+	UserID        string
+	SubjectType   string
+	Tenant        string
+	ExpiresAt     time.Time
+}
+
+// VerifyCodeVerifier checks a PKCE code verifier against the S256 code
+// challenge the code was issued for.
+func (c AuthorizationCode) VerifyCodeVerifier(verifier string) bool {
+	if !validPKCEValue(verifier) {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(challenge), []byte(c.CodeChallenge)) == 1
+}
+
+// TokenRequest holds the parameters of an authorization_code grant.
+type TokenRequest struct {
+	Code         string
+	ClientID     string
+	RedirectURI  string
+	CodeVerifier string
+}
+
+// ValidCodeChallenge reports whether challenge can be an S256 code
+// challenge: the unpadded base64url encoding of a SHA-256 hash.
+func ValidCodeChallenge(challenge string) bool {
+	return len(challenge) == 43 && validPKCEValue(challenge)
+}
+
+// validPKCEValue reports whether v is 43 to 128 characters of the RFC 7636
+// alphabet [A-Za-z0-9-._~].
+func validPKCEValue(v string) bool {
+	if len(v) < 43 || len(v) > 128 {
+		return false
+	}
+	for _, r := range v {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case r == '-', r == '.', r == '_', r == '~':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+// The code verifier and challenge of RFC 7636, appendix B.
+const (
+	rfcVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	rfcChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func s256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func TestVerifyCodeVerifier(t *testing.T) {
+	tests := []struct {
+		name      string
+		challenge string
+		verifier  string
+		want      bool
+	}{
+		{name: "RFC 7636 example", challenge: rfcChallenge, verifier: rfcVerifier, want: true},
+		{name: "longest verifier", challenge: s256(strings.Repeat("~", 128)), verifier: strings.Repeat("~", 128), want: true},
+		{name: "other verifier", challenge: rfcChallenge, verifier: strings.Repeat("a", 43)},
+		{name: "challenge as verifier", challenge: rfcChallenge, verifier: rfcChallenge},
+		{name: "empty verifier", challenge: s256(""), verifier: ""},
+		{name: "too short", challenge: s256(rfcVerifier[:42]), verifier: rfcVerifier[:42]},
+		{name: "too long", challenge: s256(strings.Repeat("a", 129)), verifier: strings.Repeat("a", 129)},
+		{name: "character outside the alphabet", challenge: s256(rfcVerifier + "+"), verifier: rfcVerifier + "+"},
+		{name: "padding", challenge: s256(rfcVerifier + "="), verifier: rfcVerifier + "="},
+		{name: "plain method", challenge: rfcVerifier, verifier: rfcVerifier},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := AuthorizationCode{CodeChallenge: tt.challenge}
+			if got := code.VerifyCodeVerifier(tt.verifier); got != tt.want {
+				t.Errorf("VerifyCodeVerifier(%q) = %v, want %v", tt.verifier, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidCodeChallenge(t *testing.T) {
+	tests := []struct {
+		name      string
+		challenge string
+		want      bool
+	}{
+		{name: "RFC 7636 example", challenge: rfcChallenge, want: true},
+		{name: "empty", challenge: ""},
+		{name: "too short", challenge: rfcChallenge[:42]},
+		{name: "too long", challenge: rfcChallenge + "A"},
+		{name: "padded", challenge: rfcChallenge[:42] + "="},
+		{name: "standard base64", challenge: strings.ReplaceAll(rfcChallenge, "-", "+")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidCodeChallenge(tt.challenge); got != tt.want {
+				t.Errorf("ValidCodeChallenge(%q) = %v, want %v", tt.challenge, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,13 +1,43 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
 	platformconfig "github.com/woffVienna/proteon-cursor/libs/platform/config"
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/serviceauth"
 )
 
 type Config = platformconfig.Config[ServiceConfig]
 
 type ServiceConfig struct {
 	IdentityURL string
+	Identity    serviceauth.IdentityClientConfig
+	OAuth       OAuthConfig
+}
+
+// OAuthConfig configures the authorization server for first-party clients.
+type OAuthConfig struct {
+	// Clients are the registered clients, from the JSON list OAUTH_CLIENTS,
+	// e.g. [{"client_id":"backoffice-spa","redirect_uris":["https://..."]}].
+	Clients []OAuthClient
+	// CodeTTL is how long an authorization code may be redeemed.
+	CodeTTL time.Duration
+	// CSRFKeyFile or CSRFKey hold the key the login form tokens are signed
+	// with, at least 32 bytes. Without one, ENV=dev generates a key on
+	// startup.
+	CSRFKeyFile string
+	CSRFKey     string
+}
+
+// OAuthClient is a registered public client and its redirect URIs.
+type OAuthClient struct {
+	ID           string
+	RedirectURIs []string
 }
 
 func Load() (Config, error) {
@@ -20,9 +50,87 @@ func Load() (Config, error) {
 		DefaultPort:        "8083",
 	})
 
-	return loader.Load(func(env platformconfig.Env) (ServiceConfig, error) {
+	cfg, err := loader.Load(func(env platformconfig.Env) (ServiceConfig, error) {
+		clients, err := parseOAuthClients(env.String("OAUTH_CLIENTS", ""))
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+		codeTTL, err := env.Duration("AUTHORIZATION_CODE_TTL", time.Minute)
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+		if codeTTL <= 0 || codeTTL > 10*time.Minute {
+			return ServiceConfig{}, errors.New("AUTHORIZATION_CODE_TTL must be between 0 and 10m")
+		}
+
+		identityURL := env.String("IDENTITY_URL", "http://localhost:8081")
 		return ServiceConfig{
-			IdentityURL: env.String("IDENTITY_URL", "http://localhost:8081"),
+			IdentityURL: identityURL,
+			Identity:    serviceauth.IdentityClientConfigFromEnv(env, identityURL),
+			OAuth: OAuthConfig{
+				Clients:     clients,
+				CodeTTL:     codeTTL,
+				CSRFKeyFile: env.String("CSRF_KEY_FILE", ""),
+				CSRFKey:     env.String("CSRF_KEY", ""),
+			},
 		}, nil
 	})
+	if err != nil {
+		return Config{}, err
+	}
+	if err := validateCSRFKey(cfg.Environment, cfg.Service.OAuth); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// validateCSRFKey enforces that only ENV=dev may generate the login form
+// key: replicas and restarts must share it.
+func validateCSRFKey(environment string, oauth OAuthConfig) error {
+	if oauth.CSRFKeyFile != "" && oauth.CSRFKey != "" {
+		return errors.New("set only one of CSRF_KEY_FILE and CSRF_KEY")
+	}
+	if oauth.CSRFKeyFile == "" && oauth.CSRFKey == "" && environment != "dev" {
+		return fmt.Errorf("CSRF_KEY_FILE or CSRF_KEY is required when ENV=%s", environment)
+	}
+	return nil
+}
+
+// parseOAuthClients parses the JSON array of OAUTH_CLIENTS. Redirect URIs
+// must be absolute http(s) URLs without a fragment; they are matched
+// exactly.
+func parseOAuthClients(value string) ([]OAuthClient, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var raw []struct {
+		ClientID     string   `json:"client_id"`
+		RedirectURIs []string `json:"redirect_uris"`
+	}
+	if err := json.Unmarshal([]byte(value), &raw); err != nil {
+		return nil, fmt.Errorf("invalid OAUTH_CLIENTS: %w", err)
+	}
+
+	clients := make([]OAuthClient, 0, len(raw))
+	seen := make(map[string]bool, len(raw))
+	for i, r := range raw {
+		if r.ClientID == "" {
+			return nil, fmt.Errorf("invalid OAUTH_CLIENTS[%d]: client_id is required", i)
+		}
+		if seen[r.ClientID] {
+			return nil, fmt.Errorf("invalid OAUTH_CLIENTS[%d]: duplicate client_id %q", i, r.ClientID)
+		}
+		seen[r.ClientID] = true
+		if len(r.RedirectURIs) == 0 {
+			return nil, fmt.Errorf("invalid OAUTH_CLIENTS[%d]: redirect_uris is required", i)
+		}
+		for _, uri := range r.RedirectURIs {
+			u, err := url.Parse(uri)
+			if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.Fragment != "" {
+				return nil, fmt.Errorf("invalid OAUTH_CLIENTS[%d] redirect uri %q: must be an absolute http(s) URL without fragment", i, uri)
+			}
+		}
+		clients = append(clients, OAuthClient{ID: r.ClientID, RedirectURIs: r.RedirectURIs})
+	}
+	return clients, nil
 }
@@ -1,12 +1,13 @@
 package main
 
 import (
-	"crypto/ed25519"
+	"context"
 	"log"
 	"time"
 
 	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
-	boauth "github.com/woffVienna/proteon-cursor/services/backoffice-gateway/internal/adapters/auth"
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/serviceauth"
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/tokencache"
 	httpadapter "github.com/woffVienna/proteon-cursor/services/backoffice-gateway/internal/adapters/http"
 	bomw "github.com/woffVienna/proteon-cursor/services/backoffice-gateway/internal/adapters/http/middleware"
 	"github.com/woffVienna/proteon-cursor/services/backoffice-gateway/internal/adapters/http/proxy"
@@ -25,31 +26,42 @@ func main() {
 	}
 
 	identityURL := cfg.Service.Upstream.IdentityURL
-	log.Printf("fetching JWKS from %s (retrying up to %v)", identityURL, jwksRetryTimeout)
-	var keys map[string]ed25519.PublicKey
+	identityHTTP, err := serviceauth.NewIdentityHTTPClient(cfg.ServiceName, cfg.Service.Identity)
+	if err != nil {
+		log.Fatalf("failed to create identity client: %v", err)
+	}
+	log.Printf("fetching JWKS through the discovery document of %s (retrying up to %v)", identityURL, jwksRetryTimeout)
+	jwks := tokencache.NewJWKSCache(identityURL, cfg.Service.JWT.Issuer, cfg.Service.JWT.Algorithms)
+	revocations := tokencache.NewRevocationCache(cfg.Service.Identity.InternalURL, cfg.Service.JWT.RevocationPollInterval, identityHTTP)
 	deadline := time.Now().Add(jwksRetryTimeout)
 	for {
-		keys, err = boauth.FetchJWKS(identityURL)
+		err = jwks.Refresh()
+		if err == nil {
+			err = revocations.Refresh()
+		}
 		if err == nil {
 			break
 		}
 		if time.Now().After(deadline) {
-			log.Fatalf("failed to fetch JWKS after %v: %v", jwksRetryTimeout, err)
+			log.Fatalf("failed to fetch JWKS and revocations after %v: %v", jwksRetryTimeout, err)
 		}
-		log.Printf("JWKS fetch failed (will retry): %v", err)
+		log.Printf("JWKS/revocation fetch failed (will retry): %v", err)
 		time.Sleep(jwksRetryInterval)
 	}
-	log.Printf("loaded %d signing key(s) from identity", len(keys))
+	log.Printf("loaded %d signing key(s) of issuer %s and %d session revocation(s) from identity", jwks.Len(), jwks.Issuer(), revocations.Len())
+	go jwks.Run(context.Background())
+	go revocations.Run(context.Background())
 
 	verifier := jwtverifier.New(jwtverifier.Config{
-		Issuer:   cfg.Service.JWT.Issuer,
-		Audience: cfg.Service.JWT.Audience,
-		Keys:     keys,
-		Leeway:   30 * time.Second,
+		Issuer:     jwks.Issuer(),
+		Audience:   cfg.Service.JWT.Audience,
+		KeySource:  jwks,
+		Algorithms: cfg.Service.JWT.Algorithms,
+		Leeway:     30 * time.Second,
 	})
 
 	appKeyMW := bomw.AppKeyMiddleware(cfg.Service.AppKey)
-	authMW := bomw.Auth(verifier)
+	authMW := bomw.Auth(verifier, revocations)
 
 	authProxy, err := proxy.New(cfg.Service.Upstream.AuthURL)
 	if err != nil {
@@ -67,9 +67,14 @@ func (s *Server) Router() http.Handler {
 		HealthRoute:  prefix + "/v1/health",
 	})
 
+	// The authorization endpoint is a browser navigation and cannot carry
+	// the app key; the registered redirect URIs of the client protect it.
+	r.Get(prefix+"/authorize", authRoute(s.authProxy, "/authorize"))
+	r.Post(prefix+"/authorize", authRoute(s.authProxy, "/authorize"))
+
 	r.Group(func(r chi.Router) {
 		r.Use(s.appKeyMW)
-		r.Post(prefix+"/v1/auth/login", authLoginProxy(s.authProxy))
+		r.Post(prefix+"/token", authRoute(s.authProxy, "/token"))
 	})
 
 	r.Group(func(r chi.Router) {
@@ -80,15 +85,12 @@ func (s *Server) Router() http.Handler {
 	return r
 }
 
-// authLoginProxy wraps the auth proxy and rewrites the path from /v1/auth/login to /v1/login
-// so the auth service (which exposes POST /v1/login) receives the correct path.
-func authLoginProxy(proxy *httputil.ReverseProxy) http.HandlerFunc {
+// authRoute wraps the auth proxy and forwards the request to path on the
+// auth service, dropping the gateway's base path.
+func authRoute(proxy *httputil.ReverseProxy, path string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		r2 := r.Clone(r.Context())
-		r2.URL = &url.URL{Path: "/v1/login", RawPath: ""}
-		if r.URL.RawQuery != "" {
-			r2.URL.RawQuery = r.URL.RawQuery
-		}
+		r2.URL = &url.URL{Path: path, RawQuery: r.URL.RawQuery}
 		proxy.ServeHTTP(w, r2)
 	}
 }
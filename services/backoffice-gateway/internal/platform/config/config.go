@@ -1,7 +1,13 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	platformconfig "github.com/woffVienna/proteon-cursor/libs/platform/config"
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/serviceauth"
 )
 
 type Config = platformconfig.Config[ServiceConfig]
@@ -9,14 +15,23 @@ type Config = platformconfig.Config[ServiceConfig]
 type ServiceConfig struct {
 	JWT      JWTConfig
 	Upstream UpstreamConfig
+	Identity serviceauth.IdentityClientConfig
 	AppKey   string
 	// BasePath is the URL path prefix when behind an ingress (e.g. /backoffice). Empty for direct access.
 	BasePath string
 }
 
 type JWTConfig struct {
+	// Issuer pins the expected token issuer; empty takes it from
+	// identity's discovery document.
 	Issuer   string
 	Audience string
+	// Algorithms is the allowlist of JWS algorithms access tokens may be
+	// signed with; JWKS keys for other algorithms are ignored.
+	Algorithms []string
+	// RevocationPollInterval is how often the session revocation list is
+	// fetched from identity, i.e. how long a revoked token may still pass.
+	RevocationPollInterval time.Duration
 }
 
 type UpstreamConfig struct {
@@ -35,17 +50,33 @@ func Load() (Config, error) {
 	})
 
 	return loader.Load(func(env platformconfig.Env) (ServiceConfig, error) {
+		revocationPollInterval, err := env.Duration("REVOCATION_POLL_INTERVAL", 5*time.Second)
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+		if revocationPollInterval <= 0 {
+			return ServiceConfig{}, errors.New("REVOCATION_POLL_INTERVAL must be positive")
+		}
+		algorithms, err := jwtverifier.ParseAlgorithms(env.String("JWT_ALGORITHMS", jwtverifier.AlgEdDSA))
+		if err != nil {
+			return ServiceConfig{}, fmt.Errorf("invalid JWT_ALGORITHMS: %w", err)
+		}
+
+		identityURL := env.String("IDENTITY_URL", "http://localhost:8081")
 		return ServiceConfig{
 			JWT: JWTConfig{
-				Issuer:   env.String("JWT_ISSUER", "proteon.identity"),
-				Audience: env.String("JWT_AUDIENCE", "backoffice"),
+				Issuer:                 env.String("JWT_ISSUER", ""),
+				Audience:               env.String("JWT_AUDIENCE", "backoffice"),
+				Algorithms:             algorithms,
+				RevocationPollInterval: revocationPollInterval,
 			},
 			Upstream: UpstreamConfig{
-				IdentityURL: env.String("IDENTITY_URL", "http://localhost:8081"),
+				IdentityURL: identityURL,
 				AuthURL:     env.String("AUTH_URL", "http://localhost:8083"),
 			},
-			AppKey:    env.String("APP_KEY", "dev-backoffice-key-001"),
-			BasePath:  env.String("BASE_PATH", ""),
+			Identity: serviceauth.IdentityClientConfigFromEnv(env, identityURL),
+			AppKey:   env.String("APP_KEY", "dev-backoffice-key-001"),
+			BasePath: env.String("BASE_PATH", ""),
 		}, nil
 	})
 }
@@ -0,0 +1,338 @@
+// Command identity-storecheck runs the identity, session, refresh token,
+// provider store and audit log conformance checks against every store
+// adapter. The Postgres store is included when a DSN is given (-postgres,
+// default $DB_DSN); it only adds rows under unique conformance-* providers
+// and IDs.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/auth"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/conformance"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/postgres"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+)
+
+// sessionRetention is how long the session stores under check keep expired
+// sessions.
+const sessionRetention = time.Hour
+
+func main() {
+	dsn := flag.String("postgres", os.Getenv("DB_DSN"), "Postgres DSN; empty skips the Postgres store")
+	crashDir := flag.String("crash-writer", "", "internal: simulate a crash in the given store directory")
+	flag.Parse()
+
+	ctx := context.Background()
+	if *crashDir != "" {
+		crashWriter(ctx, *crashDir)
+	}
+	dir, err := os.MkdirTemp("", "identity-storecheck-")
+	if err != nil {
+		log.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	harnesses := []conformance.IdentityHarness{
+		{
+			Name: "memory",
+			Open: func() (conformance.IdentityStore, func() error, error) {
+				return auth.NewMemoryIdentityStore(uuid.NewString), func() error { return nil }, nil
+			},
+		},
+		fileHarness("file (fsync always)", filepath.Join(dir, "always"), auth.FileIdentityStoreOptions{
+			Fsync:         auth.FsyncAlways,
+			SnapshotEvery: 4,
+		}),
+		fileHarness("file (fsync interval)", filepath.Join(dir, "interval"), auth.FileIdentityStoreOptions{
+			Fsync:         auth.FsyncInterval,
+			FsyncInterval: 10 * time.Millisecond,
+		}),
+		fileHarness("file (fsync never)", filepath.Join(dir, "never"), auth.FileIdentityStoreOptions{
+			Fsync: auth.FsyncNever,
+		}),
+	}
+	sessionHarnesses := []conformance.SessionHarness{
+		{
+			Name:      "memory",
+			Retention: sessionRetention,
+			Open: func() (interfaces.SessionStore, interfaces.RefreshTokenStore, func() error, error) {
+				return auth.NewMemorySessionStore(sessionRetention), auth.NewMemoryRefreshTokenStore(), func() error { return nil }, nil
+			},
+		},
+		fileSessionHarness(filepath.Join(dir, "sessions")),
+	}
+	providerHarnesses := []conformance.ProviderHarness{
+		{
+			Name: "memory",
+			Open: func() (interfaces.ProviderStore, func() error, error) {
+				return auth.NewMemoryProviderStore(), func() error { return nil }, nil
+			},
+		},
+		{
+			Name:    "file",
+			Durable: true,
+			Open: func() (interfaces.ProviderStore, func() error, error) {
+				store, err := auth.NewFileProviderStore(filepath.Join(dir, "providers.json"))
+				if err != nil {
+					return nil, nil, err
+				}
+				return store, func() error { return nil }, nil
+			},
+		},
+	}
+	auditHarnesses := []conformance.AuditHarness{
+		{
+			Name: "memory",
+			Open: func() (interfaces.AuditLog, func() error, error) {
+				return auth.NewMemoryAuditLog(), func() error { return nil }, nil
+			},
+		},
+		{
+			Name:    "file",
+			Durable: true,
+			Open: func() (interfaces.AuditLog, func() error, error) {
+				auditDir := filepath.Join(dir, "audit")
+				if err := os.MkdirAll(auditDir, 0o700); err != nil {
+					return nil, nil, err
+				}
+				l, err := auth.NewFileAuditLog(auditDir)
+				if err != nil {
+					return nil, nil, err
+				}
+				return l, l.Close, nil
+			},
+		},
+	}
+	if *dsn != "" {
+		harnesses = append(harnesses, conformance.IdentityHarness{
+			Name:    "postgres",
+			Durable: true,
+			Open: func() (conformance.IdentityStore, func() error, error) {
+				pool, err := postgres.Open(ctx, *dsn)
+				if err != nil {
+					return nil, nil, err
+				}
+				if err := postgres.Migrate(ctx, pool); err != nil {
+					pool.Close()
+					return nil, nil, err
+				}
+				return postgres.NewIdentityStore(pool, uuid.NewString), func() error { pool.Close(); return nil }, nil
+			},
+		})
+		sessionHarnesses = append(sessionHarnesses, conformance.SessionHarness{
+			Name:      "postgres",
+			Retention: sessionRetention,
+			Durable:   true,
+			Open: func() (interfaces.SessionStore, interfaces.RefreshTokenStore, func() error, error) {
+				pool, err := postgres.Open(ctx, *dsn)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				if err := postgres.Migrate(ctx, pool); err != nil {
+					pool.Close()
+					return nil, nil, nil, err
+				}
+				release := func() error { pool.Close(); return nil }
+				return postgres.NewSessionStore(pool, sessionRetention), postgres.NewRefreshTokenStore(pool), release, nil
+			},
+		})
+		providerHarnesses = append(providerHarnesses, conformance.ProviderHarness{
+			Name:    "postgres",
+			Durable: true,
+			Open: func() (interfaces.ProviderStore, func() error, error) {
+				pool, err := postgres.Open(ctx, *dsn)
+				if err != nil {
+					return nil, nil, err
+				}
+				if err := postgres.Migrate(ctx, pool); err != nil {
+					pool.Close()
+					return nil, nil, err
+				}
+				return postgres.NewProviderStore(pool), func() error { pool.Close(); return nil }, nil
+			},
+		})
+		auditHarnesses = append(auditHarnesses, conformance.AuditHarness{
+			Name:    "postgres",
+			Durable: true,
+			Open: func() (interfaces.AuditLog, func() error, error) {
+				pool, err := postgres.Open(ctx, *dsn)
+				if err != nil {
+					return nil, nil, err
+				}
+				if err := postgres.Migrate(ctx, pool); err != nil {
+					pool.Close()
+					return nil, nil, err
+				}
+				return postgres.NewAuditLog(pool), func() error { pool.Close(); return nil }, nil
+			},
+		})
+	} else {
+		fmt.Println("SKIP postgres: no DSN")
+	}
+
+	failed := false
+	for _, h := range harnesses {
+		for _, r := range conformance.RunIdentityStore(ctx, h) {
+			if r.Err != nil {
+				failed = true
+				fmt.Printf("FAIL %s: %s: %v\n", h.Name, r.Check, r.Err)
+				continue
+			}
+			fmt.Printf("ok   %s: %s\n", h.Name, r.Check)
+		}
+	}
+	for _, h := range sessionHarnesses {
+		for _, r := range conformance.RunSessionStores(ctx, h) {
+			if r.Err != nil {
+				failed = true
+				fmt.Printf("FAIL %s sessions: %s: %v\n", h.Name, r.Check, r.Err)
+				continue
+			}
+			fmt.Printf("ok   %s sessions: %s\n", h.Name, r.Check)
+		}
+	}
+	for _, h := range providerHarnesses {
+		for _, r := range conformance.RunProviderStore(ctx, h) {
+			if r.Err != nil {
+				failed = true
+				fmt.Printf("FAIL %s providers: %s: %v\n", h.Name, r.Check, r.Err)
+				continue
+			}
+			fmt.Printf("ok   %s providers: %s\n", h.Name, r.Check)
+		}
+	}
+	for _, h := range auditHarnesses {
+		for _, r := range conformance.RunAuditLog(ctx, h) {
+			if r.Err != nil {
+				failed = true
+				fmt.Printf("FAIL %s audit log: %s: %v\n", h.Name, r.Check, r.Err)
+				continue
+			}
+			fmt.Printf("ok   %s audit log: %s\n", h.Name, r.Check)
+		}
+	}
+	if err := checkTornLog(ctx, filepath.Join(dir, "torn")); err != nil {
+		failed = true
+		fmt.Printf("FAIL file: torn log tail is recovered: %v\n", err)
+	} else {
+		fmt.Println("ok   file: torn log tail is recovered")
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func fileHarness(name, dir string, opts auth.FileIdentityStoreOptions) conformance.IdentityHarness {
+	return conformance.IdentityHarness{
+		Name:    name,
+		Durable: true,
+		Open: func() (conformance.IdentityStore, func() error, error) {
+			store, err := auth.NewFileIdentityStore(dir, uuid.NewString, opts)
+			if err != nil {
+				return nil, nil, err
+			}
+			return store, store.Close, nil
+		},
+	}
+}
+
+func fileSessionHarness(dir string) conformance.SessionHarness {
+	return conformance.SessionHarness{
+		Name:      "file",
+		Retention: sessionRetention,
+		Durable:   true,
+		Open: func() (interfaces.SessionStore, interfaces.RefreshTokenStore, func() error, error) {
+			if err := os.MkdirAll(dir, 0o700); err != nil {
+				return nil, nil, nil, err
+			}
+			sessions, err := auth.NewFileSessionStore(dir, sessionRetention)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			tokens, err := auth.NewFileRefreshTokenStore(dir)
+			if err != nil {
+				sessions.Close()
+				return nil, nil, nil, err
+			}
+			release := func() error { return errors.Join(sessions.Close(), tokens.Close()) }
+			return sessions, tokens, release, nil
+		},
+	}
+}
+
+// checkTornLog simulates a crash in the middle of an append: a child
+// process writes identities, appends a partial record and exits without
+// closing the store. Reopening must drop the partial record and keep
+// everything before it.
+func checkTornLog(ctx context.Context, dir string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	out, err := exec.Command(exe, "-crash-writer", dir).Output()
+	if err != nil {
+		return fmt.Errorf("crash writer: %w", err)
+	}
+	ids := strings.Fields(string(out))
+	if len(ids) != 2 {
+		return fmt.Errorf("crash writer printed %q", out)
+	}
+
+	store, err := auth.NewFileIdentityStore(dir, uuid.NewString, auth.FileIdentityStoreOptions{})
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	for _, id := range ids {
+		if _, err := store.GetByPlatformUserID(ctx, id); err != nil {
+			return fmt.Errorf("identity %s after recovery: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// crashWriter creates one identity in the snapshot and one in the log,
+// tears the log and exits without closing the store.
+func crashWriter(ctx context.Context, dir string) {
+	store, err := auth.NewFileIdentityStore(dir, uuid.NewString, auth.FileIdentityStoreOptions{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	inSnapshot, err := store.Resolve(ctx, "torn", "u1", "t1")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := store.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	if store, err = auth.NewFileIdentityStore(dir, uuid.NewString, auth.FileIdentityStoreOptions{}); err != nil {
+		log.Fatal(err)
+	}
+	inLog, err := store.Resolve(ctx, "torn", "u2", "t1")
+	if err != nil {
+		log.Fatal(err)
+	}
+	f, err := os.OpenFile(filepath.Join(dir, "identities.log"), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := f.WriteString(`1234abcd {"seq":3,"op":"cre`); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(inSnapshot.PlatformUserID, inLog.PlatformUserID)
+	os.Exit(0)
+}
@@ -1,13 +1,29 @@
 package main
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"log"
+	"time"
 
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/serviceauth"
 	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/auth"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/events"
 	httpadapter "github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http"
+	natsadapter "github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/nats"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/postgres"
 	authapp "github.com/woffVienna/proteon-cursor/services/identity/internal/application/auth"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/identities"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/introspection"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/outbox"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/providers"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/sessions"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/signingkeys"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
 	"github.com/woffVienna/proteon-cursor/services/identity/internal/platform/config"
 )
 
@@ -17,21 +33,142 @@ func main() {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
-	identityStore := auth.NewMemoryIdentityStore(generateUUID)
-	issuer, err := auth.NewJWTIssuer(cfg.Service.JWT.Issuer, cfg.Service.JWT.Audience)
+	ttlPolicy := tokenTTLPolicy(cfg)
+
+	storage, err := loadIdentityStorage(context.Background(), cfg, ttlPolicy.MaxAccessTTL())
+	if err != nil {
+		log.Fatalf("failed to open identity store: %v", err)
+	}
+	defer storage.close()
+
+	publisher, closePublisher, err := loadEventPublisher(context.Background(), cfg)
+	if err != nil {
+		log.Fatalf("failed to connect event publisher: %v", err)
+	}
+	defer closePublisher()
+	go outbox.NewRelay(storage.identities, publisher, cfg.Service.Events.RelayInterval).Run(context.Background())
+
+	signingAlgs := auth.SigningAlgorithms{
+		Default:   cfg.Service.JWT.SigningAlg,
+		Audiences: cfg.Service.JWT.AudienceAlgs,
+	}
+	keyRing, err := loadKeyRing(context.Background(), cfg, storage.keyRing, signingAlgs.All())
+	if err != nil {
+		log.Fatalf("failed to load signing key ring: %v", err)
+	}
+	for _, alg := range signingAlgs.All() {
+		activeKey, err := keyRing.ActiveKey(alg)
+		if err != nil {
+			log.Printf("the %s key is published but not active yet; its audiences get %s tokens until then", alg, signingAlgs.For(""))
+			continue
+		}
+		log.Printf("signing %s tokens with kid %s", alg, activeKey.Kid)
+	}
+
+	issuer, err := auth.NewJWTIssuer(cfg.Service.JWT.Issuer, cfg.Service.JWT.Audience, signingAlgs, keyRing)
 	if err != nil {
 		log.Fatalf("failed to create JWT issuer: %v", err)
 	}
 
-	authSvc := authapp.NewService(identityStore, identityStore, issuer)
+	scopePolicy, err := loadScopePolicy(cfg)
+	if err != nil {
+		log.Fatalf("failed to load scope policy: %v", err)
+	}
+
+	delegationPolicy, err := loadDelegationPolicy(cfg)
+	if err != nil {
+		log.Fatalf("failed to load delegation policy: %v", err)
+	}
+
+	providerStore, err := loadProviderStore(context.Background(), cfg, storage)
+	if err != nil {
+		log.Fatalf("failed to load provider registry: %v", err)
+	}
+
+	tokenVerifier := auth.NewTokenVerifier(cfg.Service.JWT.Issuer, keyRing, signingAlgs.All(), 30*time.Second)
+
+	authSvc := authapp.NewService(
+		authapp.Deps{
+			Resolver:      storage.identities,
+			Linker:        storage.identities,
+			Merger:        storage.identities,
+			Eraser:        storage.identities,
+			Profiles:      storage.identities,
+			Statuses:      storage.identities,
+			Lookup:        storage.identities,
+			Audit:         storage.audit,
+			Outbox:        storage.identities,
+			Issuer:        issuer,
+			Verifier:      tokenVerifier,
+			RefreshTokens: storage.refreshTokens,
+			Sessions:      storage.sessions,
+			Providers:     providerStore,
+			Assertions:    auth.NewAssertionVerifier(providerStore),
+			Replay:        storage.replay,
+		},
+		authapp.Policies{
+			Scopes: scopePolicy,
+			TTL:    ttlPolicy,
+			Assertions: domain.AssertionPolicy{
+				Audience:    cfg.Service.Assertions.Audience,
+				MaxLifetime: cfg.Service.Assertions.MaxLifetime,
+				Leeway:      cfg.Service.Assertions.Leeway,
+			},
+			Delegation: delegationPolicy,
+		},
+	)
+	providersSvc := providers.NewService(providerStore, ttlPolicy)
+	identitiesSvc := identities.NewService(storage.identities, storage.identities)
+	sessionsSvc := sessions.NewService(storage.sessions, storage.refreshTokens, ttlPolicy.MaxAccessTTL())
+	// Replicas sharing the ring pick up a change up to one tick after it
+	// was made, so keys are published and retired that much later.
+	const keyTick = time.Minute
+	keysSvc := signingkeys.NewService(keyRing, signingkeys.Policy{
+		RotationInterval: cfg.Service.JWT.KeyRotationInterval,
+		PublishLead:      cfg.Service.JWT.JWKSMaxAge + keyTick,
+		RetireAfter:      cfg.Service.JWT.KeyRetireAfter + keyTick,
+	})
+	go signingkeys.NewScheduler(keysSvc, keyTick).Run(context.Background())
+
+	introspectionSvc := introspection.NewService(
+		auth.NewStaticClientAuthenticator(cfg.Service.Introspection.Clients),
+		tokenVerifier,
+		storage.sessions,
+	)
+	if len(cfg.Service.Introspection.Clients) == 0 {
+		log.Printf("no introspection clients configured; POST /internal/v1/introspect rejects every caller")
+	}
 
 	httpCfg := httpadapter.Config{
 		Port:              cfg.HTTP.Port,
 		OpenAPIBundlePath: ".build/generated/openapi.bundle.yml",
 		ServiceName:       cfg.ServiceName,
 		Version:           cfg.Version,
+		JWKSMaxAge:        cfg.Service.JWT.JWKSMaxAge,
+		Discovery: httpadapter.Discovery{
+			Issuer:                cfg.Service.JWT.Issuer,
+			BaseURL:               cfg.HTTP.PublicBaseURL,
+			AuthorizationEndpoint: cfg.Service.OAuth.AuthorizationEndpoint,
+			TokenEndpoint:         cfg.Service.OAuth.TokenEndpoint,
+		},
+		Internal:        internalAuth(cfg),
+		InternalTLSPort: cfg.Service.Internal.TLSPort,
+	}
+	if cfg.Service.Internal.TLSPort != "" {
+		internal := cfg.Service.Internal
+		httpCfg.InternalTLS, err = serviceauth.ServerTLSConfig(internal.TLSCertFile, internal.TLSKeyFile, internal.ClientCAFile)
+		if err != nil {
+			log.Fatalf("failed to configure internal TLS listener: %v", err)
+		}
 	}
-	srv := httpadapter.NewServer(httpCfg, authSvc, issuer)
+	srv := httpadapter.NewServer(httpCfg, httpadapter.Services{
+		Auth:          authSvc,
+		SigningKeys:   keysSvc,
+		Sessions:      sessionsSvc,
+		Introspection: introspectionSvc,
+		Providers:     providersSvc,
+		Identities:    identitiesSvc,
+	})
 
 	addr := ":" + cfg.HTTP.Port
 	log.Printf("Identity service listening on %s", addr)
@@ -44,6 +181,371 @@ func main() {
 	}
 }
 
+// loadKeyRing opens the signing key ring and seeds it with the configured
+// key when it is empty. An existing ring is authoritative. With
+// IDENTITY_STORE=postgres the ring is kept in the database, shared by all
+// replicas, and an existing ring file is imported into an empty one.
+// Keys for signing algorithms the ring has none for are generated, which
+// outside ENV=dev needs a ring file or Postgres to persist them. Added to
+// a new ring they sign right away; added to an existing one they are
+// published first and activated after JWKS_CACHE_MAX_AGE, like a rotation.
+func loadKeyRing(ctx context.Context, cfg config.Config, store auth.KeyRingStore, algs []string) (*auth.KeyRing, error) {
+	// Replicas starting together race to seed the ring; the losers load
+	// the winner's ring on the next attempt.
+	for attempt := 1; ; attempt++ {
+		ring, err := openKeyRing(ctx, cfg, store, algs)
+		if !errors.Is(err, domain.ErrKeyRingChanged) || attempt == 3 {
+			return ring, err
+		}
+		log.Printf("signing key ring changed by another replica while loading; retrying")
+	}
+}
+
+func openKeyRing(ctx context.Context, cfg config.Config, store auth.KeyRingStore, algs []string) (*auth.KeyRing, error) {
+	path := cfg.Service.JWT.KeyRingFile
+	source := path
+	var (
+		ring *auth.KeyRing
+		err  error
+	)
+	if store != nil {
+		source = store.String()
+		ring, err = auth.NewStoredKeyRing(ctx, store)
+	} else {
+		ring, err = auth.NewKeyRing(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if store != nil && path != "" && ring.Empty() {
+		imported, err := ring.Import(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		if imported {
+			log.Printf("imported signing key ring %s into %s", path, source)
+		}
+	}
+
+	seeded := ring.Empty()
+	if seeded {
+		key, err := loadSigningKey(cfg, source)
+		if err != nil {
+			return nil, err
+		}
+		if err := ring.Seed(key, time.Now()); err != nil {
+			return nil, err
+		}
+	} else {
+		log.Printf("loaded signing key ring from %s", source)
+	}
+
+	for _, alg := range algs {
+		if ring.HasAlgorithm(alg) {
+			continue
+		}
+		if source == "" && cfg.Environment != "dev" {
+			return nil, fmt.Errorf("no %s signing key configured; set JWT_KEY_RING_FILE to persist a generated one", alg)
+		}
+		if !seeded && alg == cfg.Service.JWT.SigningAlg {
+			return nil, fmt.Errorf("key ring has no %s key to sign with by default; add %s for an audience first and change JWT_SIGNING_ALG once it is active", alg, alg)
+		}
+		log.Printf("generating a signing key for %s", alg)
+		if err := ring.AddAlgorithm(alg, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	if seeded {
+		if _, err := ring.ActivatePending(ctx, time.Now(), 0); err != nil {
+			return nil, err
+		}
+	}
+	return ring, nil
+}
+
+// loadSigningKey resolves the configured signing key of a ring persisted
+// in ringSource, if any. Config validation guarantees that an unpersisted
+// ephemeral key is only used with ENV=dev.
+func loadSigningKey(cfg config.Config, ringSource string) (auth.SigningKey, error) {
+	jwtCfg := cfg.Service.JWT
+	switch {
+	case jwtCfg.SigningKeyFile != "":
+		return auth.LoadSigningKeyFile(jwtCfg.SigningKeyFile)
+	case jwtCfg.SigningKey != "":
+		return auth.ParseSigningKey([]byte(jwtCfg.SigningKey))
+	case ringSource != "":
+		log.Printf("no signing key configured; generating a new key for %s", ringSource)
+		return auth.GenerateSigningKey(jwtCfg.SigningAlg)
+	default:
+		log.Printf("WARNING: no signing key configured (ENV=%s); using an ephemeral key, tokens will not survive a restart", cfg.Environment)
+		return auth.GenerateSigningKey(jwtCfg.SigningAlg)
+	}
+}
+
+// loadScopePolicy resolves the configured scope policy. Without one no
+// scopes are granted.
+func loadScopePolicy(cfg config.Config) (domain.ScopePolicy, error) {
+	scopeCfg := cfg.Service.Scopes
+	switch {
+	case scopeCfg.PolicyFile != "":
+		return auth.LoadScopePolicyFile(scopeCfg.PolicyFile)
+	case scopeCfg.Policy != "":
+		return auth.ParseScopePolicy([]byte(scopeCfg.Policy))
+	default:
+		return domain.ScopePolicy{}, nil
+	}
+}
+
+// loadDelegationPolicy resolves the configured delegation policy. Without
+// one no token exchange is allowed.
+func loadDelegationPolicy(cfg config.Config) (domain.DelegationPolicy, error) {
+	delegationCfg := cfg.Service.Delegation
+	switch {
+	case delegationCfg.PolicyFile != "":
+		return auth.LoadDelegationPolicyFile(delegationCfg.PolicyFile)
+	case delegationCfg.Policy != "":
+		return auth.ParseDelegationPolicy([]byte(delegationCfg.Policy))
+	default:
+		return domain.DelegationPolicy{}, nil
+	}
+}
+
+// internalAuth maps the caller config to the HTTP adapter. Config validation
+// guarantees that open /internal routes are only used with ENV=dev.
+func internalAuth(cfg config.Config) httpadapter.InternalAuth {
+	internal := cfg.Service.Internal
+	if !internal.Authenticated() {
+		log.Printf("WARNING: no service callers configured (ENV=%s); /internal routes are open to every caller", cfg.Environment)
+		return httpadapter.InternalAuth{Open: true}
+	}
+	if len(internal.Allow) == 0 {
+		log.Printf("INTERNAL_ALLOWLIST is empty; every /internal operation except introspection is denied")
+	}
+	return httpadapter.InternalAuth{
+		Tokens: serviceauth.NewVerifier(internal.TokenAudience, internal.Callers, 30*time.Second),
+		Allow:  internal.Allow,
+	}
+}
+
+// identityStore resolves, links, merges and looks up platform identities
+// and holds the outbox of their events.
+type identityStore interface {
+	interfaces.IdentityResolver
+	interfaces.IdentityLinker
+	interfaces.IdentityMerger
+	interfaces.IdentityEraser
+	interfaces.IdentityProfiles
+	interfaces.IdentityStatuses
+	interfaces.IdentityLookup
+	interfaces.IdentityQuery
+	interfaces.Outbox
+}
+
+// identityStorage is the persistence selected by IDENTITY_STORE.
+type identityStorage struct {
+	identities    identityStore
+	audit         interfaces.AuditLog
+	refreshTokens interfaces.RefreshTokenStore
+	sessions      interfaces.SessionStore
+	replay        interfaces.AssertionReplayCache
+	// keyRing keeps the signing key ring and providers the provider
+	// registry; nil leaves them to JWT_KEY_RING_FILE and
+	// PROVIDER_STORE_FILE.
+	keyRing   auth.KeyRingStore
+	providers interfaces.ProviderStore
+	// close releases the storage.
+	close func()
+}
+
+// loadIdentityStorage opens the configured identity store together with the
+// audit log, refresh token and session stores and the assertion replay
+// cache next to it, and for Postgres the signing key ring and provider
+// stores; for Postgres it applies pending migrations first. Expired sessions are kept for
+// sessionRetention, the longest access token lifetime, so their
+// revocations are listed until every token has expired.
+func loadIdentityStorage(ctx context.Context, cfg config.Config, sessionRetention time.Duration) (identityStorage, error) {
+	storeCfg := cfg.Service.Store
+	switch storeCfg.Backend {
+	case config.StoreFile:
+		store, err := auth.NewFileIdentityStore(storeCfg.Dir, generateUUID, auth.FileIdentityStoreOptions{
+			Fsync:         auth.FsyncPolicy(storeCfg.Fsync),
+			FsyncInterval: storeCfg.FsyncInterval,
+			SnapshotEvery: storeCfg.SnapshotEvery,
+		})
+		if err != nil {
+			return identityStorage{}, err
+		}
+		audit, err := auth.NewFileAuditLog(storeCfg.Dir)
+		if err != nil {
+			store.Close()
+			return identityStorage{}, err
+		}
+		refreshTokens, err := auth.NewFileRefreshTokenStore(storeCfg.Dir)
+		if err != nil {
+			store.Close()
+			audit.Close()
+			return identityStorage{}, err
+		}
+		sessionStore, err := auth.NewFileSessionStore(storeCfg.Dir, sessionRetention)
+		if err != nil {
+			store.Close()
+			audit.Close()
+			refreshTokens.Close()
+			return identityStorage{}, err
+		}
+		replay, err := auth.NewFileReplayCache(storeCfg.Dir)
+		if err != nil {
+			store.Close()
+			audit.Close()
+			refreshTokens.Close()
+			sessionStore.Close()
+			return identityStorage{}, err
+		}
+		log.Printf("using file identity store in %s (fsync %s)", storeCfg.Dir, storeCfg.Fsync)
+		return identityStorage{identities: store, audit: audit, refreshTokens: refreshTokens, sessions: sessionStore, replay: replay, close: func() {
+			if err := store.Close(); err != nil {
+				log.Printf("close identity store: %v", err)
+			}
+			if err := audit.Close(); err != nil {
+				log.Printf("close audit log: %v", err)
+			}
+			if err := refreshTokens.Close(); err != nil {
+				log.Printf("close refresh token store: %v", err)
+			}
+			if err := sessionStore.Close(); err != nil {
+				log.Printf("close session store: %v", err)
+			}
+			if err := replay.Close(); err != nil {
+				log.Printf("close replay cache: %v", err)
+			}
+		}}, nil
+	case config.StorePostgres:
+	default:
+		log.Printf("WARNING: IDENTITY_STORE=%s; platform identities, sessions, refresh tokens and used assertion IDs will not survive a restart", storeCfg.Backend)
+		return identityStorage{
+			identities:    auth.NewMemoryIdentityStore(generateUUID),
+			audit:         auth.NewMemoryAuditLog(),
+			refreshTokens: auth.NewMemoryRefreshTokenStore(),
+			sessions:      auth.NewMemorySessionStore(sessionRetention),
+			replay:        auth.NewMemoryReplayCache(),
+			close:         func() {},
+		}, nil
+	}
+
+	pool, err := postgres.Open(ctx, storeCfg.DSN)
+	if err != nil {
+		return identityStorage{}, err
+	}
+	if err := postgres.Migrate(ctx, pool); err != nil {
+		pool.Close()
+		return identityStorage{}, fmt.Errorf("migrate: %w", err)
+	}
+	log.Printf("using postgres identity store")
+	return identityStorage{
+		identities:    postgres.NewIdentityStore(pool, generateUUID),
+		audit:         postgres.NewAuditLog(pool),
+		refreshTokens: postgres.NewRefreshTokenStore(pool),
+		sessions:      postgres.NewSessionStore(pool, sessionRetention),
+		replay:        postgres.NewReplayCache(pool),
+		keyRing:       postgres.NewKeyRingStore(pool),
+		providers:     postgres.NewProviderStore(pool),
+		close:         pool.Close,
+	}, nil
+}
+
+// loadEventPublisher connects to JetStream, or falls back to the service
+// log without NATS_URL. The returned func closes the connection.
+func loadEventPublisher(ctx context.Context, cfg config.Config) (interfaces.EventPublisher, func(), error) {
+	eventsCfg := cfg.Service.Events
+	if eventsCfg.NATSURL == "" {
+		log.Printf("NATS_URL not set; events are written to the service log")
+		return events.NewLogPublisher(), func() {}, nil
+	}
+	publisher, err := natsadapter.NewPublisher(ctx, eventsCfg.NATSURL, eventsCfg.Stream)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Printf("publishing events to stream %s at %s", eventsCfg.Stream, eventsCfg.NATSURL)
+	return publisher, func() {
+		if err := publisher.Close(); err != nil {
+			log.Printf("close event publisher: %v", err)
+		}
+	}, nil
+}
+
+// loadProviderStore opens the provider registry. With
+// IDENTITY_STORE=postgres it is kept in the database, shared by all
+// replicas, and providers of PROVIDER_STORE_FILE it lacks are imported.
+// Otherwise, without a store file providers only live until the next
+// restart.
+func loadProviderStore(ctx context.Context, cfg config.Config, storage identityStorage) (interfaces.ProviderStore, error) {
+	path := cfg.Service.Providers.StoreFile
+	if storage.providers != nil {
+		if path != "" {
+			if err := importProviders(ctx, storage.providers, path); err != nil {
+				return nil, err
+			}
+		}
+		log.Printf("using postgres provider registry")
+		return storage.providers, nil
+	}
+	if path == "" {
+		log.Printf("WARNING: PROVIDER_STORE_FILE not set; registered providers will not survive a restart")
+		return auth.NewMemoryProviderStore(), nil
+	}
+	store, err := auth.NewFileProviderStore(path)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("loaded provider registry from %s", path)
+	return store, nil
+}
+
+// importProviders copies the providers of the registry file at path that
+// store lacks into it.
+func importProviders(ctx context.Context, store interfaces.ProviderStore, path string) error {
+	file, err := auth.NewFileProviderStore(path)
+	if err != nil {
+		return err
+	}
+	providers, err := file.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range providers {
+		err := store.Create(ctx, p)
+		if errors.Is(err, domain.ErrProviderExists) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("import provider %s: %w", p.ID, err)
+		}
+		log.Printf("imported provider %s from %s", p.ID, path)
+	}
+	return nil
+}
+
+// tokenTTLPolicy maps the validated token lifetime config to the domain policy.
+func tokenTTLPolicy(cfg config.Config) domain.TTLPolicy {
+	tokens := cfg.Service.Tokens
+	policy := domain.TTLPolicy{
+		PlayerAudience:   cfg.Service.JWT.Audience,
+		PlayerAccess:     tokens.AccessTTL,
+		BackofficeAccess: tokens.BackofficeTTL,
+		Refresh:          tokens.RefreshTTL,
+		Overrides:        make([]domain.TTLOverride, 0, len(tokens.Overrides)),
+	}
+	for _, o := range tokens.Overrides {
+		policy.Overrides = append(policy.Overrides, domain.TTLOverride{
+			Audience:    o.Audience,
+			Tenant:      o.Tenant,
+			SubjectType: o.SubjectType,
+			TTL:         o.TTL,
+		})
+	}
+	return policy
+}
+
 func generateUUID() string {
 	b := make([]byte, 16)
 	_, _ = rand.Read(b)
@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// failingProviders is a provider store that cannot be read.
+type failingProviders struct {
+	*MemoryProviderStore
+	err error
+}
+
+func (s failingProviders) Get(context.Context, string) (domain.Provider, error) {
+	return domain.Provider{}, s.err
+}
+
+func TestAssertionVerifierVerify(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherEdKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64 := base64.RawURLEncoding.EncodeToString
+	edPub := edKey.Public().(ed25519.PublicKey)
+	edJWK := domain.PublicJWK{Kty: "OKP", Kid: "ed-1", Crv: "Ed25519", X: b64(edPub)}
+	ecJWK := domain.PublicJWK{
+		Kty: "EC", Kid: "ec-1", Crv: "P-256",
+		X: b64(ecKey.X.FillBytes(make([]byte, 32))),
+		Y: b64(ecKey.Y.FillBytes(make([]byte, 32))),
+	}
+
+	providers := NewMemoryProviderStore()
+	for _, p := range []domain.Provider{
+		{ID: "single", Status: domain.ProviderActive, AssertionKeys: []domain.PublicJWK{edJWK}},
+		{ID: "multi", Status: domain.ProviderActive, AssertionKeys: []domain.PublicJWK{edJWK, ecJWK}},
+	} {
+		if err := providers.Create(context.Background(), p); err != nil {
+			t.Fatalf("create provider: %v", err)
+		}
+	}
+	errStore := errors.New("provider store unavailable")
+
+	tests := []struct {
+		name     string
+		method   jwt.SigningMethod
+		key      crypto.PrivateKey
+		kid      string
+		iss      string
+		failing  bool
+		wantErr  error
+		wantNone error
+	}{
+		{name: "single key without kid", method: jwt.SigningMethodEdDSA, key: edKey, iss: "single"},
+		{name: "single key with kid", method: jwt.SigningMethodEdDSA, key: edKey, kid: "ed-1", iss: "single"},
+		{name: "ES256 key by kid", method: jwt.SigningMethodES256, key: ecKey, kid: "ec-1", iss: "multi"},
+		{
+			name: "several keys without kid", method: jwt.SigningMethodEdDSA, key: edKey, iss: "multi",
+			wantErr: domain.ErrAssertionSignature,
+		},
+		{
+			name: "unknown kid", method: jwt.SigningMethodEdDSA, key: edKey, kid: "other", iss: "single",
+			wantErr: domain.ErrAssertionSignature,
+		},
+		{
+			name: "signed by another key", method: jwt.SigningMethodEdDSA, key: otherEdKey, kid: "ed-1", iss: "single",
+			wantErr: domain.ErrAssertionSignature,
+		},
+		{
+			name: "kid of a key for another algorithm", method: jwt.SigningMethodES256, key: ecKey, kid: "ed-1", iss: "multi",
+			wantErr: domain.ErrAssertionSignature,
+		},
+		{
+			name: "alg none", method: jwt.SigningMethodNone, key: jwt.UnsafeAllowNoneSignatureType, iss: "single",
+			wantErr: domain.ErrAssertionSignature,
+		},
+		{
+			name: "HS256 keyed with the public key", method: jwt.SigningMethodHS256, key: []byte(edPub), iss: "single",
+			wantErr: domain.ErrAssertionSignature,
+		},
+		{
+			name: "missing iss", method: jwt.SigningMethodEdDSA, key: edKey,
+			wantErr: domain.ErrAssertionSignature,
+		},
+		{
+			name: "unknown provider", method: jwt.SigningMethodEdDSA, key: edKey, iss: "unknown",
+			wantErr: domain.ErrUnknownProvider,
+		},
+		{
+			name: "provider store failure", method: jwt.SigningMethodEdDSA, key: edKey, iss: "single", failing: true,
+			wantErr: errStore, wantNone: domain.ErrInvalidAssertion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			claims := jwt.MapClaims{
+				"sub":    "external-1",
+				"tenant": "tenant-a",
+				"aud":    "proteon",
+				"jti":    "assertion-1",
+				"iat":    now.Unix(),
+				"exp":    now.Add(time.Minute).Unix(),
+			}
+			if tt.iss != "" {
+				claims["iss"] = tt.iss
+			}
+			tok := jwt.NewWithClaims(tt.method, claims)
+			if tt.kid != "" {
+				tok.Header["kid"] = tt.kid
+			}
+			raw, err := tok.SignedString(tt.key)
+			if err != nil {
+				t.Fatalf("sign: %v", err)
+			}
+
+			v := NewAssertionVerifier(providers)
+			if tt.failing {
+				v = NewAssertionVerifier(failingProviders{MemoryProviderStore: providers, err: errStore})
+			}
+			got, err := v.Verify(context.Background(), raw)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantNone != nil && errors.Is(err, tt.wantNone) {
+				t.Fatalf("Verify error = %v, must not wrap %v", err, tt.wantNone)
+			}
+			if err != nil {
+				return
+			}
+			if got.Provider != tt.iss || got.ExternalUserID != "external-1" || got.Tenant != "tenant-a" || got.ID != "assertion-1" {
+				t.Errorf("assertion = %+v", got)
+			}
+			if got.ExpiresAt.Sub(got.IssuedAt) != time.Minute {
+				t.Errorf("assertion lifetime = %v, want 1m", got.ExpiresAt.Sub(got.IssuedAt))
+			}
+		})
+	}
+}
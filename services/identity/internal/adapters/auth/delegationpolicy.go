@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// delegationPolicyFile is the JSON representation of a delegation policy:
+//
+//	{"max_actor_chain": 2, "rules": [{"actor": "game-service", "subject_type": "player",
+//	  "audiences": ["wallet-service"], "scopes": ["wallet:read"], "via": []}]}
+//
+// Omitted match fields match any value; an omitted max_actor_chain is
+// domain.DefaultMaxActorChain.
+type delegationPolicyFile struct {
+	MaxActorChain *int `json:"max_actor_chain"`
+	Rules         []struct {
+		Actor       string   `json:"actor"`
+		Tenant      string   `json:"tenant"`
+		SubjectType string   `json:"subject_type"`
+		Audiences   []string `json:"audiences"`
+		Scopes      []string `json:"scopes"`
+		Via         []string `json:"via"`
+	} `json:"rules"`
+}
+
+// LoadDelegationPolicyFile reads a JSON delegation policy.
+func LoadDelegationPolicyFile(path string) (domain.DelegationPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.DelegationPolicy{}, fmt.Errorf("read delegation policy %s: %w", path, err)
+	}
+	policy, err := ParseDelegationPolicy(data)
+	if err != nil {
+		return domain.DelegationPolicy{}, fmt.Errorf("parse delegation policy %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// ParseDelegationPolicy parses a JSON delegation policy. Unknown fields,
+// rules without actor or audiences, scopes that are empty or contain
+// whitespace and a max_actor_chain below 1 are rejected.
+func ParseDelegationPolicy(data []byte) (domain.DelegationPolicy, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var f delegationPolicyFile
+	if err := dec.Decode(&f); err != nil {
+		return domain.DelegationPolicy{}, err
+	}
+
+	policy := domain.DelegationPolicy{
+		Rules:         make([]domain.DelegationRule, 0, len(f.Rules)),
+		MaxActorChain: domain.DefaultMaxActorChain,
+	}
+	if f.MaxActorChain != nil {
+		if *f.MaxActorChain < 1 {
+			return domain.DelegationPolicy{}, fmt.Errorf("max_actor_chain must be at least 1")
+		}
+		policy.MaxActorChain = *f.MaxActorChain
+	}
+	for i, r := range f.Rules {
+		if r.Actor == "" {
+			return domain.DelegationPolicy{}, fmt.Errorf("rule %d has no actor", i)
+		}
+		if len(r.Audiences) == 0 {
+			return domain.DelegationPolicy{}, fmt.Errorf("rule %d allows no audiences", i)
+		}
+		for _, scope := range r.Scopes {
+			if scope == "" || strings.ContainsAny(scope, " \t\r\n") {
+				return domain.DelegationPolicy{}, fmt.Errorf("rule %d: invalid scope %q", i, scope)
+			}
+		}
+		policy.Rules = append(policy.Rules, domain.DelegationRule{
+			Actor:       r.Actor,
+			Tenant:      r.Tenant,
+			SubjectType: r.SubjectType,
+			Audiences:   r.Audiences,
+			Scopes:      r.Scopes,
+			Via:         r.Via,
+		})
+	}
+	return policy, nil
+}
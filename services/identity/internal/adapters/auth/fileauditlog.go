@@ -0,0 +1,181 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// identityAuditFile is the audit log in a file identity store directory.
+const identityAuditFile = "audit.log"
+
+// FileAuditLog is an AuditLog appended to a file of JSON lines, one per
+// entry, and synced before Append returns. Entries are also kept in memory
+// for reads. Like the file identity store it belongs to a single process.
+type FileAuditLog struct {
+	mu   sync.Mutex
+	mem  *MemoryAuditLog
+	file *os.File
+}
+
+// NewFileAuditLog opens the audit log in dir, the directory of a file
+// identity store, creating it if needed. A line torn by a crash at the end
+// of the file is dropped.
+func NewFileAuditLog(dir string) (*FileAuditLog, error) {
+	path := filepath.Join(dir, identityAuditFile)
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log %s: %w", path, err)
+	}
+	l := &FileAuditLog{mem: NewMemoryAuditLog(), file: f}
+	if err := l.load(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// Append implements interfaces.AuditLog.
+func (l *FileAuditLog) Append(ctx context.Context, entry domain.AuditEntry) error {
+	line, err := json.Marshal(fileAuditEntry{
+		ID:             entry.ID,
+		PlatformUserID: entry.PlatformUserID,
+		Action:         string(entry.Action),
+		Actor:          entry.Actor,
+		Details:        entry.Details,
+		At:             entry.At,
+	})
+	if err != nil {
+		return fmt.Errorf("encode audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("append audit log: %w", err)
+	}
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("sync audit log: %w", err)
+	}
+	return l.mem.Append(ctx, entry)
+}
+
+// ListByUser implements interfaces.AuditLog.
+func (l *FileAuditLog) ListByUser(ctx context.Context, platformUserID string) ([]domain.AuditEntry, error) {
+	return l.mem.ListByUser(ctx, platformUserID)
+}
+
+// ReplaceDetails implements interfaces.AuditLog. The file is rewritten, so
+// replaced details do not linger on disk.
+func (l *FileAuditLog) ReplaceDetails(ctx context.Context, platformUserID string, details map[string]map[string]string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	path := l.file.Name()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read audit log %s: %w", path, err)
+	}
+	var out bytes.Buffer
+	for line := range bytes.Lines(data) {
+		var fe fileAuditEntry
+		if err := json.Unmarshal(line, &fe); err != nil {
+			return fmt.Errorf("audit log %s: corrupt entry: %w", path, err)
+		}
+		d, ok := details[fe.ID]
+		if !ok || fe.PlatformUserID != platformUserID {
+			out.Write(line)
+			continue
+		}
+		fe.Details = d
+		replaced, err := json.Marshal(fe)
+		if err != nil {
+			return fmt.Errorf("encode audit entry: %w", err)
+		}
+		out.Write(append(replaced, '\n'))
+	}
+	if err := writeFileAtomic(filepath.Dir(path), identityAuditFile, out.Bytes()); err != nil {
+		return fmt.Errorf("rewrite audit log %s: %w", path, err)
+	}
+
+	// Appends go to the rewritten file from now on.
+	f, err := os.OpenFile(path, os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("open audit log %s: %w", path, err)
+	}
+	if _, err := f.Seek(0, io.SeekEnd); err != nil {
+		f.Close()
+		return fmt.Errorf("seek audit log %s: %w", path, err)
+	}
+	l.file.Close()
+	l.file = f
+	return l.mem.ReplaceDetails(ctx, platformUserID, details)
+}
+
+// Close releases the file.
+func (l *FileAuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.file.Close()
+}
+
+// fileAuditEntry is the on-disk representation of domain.AuditEntry.
+type fileAuditEntry struct {
+	ID             string            `json:"id"`
+	PlatformUserID string            `json:"platform_user_id"`
+	Action         string            `json:"action"`
+	Actor          string            `json:"actor,omitempty"`
+	Details        map[string]string `json:"details,omitempty"`
+	At             time.Time         `json:"at"`
+}
+
+func (l *FileAuditLog) load() error {
+	ctx := context.Background()
+	r := bufio.NewReader(l.file)
+	var offset int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("read audit log %s: %w", l.file.Name(), err)
+		}
+		if err == io.EOF {
+			// Anything after the last newline was torn by a crash.
+			if len(line) > 0 {
+				log.Printf("audit log %s: dropping %d bytes of a torn entry at the end", l.file.Name(), len(line))
+				if err := l.file.Truncate(offset); err != nil {
+					return fmt.Errorf("truncate audit log %s: %w", l.file.Name(), err)
+				}
+			}
+			break
+		}
+		var fe fileAuditEntry
+		if err := json.Unmarshal(line, &fe); err != nil {
+			return fmt.Errorf("audit log %s: corrupt entry at offset %d: %w", l.file.Name(), offset, err)
+		}
+		_ = l.mem.Append(ctx, domain.AuditEntry{
+			ID:             fe.ID,
+			PlatformUserID: fe.PlatformUserID,
+			Action:         domain.AuditAction(fe.Action),
+			Actor:          fe.Actor,
+			Details:        fe.Details,
+			At:             fe.At,
+		})
+		offset += int64(len(line))
+	}
+	if _, err := l.file.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("seek audit log %s: %w", l.file.Name(), err)
+	}
+	return nil
+}
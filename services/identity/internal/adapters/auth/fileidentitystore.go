@@ -0,0 +1,1019 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/events"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// FsyncPolicy controls when appended log records reach stable storage.
+type FsyncPolicy string
+
+const (
+	// FsyncAlways syncs every record before the write returns; an identity
+	// or linkage that was handed out is never lost.
+	FsyncAlways FsyncPolicy = "always"
+	// FsyncInterval syncs in the background; a crash of the host loses at
+	// most the changes made during the last interval.
+	FsyncInterval FsyncPolicy = "interval"
+	// FsyncNever leaves flushing to the operating system.
+	FsyncNever FsyncPolicy = "never"
+)
+
+const (
+	identityLogFile      = "identities.log"
+	identitySnapshotFile = "identities.snapshot.json"
+	identityLockFile     = "LOCK"
+
+	identityOpCreate  = "create"
+	identityOpLink    = "link"
+	identityOpUnlink  = "unlink"
+	identityOpMerge   = "merge"
+	identityOpErase   = "erase"
+	identityOpProfile = "profile"
+	identityOpStatus  = "status"
+	// Events of changes stored elsewhere, and published events.
+	identityOpEvent     = "event"
+	identityOpPublished = "published"
+)
+
+var (
+	errFileStoreClosed = errors.New("identity store closed")
+	logChecksumTable   = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// FileIdentityStoreOptions tunes durability and compaction.
+type FileIdentityStoreOptions struct {
+	// Fsync defaults to FsyncAlways.
+	Fsync FsyncPolicy
+	// FsyncInterval is the background sync period for FsyncInterval.
+	FsyncInterval time.Duration
+	// SnapshotEvery compacts the log into a snapshot once it holds that
+	// many records. Zero only compacts on Close.
+	SnapshotEvery int
+}
+
+// FileIdentityStore is an implementation of IdentityResolver,
+// IdentityLinker, IdentityMerger, IdentityEraser, IdentityProfiles,
+// IdentityStatuses, IdentityLookup, IdentityQuery and Outbox for
+// single-node setups without Postgres. Identities are kept in memory and
+// every change is appended to a checksummed log before it is applied,
+// together with its events; the log is periodically compacted into a
+// snapshot.
+//
+// On open the snapshot is loaded and the log replayed. A record torn by a
+// crash at the end of the log is dropped; corruption anywhere else fails
+// the open. The directory is locked so only one process can use it.
+type FileIdentityStore struct {
+	// mu serializes writers so the log always matches the memory state.
+	mu    sync.Mutex
+	mem   *MemoryIdentityStore
+	idGen func() string
+	dir   string
+	opts  FileIdentityStoreOptions
+
+	lock    *os.File
+	log     *os.File
+	size    int64  // bytes of valid records in the log
+	seq     uint64 // sequence number of the last record
+	records int    // records in the log since the last snapshot
+	dirty   bool   // appended but not yet synced (FsyncInterval)
+	err     error  // set once the log can no longer be trusted
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewFileIdentityStore opens the store in dir, creating it if needed, and
+// recovers its state. idGen provides platform user IDs for new identities
+// and event IDs.
+func NewFileIdentityStore(dir string, idGen func() string, opts FileIdentityStoreOptions) (*FileIdentityStore, error) {
+	if opts.Fsync == "" {
+		opts.Fsync = FsyncAlways
+	}
+	switch opts.Fsync {
+	case FsyncAlways, FsyncNever:
+	case FsyncInterval:
+		if opts.FsyncInterval <= 0 {
+			return nil, fmt.Errorf("fsync policy %q needs a positive interval", opts.Fsync)
+		}
+	default:
+		return nil, fmt.Errorf("unknown fsync policy %q", opts.Fsync)
+	}
+	if opts.SnapshotEvery < 0 {
+		return nil, fmt.Errorf("snapshot threshold must not be negative")
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create identity store %s: %w", dir, err)
+	}
+	lock, err := os.OpenFile(filepath.Join(dir, identityLockFile), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open identity store %s: %w", dir, err)
+	}
+	if err := lockFile(lock); err != nil {
+		lock.Close()
+		return nil, err
+	}
+
+	s := &FileIdentityStore{
+		mem:   newMemoryIdentityState(idGen),
+		idGen: idGen,
+		dir:   dir,
+		opts:  opts,
+		lock:  lock,
+	}
+	if err := s.recover(); err != nil {
+		lock.Close()
+		return nil, err
+	}
+
+	if opts.Fsync == FsyncInterval {
+		s.stop = make(chan struct{})
+		s.done = make(chan struct{})
+		go s.syncLoop()
+	}
+	return s, nil
+}
+
+// Resolve implements interfaces.IdentityResolver.
+// Returns an existing platform identity or creates a new one.
+func (s *FileIdentityStore) Resolve(_ context.Context, provider, externalUserID, tenant string) (domain.PlatformIdentity, error) {
+	if identity, ok := s.mem.find(provider, externalUserID); ok {
+		return identity, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Another writer may have created it while we waited for the lock.
+	if identity, ok := s.mem.find(provider, externalUserID); ok {
+		return identity, nil
+	}
+
+	now := time.Now()
+	identity := domain.PlatformIdentity{
+		PlatformUserID: s.idGen(),
+		Tenant:         tenant,
+		Linkages: []domain.Linkage{{
+			Provider:       provider,
+			ExternalUserID: externalUserID,
+			Tenant:         tenant,
+			LinkedAt:       now,
+		}},
+		CreatedAt: now,
+	}
+	msg, err := events.Encode(domain.NewIdentityCreatedEvent(s.idGen(), identity))
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	fi := toFileIdentity(identity)
+	if err := s.append(identityLogRecord{Op: identityOpCreate, Identity: &fi, Events: toFileOutbox(msg)}); err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	s.mem.put(identity)
+	s.mem.enqueue(msg)
+	s.maybeSnapshot()
+	return identity, nil
+}
+
+// Link implements interfaces.IdentityLinker.
+func (s *FileIdentityStore) Link(ctx context.Context, platformUserID string, linkage domain.Linkage) (domain.PlatformIdentity, error) {
+	if err := linkage.Validate(); err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Writers hold s.mu, so the checks below still hold when the record
+	// is applied.
+	identity, err := s.live(ctx, platformUserID)
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	if owner, ok := s.mem.find(linkage.Provider, linkage.ExternalUserID); ok {
+		if owner.PlatformUserID != platformUserID {
+			return domain.PlatformIdentity{}, domain.ErrLinkageConflict
+		}
+		return identity, nil
+	}
+
+	msg, err := events.Encode(domain.NewIdentityLinkedEvent(s.idGen(), identity, linkage))
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	fl := fileLinkage(linkage)
+	if err := s.append(identityLogRecord{Op: identityOpLink, PlatformUserID: platformUserID, Linkage: &fl, Events: toFileOutbox(msg)}); err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	identity, err = s.mem.Link(ctx, platformUserID, linkage)
+	s.mem.enqueue(msg)
+	s.maybeSnapshot()
+	return identity, err
+}
+
+// Unlink implements interfaces.IdentityLinker.
+func (s *FileIdentityStore) Unlink(ctx context.Context, platformUserID, provider, externalUserID string) (domain.PlatformIdentity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	identity, err := s.live(ctx, platformUserID)
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	linkage, ok := identity.Linkage(provider, externalUserID)
+	if !ok {
+		return domain.PlatformIdentity{}, domain.ErrLinkageNotFound
+	}
+	if len(identity.Linkages) == 1 {
+		return domain.PlatformIdentity{}, domain.ErrLastLinkage
+	}
+
+	fl := fileLinkage(linkage)
+	if err := s.append(identityLogRecord{Op: identityOpUnlink, PlatformUserID: platformUserID, Linkage: &fl}); err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	identity, err = s.mem.Unlink(ctx, platformUserID, provider, externalUserID)
+	s.maybeSnapshot()
+	return identity, err
+}
+
+// Merge implements interfaces.IdentityMerger.
+func (s *FileIdentityStore) Merge(ctx context.Context, survivorID, mergedID string, at time.Time) (domain.PlatformIdentity, error) {
+	if survivorID == mergedID {
+		return domain.PlatformIdentity{}, domain.ErrMergeSelf
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range []string{survivorID, mergedID} {
+		if _, err := s.live(ctx, id); err != nil {
+			return domain.PlatformIdentity{}, err
+		}
+	}
+
+	if err := s.append(identityLogRecord{Op: identityOpMerge, PlatformUserID: survivorID, MergedUserID: mergedID, At: at}); err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	identity, err := s.mem.Merge(ctx, survivorID, mergedID, at)
+	s.maybeSnapshot()
+	return identity, err
+}
+
+// Erase implements interfaces.IdentityEraser. The log is compacted right
+// away so that no record of the erased identity's linkages remains.
+func (s *FileIdentityStore) Erase(ctx context.Context, platformUserID string, at time.Time) (domain.Erasure, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var msg *domain.OutboxMessage
+	if !s.mem.erased(platformUserID) {
+		if _, err := s.live(ctx, platformUserID); err != nil {
+			return domain.Erasure{}, err
+		}
+		erasure := domain.Erasure{PlatformUserID: platformUserID, ErasedAt: at}
+		for id, t := range s.mem.allTombstones() {
+			if t.MergedInto == platformUserID {
+				erasure.MergedUserIDs = append(erasure.MergedUserIDs, id)
+			}
+		}
+		slices.Sort(erasure.MergedUserIDs)
+		m, err := events.Encode(domain.NewIdentityErasedEvent(s.idGen(), erasure))
+		if err != nil {
+			return domain.Erasure{}, err
+		}
+		if err := s.append(identityLogRecord{Op: identityOpErase, PlatformUserID: platformUserID, At: at, Events: toFileOutbox(m)}); err != nil {
+			return domain.Erasure{}, err
+		}
+		msg = &m
+	}
+	erasure, err := s.mem.Erase(ctx, platformUserID, at)
+	if err != nil {
+		return domain.Erasure{}, err
+	}
+	if msg != nil {
+		s.mem.enqueue(*msg)
+	}
+	// Also retried when erasing again, in case an earlier compaction failed.
+	if s.records > 0 {
+		if err := s.snapshot(); err != nil {
+			return domain.Erasure{}, fmt.Errorf("compact identity log after erasure: %w", err)
+		}
+	}
+	return erasure, nil
+}
+
+// UpdateProfile implements interfaces.IdentityProfiles. Updates that
+// change nothing are not logged.
+func (s *FileIdentityStore) UpdateProfile(ctx context.Context, platformUserID string, update domain.ProfileUpdate) (domain.PlatformIdentity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Follows tombstones: the update is logged for the survivor.
+	identity, err := s.mem.GetByPlatformUserID(ctx, platformUserID)
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	if _, changed := identity.Profile.Apply(update); !changed {
+		return identity, nil
+	}
+
+	fu := toFileProfileUpdate(update)
+	if err := s.append(identityLogRecord{Op: identityOpProfile, PlatformUserID: identity.PlatformUserID, Profile: &fu}); err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	identity, err = s.mem.UpdateProfile(ctx, identity.PlatformUserID, update)
+	s.maybeSnapshot()
+	return identity, err
+}
+
+// SetStatus implements interfaces.IdentityStatuses.
+func (s *FileIdentityStore) SetStatus(ctx context.Context, platformUserID string, status domain.IdentityStatus) (domain.PlatformIdentity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	identity, err := s.live(ctx, platformUserID)
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	identity.Status = status
+	msg, err := events.Encode(domain.NewIdentityStatusChangedEvent(s.idGen(), identity))
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	fs := fileStatus(status)
+	if err := s.append(identityLogRecord{Op: identityOpStatus, PlatformUserID: platformUserID, Status: &fs, Events: toFileOutbox(msg)}); err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	identity, err = s.mem.SetStatus(ctx, platformUserID, status)
+	s.mem.enqueue(msg)
+	s.maybeSnapshot()
+	return identity, err
+}
+
+// Add implements interfaces.Outbox.
+func (s *FileIdentityStore) Add(_ context.Context, event domain.Event) error {
+	msg, err := events.Encode(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.append(identityLogRecord{Op: identityOpEvent, Events: toFileOutbox(msg)}); err != nil {
+		return err
+	}
+	s.mem.enqueue(msg)
+	s.maybeSnapshot()
+	return nil
+}
+
+// Pending implements interfaces.Outbox.
+func (s *FileIdentityStore) Pending(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	return s.mem.Pending(ctx, limit)
+}
+
+// MarkPublished implements interfaces.Outbox.
+func (s *FileIdentityStore) MarkPublished(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.append(identityLogRecord{Op: identityOpPublished, EventIDs: ids}); err != nil {
+		return err
+	}
+	err := s.mem.MarkPublished(ctx, ids)
+	s.maybeSnapshot()
+	return err
+}
+
+// GetByPlatformUserID implements interfaces.IdentityLookup.
+func (s *FileIdentityStore) GetByPlatformUserID(ctx context.Context, platformUserID string) (domain.PlatformIdentity, error) {
+	return s.mem.GetByPlatformUserID(ctx, platformUserID)
+}
+
+// GetByLinkage implements interfaces.IdentityLookup.
+func (s *FileIdentityStore) GetByLinkage(ctx context.Context, provider, externalUserID string) (domain.PlatformIdentity, error) {
+	return s.mem.GetByLinkage(ctx, provider, externalUserID)
+}
+
+// List implements interfaces.IdentityQuery.
+func (s *FileIdentityStore) List(ctx context.Context, filter domain.IdentityFilter, after *domain.IdentityCursor, limit int) (domain.IdentityPage, error) {
+	return s.mem.List(ctx, filter, after, limit)
+}
+
+// live returns an identity that has not been merged. Lookups follow
+// tombstones, but changes must only be logged for live identities.
+func (s *FileIdentityStore) live(ctx context.Context, platformUserID string) (domain.PlatformIdentity, error) {
+	identity, err := s.mem.GetByPlatformUserID(ctx, platformUserID)
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	if identity.PlatformUserID != platformUserID {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	return identity, nil
+}
+
+// Close compacts the log, syncs and releases the store.
+func (s *FileIdentityStore) Close() error {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if errors.Is(s.err, errFileStoreClosed) {
+		return nil
+	}
+
+	var errs []error
+	if s.err == nil && s.records > 0 {
+		errs = append(errs, s.snapshot())
+	}
+	errs = append(errs, s.log.Sync(), s.log.Close(), s.lock.Close())
+	s.err = errFileStoreClosed
+	return errors.Join(errs...)
+}
+
+// identityLogRecord is one line of the log. A create record carries the
+// new identity; link and unlink records carry the platform user ID and the
+// linkage; merge records carry the survivor's platform user ID, the merged
+// one and the merge time; erase records carry the erased platform user ID
+// and the erasure time; profile records carry the platform user ID and the
+// profile update, status records the platform user ID and the new status.
+// Records of changes with events carry them, event records only events;
+// published records carry the IDs of published events.
+type identityLogRecord struct {
+	Seq            uint64              `json:"seq"`
+	Op             string              `json:"op"`
+	Identity       *fileIdentity       `json:"identity,omitempty"`
+	PlatformUserID string              `json:"platform_user_id,omitempty"`
+	Linkage        *fileLinkage        `json:"linkage,omitempty"`
+	MergedUserID   string              `json:"merged_user_id,omitempty"`
+	Profile        *fileProfileUpdate  `json:"profile,omitempty"`
+	Status         *fileStatus         `json:"status,omitempty"`
+	At             time.Time           `json:"at,omitzero"`
+	Events         []fileOutboxMessage `json:"events,omitempty"`
+	EventIDs       []string            `json:"event_ids,omitempty"`
+}
+
+type identitySnapshot struct {
+	// Seq is the last log record included in the snapshot.
+	Seq        uint64              `json:"seq"`
+	Identities []fileIdentity      `json:"identities"`
+	Tombstones []fileTombstone     `json:"tombstones,omitempty"`
+	Erasures   []fileErasure       `json:"erasures,omitempty"`
+	Outbox     []fileOutboxMessage `json:"outbox,omitempty"`
+}
+
+// fileTombstone is the on-disk representation of a merged identity.
+type fileTombstone struct {
+	PlatformUserID string    `json:"platform_user_id"`
+	MergedInto     string    `json:"merged_into"`
+	MergedAt       time.Time `json:"merged_at"`
+}
+
+// fileErasure is the on-disk representation of an erased identity.
+type fileErasure struct {
+	PlatformUserID string    `json:"platform_user_id"`
+	ErasedAt       time.Time `json:"erased_at"`
+	ErasedWith     string    `json:"erased_with,omitempty"`
+}
+
+// fileIdentity is the on-disk representation of domain.PlatformIdentity.
+type fileIdentity struct {
+	PlatformUserID string                 `json:"platform_user_id"`
+	Tenant         string                 `json:"tenant,omitempty"`
+	Linkages       []fileLinkage          `json:"linkages,omitempty"`
+	Profile        []fileProfileAttribute `json:"profile,omitempty"`
+	Status         *fileStatus            `json:"status,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+
+	// Provider and ExternalUserID hold the single linkage of identities
+	// written before linking was supported.
+	Provider       string `json:"provider,omitempty"`
+	ExternalUserID string `json:"external_user_id,omitempty"`
+}
+
+// fileLinkage is the on-disk representation of domain.Linkage.
+type fileLinkage struct {
+	Provider       string    `json:"provider"`
+	ExternalUserID string    `json:"external_user_id"`
+	Tenant         string    `json:"tenant,omitempty"`
+	LinkedAt       time.Time `json:"linked_at"`
+}
+
+// fileProfileAttribute is the on-disk representation of one attribute of
+// domain.Profile.
+type fileProfileAttribute struct {
+	Field     domain.ProfileField  `json:"field"`
+	Value     string               `json:"value"`
+	Source    domain.ProfileSource `json:"source"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// fileProfileUpdate is the on-disk representation of domain.ProfileUpdate.
+type fileProfileUpdate struct {
+	Source domain.ProfileSource           `json:"source"`
+	At     time.Time                      `json:"at"`
+	Set    map[domain.ProfileField]string `json:"set,omitempty"`
+	Clear  []domain.ProfileField          `json:"clear,omitempty"`
+}
+
+func toFileProfileUpdate(u domain.ProfileUpdate) fileProfileUpdate {
+	return fileProfileUpdate{Source: u.Source, At: u.At, Set: u.Set, Clear: u.Clear}
+}
+
+func (fu fileProfileUpdate) toDomain() domain.ProfileUpdate {
+	return domain.ProfileUpdate{Source: fu.Source, At: fu.At, Set: fu.Set, Clear: fu.Clear}
+}
+
+// fileStatus is the on-disk representation of domain.IdentityStatus.
+type fileStatus struct {
+	State     domain.IdentityState `json:"state"`
+	Reason    domain.StatusReason  `json:"reason,omitempty"`
+	Until     time.Time            `json:"until,omitzero"`
+	ChangedAt time.Time            `json:"changed_at"`
+}
+
+// toFileStatus returns nil for the zero status.
+func toFileStatus(status domain.IdentityStatus) *fileStatus {
+	if status == (domain.IdentityStatus{}) {
+		return nil
+	}
+	fs := fileStatus(status)
+	return &fs
+}
+
+func (fs *fileStatus) toDomain() domain.IdentityStatus {
+	if fs == nil {
+		return domain.IdentityStatus{}
+	}
+	return domain.IdentityStatus(*fs)
+}
+
+// fileOutboxMessage is the on-disk representation of domain.OutboxMessage.
+type fileOutboxMessage struct {
+	ID             string           `json:"id"`
+	Type           domain.EventType `json:"type"`
+	PlatformUserID string           `json:"platform_user_id,omitempty"`
+	Data           json.RawMessage  `json:"data"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
+
+func toFileOutbox(msgs ...domain.OutboxMessage) []fileOutboxMessage {
+	out := make([]fileOutboxMessage, 0, len(msgs))
+	for _, m := range msgs {
+		out = append(out, fileOutboxMessage{ID: m.ID, Type: m.Type, PlatformUserID: m.PlatformUserID, Data: m.Data, CreatedAt: m.CreatedAt})
+	}
+	return out
+}
+
+func (fm fileOutboxMessage) toDomain() domain.OutboxMessage {
+	return domain.OutboxMessage{ID: fm.ID, Type: fm.Type, PlatformUserID: fm.PlatformUserID, Data: fm.Data, CreatedAt: fm.CreatedAt}
+}
+
+func toFileIdentity(identity domain.PlatformIdentity) fileIdentity {
+	fi := fileIdentity{
+		PlatformUserID: identity.PlatformUserID,
+		Tenant:         identity.Tenant,
+		Linkages:       make([]fileLinkage, 0, len(identity.Linkages)),
+		Status:         toFileStatus(identity.Status),
+		CreatedAt:      identity.CreatedAt,
+	}
+	for _, l := range identity.Linkages {
+		fi.Linkages = append(fi.Linkages, fileLinkage(l))
+	}
+	for _, field := range domain.ProfileFields {
+		if a, ok := identity.Profile[field]; ok {
+			fi.Profile = append(fi.Profile, fileProfileAttribute{Field: field, Value: a.Value, Source: a.Source, UpdatedAt: a.UpdatedAt})
+		}
+	}
+	return fi
+}
+
+func (fi fileIdentity) toDomain() domain.PlatformIdentity {
+	identity := domain.PlatformIdentity{
+		PlatformUserID: fi.PlatformUserID,
+		Tenant:         fi.Tenant,
+		Status:         fi.Status.toDomain(),
+		CreatedAt:      fi.CreatedAt,
+	}
+	for _, a := range fi.Profile {
+		if identity.Profile == nil {
+			identity.Profile = make(domain.Profile, len(fi.Profile))
+		}
+		identity.Profile[a.Field] = domain.ProfileAttribute{Value: a.Value, Source: a.Source, UpdatedAt: a.UpdatedAt}
+	}
+	if len(fi.Linkages) == 0 && fi.Provider != "" {
+		identity.Linkages = []domain.Linkage{{
+			Provider:       fi.Provider,
+			ExternalUserID: fi.ExternalUserID,
+			Tenant:         fi.Tenant,
+			LinkedAt:       fi.CreatedAt,
+		}}
+		return identity
+	}
+	for _, l := range fi.Linkages {
+		identity.Linkages = append(identity.Linkages, domain.Linkage(l))
+	}
+	return identity
+}
+
+func (s *FileIdentityStore) recover() error {
+	snapshotSeq, err := s.loadSnapshot()
+	if err != nil {
+		return err
+	}
+	s.seq = snapshotSeq
+
+	path := filepath.Join(s.dir, identityLogFile)
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("open identity log %s: %w", path, err)
+	}
+	valid, err := s.replay(f, snapshotSeq)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("stat identity log %s: %w", path, err)
+	}
+	if info.Size() > valid {
+		log.Printf("identity store %s: dropping %d bytes of a torn record at the end of the log", s.dir, info.Size()-valid)
+		if err := f.Truncate(valid); err != nil {
+			f.Close()
+			return fmt.Errorf("truncate identity log %s: %w", path, err)
+		}
+		if err := f.Sync(); err != nil {
+			f.Close()
+			return fmt.Errorf("sync identity log %s: %w", path, err)
+		}
+	}
+
+	s.log = f
+	s.size = valid
+	return nil
+}
+
+func (s *FileIdentityStore) loadSnapshot() (uint64, error) {
+	path := filepath.Join(s.dir, identitySnapshotFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("read identity snapshot %s: %w", path, err)
+	}
+
+	var snap identitySnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return 0, fmt.Errorf("decode identity snapshot %s: %w", path, err)
+	}
+	for _, fi := range snap.Identities {
+		if err := s.apply(identityLogRecord{Op: identityOpCreate, Identity: &fi}); err != nil {
+			return 0, fmt.Errorf("identity snapshot %s: %w", path, err)
+		}
+	}
+	for _, t := range snap.Tombstones {
+		if err := s.mem.putTombstone(t.PlatformUserID, identityTombstone{MergedInto: t.MergedInto, MergedAt: t.MergedAt}); err != nil {
+			return 0, fmt.Errorf("identity snapshot %s: %w", path, err)
+		}
+	}
+	for _, e := range snap.Erasures {
+		if err := s.mem.putErasure(e.PlatformUserID, identityErasure{ErasedAt: e.ErasedAt, ErasedWith: e.ErasedWith}); err != nil {
+			return 0, fmt.Errorf("identity snapshot %s: %w", path, err)
+		}
+	}
+	for _, fm := range snap.Outbox {
+		s.mem.enqueue(fm.toDomain())
+	}
+	return snap.Seq, nil
+}
+
+// replay applies the log records after the snapshot and returns the length
+// of the valid prefix of the log.
+func (s *FileIdentityStore) replay(f *os.File, snapshotSeq uint64) (int64, error) {
+	r := bufio.NewReader(f)
+	var offset int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			// Empty, or a final record without its newline: torn by a crash.
+			return offset, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("read identity log %s: %w", f.Name(), err)
+		}
+
+		rec, decodeErr := decodeLogRecord(line)
+		if decodeErr != nil {
+			// Only the last record can be torn; a bad record followed by
+			// complete ones means the log is corrupt.
+			rest, err := io.ReadAll(r)
+			if err != nil {
+				return 0, fmt.Errorf("read identity log %s: %w", f.Name(), err)
+			}
+			if bytes.IndexByte(rest, '\n') >= 0 {
+				return 0, fmt.Errorf("identity log %s: corrupt record at offset %d: %w", f.Name(), offset, decodeErr)
+			}
+			return offset, nil
+		}
+
+		switch {
+		case rec.Seq <= snapshotSeq:
+			// Already in the snapshot; the log was not reset after it.
+		case rec.Seq <= s.seq:
+			return 0, fmt.Errorf("identity log %s: record %d at offset %d is out of order", f.Name(), rec.Seq, offset)
+		default:
+			if err := s.apply(rec); err != nil {
+				return 0, fmt.Errorf("identity log %s: record %d: %w", f.Name(), rec.Seq, err)
+			}
+			s.seq = rec.Seq
+			s.records++
+		}
+		offset += int64(len(line))
+	}
+}
+
+// apply applies a record to the memory state and adds its events to the
+// outbox.
+func (s *FileIdentityStore) apply(rec identityLogRecord) error {
+	if err := s.applyOp(rec); err != nil {
+		return err
+	}
+	for _, fm := range rec.Events {
+		s.mem.enqueue(fm.toDomain())
+	}
+	return nil
+}
+
+func (s *FileIdentityStore) applyOp(rec identityLogRecord) error {
+	ctx := context.Background()
+	switch rec.Op {
+	case identityOpCreate:
+		if rec.Identity == nil {
+			return errors.New("create record without identity")
+		}
+		identity := rec.Identity.toDomain()
+		if identity.PlatformUserID == "" || len(identity.Linkages) == 0 {
+			return fmt.Errorf("incomplete identity %q", identity.PlatformUserID)
+		}
+		for _, l := range identity.Linkages {
+			if err := l.Validate(); err != nil {
+				return fmt.Errorf("identity %s: %w", identity.PlatformUserID, err)
+			}
+			if existing, ok := s.mem.find(l.Provider, l.ExternalUserID); ok && existing.PlatformUserID != identity.PlatformUserID {
+				return fmt.Errorf("identity %s conflicts with %s for the same external user", identity.PlatformUserID, existing.PlatformUserID)
+			}
+		}
+		s.mem.put(identity)
+		return nil
+	case identityOpLink:
+		if rec.Linkage == nil {
+			return errors.New("link record without linkage")
+		}
+		_, err := s.mem.Link(ctx, rec.PlatformUserID, domain.Linkage(*rec.Linkage))
+		return err
+	case identityOpUnlink:
+		if rec.Linkage == nil {
+			return errors.New("unlink record without linkage")
+		}
+		_, err := s.mem.Unlink(ctx, rec.PlatformUserID, rec.Linkage.Provider, rec.Linkage.ExternalUserID)
+		return err
+	case identityOpMerge:
+		_, err := s.mem.Merge(ctx, rec.PlatformUserID, rec.MergedUserID, rec.At)
+		return err
+	case identityOpErase:
+		_, err := s.mem.Erase(ctx, rec.PlatformUserID, rec.At)
+		return err
+	case identityOpProfile:
+		if rec.Profile == nil {
+			return errors.New("profile record without update")
+		}
+		_, err := s.mem.UpdateProfile(ctx, rec.PlatformUserID, rec.Profile.toDomain())
+		return err
+	case identityOpStatus:
+		if rec.Status == nil {
+			return errors.New("status record without status")
+		}
+		_, err := s.mem.SetStatus(ctx, rec.PlatformUserID, rec.Status.toDomain())
+		return err
+	case identityOpEvent:
+		if len(rec.Events) == 0 {
+			return errors.New("event record without events")
+		}
+		return nil
+	case identityOpPublished:
+		return s.mem.MarkPublished(ctx, rec.EventIDs)
+	default:
+		return fmt.Errorf("unknown operation %q", rec.Op)
+	}
+}
+
+// append writes a record to the log, assigning its sequence number.
+// Callers hold s.mu.
+func (s *FileIdentityStore) append(rec identityLogRecord) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	rec.Seq = s.seq + 1
+	line, err := encodeLogRecord(rec)
+	if err != nil {
+		return err
+	}
+	if _, err := s.log.WriteAt(line, s.size); err != nil {
+		// Cut off a partial write so the next record does not follow it.
+		if truncErr := s.log.Truncate(s.size); truncErr != nil {
+			s.err = fmt.Errorf("identity log unusable after failed write: %w", err)
+		}
+		return fmt.Errorf("append identity log: %w", err)
+	}
+	if s.opts.Fsync == FsyncAlways {
+		if err := s.log.Sync(); err != nil {
+			// After a failed fsync the kernel may have dropped the data;
+			// refuse further writes rather than pretend they are durable.
+			s.err = fmt.Errorf("identity log unusable after failed sync: %w", err)
+			return s.err
+		}
+	} else {
+		s.dirty = true
+	}
+
+	s.size += int64(len(line))
+	s.seq++
+	s.records++
+	return nil
+}
+
+// maybeSnapshot compacts the log once it reaches the threshold. A failed
+// snapshot is not fatal: the log still holds every record. Callers hold
+// s.mu.
+func (s *FileIdentityStore) maybeSnapshot() {
+	if s.opts.SnapshotEvery > 0 && s.records >= s.opts.SnapshotEvery {
+		if err := s.snapshot(); err != nil {
+			log.Printf("identity store %s: snapshot failed, keeping the log: %v", s.dir, err)
+		}
+	}
+}
+
+// snapshot writes the full state and starts a new log. Callers hold s.mu.
+func (s *FileIdentityStore) snapshot() error {
+	identities := s.mem.all()
+	sort.Slice(identities, func(i, j int) bool {
+		return identities[i].PlatformUserID < identities[j].PlatformUserID
+	})
+	snap := identitySnapshot{Seq: s.seq, Identities: make([]fileIdentity, 0, len(identities))}
+	for _, identity := range identities {
+		snap.Identities = append(snap.Identities, toFileIdentity(identity))
+	}
+	tombstones := s.mem.allTombstones()
+	for _, id := range slices.Sorted(maps.Keys(tombstones)) {
+		t := tombstones[id]
+		snap.Tombstones = append(snap.Tombstones, fileTombstone{PlatformUserID: id, MergedInto: t.MergedInto, MergedAt: t.MergedAt})
+	}
+	erasures := s.mem.allErasures()
+	for _, id := range slices.Sorted(maps.Keys(erasures)) {
+		e := erasures[id]
+		snap.Erasures = append(snap.Erasures, fileErasure{PlatformUserID: id, ErasedAt: e.ErasedAt, ErasedWith: e.ErasedWith})
+	}
+	if pending := s.mem.allPending(); len(pending) > 0 {
+		snap.Outbox = toFileOutbox(pending...)
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("encode identity snapshot: %w", err)
+	}
+	if err := writeFileAtomic(s.dir, identitySnapshotFile, data); err != nil {
+		return fmt.Errorf("write identity snapshot: %w", err)
+	}
+
+	// The snapshot covers every record; records left behind by a failed
+	// truncate are skipped on replay by their sequence number.
+	if err := s.log.Truncate(0); err != nil {
+		return fmt.Errorf("reset identity log: %w", err)
+	}
+	if err := s.log.Sync(); err != nil {
+		return fmt.Errorf("reset identity log: %w", err)
+	}
+	s.size = 0
+	s.records = 0
+	s.dirty = false
+	return nil
+}
+
+func (s *FileIdentityStore) syncLoop() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.opts.FsyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			if s.dirty && s.err == nil {
+				if err := s.log.Sync(); err != nil {
+					s.err = fmt.Errorf("identity log unusable after failed sync: %w", err)
+					log.Printf("identity store %s: %v", s.dir, s.err)
+				}
+				s.dirty = false
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// encodeLogRecord frames a record as "<crc32c hex> <json>\n".
+func encodeLogRecord(rec identityLogRecord) ([]byte, error) {
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return nil, fmt.Errorf("encode identity log record: %w", err)
+	}
+	line := make([]byte, 0, len(payload)+10)
+	line = fmt.Appendf(line, "%08x ", crc32.Checksum(payload, logChecksumTable))
+	line = append(line, payload...)
+	return append(line, '\n'), nil
+}
+
+func decodeLogRecord(line []byte) (identityLogRecord, error) {
+	var rec identityLogRecord
+	sum, payload, ok := bytes.Cut(bytes.TrimSuffix(line, []byte("\n")), []byte(" "))
+	if !ok || len(sum) != 8 {
+		return rec, errors.New("malformed record")
+	}
+	var want uint32
+	if _, err := fmt.Sscanf(string(sum), "%08x", &want); err != nil {
+		return rec, errors.New("malformed checksum")
+	}
+	if crc32.Checksum(payload, logChecksumTable) != want {
+		return rec, errors.New("checksum mismatch")
+	}
+	if err := json.Unmarshal(payload, &rec); err != nil {
+		return rec, err
+	}
+	return rec, nil
+}
+
+// writeFileAtomic replaces dir/name so that a crash leaves either the old
+// or the new content, never a torn file.
+func writeFileAtomic(dir, name string, data []byte) error {
+	tmp, err := os.CreateTemp(dir, "."+name+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, name)); err != nil {
+		return err
+	}
+	// Persist the rename itself.
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
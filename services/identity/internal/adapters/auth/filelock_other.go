@@ -0,0 +1,11 @@
+//go:build !unix
+
+package auth
+
+import "os"
+
+// lockFile is a no-op where flock is unavailable; a single process must own
+// the store.
+func lockFile(*os.File) error {
+	return nil
+}
@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// FileProviderStore is a ProviderStore persisted as a JSON file. The whole
+// registry is kept in memory and rewritten after every change, which suits
+// the handful of providers a deployment has. Like the key ring file, it is
+// meant to be owned by a single replica.
+type FileProviderStore struct {
+	// mu serializes writers so the file always matches the memory state.
+	mu   sync.Mutex
+	mem  *MemoryProviderStore
+	path string
+}
+
+// NewFileProviderStore opens the provider registry at path. A missing file
+// is an empty registry and is created on the first change.
+func NewFileProviderStore(path string) (*FileProviderStore, error) {
+	s := &FileProviderStore{mem: NewMemoryProviderStore(), path: path}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Create implements interfaces.ProviderStore.
+func (s *FileProviderStore) Create(ctx context.Context, provider domain.Provider) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.mem.Create(ctx, provider); err != nil {
+		return err
+	}
+	if err := s.save(ctx); err != nil {
+		_ = s.mem.Delete(ctx, provider.ID)
+		return err
+	}
+	return nil
+}
+
+// Get implements interfaces.ProviderStore.
+func (s *FileProviderStore) Get(ctx context.Context, id string) (domain.Provider, error) {
+	return s.mem.Get(ctx, id)
+}
+
+// List implements interfaces.ProviderStore.
+func (s *FileProviderStore) List(ctx context.Context) ([]domain.Provider, error) {
+	return s.mem.List(ctx)
+}
+
+// Update implements interfaces.ProviderStore.
+func (s *FileProviderStore) Update(ctx context.Context, provider domain.Provider) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, err := s.mem.Get(ctx, provider.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.mem.Update(ctx, provider); err != nil {
+		return err
+	}
+	if err := s.save(ctx); err != nil {
+		_ = s.mem.Update(ctx, previous)
+		return err
+	}
+	return nil
+}
+
+// Delete implements interfaces.ProviderStore.
+func (s *FileProviderStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, err := s.mem.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.mem.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := s.save(ctx); err != nil {
+		_ = s.mem.Create(ctx, previous)
+		return err
+	}
+	return nil
+}
+
+// providerFile is the on-disk representation of the registry.
+type providerFile struct {
+	Providers []providerFileEntry `json:"providers"`
+}
+
+type providerFileEntry struct {
+	ID             string                `json:"id"`
+	DisplayName    string                `json:"display_name"`
+	Status         domain.ProviderStatus `json:"status"`
+	AllowedTenants []string              `json:"allowed_tenants,omitempty"`
+	AssertionKeys  []publicJWK           `json:"assertion_keys,omitempty"`
+	AccessTTL      string                `json:"access_token_ttl,omitempty"`
+	RefreshTTL     string                `json:"refresh_token_ttl,omitempty"`
+	ProfileClaims  []domain.ProfileField `json:"profile_claims,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// publicJWK is the JSON form of domain.PublicJWK.
+type publicJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+func (s *FileProviderStore) load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("read provider registry %s: %w", s.path, err)
+	}
+
+	var f providerFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("decode provider registry %s: %w", s.path, err)
+	}
+
+	for _, fe := range f.Providers {
+		p := domain.Provider{
+			ID:             fe.ID,
+			DisplayName:    fe.DisplayName,
+			Status:         fe.Status,
+			AllowedTenants: fe.AllowedTenants,
+			AssertionKeys:  make([]domain.PublicJWK, 0, len(fe.AssertionKeys)),
+			CreatedAt:      fe.CreatedAt,
+			UpdatedAt:      fe.UpdatedAt,
+		}
+		for _, k := range fe.AssertionKeys {
+			p.AssertionKeys = append(p.AssertionKeys, domain.PublicJWK(k))
+		}
+		if p.Tokens.AccessTTL, err = parseOptionalDuration(fe.AccessTTL); err != nil {
+			return fmt.Errorf("provider registry %s: provider %s: access_token_ttl: %w", s.path, fe.ID, err)
+		}
+		if p.Tokens.RefreshTTL, err = parseOptionalDuration(fe.RefreshTTL); err != nil {
+			return fmt.Errorf("provider registry %s: provider %s: refresh_token_ttl: %w", s.path, fe.ID, err)
+		}
+		p.Tokens.ProfileClaims = fe.ProfileClaims
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("provider registry %s: provider %s: %w", s.path, fe.ID, err)
+		}
+		if err := s.mem.Create(context.Background(), p); err != nil {
+			return fmt.Errorf("provider registry %s: provider %s: %w", s.path, fe.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *FileProviderStore) save(ctx context.Context) error {
+	providers, err := s.mem.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	f := providerFile{Providers: make([]providerFileEntry, 0, len(providers))}
+	for _, p := range providers {
+		fe := providerFileEntry{
+			ID:             p.ID,
+			DisplayName:    p.DisplayName,
+			Status:         p.Status,
+			AllowedTenants: p.AllowedTenants,
+			AssertionKeys:  make([]publicJWK, 0, len(p.AssertionKeys)),
+			CreatedAt:      p.CreatedAt,
+			UpdatedAt:      p.UpdatedAt,
+		}
+		for _, k := range p.AssertionKeys {
+			fe.AssertionKeys = append(fe.AssertionKeys, publicJWK(k))
+		}
+		if p.Tokens.AccessTTL > 0 {
+			fe.AccessTTL = p.Tokens.AccessTTL.String()
+		}
+		if p.Tokens.RefreshTTL > 0 {
+			fe.RefreshTTL = p.Tokens.RefreshTTL.String()
+		}
+		fe.ProfileClaims = p.Tokens.ProfileClaims
+		f.Providers = append(f.Providers, fe)
+	}
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode provider registry: %w", err)
+	}
+
+	// Write to a temp file and rename so a crash never leaves a torn file.
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".providers-*")
+	if err != nil {
+		return fmt.Errorf("write provider registry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write provider registry: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write provider registry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write provider registry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("write provider registry: %w", err)
+	}
+	return nil
+}
+
+func parseOptionalDuration(v string) (time.Duration, error) {
+	if v == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(v)
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// recordLogCompactMin is the number of records a record log may hold beyond
+// twice the live state before it is compacted.
+const recordLogCompactMin = 1024
+
+// recordLog is a file of JSON lines, one record per change, that is synced
+// after every append and compacted by rewriting it with the live state. It
+// backs the file session and refresh token stores and the file replay
+// cache, which keep their state in memory like the file audit log.
+type recordLog struct {
+	dir     string
+	name    string
+	file    *os.File
+	records int // records in the file
+}
+
+// openRecordLog opens dir/name, creating it if needed, and passes every
+// record to apply in write order. A line torn by a crash at the end of the
+// file is dropped.
+func openRecordLog(dir, name string, apply func(line []byte) error) (*recordLog, error) {
+	path := filepath.Join(dir, name)
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	l := &recordLog{dir: dir, name: name, file: f}
+	if err := l.load(apply); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *recordLog) load(apply func(line []byte) error) error {
+	r := bufio.NewReader(l.file)
+	var offset int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("read %s: %w", l.file.Name(), err)
+		}
+		if err == io.EOF {
+			// Anything after the last newline was torn by a crash.
+			if len(line) > 0 {
+				log.Printf("%s: dropping %d bytes of a torn record at the end", l.file.Name(), len(line))
+				if err := l.file.Truncate(offset); err != nil {
+					return fmt.Errorf("truncate %s: %w", l.file.Name(), err)
+				}
+			}
+			break
+		}
+		if err := apply(line); err != nil {
+			return fmt.Errorf("%s: corrupt record at offset %d: %w", l.file.Name(), offset, err)
+		}
+		offset += int64(len(line))
+		l.records++
+	}
+	if _, err := l.file.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("seek %s: %w", l.file.Name(), err)
+	}
+	return nil
+}
+
+// append writes records and syncs the file.
+func (l *recordLog) append(records ...any) error {
+	data, err := encodeRecords(l.name, records)
+	if err != nil {
+		return err
+	}
+	if _, err := l.file.Write(data); err != nil {
+		return fmt.Errorf("append %s: %w", l.name, err)
+	}
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("sync %s: %w", l.name, err)
+	}
+	l.records += len(records)
+	return nil
+}
+
+// needsCompaction reports whether the file holds many more records than
+// the live state it describes.
+func (l *recordLog) needsCompaction(live int) bool {
+	return l.records > 2*live+recordLogCompactMin
+}
+
+// rewrite atomically replaces the file with records, the live state.
+func (l *recordLog) rewrite(records []any) error {
+	data, err := encodeRecords(l.name, records)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(l.dir, l.name, data); err != nil {
+		return fmt.Errorf("compact %s: %w", l.name, err)
+	}
+
+	path := filepath.Join(l.dir, l.name)
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	l.file.Close()
+	l.file = f
+	l.records = len(records)
+	return nil
+}
+
+func encodeRecords(name string, records []any) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, rec := range records {
+		line, err := json.Marshal(rec)
+		if err != nil {
+			return nil, fmt.Errorf("encode %s record: %w", name, err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func (l *recordLog) close() error {
+	return l.file.Close()
+}
@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// refreshTokenLogFile is the refresh token log in a file identity store
+// directory.
+const refreshTokenLogFile = "refresh_tokens.log"
+
+// FileRefreshTokenStore is a RefreshTokenStore for IDENTITY_STORE=file.
+// Tokens are kept in a MemoryRefreshTokenStore and every change is appended
+// to a log of JSON lines and synced before it is applied. On open, and once
+// the log has grown well beyond the live tokens, expired tokens and revoked
+// families are dropped and the log is rewritten. Like the file identity
+// store it belongs to a single process.
+type FileRefreshTokenStore struct {
+	// mu serializes writers so the log always matches the memory state.
+	mu  sync.Mutex
+	mem *MemoryRefreshTokenStore
+	log *recordLog
+	now func() time.Time
+}
+
+// NewFileRefreshTokenStore opens the refresh token log in dir, the
+// directory of a file identity store, creating it if needed.
+func NewFileRefreshTokenStore(dir string) (*FileRefreshTokenStore, error) {
+	s := &FileRefreshTokenStore{mem: NewMemoryRefreshTokenStore(), now: time.Now}
+	l, err := openRecordLog(dir, refreshTokenLogFile, s.apply)
+	if err != nil {
+		return nil, err
+	}
+	s.log = l
+	if err := s.compactLocked(); err != nil {
+		l.close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Save implements interfaces.RefreshTokenStore.
+func (s *FileRefreshTokenStore) Save(ctx context.Context, token domain.RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.log.append(fileRefreshRecord{Token: toFileRefreshToken(token)}); err != nil {
+		return err
+	}
+	if err := s.mem.Save(ctx, token); err != nil {
+		return err
+	}
+	s.maybeCompactLocked()
+	return nil
+}
+
+// Consume implements interfaces.RefreshTokenStore.
+func (s *FileRefreshTokenStore) Consume(ctx context.Context, tokenHash string, at time.Time) (domain.RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.mem.get(tokenHash)
+	if !ok {
+		return domain.RefreshToken{}, domain.ErrInvalidRefreshToken
+	}
+	if !token.UsedAt.IsZero() {
+		return token, domain.ErrRefreshTokenReused
+	}
+	used := token
+	used.UsedAt = at
+	if err := s.log.append(fileRefreshRecord{Token: toFileRefreshToken(used)}); err != nil {
+		return domain.RefreshToken{}, err
+	}
+	return s.mem.Consume(ctx, tokenHash, at)
+}
+
+// RevokeFamily implements interfaces.RefreshTokenStore.
+func (s *FileRefreshTokenStore) RevokeFamily(ctx context.Context, sessionID string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.log.append(fileRefreshRecord{RevokedFamily: sessionID, At: at}); err != nil {
+		return err
+	}
+	return s.mem.RevokeFamily(ctx, sessionID, at)
+}
+
+// Close releases the log.
+func (s *FileRefreshTokenStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.log.close()
+}
+
+// fileRefreshRecord is a line of the refresh token log: either the new
+// state of a token or the revocation of a family.
+type fileRefreshRecord struct {
+	Token         *fileRefreshToken `json:"token,omitempty"`
+	RevokedFamily string            `json:"revoked_family,omitempty"`
+	At            time.Time         `json:"at,omitzero"`
+}
+
+// fileRefreshToken is the on-disk representation of domain.RefreshToken.
+type fileRefreshToken struct {
+	TokenHash      string    `json:"token_hash"`
+	SessionID      string    `json:"session_id"`
+	PlatformUserID string    `json:"platform_user_id"`
+	Tenant         string    `json:"tenant,omitempty"`
+	IssuedAt       time.Time `json:"issued_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	UsedAt         time.Time `json:"used_at,omitzero"`
+	RevokedAt      time.Time `json:"revoked_at,omitzero"`
+}
+
+func toFileRefreshToken(t domain.RefreshToken) *fileRefreshToken {
+	return &fileRefreshToken{
+		TokenHash:      t.TokenHash,
+		SessionID:      t.SessionID,
+		PlatformUserID: t.PlatformUserID,
+		Tenant:         t.Tenant,
+		IssuedAt:       t.IssuedAt,
+		ExpiresAt:      t.ExpiresAt,
+		UsedAt:         t.UsedAt,
+		RevokedAt:      t.RevokedAt,
+	}
+}
+
+func (ft fileRefreshToken) toDomain() domain.RefreshToken {
+	return domain.RefreshToken{
+		TokenHash:      ft.TokenHash,
+		SessionID:      ft.SessionID,
+		PlatformUserID: ft.PlatformUserID,
+		Tenant:         ft.Tenant,
+		IssuedAt:       ft.IssuedAt,
+		ExpiresAt:      ft.ExpiresAt,
+		UsedAt:         ft.UsedAt,
+		RevokedAt:      ft.RevokedAt,
+	}
+}
+
+// apply replays a log line into memory.
+func (s *FileRefreshTokenStore) apply(line []byte) error {
+	var rec fileRefreshRecord
+	if err := json.Unmarshal(line, &rec); err != nil {
+		return err
+	}
+	if rec.Token != nil {
+		s.mem.put(rec.Token.toDomain())
+	}
+	if rec.RevokedFamily != "" {
+		return s.mem.RevokeFamily(context.Background(), rec.RevokedFamily, rec.At)
+	}
+	return nil
+}
+
+// maybeCompactLocked compacts the log once it holds many more records than
+// live tokens. A failed compaction is not fatal: the log still holds every
+// record.
+func (s *FileRefreshTokenStore) maybeCompactLocked() {
+	if !s.log.needsCompaction(s.mem.size()) {
+		return
+	}
+	if err := s.compactLocked(); err != nil {
+		log.Printf("refresh token store: compaction failed, keeping the log: %v", err)
+	}
+}
+
+// compactLocked drops tokens that can no longer be exchanged and rewrites
+// the log with the rest.
+func (s *FileRefreshTokenStore) compactLocked() error {
+	tokens := s.mem.sweep(s.now())
+	records := make([]any, 0, len(tokens))
+	for _, token := range tokens {
+		records = append(records, fileRefreshRecord{Token: toFileRefreshToken(token)})
+	}
+	return s.log.rewrite(records)
+}
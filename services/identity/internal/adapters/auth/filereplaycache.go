@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// replayLogFile is the log of used assertion IDs in a file identity store
+// directory.
+const replayLogFile = "assertion_replays.log"
+
+// FileReplayCache is an AssertionReplayCache for IDENTITY_STORE=file, so a
+// restart does not let used assertions be exchanged again. Used jtis are
+// kept in a MemoryReplayCache and appended to a log of JSON lines, synced
+// before the exchange goes on. On open, and once the log has grown well
+// beyond the live entries, expired jtis are dropped and the log is
+// rewritten. Like the file identity store it belongs to a single process.
+type FileReplayCache struct {
+	// mu serializes writers so the log always matches the memory state.
+	mu  sync.Mutex
+	mem *MemoryReplayCache
+	log *recordLog
+	now func() time.Time
+}
+
+// NewFileReplayCache opens the replay log in dir, the directory of a file
+// identity store, creating it if needed.
+func NewFileReplayCache(dir string) (*FileReplayCache, error) {
+	c := &FileReplayCache{mem: NewMemoryReplayCache(), now: time.Now}
+	l, err := openRecordLog(dir, replayLogFile, c.apply)
+	if err != nil {
+		return nil, err
+	}
+	c.log = l
+	if err := c.compactLocked(); err != nil {
+		l.close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// MarkUsed implements interfaces.AssertionReplayCache.
+func (c *FileReplayCache) MarkUsed(ctx context.Context, provider, jti string, expiresAt time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.mem.contains(provider, jti) {
+		return domain.ErrAssertionReplayed
+	}
+	if err := c.log.append(fileReplayRecord{Provider: provider, JTI: jti, ExpiresAt: expiresAt}); err != nil {
+		return err
+	}
+	if err := c.mem.MarkUsed(ctx, provider, jti, expiresAt); err != nil {
+		return err
+	}
+	c.maybeCompactLocked()
+	return nil
+}
+
+// Close releases the log.
+func (c *FileReplayCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.log.close()
+}
+
+// fileReplayRecord is a line of the replay log: a used jti.
+type fileReplayRecord struct {
+	Provider  string    `json:"provider"`
+	JTI       string    `json:"jti"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// apply replays a log line into memory.
+func (c *FileReplayCache) apply(line []byte) error {
+	var rec fileReplayRecord
+	if err := json.Unmarshal(line, &rec); err != nil {
+		return err
+	}
+	c.mem.put(rec.Provider, rec.JTI, rec.ExpiresAt)
+	return nil
+}
+
+// maybeCompactLocked compacts the log once it holds many more records than
+// live entries. A failed compaction is not fatal: the log still holds every
+// record.
+func (c *FileReplayCache) maybeCompactLocked() {
+	if !c.log.needsCompaction(c.mem.size()) {
+		return
+	}
+	if err := c.compactLocked(); err != nil {
+		log.Printf("replay cache: compaction failed, keeping the log: %v", err)
+	}
+}
+
+// compactLocked drops expired jtis and rewrites the log with the rest.
+func (c *FileReplayCache) compactLocked() error {
+	var records []any
+	c.mem.sweep(c.now(), func(provider, jti string, expiresAt time.Time) {
+		records = append(records, fileReplayRecord{Provider: provider, JTI: jti, ExpiresAt: expiresAt})
+	})
+	return c.log.rewrite(records)
+}
@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// sessionLogFile is the session log in a file identity store directory.
+const sessionLogFile = "sessions.log"
+
+// FileSessionStore is a SessionStore for IDENTITY_STORE=file. Sessions are
+// kept in a MemorySessionStore and the new state of every changed session
+// is appended to a log of JSON lines and synced before it is applied, so
+// revocations survive a restart. On open, and once the log has grown well
+// beyond the live sessions, sessions expired for longer than the retention
+// window are dropped and the log is rewritten. Like the file identity
+// store it belongs to a single process.
+type FileSessionStore struct {
+	// mu serializes writers so the log always matches the memory state.
+	mu  sync.Mutex
+	mem *MemorySessionStore
+	log *recordLog
+	now func() time.Time
+}
+
+// NewFileSessionStore opens the session log in dir, the directory of a
+// file identity store, creating it if needed. Expired sessions are kept
+// for retention, at least the longest access token lifetime.
+func NewFileSessionStore(dir string, retention time.Duration) (*FileSessionStore, error) {
+	s := &FileSessionStore{mem: NewMemorySessionStore(retention), now: time.Now}
+	l, err := openRecordLog(dir, sessionLogFile, s.apply)
+	if err != nil {
+		return nil, err
+	}
+	s.log = l
+	if err := s.compactLocked(); err != nil {
+		l.close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Create implements interfaces.SessionStore.
+func (s *FileSessionStore) Create(ctx context.Context, session domain.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.log.append(toFileSession(session)); err != nil {
+		return err
+	}
+	if err := s.mem.Create(ctx, session); err != nil {
+		return err
+	}
+	s.maybeCompactLocked()
+	return nil
+}
+
+// Get implements interfaces.SessionStore.
+func (s *FileSessionStore) Get(ctx context.Context, sessionID string) (domain.Session, error) {
+	return s.mem.Get(ctx, sessionID)
+}
+
+// ListByUser implements interfaces.SessionStore.
+func (s *FileSessionStore) ListByUser(ctx context.Context, platformUserID string) ([]domain.Session, error) {
+	return s.mem.ListByUser(ctx, platformUserID)
+}
+
+// Extend implements interfaces.SessionStore.
+func (s *FileSessionStore) Extend(ctx context.Context, sessionID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, err := s.mem.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	session.ExpiresAt = expiresAt
+	if err := s.log.append(toFileSession(session)); err != nil {
+		return err
+	}
+	return s.mem.Extend(ctx, sessionID, expiresAt)
+}
+
+// Revoke implements interfaces.SessionStore.
+func (s *FileSessionStore) Revoke(ctx context.Context, sessionID, reason string, at time.Time) (domain.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, err := s.mem.Get(ctx, sessionID)
+	if err != nil {
+		return domain.Session{}, err
+	}
+	if !session.RevokedAt.IsZero() {
+		return session, nil
+	}
+	session.RevokedAt = at
+	session.RevokeReason = reason
+	if err := s.log.append(toFileSession(session)); err != nil {
+		return domain.Session{}, err
+	}
+	return s.mem.Revoke(ctx, sessionID, reason, at)
+}
+
+// RevokeAllForUser implements interfaces.SessionStore.
+func (s *FileSessionStore) RevokeAllForUser(ctx context.Context, platformUserID, reason string, at time.Time) ([]domain.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions, err := s.mem.ListByUser(ctx, platformUserID)
+	if err != nil {
+		return nil, err
+	}
+	var records []any
+	for _, session := range sessions {
+		if !session.RevokedAt.IsZero() {
+			continue
+		}
+		session.RevokedAt = at
+		session.RevokeReason = reason
+		records = append(records, toFileSession(session))
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	if err := s.log.append(records...); err != nil {
+		return nil, err
+	}
+	return s.mem.RevokeAllForUser(ctx, platformUserID, reason, at)
+}
+
+// RevokedSince implements interfaces.SessionStore.
+func (s *FileSessionStore) RevokedSince(ctx context.Context, since time.Time) ([]domain.Session, error) {
+	return s.mem.RevokedSince(ctx, since)
+}
+
+// Close releases the log.
+func (s *FileSessionStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.log.close()
+}
+
+// fileSession is the on-disk representation of domain.Session and a line
+// of the session log.
+type fileSession struct {
+	ID             string    `json:"id"`
+	PlatformUserID string    `json:"platform_user_id"`
+	SubjectType    string    `json:"subject_type"`
+	Tenant         string    `json:"tenant,omitempty"`
+	Provider       string    `json:"provider,omitempty"`
+	Scopes         []string  `json:"scopes,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	RevokedAt      time.Time `json:"revoked_at,omitzero"`
+	RevokeReason   string    `json:"revoke_reason,omitempty"`
+}
+
+func toFileSession(session domain.Session) fileSession {
+	return fileSession{
+		ID:             session.ID,
+		PlatformUserID: session.PlatformUserID,
+		SubjectType:    session.SubjectType,
+		Tenant:         session.Tenant,
+		Provider:       session.Provider,
+		Scopes:         session.Scopes,
+		CreatedAt:      session.CreatedAt,
+		ExpiresAt:      session.ExpiresAt,
+		RevokedAt:      session.RevokedAt,
+		RevokeReason:   session.RevokeReason,
+	}
+}
+
+func (fs fileSession) toDomain() domain.Session {
+	return domain.Session{
+		ID:             fs.ID,
+		PlatformUserID: fs.PlatformUserID,
+		SubjectType:    fs.SubjectType,
+		Tenant:         fs.Tenant,
+		Provider:       fs.Provider,
+		Scopes:         fs.Scopes,
+		CreatedAt:      fs.CreatedAt,
+		ExpiresAt:      fs.ExpiresAt,
+		RevokedAt:      fs.RevokedAt,
+		RevokeReason:   fs.RevokeReason,
+	}
+}
+
+// apply replays a log line into memory.
+func (s *FileSessionStore) apply(line []byte) error {
+	var fs fileSession
+	if err := json.Unmarshal(line, &fs); err != nil {
+		return err
+	}
+	s.mem.put(fs.toDomain())
+	return nil
+}
+
+// maybeCompactLocked compacts the log once it holds many more records than
+// live sessions. A failed compaction is not fatal: the log still holds
+// every record.
+func (s *FileSessionStore) maybeCompactLocked() {
+	if !s.log.needsCompaction(s.mem.size()) {
+		return
+	}
+	if err := s.compactLocked(); err != nil {
+		log.Printf("session store: compaction failed, keeping the log: %v", err)
+	}
+}
+
+// compactLocked drops long expired sessions and rewrites the log with the
+// rest.
+func (s *FileSessionStore) compactLocked() error {
+	sessions := s.mem.sweep(s.now())
+	records := make([]any, 0, len(sessions))
+	for _, session := range sessions {
+		records = append(records, toFileSession(session))
+	}
+	return s.log.rewrite(records)
+}
@@ -2,27 +2,67 @@ package auth
 
 import (
 	"context"
-	"crypto/ed25519"
-	"crypto/rand"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
 )
 
-// JWTIssuer issues Ed25519-signed JWTs.
+// SigningAlgorithms selects the JWS algorithm of an access token by its
+// audience, for consumers that can only verify RS256 or ES256.
+type SigningAlgorithms struct {
+	// Default signs tokens of audiences without an entry; empty is EdDSA.
+	Default string
+	// Audiences maps audience -> algorithm.
+	Audiences map[string]string
+}
+
+// For returns the algorithm tokens for audience are signed with.
+func (a SigningAlgorithms) For(audience string) string {
+	if alg, ok := a.Audiences[audience]; ok {
+		return alg
+	}
+	if a.Default == "" {
+		return jwtverifier.AlgEdDSA
+	}
+	return a.Default
+}
+
+// All returns every configured algorithm, sorted.
+func (a SigningAlgorithms) All() []string {
+	algs := []string{a.For("")}
+	for _, alg := range a.Audiences {
+		algs = append(algs, alg)
+	}
+	slices.Sort(algs)
+	return slices.Compact(algs)
+}
+
+// JWTIssuer issues JWTs signed with the active key of a key ring for the
+// algorithm of their audience.
 type JWTIssuer struct {
-	kid        string
-	publicKey  ed25519.PublicKey
-	privateKey ed25519.PrivateKey
-	issuer     string
-	audience   string
+	keys     *KeyRing
+	algs     SigningAlgorithms
+	issuer   string
+	audience string
 }
 
-// NewJWTIssuer creates an Ed25519 JWT issuer.
-// For production, load keys from env/Secrets Manager/KMS.
-func NewJWTIssuer(issuer, audience string) (*JWTIssuer, error) {
-	pub, priv, err := ed25519.GenerateKey(rand.Reader)
-	if err != nil {
+// NewJWTIssuer creates a JWT issuer that signs with the active keys of the
+// given key ring. The ring must hold an active key for the default
+// algorithm of algs and a key for every other one.
+func NewJWTIssuer(issuer, audience string, algs SigningAlgorithms, keys *KeyRing) (*JWTIssuer, error) {
+	for _, alg := range algs.All() {
+		if !jwtverifier.Supported(alg) {
+			return nil, fmt.Errorf("unsupported signing algorithm %q", alg)
+		}
+		if !keys.HasAlgorithm(alg) {
+			return nil, fmt.Errorf("%s: %w", alg, domain.ErrNoActiveSigningKey)
+		}
+	}
+	if _, err := keys.ActiveKey(algs.For("")); err != nil {
 		return nil, err
 	}
 	if issuer == "" {
@@ -32,59 +72,76 @@ func NewJWTIssuer(issuer, audience string) (*JWTIssuer, error) {
 		audience = "proteon-api"
 	}
 	return &JWTIssuer{
-		kid:        "dev-identity-001",
-		publicKey:  pub,
-		privateKey: priv,
-		issuer:     issuer,
-		audience:   audience,
+		keys:     keys,
+		algs:     algs,
+		issuer:   issuer,
+		audience: audience,
 	}, nil
 }
 
-// Issue implements interfaces.TokenIssuer.
-func (j *JWTIssuer) Issue(_ context.Context, platformUserID, tenant string, ttl time.Duration) (string, error) {
+// Issue implements interfaces.TokenIssuer. Tokens without an explicit
+// audience get the issuer's default audience; subject_type, sid, scope,
+// act and profile claims are only set when present.
+func (j *JWTIssuer) Issue(_ context.Context, c domain.AccessTokenClaims) (string, error) {
 	now := time.Now()
+	audience := c.Audience
+	if audience == "" {
+		audience = j.audience
+	}
 	claims := jwt.MapClaims{
 		"iss":    j.issuer,
-		"aud":    j.audience,
-		"sub":    platformUserID,
+		"aud":    audience,
+		"sub":    c.Subject,
 		"iat":    now.Unix(),
 		"nbf":    now.Unix(),
-		"exp":    now.Add(ttl).Unix(),
-		"tenant": tenant,
+		"exp":    now.Add(c.TTL).Unix(),
+		"tenant": c.Tenant,
+	}
+	if c.SubjectType != "" {
+		claims["subject_type"] = c.SubjectType
+	}
+	if c.SessionID != "" {
+		claims["sid"] = c.SessionID
+	}
+	if len(c.Scopes) > 0 {
+		claims["scope"] = domain.FormatScope(c.Scopes)
+	}
+	if c.Actor != nil {
+		claims["act"] = actClaim(c.Actor)
+	}
+	for name, value := range c.Profile {
+		claims[name] = value
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
-	token.Header["kid"] = j.kid
-	return token.SignedString(j.privateKey)
+	return j.sign(j.algorithm(audience), claims)
 }
 
-// IssueBackoffice implements interfaces.TokenIssuer for backoffice tokens.
-// It allows specifying a dedicated audience and adds a subject_type claim.
-func (j *JWTIssuer) IssueBackoffice(_ context.Context, userID, subjectType, tenant, audience string, ttl time.Duration) (string, error) {
-	now := time.Now()
-	if audience == "" {
-		audience = "backoffice"
+// algorithm returns the algorithm tokens for audience are signed with. An
+// algorithm whose first key is still waiting out the publish lead has no
+// active key yet; its audiences get tokens of the default algorithm until
+// then.
+func (j *JWTIssuer) algorithm(audience string) string {
+	alg := j.algs.For(audience)
+	if _, err := j.keys.ActiveKey(alg); err != nil {
+		return j.algs.For("")
 	}
-	claims := jwt.MapClaims{
-		"iss":          j.issuer,
-		"aud":          audience,
-		"sub":          userID,
-		"subject_type": subjectType,
-		"iat":          now.Unix(),
-		"nbf":          now.Unix(),
-		"exp":          now.Add(ttl).Unix(),
-		"tenant":       tenant,
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
-	token.Header["kid"] = j.kid
-	return token.SignedString(j.privateKey)
+	return alg
 }
 
-// PublicKey implements interfaces.TokenIssuer.
-func (j *JWTIssuer) PublicKey() ed25519.PublicKey {
-	return j.publicKey
+// actClaim returns the nested act claim of an actor chain.
+func actClaim(a *domain.Actor) map[string]any {
+	act := map[string]any{"sub": a.Subject}
+	if a.Actor != nil {
+		act["act"] = actClaim(a.Actor)
+	}
+	return act
 }
 
-// Kid implements interfaces.TokenIssuer.
-func (j *JWTIssuer) Kid() string {
-	return j.kid
+func (j *JWTIssuer) sign(alg string, claims jwt.MapClaims) (string, error) {
+	key, err := j.keys.ActiveKey(alg)
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(alg), claims)
+	token.Header["kid"] = key.Kid
+	return token.SignedString(key.PrivateKey)
 }
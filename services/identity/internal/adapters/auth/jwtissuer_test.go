@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+func TestJWTIssuerAlgorithm(t *testing.T) {
+	tests := []struct {
+		name     string
+		audience string
+		activate bool
+		wantAlg  string
+	}{
+		{name: "default audience", audience: "proteon-api", wantAlg: jwtverifier.AlgEdDSA},
+		{name: "audience of a pending algorithm", audience: "legacy", wantAlg: jwtverifier.AlgEdDSA},
+		{name: "audience of an activated algorithm", audience: "legacy", activate: true, wantAlg: jwtverifier.AlgRS256},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ring := seededRing(t, "", jwtverifier.AlgEdDSA)
+			if err := ring.AddAlgorithm(jwtverifier.AlgRS256, time.Now()); err != nil {
+				t.Fatalf("AddAlgorithm: %v", err)
+			}
+			algs := SigningAlgorithms{Default: jwtverifier.AlgEdDSA, Audiences: map[string]string{"legacy": jwtverifier.AlgRS256}}
+			issuer, err := NewJWTIssuer("issuer", "proteon-api", algs, ring)
+			if err != nil {
+				t.Fatalf("NewJWTIssuer: %v", err)
+			}
+			if tt.activate {
+				if _, err := ring.ActivatePending(context.Background(), time.Now(), 0); err != nil {
+					t.Fatalf("ActivatePending: %v", err)
+				}
+			}
+
+			raw, err := issuer.Issue(context.Background(), domain.AccessTokenClaims{
+				Subject:  "user-1",
+				Audience: tt.audience,
+				TTL:      time.Minute,
+			})
+			if err != nil {
+				t.Fatalf("Issue: %v", err)
+			}
+			tok, _, err := jwt.NewParser().ParseUnverified(raw, jwt.MapClaims{})
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			kid, _ := tok.Header["kid"].(string)
+			key, ok := ring.Key(kid)
+			if tok.Method.Alg() != tt.wantAlg || !ok || key.Algorithm != tt.wantAlg {
+				t.Errorf("token signed with %s by kid %s (%s), want %s", tok.Method.Alg(), kid, key.Algorithm, tt.wantAlg)
+			}
+		})
+	}
+}
@@ -0,0 +1,622 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+type keyRingEntry struct {
+	key  SigningKey
+	info domain.SigningKeyInfo
+}
+
+// KeyRing is a signing key ring implementing interfaces.SigningKeyRing. For
+// every algorithm it signs with (EdDSA, RS256, ES256), the ring holds
+// exactly one active and one next key; an algorithm added to a running
+// ring has only a next key until it is activated. When a store is
+// configured, the ring (including private keys of non-retired keys) is
+// saved there after every change and is the source of truth on the next
+// start.
+type KeyRing struct {
+	mu      sync.RWMutex
+	entries []keyRingEntry
+	// store persists the ring, nil keeps it in memory only. version is the
+	// stored version entries were loaded from or saved as.
+	store   KeyRingStore
+	version int64
+}
+
+// KeyRingStore persists an encoded key ring. Every save names the version
+// it was built on, so replicas sharing a store do not overwrite each
+// other's changes.
+type KeyRingStore interface {
+	// LoadKeyRing returns the stored ring and its version, or no data if
+	// nothing was stored yet.
+	LoadKeyRing(ctx context.Context) ([]byte, int64, error)
+	// SaveKeyRing stores data and returns its version. If the stored
+	// version is no longer version, it returns domain.ErrKeyRingChanged.
+	SaveKeyRing(ctx context.Context, data []byte, version int64) (int64, error)
+	// String names the store in messages.
+	String() string
+}
+
+// NewKeyRing creates a key ring. If path is non-empty and the file exists,
+// the ring is loaded from it; otherwise the ring starts empty and must be
+// seeded.
+func NewKeyRing(path string) (*KeyRing, error) {
+	if path == "" {
+		return &KeyRing{}, nil
+	}
+	return NewStoredKeyRing(context.Background(), &fileKeyRingStore{path: path})
+}
+
+// NewStoredKeyRing creates a key ring saved in store and loads the ring
+// stored there, if any.
+func NewStoredKeyRing(ctx context.Context, store KeyRingStore) (*KeyRing, error) {
+	r := &KeyRing{store: store}
+	if err := r.load(ctx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload implements interfaces.SigningKeyRing. It picks up the changes
+// other replicas sharing the store made to the ring.
+func (r *KeyRing) Reload(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.reloadLocked(ctx)
+}
+
+// Import copies the ring file at path into an empty ring, for moving a
+// file ring to a shared store. It reports whether there was a file to
+// import.
+func (r *KeyRing) Import(ctx context.Context, path string) (bool, error) {
+	data, _, err := (&fileKeyRingStore{path: path}).LoadKeyRing(ctx)
+	if err != nil || data == nil {
+		return false, err
+	}
+	entries, err := decodeKeyRing(data)
+	if err != nil {
+		return false, fmt.Errorf("key ring %s: %w", path, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.entries) != 0 {
+		return false, errors.New("key ring already seeded")
+	}
+	if err := r.swapLocked(ctx, entries); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Empty reports whether the ring holds no keys yet.
+func (r *KeyRing) Empty() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.entries) == 0
+}
+
+// Seed adds key as the active key of an empty ring and generates a next key
+// for its algorithm.
+func (r *KeyRing) Seed(key SigningKey, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.entries) != 0 {
+		return errors.New("key ring already seeded")
+	}
+	entries := addActiveKey(nil, key, now)
+	entries, err := addNextKey(entries, key.Algorithm, now)
+	if err != nil {
+		return err
+	}
+	return r.swapLocked(context.Background(), entries)
+}
+
+// AddAlgorithm generates a next key for an algorithm the ring has no key
+// for. Like every next key it is published before it signs anything:
+// ActivatePending makes it active once verifiers have had the publish lead
+// to fetch it.
+func (r *KeyRing) AddAlgorithm(alg string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if findKey(r.entries, alg, domain.SigningKeyActive) >= 0 || findKey(r.entries, alg, domain.SigningKeyNext) >= 0 {
+		return fmt.Errorf("key ring already has a %s key", alg)
+	}
+	entries, err := addNextKey(slices.Clone(r.entries), alg, now)
+	if err != nil {
+		return err
+	}
+	return r.swapLocked(context.Background(), entries)
+}
+
+// HasAlgorithm reports whether the ring holds an active or next key for
+// alg.
+func (r *KeyRing) HasAlgorithm(alg string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return findKey(r.entries, alg, domain.SigningKeyActive) >= 0 || findKey(r.entries, alg, domain.SigningKeyNext) >= 0
+}
+
+// ActiveKey returns the key that signs new tokens with alg.
+func (r *KeyRing) ActiveKey(alg string) (SigningKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if i := findKey(r.entries, alg, domain.SigningKeyActive); i >= 0 {
+		return r.entries[i].key, nil
+	}
+	return SigningKey{}, fmt.Errorf("%s: %w", alg, domain.ErrNoActiveSigningKey)
+}
+
+// Keys implements interfaces.SigningKeyRing.
+func (r *KeyRing) Keys(_ context.Context) ([]domain.SigningKeyInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]domain.SigningKeyInfo, 0, len(r.entries))
+	for _, e := range r.entries {
+		out = append(out, e.info)
+	}
+	return out, nil
+}
+
+// PublishedKeys implements interfaces.SigningKeyRing.
+func (r *KeyRing) PublishedKeys(_ context.Context) ([]domain.VerificationKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]domain.VerificationKey, 0, 3)
+	for _, e := range r.entries {
+		if !e.info.State.Published() {
+			continue
+		}
+		out = append(out, domain.VerificationKey{
+			Kid:       e.info.Kid,
+			Algorithm: e.info.Algorithm,
+			PublicKey: e.key.PublicKey(),
+		})
+	}
+	return out, nil
+}
+
+// Key returns the public key of a published key together with its
+// algorithm. Implements jwtverifier.KeySource.
+func (r *KeyRing) Key(kid string) (jwtverifier.Key, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, e := range r.entries {
+		if e.info.Kid == kid && e.info.State.Published() {
+			return jwtverifier.Key{Algorithm: e.info.Algorithm, PublicKey: e.key.PublicKey()}, true
+		}
+	}
+	return jwtverifier.Key{}, false
+}
+
+// Rotate implements interfaces.SigningKeyRing. The next keys of all
+// algorithms are promoted together, and only once each of them has been
+// published for minNextAge. The rotated ring is built on a copy, so a
+// failure leaves the ring as it was.
+func (r *KeyRing) Rotate(ctx context.Context, now time.Time, minNextAge time.Duration) ([]domain.SigningKeyInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	algs := ringAlgorithms(r.entries)
+	next := make([]int, 0, len(algs))
+	for _, alg := range algs {
+		i := findKey(r.entries, alg, domain.SigningKeyNext)
+		if i < 0 {
+			return nil, fmt.Errorf("key ring has no next %s key", alg)
+		}
+		if now.Sub(r.entries[i].info.CreatedAt) < minNextAge {
+			return nil, domain.ErrRotationTooSoon
+		}
+		next = append(next, i)
+	}
+
+	entries := slices.Clone(r.entries)
+	promoted := make([]domain.SigningKeyInfo, 0, len(next))
+	for _, i := range next {
+		alg := entries[i].info.Algorithm
+		if active := findKey(entries, alg, domain.SigningKeyActive); active >= 0 {
+			entries[active].info.State = domain.SigningKeyRetiring
+			entries[active].info.RetiringAt = now
+		}
+		entries[i].info.State = domain.SigningKeyActive
+		entries[i].info.ActivatedAt = now
+		promoted = append(promoted, entries[i].info)
+
+		var err error
+		if entries, err = addNextKey(entries, alg, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.swapLocked(ctx, entries); err != nil {
+		return nil, err
+	}
+	return promoted, nil
+}
+
+// ActivatePending implements interfaces.SigningKeyRing. Algorithms added
+// with AddAlgorithm have a next key but no active one; each such key that
+// has been published for minNextAge becomes active and gets a next key of
+// its own.
+func (r *KeyRing) ActivatePending(ctx context.Context, now time.Time, minNextAge time.Duration) ([]domain.SigningKeyInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := slices.Clone(r.entries)
+	var activated []domain.SigningKeyInfo
+	for i := range entries {
+		e := &entries[i]
+		if e.info.State != domain.SigningKeyNext || findKey(entries, e.info.Algorithm, domain.SigningKeyActive) >= 0 {
+			continue
+		}
+		if now.Sub(e.info.CreatedAt) < minNextAge {
+			continue
+		}
+		e.info.State = domain.SigningKeyActive
+		e.info.ActivatedAt = now
+		activated = append(activated, e.info)
+	}
+	if len(activated) == 0 {
+		return nil, nil
+	}
+	for _, info := range activated {
+		var err error
+		if entries, err = addNextKey(entries, info.Algorithm, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.swapLocked(ctx, entries); err != nil {
+		return nil, err
+	}
+	return activated, nil
+}
+
+// RetireExpired implements interfaces.SigningKeyRing.
+// Private key material of retired keys is discarded, and keys retired for
+// retireAfter are removed so the ring does not grow with every rotation.
+func (r *KeyRing) RetireExpired(ctx context.Context, now time.Time, retireAfter time.Duration) ([]domain.SigningKeyInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := slices.DeleteFunc(slices.Clone(r.entries), func(e keyRingEntry) bool {
+		return e.info.State == domain.SigningKeyRetired && now.Sub(e.info.RetiredAt) >= retireAfter
+	})
+	removed := len(entries) < len(r.entries)
+	var retired []domain.SigningKeyInfo
+	for i := range entries {
+		e := &entries[i]
+		if e.info.State != domain.SigningKeyRetiring || now.Sub(e.info.RetiringAt) < retireAfter {
+			continue
+		}
+		e.info.State = domain.SigningKeyRetired
+		e.info.RetiredAt = now
+		e.key.PrivateKey = nil
+		retired = append(retired, e.info)
+	}
+	if len(retired) == 0 && !removed {
+		return nil, nil
+	}
+	if err := r.swapLocked(ctx, entries); err != nil {
+		return nil, err
+	}
+	return retired, nil
+}
+
+// swapLocked persists entries and only then makes them the ring's keys. If
+// another replica changed the stored ring first, the ring is reloaded and
+// domain.ErrKeyRingChanged returned.
+func (r *KeyRing) swapLocked(ctx context.Context, entries []keyRingEntry) error {
+	if r.store == nil {
+		r.entries = entries
+		return nil
+	}
+	data, err := encodeKeyRing(entries)
+	if err != nil {
+		return err
+	}
+	version, err := r.store.SaveKeyRing(ctx, data, r.version)
+	if errors.Is(err, domain.ErrKeyRingChanged) {
+		if reloadErr := r.reloadLocked(ctx); reloadErr != nil {
+			return errors.Join(err, reloadErr)
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	r.entries, r.version = entries, version
+	return nil
+}
+
+func findKey(entries []keyRingEntry, alg string, state domain.SigningKeyState) int {
+	for i, e := range entries {
+		if e.info.Algorithm == alg && e.info.State == state {
+			return i
+		}
+	}
+	return -1
+}
+
+// ringAlgorithms returns the algorithms entries hold an active key for.
+func ringAlgorithms(entries []keyRingEntry) []string {
+	var algs []string
+	for _, e := range entries {
+		if e.info.State == domain.SigningKeyActive && !slices.Contains(algs, e.info.Algorithm) {
+			algs = append(algs, e.info.Algorithm)
+		}
+	}
+	return algs
+}
+
+func addActiveKey(entries []keyRingEntry, key SigningKey, now time.Time) []keyRingEntry {
+	return append(entries, keyRingEntry{
+		key: key,
+		info: domain.SigningKeyInfo{
+			Kid:         key.Kid,
+			Algorithm:   key.Algorithm,
+			State:       domain.SigningKeyActive,
+			CreatedAt:   now,
+			ActivatedAt: now,
+		},
+	})
+}
+
+func addNextKey(entries []keyRingEntry, alg string, now time.Time) ([]keyRingEntry, error) {
+	key, err := GenerateSigningKey(alg)
+	if err != nil {
+		return nil, err
+	}
+	return append(entries, keyRingEntry{
+		key: key,
+		info: domain.SigningKeyInfo{
+			Kid:       key.Kid,
+			Algorithm: alg,
+			State:     domain.SigningKeyNext,
+			CreatedAt: now,
+		},
+	}), nil
+}
+
+// keyRingFile is the stored representation of the ring.
+type keyRingFile struct {
+	Keys []keyRingFileEntry `json:"keys"`
+}
+
+type keyRingFileEntry struct {
+	Kid         string                 `json:"kid"`
+	Algorithm   string                 `json:"alg"`
+	State       domain.SigningKeyState `json:"state"`
+	CreatedAt   time.Time              `json:"created_at"`
+	ActivatedAt time.Time              `json:"activated_at,omitzero"`
+	RetiringAt  time.Time              `json:"retiring_at,omitzero"`
+	RetiredAt   time.Time              `json:"retired_at,omitzero"`
+	// Seed is the base64url encoded seed of EdDSA keys and PrivateKey the
+	// base64url encoded PKCS#8 DER of RS256 and ES256 keys; both are empty
+	// for retired keys.
+	Seed       string `json:"seed,omitempty"`
+	PrivateKey string `json:"private_key,omitempty"`
+}
+
+// load loads the stored ring and generates the next keys it lacks.
+func (r *KeyRing) load(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.reloadLocked(ctx); err != nil {
+		return err
+	}
+	entries := slices.Clone(r.entries)
+	var added bool
+	for _, alg := range ringAlgorithms(entries) {
+		if findKey(entries, alg, domain.SigningKeyNext) >= 0 {
+			continue
+		}
+		var err error
+		if entries, err = addNextKey(entries, alg, time.Now()); err != nil {
+			return err
+		}
+		added = true
+	}
+	if !added {
+		return nil
+	}
+	// A replica starting at the same time may have added them first; the
+	// reloaded ring then has its keys.
+	if err := r.swapLocked(ctx, entries); err != nil && !errors.Is(err, domain.ErrKeyRingChanged) {
+		return err
+	}
+	return nil
+}
+
+// reloadLocked replaces the ring with the stored one unless that is the
+// version the ring already holds.
+func (r *KeyRing) reloadLocked(ctx context.Context) error {
+	if r.store == nil {
+		return nil
+	}
+	data, version, err := r.store.LoadKeyRing(ctx)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		r.entries, r.version = nil, version
+		return nil
+	}
+	if r.entries != nil && version == r.version {
+		return nil
+	}
+	entries, err := decodeKeyRing(data)
+	if err != nil {
+		return fmt.Errorf("key ring %s: %w", r.store, err)
+	}
+	r.entries, r.version = entries, version
+	return nil
+}
+
+// decodeKeyRing decodes a stored ring.
+func decodeKeyRing(data []byte) ([]keyRingEntry, error) {
+	var f keyRingFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	var entries []keyRingEntry
+	for _, fe := range f.Keys {
+		e := keyRingEntry{info: domain.SigningKeyInfo{
+			Kid:         fe.Kid,
+			Algorithm:   fe.Algorithm,
+			State:       fe.State,
+			CreatedAt:   fe.CreatedAt,
+			ActivatedAt: fe.ActivatedAt,
+			RetiringAt:  fe.RetiringAt,
+			RetiredAt:   fe.RetiredAt,
+		}}
+		// Rings written before RS256 and ES256 support carry no alg.
+		if e.info.Algorithm == "" {
+			e.info.Algorithm = jwtverifier.AlgEdDSA
+		}
+		if fe.State != domain.SigningKeyRetired {
+			key, err := decodeRingKey(fe)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key material for kid %s", fe.Kid)
+			}
+			if key.Kid != fe.Kid {
+				return nil, fmt.Errorf("kid %s does not match key thumbprint", fe.Kid)
+			}
+			if key.Algorithm != e.info.Algorithm {
+				return nil, fmt.Errorf("kid %s is a %s key, not %s", fe.Kid, key.Algorithm, e.info.Algorithm)
+			}
+			e.key = key
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) > 0 && len(ringAlgorithms(entries)) == 0 {
+		return nil, domain.ErrNoActiveSigningKey
+	}
+	return entries, nil
+}
+
+// decodeRingKey decodes the private key of a ring file entry.
+func decodeRingKey(fe keyRingFileEntry) (SigningKey, error) {
+	if fe.Seed != "" {
+		seed, err := base64.RawURLEncoding.DecodeString(fe.Seed)
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return SigningKey{}, ErrUnsupportedKey
+		}
+		return NewSigningKey(ed25519.NewKeyFromSeed(seed))
+	}
+	der, err := base64.RawURLEncoding.DecodeString(fe.PrivateKey)
+	if err != nil || len(der) == 0 {
+		return SigningKey{}, ErrUnsupportedKey
+	}
+	return parsePKCS8SigningKey(der)
+}
+
+// encodeKeyRing encodes entries in the ring file format.
+func encodeKeyRing(entries []keyRingEntry) ([]byte, error) {
+	f := keyRingFile{Keys: make([]keyRingFileEntry, 0, len(entries))}
+	for _, e := range entries {
+		fe := keyRingFileEntry{
+			Kid:         e.info.Kid,
+			Algorithm:   e.info.Algorithm,
+			State:       e.info.State,
+			CreatedAt:   e.info.CreatedAt,
+			ActivatedAt: e.info.ActivatedAt,
+			RetiringAt:  e.info.RetiringAt,
+			RetiredAt:   e.info.RetiredAt,
+		}
+		switch priv := e.key.PrivateKey.(type) {
+		case nil:
+		case ed25519.PrivateKey:
+			fe.Seed = base64.RawURLEncoding.EncodeToString(priv.Seed())
+		default:
+			der, err := x509.MarshalPKCS8PrivateKey(priv)
+			if err != nil {
+				return nil, fmt.Errorf("encode key ring: kid %s: %w", e.info.Kid, err)
+			}
+			fe.PrivateKey = base64.RawURLEncoding.EncodeToString(der)
+		}
+		f.Keys = append(f.Keys, fe)
+	}
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode key ring: %w", err)
+	}
+	return data, nil
+}
+
+// fileKeyRingStore keeps the ring in a JSON file. A file belongs to a
+// single replica, so it has no versions.
+type fileKeyRingStore struct {
+	path string
+}
+
+// LoadKeyRing implements KeyRingStore.
+func (s *fileKeyRingStore) LoadKeyRing(context.Context) ([]byte, int64, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, nil
+		}
+		return nil, 0, fmt.Errorf("read key ring %s: %w", s.path, err)
+	}
+	return data, 0, nil
+}
+
+// SaveKeyRing implements KeyRingStore.
+func (s *fileKeyRingStore) SaveKeyRing(_ context.Context, data []byte, _ int64) (int64, error) {
+	// Write to a temp file and rename so a crash never leaves a torn ring.
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".keyring-*")
+	if err != nil {
+		return 0, fmt.Errorf("write key ring: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return 0, fmt.Errorf("write key ring: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return 0, fmt.Errorf("write key ring: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return 0, fmt.Errorf("write key ring: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, fmt.Errorf("write key ring: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return 0, fmt.Errorf("write key ring: %w", err)
+	}
+	return 0, nil
+}
+
+func (s *fileKeyRingStore) String() string {
+	return s.path
+}
@@ -0,0 +1,387 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+var ringStart = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func seededRing(t *testing.T, path string, algs ...string) *KeyRing {
+	t.Helper()
+	ring, err := NewKeyRing(path)
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+	key, err := GenerateSigningKey(algs[0])
+	if err != nil {
+		t.Fatalf("GenerateSigningKey: %v", err)
+	}
+	if err := ring.Seed(key, ringStart); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	for _, alg := range algs[1:] {
+		if err := ring.AddAlgorithm(alg, ringStart); err != nil {
+			t.Fatalf("AddAlgorithm %s: %v", alg, err)
+		}
+	}
+	if _, err := ring.ActivatePending(context.Background(), ringStart, 0); err != nil {
+		t.Fatalf("ActivatePending: %v", err)
+	}
+	return ring
+}
+
+// ringStates maps the kids of the ring to their states.
+func ringStates(t *testing.T, ring *KeyRing) map[string]domain.SigningKeyState {
+	t.Helper()
+	keys, err := ring.Keys(context.Background())
+	if err != nil {
+		t.Fatalf("Keys: %v", err)
+	}
+	states := make(map[string]domain.SigningKeyState, len(keys))
+	for _, k := range keys {
+		states[k.Kid] = k.State
+	}
+	return states
+}
+
+func kidIn(t *testing.T, ring *KeyRing, alg string, state domain.SigningKeyState) string {
+	t.Helper()
+	keys, _ := ring.Keys(context.Background())
+	for _, k := range keys {
+		if k.Algorithm == alg && k.State == state {
+			return k.Kid
+		}
+	}
+	t.Fatalf("no %s key in state %s", alg, state)
+	return ""
+}
+
+func TestKeyRingRotate(t *testing.T) {
+	tests := []struct {
+		name       string
+		algs       []string
+		at         time.Time
+		minNextAge time.Duration
+		wantErr    error
+	}{
+		{
+			name:       "single algorithm",
+			algs:       []string{jwtverifier.AlgEdDSA},
+			at:         ringStart.Add(time.Hour),
+			minNextAge: time.Hour,
+		},
+		{
+			name:       "all algorithms together",
+			algs:       []string{jwtverifier.AlgEdDSA, jwtverifier.AlgES256},
+			at:         ringStart.Add(2 * time.Hour),
+			minNextAge: time.Hour,
+		},
+		{
+			name:       "next key published too briefly",
+			algs:       []string{jwtverifier.AlgEdDSA},
+			at:         ringStart.Add(time.Minute),
+			minNextAge: time.Hour,
+			wantErr:    domain.ErrRotationTooSoon,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ring := seededRing(t, "", tt.algs...)
+			before := ringStates(t, ring)
+			oldActive := make(map[string]string)
+			oldNext := make(map[string]string)
+			for _, alg := range tt.algs {
+				oldActive[alg] = kidIn(t, ring, alg, domain.SigningKeyActive)
+				oldNext[alg] = kidIn(t, ring, alg, domain.SigningKeyNext)
+			}
+
+			promoted, err := ring.Rotate(context.Background(), tt.at, tt.minNextAge)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Rotate error = %v, want %v", err, tt.wantErr)
+				}
+				if got := ringStates(t, ring); len(got) != len(before) {
+					t.Fatalf("ring changed on a refused rotation: %v, was %v", got, before)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Rotate: %v", err)
+			}
+			if len(promoted) != len(tt.algs) {
+				t.Fatalf("promoted %d keys, want %d", len(promoted), len(tt.algs))
+			}
+
+			states := ringStates(t, ring)
+			for _, alg := range tt.algs {
+				if got := states[oldActive[alg]]; got != domain.SigningKeyRetiring {
+					t.Errorf("%s old active key is %s, want retiring", alg, got)
+				}
+				if got := kidIn(t, ring, alg, domain.SigningKeyActive); got != oldNext[alg] {
+					t.Errorf("%s active kid = %s, want the old next key %s", alg, got, oldNext[alg])
+				}
+				if kidIn(t, ring, alg, domain.SigningKeyNext) == oldNext[alg] {
+					t.Errorf("%s has no fresh next key", alg)
+				}
+				if _, ok := ring.Key(oldActive[alg]); !ok {
+					t.Errorf("%s retiring key is no longer published", alg)
+				}
+			}
+		})
+	}
+}
+
+func TestKeyRingAddAlgorithm(t *testing.T) {
+	tests := []struct {
+		name string
+		// rotate rotates the ring that long after the algorithm was added.
+		rotate     time.Duration
+		activate   time.Duration
+		wantActive bool
+	}{
+		{name: "within the publish lead", activate: 30 * time.Minute},
+		{name: "after the publish lead", activate: time.Hour, wantActive: true},
+		{name: "rotation leaves it pending", rotate: 2 * time.Hour, activate: 30 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ring := seededRing(t, "", jwtverifier.AlgEdDSA)
+			addedAt := ringStart.Add(time.Hour)
+			if err := ring.AddAlgorithm(jwtverifier.AlgRS256, addedAt); err != nil {
+				t.Fatalf("AddAlgorithm: %v", err)
+			}
+			if err := ring.AddAlgorithm(jwtverifier.AlgRS256, addedAt); err == nil {
+				t.Error("AddAlgorithm added a second RS256 key")
+			}
+			next := kidIn(t, ring, jwtverifier.AlgRS256, domain.SigningKeyNext)
+			if _, ok := ring.Key(next); !ok {
+				t.Fatal("added key is not published")
+			}
+			if _, err := ring.ActiveKey(jwtverifier.AlgRS256); !errors.Is(err, domain.ErrNoActiveSigningKey) {
+				t.Fatalf("ActiveKey of the added algorithm = %v, want %v", err, domain.ErrNoActiveSigningKey)
+			}
+
+			if tt.rotate > 0 {
+				if _, err := ring.Rotate(ctx, addedAt.Add(tt.rotate), time.Hour); err != nil {
+					t.Fatalf("Rotate: %v", err)
+				}
+				if got := ringStates(t, ring)[next]; got != domain.SigningKeyNext {
+					t.Fatalf("rotation moved the pending key to %s", got)
+				}
+			}
+
+			activated, err := ring.ActivatePending(ctx, addedAt.Add(tt.activate), time.Hour)
+			if err != nil {
+				t.Fatalf("ActivatePending: %v", err)
+			}
+			if got := len(activated) == 1; got != tt.wantActive {
+				t.Fatalf("activated %v, want active=%v", activated, tt.wantActive)
+			}
+			key, err := ring.ActiveKey(jwtverifier.AlgRS256)
+			if !tt.wantActive {
+				if err == nil {
+					t.Errorf("RS256 key %s is active within the publish lead", key.Kid)
+				}
+				return
+			}
+			if err != nil || key.Kid != next {
+				t.Fatalf("active RS256 key = %v, %v; want %s", key.Kid, err, next)
+			}
+			if kidIn(t, ring, jwtverifier.AlgRS256, domain.SigningKeyNext) == next {
+				t.Error("activated algorithm has no fresh next key")
+			}
+		})
+	}
+}
+
+func TestKeyRingRetireExpired(t *testing.T) {
+	tests := []struct {
+		name        string
+		at          time.Duration
+		wantRetired bool
+		// removeAt is when a second call, after the retirement, runs.
+		removeAt    time.Duration
+		wantRemoved bool
+	}{
+		{name: "within the retirement window", at: 30 * time.Minute},
+		{name: "after the retirement window", at: 2 * time.Hour, wantRetired: true},
+		{name: "retired within the window", at: 2 * time.Hour, wantRetired: true, removeAt: 2*time.Hour + 30*time.Minute},
+		{name: "retired for the window", at: 2 * time.Hour, wantRetired: true, removeAt: 3 * time.Hour, wantRemoved: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ring := seededRing(t, "", jwtverifier.AlgEdDSA)
+			retiring := kidIn(t, ring, jwtverifier.AlgEdDSA, domain.SigningKeyActive)
+			rotatedAt := ringStart.Add(time.Hour)
+			if _, err := ring.Rotate(context.Background(), rotatedAt, 0); err != nil {
+				t.Fatalf("Rotate: %v", err)
+			}
+
+			retired, err := ring.RetireExpired(context.Background(), rotatedAt.Add(tt.at), time.Hour)
+			if err != nil {
+				t.Fatalf("RetireExpired: %v", err)
+			}
+			if got := len(retired) == 1; got != tt.wantRetired {
+				t.Fatalf("retired %v, want retired=%v", retired, tt.wantRetired)
+			}
+			_, published := ring.Key(retiring)
+			if published == tt.wantRetired {
+				t.Errorf("retiring key published = %v, want %v", published, !tt.wantRetired)
+			}
+
+			if tt.removeAt == 0 {
+				return
+			}
+			if _, err := ring.RetireExpired(context.Background(), rotatedAt.Add(tt.removeAt), time.Hour); err != nil {
+				t.Fatalf("RetireExpired: %v", err)
+			}
+			_, kept := ringStates(t, ring)[retiring]
+			if kept == tt.wantRemoved {
+				t.Errorf("retired key kept = %v, want %v", kept, !tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestKeyRingRotateSaveFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "keys", "keyring.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	ring := seededRing(t, path, jwtverifier.AlgEdDSA)
+	before := ringStates(t, ring)
+	active := kidIn(t, ring, jwtverifier.AlgEdDSA, domain.SigningKeyActive)
+
+	// Without its directory the ring can no longer be written.
+	if err := os.RemoveAll(filepath.Dir(path)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ring.Rotate(context.Background(), ringStart.Add(time.Hour), 0); err == nil {
+		t.Fatal("Rotate succeeded without a writable ring file")
+	}
+
+	after := ringStates(t, ring)
+	if len(after) != len(before) {
+		t.Fatalf("ring has %d keys after a failed rotation, want %d", len(after), len(before))
+	}
+	for kid, state := range before {
+		if after[kid] != state {
+			t.Errorf("kid %s is %s after a failed rotation, want %s", kid, after[kid], state)
+		}
+	}
+	if key, err := ring.ActiveKey(jwtverifier.AlgEdDSA); err != nil || key.Kid != active {
+		t.Errorf("active key = %v, %v; want %s", key.Kid, err, active)
+	}
+}
+
+func TestKeyRingReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	ring := seededRing(t, path, jwtverifier.AlgEdDSA, jwtverifier.AlgES256)
+	if _, err := ring.Rotate(context.Background(), ringStart.Add(time.Hour), 0); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	want := ringStates(t, ring)
+
+	reloaded, err := NewKeyRing(path)
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+	got := ringStates(t, reloaded)
+	if len(got) != len(want) {
+		t.Fatalf("reloaded %d keys, want %d", len(got), len(want))
+	}
+	for kid, state := range want {
+		if got[kid] != state {
+			t.Errorf("reloaded kid %s is %s, want %s", kid, got[kid], state)
+		}
+	}
+}
+
+// sharedKeyRingStore is a versioned KeyRingStore in memory, shared by the
+// rings of several replicas.
+type sharedKeyRingStore struct {
+	data    []byte
+	version int64
+}
+
+func (s *sharedKeyRingStore) LoadKeyRing(context.Context) ([]byte, int64, error) {
+	return s.data, s.version, nil
+}
+
+func (s *sharedKeyRingStore) SaveKeyRing(_ context.Context, data []byte, version int64) (int64, error) {
+	if version != s.version {
+		return 0, domain.ErrKeyRingChanged
+	}
+	s.data, s.version = data, version+1
+	return s.version, nil
+}
+
+func (s *sharedKeyRingStore) String() string {
+	return "shared"
+}
+
+func TestKeyRingSharedStore(t *testing.T) {
+	ctx := context.Background()
+	store := &sharedKeyRingStore{}
+	first, err := NewStoredKeyRing(ctx, store)
+	if err != nil {
+		t.Fatalf("NewStoredKeyRing: %v", err)
+	}
+	second, err := NewStoredKeyRing(ctx, store)
+	if err != nil {
+		t.Fatalf("NewStoredKeyRing: %v", err)
+	}
+	key, err := GenerateSigningKey(jwtverifier.AlgEdDSA)
+	if err != nil {
+		t.Fatalf("GenerateSigningKey: %v", err)
+	}
+	if err := first.Seed(key, ringStart); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	if err := second.Seed(key, ringStart); !errors.Is(err, domain.ErrKeyRingChanged) {
+		t.Fatalf("second Seed error = %v, want %v", err, domain.ErrKeyRingChanged)
+	}
+	if got, err := second.ActiveKey(jwtverifier.AlgEdDSA); err != nil || got.Kid != key.Kid {
+		t.Fatalf("active key after a lost seed = %v, %v; want the winner's %s", got.Kid, err, key.Kid)
+	}
+
+	// The first replica rotates; the second one's rotation of the stale
+	// ring is refused and leaves it with the rotated ring.
+	if _, err := first.Rotate(ctx, ringStart.Add(time.Hour), 0); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	if _, err := second.Rotate(ctx, ringStart.Add(time.Hour), 0); !errors.Is(err, domain.ErrKeyRingChanged) {
+		t.Fatalf("stale Rotate error = %v, want %v", err, domain.ErrKeyRingChanged)
+	}
+	want := ringStates(t, first)
+	got := ringStates(t, second)
+	if len(got) != len(want) {
+		t.Fatalf("second ring has %d keys, want %d", len(got), len(want))
+	}
+	for kid, state := range want {
+		if got[kid] != state {
+			t.Errorf("second ring kid %s is %s, want %s", kid, got[kid], state)
+		}
+	}
+
+	// Reload picks up changes without a conflict.
+	if _, err := second.Rotate(ctx, ringStart.Add(2*time.Hour), 0); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	if err := first.Reload(ctx); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if a, b := kidIn(t, first, jwtverifier.AlgEdDSA, domain.SigningKeyActive), kidIn(t, second, jwtverifier.AlgEdDSA, domain.SigningKeyActive); a != b {
+		t.Errorf("active kid after Reload = %s, want %s", a, b)
+	}
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// MemoryAuditLog is an in-memory implementation of AuditLog.
+// For production, use the Postgres audit log (IDENTITY_STORE=postgres).
+type MemoryAuditLog struct {
+	mu     sync.RWMutex
+	byUser map[string][]domain.AuditEntry
+}
+
+// NewMemoryAuditLog creates an in-memory audit log.
+func NewMemoryAuditLog() *MemoryAuditLog {
+	return &MemoryAuditLog{byUser: make(map[string][]domain.AuditEntry)}
+}
+
+// Append implements interfaces.AuditLog.
+func (l *MemoryAuditLog) Append(_ context.Context, entry domain.AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry.Details = maps.Clone(entry.Details)
+	l.byUser[entry.PlatformUserID] = append(l.byUser[entry.PlatformUserID], entry)
+	return nil
+}
+
+// ListByUser implements interfaces.AuditLog.
+func (l *MemoryAuditLog) ListByUser(_ context.Context, platformUserID string) ([]domain.AuditEntry, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := l.byUser[platformUserID]
+	out := make([]domain.AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		entry.Details = maps.Clone(entry.Details)
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+// ReplaceDetails implements interfaces.AuditLog.
+func (l *MemoryAuditLog) ReplaceDetails(_ context.Context, platformUserID string, details map[string]map[string]string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, entry := range l.byUser[platformUserID] {
+		if d, ok := details[entry.ID]; ok {
+			l.byUser[platformUserID][i].Details = maps.Clone(d)
+		}
+	}
+	return nil
+}
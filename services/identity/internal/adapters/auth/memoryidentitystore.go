@@ -2,9 +2,13 @@ package auth
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/events"
 	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
 )
 
@@ -14,22 +18,56 @@ type linkageKey struct {
 	ExternalUserID string
 }
 
-// MemoryIdentityStore is an in-memory implementation of both IdentityResolver
-// and IdentityLookup. For production, replace with a Postgres-backed store.
+// identityTombstone records where a merged platform identity went.
+type identityTombstone struct {
+	MergedInto string
+	MergedAt   time.Time
+}
+
+// identityErasure records when a platform identity was erased. IDs merged
+// into an erased identity name it in ErasedWith.
+type identityErasure struct {
+	ErasedAt   time.Time
+	ErasedWith string
+}
+
+// MemoryIdentityStore is an in-memory implementation of IdentityResolver,
+// IdentityLinker, IdentityMerger, IdentityEraser, IdentityProfiles,
+// IdentityStatuses, IdentityLookup, IdentityQuery and Outbox. For
+// production, use the Postgres store (IDENTITY_STORE=postgres).
 type MemoryIdentityStore struct {
-	mu       sync.Mutex
-	linkages map[linkageKey]domain.PlatformIdentity
+	mu sync.Mutex
+	// linkages maps each linked external identity to its platform user ID.
+	linkages map[linkageKey]string
 	byID     map[string]domain.PlatformIdentity
-	idGen    func() string
+	// tombstones maps merged platform user IDs to their survivor. They
+	// always point at a live identity.
+	tombstones map[string]identityTombstone
+	erasures   map[string]identityErasure
+	// outbox holds the unpublished messages, oldest first.
+	outbox []domain.OutboxMessage
+	// events is false for the state of the file store, which logs the
+	// events of a change with it and adds them itself.
+	events bool
+	idGen  func() string
 }
 
 // NewMemoryIdentityStore creates an in-memory identity store.
-// idGen provides platform user IDs (e.g. UUID generator).
+// idGen provides platform user IDs and event IDs (e.g. UUID generator).
 func NewMemoryIdentityStore(idGen func() string) *MemoryIdentityStore {
+	s := newMemoryIdentityState(idGen)
+	s.events = true
+	return s
+}
+
+// newMemoryIdentityState creates a store that does not add events.
+func newMemoryIdentityState(idGen func() string) *MemoryIdentityStore {
 	return &MemoryIdentityStore{
-		linkages: make(map[linkageKey]domain.PlatformIdentity),
-		byID:     make(map[string]domain.PlatformIdentity),
-		idGen:    idGen,
+		linkages:   make(map[linkageKey]string),
+		byID:       make(map[string]domain.PlatformIdentity),
+		tombstones: make(map[string]identityTombstone),
+		erasures:   make(map[string]identityErasure),
+		idGen:      idGen,
 	}
 }
 
@@ -39,23 +77,114 @@ func (s *MemoryIdentityStore) Resolve(_ context.Context, provider, externalUserI
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	key := linkageKey{Provider: provider, ExternalUserID: externalUserID}
-
-	if identity, ok := s.linkages[key]; ok {
-		return identity, nil
+	if id, ok := s.linkages[linkageKey{Provider: provider, ExternalUserID: externalUserID}]; ok {
+		return cloneIdentity(s.byID[id]), nil
 	}
 
+	now := time.Now()
 	identity := domain.PlatformIdentity{
 		PlatformUserID: s.idGen(),
-		Provider:       provider,
-		ExternalUserID: externalUserID,
 		Tenant:         tenant,
-		CreatedAt:      time.Now(),
+		Linkages: []domain.Linkage{{
+			Provider:       provider,
+			ExternalUserID: externalUserID,
+			Tenant:         tenant,
+			LinkedAt:       now,
+		}},
+		CreatedAt: now,
 	}
-	s.linkages[key] = identity
-	s.byID[identity.PlatformUserID] = identity
+	if err := s.emitLocked(domain.NewIdentityCreatedEvent(s.idGen(), identity)); err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	s.putLocked(identity)
+	return cloneIdentity(identity), nil
+}
 
-	return identity, nil
+// Link implements interfaces.IdentityLinker.
+func (s *MemoryIdentityStore) Link(_ context.Context, platformUserID string, linkage domain.Linkage) (domain.PlatformIdentity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	linked := len(s.byID[platformUserID].Linkages)
+	identity, err := s.linkLocked(platformUserID, linkage)
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	if len(identity.Linkages) > linked {
+		if err := s.emitLocked(domain.NewIdentityLinkedEvent(s.idGen(), identity, linkage)); err != nil {
+			return domain.PlatformIdentity{}, err
+		}
+	}
+	return cloneIdentity(identity), nil
+}
+
+// Unlink implements interfaces.IdentityLinker.
+func (s *MemoryIdentityStore) Unlink(_ context.Context, platformUserID, provider, externalUserID string) (domain.PlatformIdentity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	identity, err := s.unlinkLocked(platformUserID, provider, externalUserID)
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	return cloneIdentity(identity), nil
+}
+
+// Merge implements interfaces.IdentityMerger.
+func (s *MemoryIdentityStore) Merge(_ context.Context, survivorID, mergedID string, at time.Time) (domain.PlatformIdentity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	identity, err := s.mergeLocked(survivorID, mergedID, at)
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	return cloneIdentity(identity), nil
+}
+
+// Erase implements interfaces.IdentityEraser.
+func (s *MemoryIdentityStore) Erase(_ context.Context, platformUserID string, at time.Time) (domain.Erasure, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.erasures[platformUserID]; ok {
+		return s.erasureLocked(platformUserID), nil
+	}
+	if err := s.eraseLocked(platformUserID, at); err != nil {
+		return domain.Erasure{}, err
+	}
+	erasure := s.erasureLocked(platformUserID)
+	if err := s.emitLocked(domain.NewIdentityErasedEvent(s.idGen(), erasure)); err != nil {
+		return domain.Erasure{}, err
+	}
+	return erasure, nil
+}
+
+// UpdateProfile implements interfaces.IdentityProfiles.
+func (s *MemoryIdentityStore) UpdateProfile(_ context.Context, platformUserID string, update domain.ProfileUpdate) (domain.PlatformIdentity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	identity, err := s.updateProfileLocked(platformUserID, update)
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	return cloneIdentity(identity), nil
+}
+
+// SetStatus implements interfaces.IdentityStatuses.
+func (s *MemoryIdentityStore) SetStatus(_ context.Context, platformUserID string, status domain.IdentityStatus) (domain.PlatformIdentity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	identity, err := s.setStatusLocked(platformUserID, status)
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	if err := s.emitLocked(domain.NewIdentityStatusChangedEvent(s.idGen(), identity)); err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	return cloneIdentity(identity), nil
 }
 
 // GetByPlatformUserID implements interfaces.IdentityLookup.
@@ -63,9 +192,369 @@ func (s *MemoryIdentityStore) GetByPlatformUserID(_ context.Context, platformUse
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if _, ok := s.erasures[platformUserID]; ok {
+		return domain.PlatformIdentity{}, domain.ErrIdentityErased
+	}
+	if t, ok := s.tombstones[platformUserID]; ok {
+		platformUserID = t.MergedInto
+	}
+	identity, ok := s.byID[platformUserID]
+	if !ok {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	return cloneIdentity(identity), nil
+}
+
+// GetByLinkage implements interfaces.IdentityLookup.
+func (s *MemoryIdentityStore) GetByLinkage(_ context.Context, provider, externalUserID string) (domain.PlatformIdentity, error) {
+	identity, ok := s.find(provider, externalUserID)
+	if !ok {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	return identity, nil
+}
+
+// List implements interfaces.IdentityQuery.
+func (s *MemoryIdentityStore) List(_ context.Context, filter domain.IdentityFilter, after *domain.IdentityCursor, limit int) (domain.IdentityPage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matches []domain.PlatformIdentity
+	for _, identity := range s.byID {
+		if after != nil && !after.Before(domain.CursorOf(identity)) {
+			continue
+		}
+		if filter.Matches(identity) {
+			matches = append(matches, identity)
+		}
+	}
+	slices.SortFunc(matches, func(a, b domain.PlatformIdentity) int {
+		switch ca, cb := domain.CursorOf(a), domain.CursorOf(b); {
+		case ca.Before(cb):
+			return -1
+		case cb.Before(ca):
+			return 1
+		}
+		return 0
+	})
+
+	var page domain.IdentityPage
+	if len(matches) > limit {
+		matches = matches[:limit]
+		next := domain.CursorOf(matches[limit-1])
+		page.Next = &next
+	}
+	page.Identities = make([]domain.PlatformIdentity, 0, len(matches))
+	for _, identity := range matches {
+		page.Identities = append(page.Identities, cloneIdentity(identity))
+	}
+	return page, nil
+}
+
+// Add implements interfaces.Outbox.
+func (s *MemoryIdentityStore) Add(_ context.Context, event domain.Event) error {
+	msg, err := events.Encode(event)
+	if err != nil {
+		return err
+	}
+	s.enqueue(msg)
+	return nil
+}
+
+// Pending implements interfaces.Outbox.
+func (s *MemoryIdentityStore) Pending(_ context.Context, limit int) ([]domain.OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.outbox[:min(limit, len(s.outbox))]), nil
+}
+
+// MarkPublished implements interfaces.Outbox.
+func (s *MemoryIdentityStore) MarkPublished(_ context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.outbox = slices.DeleteFunc(s.outbox, func(m domain.OutboxMessage) bool {
+		return slices.Contains(ids, m.ID)
+	})
+	return nil
+}
+
+// enqueue adds messages to the outbox, e.g. replayed from a log.
+func (s *MemoryIdentityStore) enqueue(msgs ...domain.OutboxMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.outbox = append(s.outbox, msgs...)
+}
+
+// allPending returns every message in the outbox, oldest first.
+func (s *MemoryIdentityStore) allPending() []domain.OutboxMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.outbox)
+}
+
+// find returns the identity linked to an external identity.
+func (s *MemoryIdentityStore) find(provider, externalUserID string) (domain.PlatformIdentity, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, ok := s.linkages[linkageKey{Provider: provider, ExternalUserID: externalUserID}]
+	if !ok {
+		return domain.PlatformIdentity{}, false
+	}
+	return cloneIdentity(s.byID[id]), true
+}
+
+// put stores an identity created elsewhere, e.g. replayed from a log.
+func (s *MemoryIdentityStore) put(identity domain.PlatformIdentity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.putLocked(cloneIdentity(identity))
+}
+
+// all returns every stored identity.
+func (s *MemoryIdentityStore) all() []domain.PlatformIdentity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]domain.PlatformIdentity, 0, len(s.byID))
+	for _, identity := range s.byID {
+		out = append(out, cloneIdentity(identity))
+	}
+	return out
+}
+
+// allTombstones returns every tombstone keyed by merged platform user ID.
+func (s *MemoryIdentityStore) allTombstones() map[string]identityTombstone {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return maps.Clone(s.tombstones)
+}
+
+// putTombstone stores a tombstone recorded elsewhere, e.g. in a snapshot.
+func (s *MemoryIdentityStore) putTombstone(mergedID string, t identityTombstone) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.byID[t.MergedInto]; !ok {
+		return fmt.Errorf("tombstone %s points at unknown identity %s", mergedID, t.MergedInto)
+	}
+	if _, ok := s.byID[mergedID]; ok {
+		return fmt.Errorf("tombstone %s shadows a live identity", mergedID)
+	}
+	s.tombstones[mergedID] = t
+	return nil
+}
+
+// allErasures returns every erasure keyed by platform user ID.
+func (s *MemoryIdentityStore) allErasures() map[string]identityErasure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return maps.Clone(s.erasures)
+}
+
+// putErasure stores an erasure recorded elsewhere, e.g. in a snapshot.
+func (s *MemoryIdentityStore) putErasure(platformUserID string, e identityErasure) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.byID[platformUserID]; ok {
+		return fmt.Errorf("erasure %s shadows a live identity", platformUserID)
+	}
+	if _, ok := s.tombstones[platformUserID]; ok {
+		return fmt.Errorf("erasure %s shadows a tombstone", platformUserID)
+	}
+	s.erasures[platformUserID] = e
+	return nil
+}
+
+// erased reports whether a platform user ID was erased.
+func (s *MemoryIdentityStore) erased(platformUserID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.erasures[platformUserID]
+	return ok
+}
+
+// emitLocked adds the event of a change to the outbox, unless the store
+// does not add events.
+func (s *MemoryIdentityStore) emitLocked(event domain.Event) error {
+	if !s.events {
+		return nil
+	}
+	msg, err := events.Encode(event)
+	if err != nil {
+		return err
+	}
+	s.outbox = append(s.outbox, msg)
+	return nil
+}
+
+func (s *MemoryIdentityStore) putLocked(identity domain.PlatformIdentity) {
+	for _, l := range identity.Linkages {
+		s.linkages[linkageKey{Provider: l.Provider, ExternalUserID: l.ExternalUserID}] = identity.PlatformUserID
+	}
+	s.byID[identity.PlatformUserID] = identity
+}
+
+func (s *MemoryIdentityStore) linkLocked(platformUserID string, linkage domain.Linkage) (domain.PlatformIdentity, error) {
+	if err := linkage.Validate(); err != nil {
+		return domain.PlatformIdentity{}, err
+	}
 	identity, ok := s.byID[platformUserID]
 	if !ok {
 		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
 	}
+
+	key := linkageKey{Provider: linkage.Provider, ExternalUserID: linkage.ExternalUserID}
+	if owner, ok := s.linkages[key]; ok {
+		if owner != platformUserID {
+			return domain.PlatformIdentity{}, domain.ErrLinkageConflict
+		}
+		return identity, nil
+	}
+
+	identity.Linkages = append(slices.Clip(identity.Linkages), linkage)
+	s.linkages[key] = platformUserID
+	s.byID[platformUserID] = identity
 	return identity, nil
 }
+
+func (s *MemoryIdentityStore) unlinkLocked(platformUserID, provider, externalUserID string) (domain.PlatformIdentity, error) {
+	identity, ok := s.byID[platformUserID]
+	if !ok {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	i := slices.IndexFunc(identity.Linkages, func(l domain.Linkage) bool {
+		return l.Provider == provider && l.ExternalUserID == externalUserID
+	})
+	if i < 0 {
+		return domain.PlatformIdentity{}, domain.ErrLinkageNotFound
+	}
+	if len(identity.Linkages) == 1 {
+		return domain.PlatformIdentity{}, domain.ErrLastLinkage
+	}
+
+	identity.Linkages = slices.Delete(slices.Clone(identity.Linkages), i, i+1)
+	delete(s.linkages, linkageKey{Provider: provider, ExternalUserID: externalUserID})
+	s.byID[platformUserID] = identity
+	return identity, nil
+}
+
+func (s *MemoryIdentityStore) mergeLocked(survivorID, mergedID string, at time.Time) (domain.PlatformIdentity, error) {
+	if survivorID == mergedID {
+		return domain.PlatformIdentity{}, domain.ErrMergeSelf
+	}
+	survivor, ok := s.byID[survivorID]
+	if !ok {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	merged, ok := s.byID[mergedID]
+	if !ok {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+
+	survivor.Profile = survivor.Profile.Adopt(merged.Profile)
+	survivor.Linkages = slices.Clip(survivor.Linkages)
+	for _, l := range merged.Linkages {
+		l.LinkedAt = at
+		survivor.Linkages = append(survivor.Linkages, l)
+		s.linkages[linkageKey{Provider: l.Provider, ExternalUserID: l.ExternalUserID}] = survivorID
+	}
+	s.byID[survivorID] = survivor
+	delete(s.byID, mergedID)
+
+	// Keep tombstones one hop from a live identity.
+	for id, t := range s.tombstones {
+		if t.MergedInto == mergedID {
+			t.MergedInto = survivorID
+			s.tombstones[id] = t
+		}
+	}
+	s.tombstones[mergedID] = identityTombstone{MergedInto: survivorID, MergedAt: at}
+	return survivor, nil
+}
+
+func (s *MemoryIdentityStore) updateProfileLocked(platformUserID string, update domain.ProfileUpdate) (domain.PlatformIdentity, error) {
+	if _, ok := s.erasures[platformUserID]; ok {
+		return domain.PlatformIdentity{}, domain.ErrIdentityErased
+	}
+	if t, ok := s.tombstones[platformUserID]; ok {
+		platformUserID = t.MergedInto
+	}
+	identity, ok := s.byID[platformUserID]
+	if !ok {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	if profile, changed := identity.Profile.Apply(update); changed {
+		identity.Profile = profile
+		s.byID[platformUserID] = identity
+	}
+	return identity, nil
+}
+
+func (s *MemoryIdentityStore) setStatusLocked(platformUserID string, status domain.IdentityStatus) (domain.PlatformIdentity, error) {
+	if _, ok := s.erasures[platformUserID]; ok {
+		return domain.PlatformIdentity{}, domain.ErrIdentityErased
+	}
+	identity, ok := s.byID[platformUserID]
+	if !ok {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	identity.Status = status
+	s.byID[platformUserID] = identity
+	return identity, nil
+}
+
+func (s *MemoryIdentityStore) eraseLocked(platformUserID string, at time.Time) error {
+	identity, ok := s.byID[platformUserID]
+	if !ok {
+		return domain.ErrIdentityNotFound
+	}
+	for _, l := range identity.Linkages {
+		delete(s.linkages, linkageKey{Provider: l.Provider, ExternalUserID: l.ExternalUserID})
+	}
+	delete(s.byID, platformUserID)
+	erased := []string{platformUserID}
+	for id, t := range s.tombstones {
+		if t.MergedInto == platformUserID {
+			delete(s.tombstones, id)
+			s.erasures[id] = identityErasure{ErasedAt: at, ErasedWith: platformUserID}
+			erased = append(erased, id)
+		}
+	}
+	s.erasures[platformUserID] = identityErasure{ErasedAt: at}
+	// Unpublished events must not outlive the data they describe.
+	s.outbox = slices.DeleteFunc(s.outbox, func(m domain.OutboxMessage) bool {
+		return slices.Contains(erased, m.PlatformUserID)
+	})
+	return nil
+}
+
+// erasureLocked returns the erasure an erased platform user ID belongs to.
+func (s *MemoryIdentityStore) erasureLocked(platformUserID string) domain.Erasure {
+	if e := s.erasures[platformUserID]; e.ErasedWith != "" {
+		platformUserID = e.ErasedWith
+	}
+	erasure := domain.Erasure{PlatformUserID: platformUserID, ErasedAt: s.erasures[platformUserID].ErasedAt}
+	for id, e := range s.erasures {
+		if e.ErasedWith == platformUserID {
+			erasure.MergedUserIDs = append(erasure.MergedUserIDs, id)
+		}
+	}
+	slices.Sort(erasure.MergedUserIDs)
+	return erasure
+}
+
+func cloneIdentity(identity domain.PlatformIdentity) domain.PlatformIdentity {
+	identity.Linkages = slices.Clone(identity.Linkages)
+	identity.Profile = maps.Clone(identity.Profile)
+	return identity
+}
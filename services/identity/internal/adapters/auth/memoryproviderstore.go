@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// MemoryProviderStore is an in-memory implementation of ProviderStore.
+// Providers are lost on restart; use FileProviderStore to keep them.
+type MemoryProviderStore struct {
+	mu   sync.RWMutex
+	byID map[string]domain.Provider
+}
+
+// NewMemoryProviderStore creates an empty in-memory provider store.
+func NewMemoryProviderStore() *MemoryProviderStore {
+	return &MemoryProviderStore{byID: make(map[string]domain.Provider)}
+}
+
+// Create implements interfaces.ProviderStore.
+func (s *MemoryProviderStore) Create(_ context.Context, provider domain.Provider) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.byID[provider.ID]; exists {
+		return domain.ErrProviderExists
+	}
+	s.byID[provider.ID] = cloneProvider(provider)
+	return nil
+}
+
+// Get implements interfaces.ProviderStore.
+func (s *MemoryProviderStore) Get(_ context.Context, id string) (domain.Provider, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	provider, ok := s.byID[id]
+	if !ok {
+		return domain.Provider{}, domain.ErrProviderNotFound
+	}
+	return cloneProvider(provider), nil
+}
+
+// List implements interfaces.ProviderStore.
+func (s *MemoryProviderStore) List(_ context.Context) ([]domain.Provider, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]domain.Provider, 0, len(s.byID))
+	for _, provider := range s.byID {
+		out = append(out, cloneProvider(provider))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// Update implements interfaces.ProviderStore.
+func (s *MemoryProviderStore) Update(_ context.Context, provider domain.Provider) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.byID[provider.ID]; !ok {
+		return domain.ErrProviderNotFound
+	}
+	s.byID[provider.ID] = cloneProvider(provider)
+	return nil
+}
+
+// Delete implements interfaces.ProviderStore.
+func (s *MemoryProviderStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.byID[id]; !ok {
+		return domain.ErrProviderNotFound
+	}
+	delete(s.byID, id)
+	return nil
+}
+
+// cloneProvider copies the provider's slices so callers cannot mutate
+// stored state.
+func cloneProvider(p domain.Provider) domain.Provider {
+	p.AllowedTenants = slices.Clone(p.AllowedTenants)
+	p.AssertionKeys = slices.Clone(p.AssertionKeys)
+	p.Tokens.ProfileClaims = slices.Clone(p.Tokens.ProfileClaims)
+	return p
+}
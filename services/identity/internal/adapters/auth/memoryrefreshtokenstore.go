@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// refreshSweepInterval bounds how often expired and revoked refresh tokens
+// are dropped.
+const refreshSweepInterval = time.Minute
+
+// MemoryRefreshTokenStore is an in-memory implementation of
+// RefreshTokenStore. Tokens do not survive a restart and reuse is only
+// detected within a single instance; IDENTITY_STORE=file and postgres
+// persist them. Expired tokens and revoked families are dropped
+// periodically: neither can be exchanged anymore.
+type MemoryRefreshTokenStore struct {
+	mu        sync.Mutex
+	byHash    map[string]domain.RefreshToken
+	families  map[string][]string
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryRefreshTokenStore creates an in-memory refresh token store.
+func NewMemoryRefreshTokenStore() *MemoryRefreshTokenStore {
+	return &MemoryRefreshTokenStore{
+		byHash:   make(map[string]domain.RefreshToken),
+		families: make(map[string][]string),
+		now:      time.Now,
+	}
+}
+
+// Save implements interfaces.RefreshTokenStore.
+func (s *MemoryRefreshTokenStore) Save(_ context.Context, token domain.RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now := s.now(); now.After(s.nextSweep) {
+		s.sweepLocked(now)
+		s.nextSweep = now.Add(refreshSweepInterval)
+	}
+	s.putLocked(token)
+	return nil
+}
+
+// Consume implements interfaces.RefreshTokenStore.
+func (s *MemoryRefreshTokenStore) Consume(_ context.Context, tokenHash string, at time.Time) (domain.RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.byHash[tokenHash]
+	if !ok {
+		return domain.RefreshToken{}, domain.ErrInvalidRefreshToken
+	}
+	if !token.UsedAt.IsZero() {
+		return token, domain.ErrRefreshTokenReused
+	}
+
+	used := token
+	used.UsedAt = at
+	s.byHash[tokenHash] = used
+	return token, nil
+}
+
+// RevokeFamily implements interfaces.RefreshTokenStore.
+func (s *MemoryRefreshTokenStore) RevokeFamily(_ context.Context, sessionID string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, hash := range s.families[sessionID] {
+		token := s.byHash[hash]
+		if token.RevokedAt.IsZero() {
+			token.RevokedAt = at
+			s.byHash[hash] = token
+		}
+	}
+	return nil
+}
+
+// get returns the stored state of a token.
+func (s *MemoryRefreshTokenStore) get(tokenHash string) (domain.RefreshToken, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.byHash[tokenHash]
+	return token, ok
+}
+
+// put stores a token as is.
+func (s *MemoryRefreshTokenStore) put(token domain.RefreshToken) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.putLocked(token)
+}
+
+// size returns the number of stored tokens.
+func (s *MemoryRefreshTokenStore) size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.byHash)
+}
+
+// sweep drops expired tokens and revoked families right away and returns
+// the tokens left.
+func (s *MemoryRefreshTokenStore) sweep(now time.Time) []domain.RefreshToken {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweepLocked(now)
+	s.nextSweep = now.Add(refreshSweepInterval)
+	tokens := make([]domain.RefreshToken, 0, len(s.byHash))
+	for _, token := range s.byHash {
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+func (s *MemoryRefreshTokenStore) putLocked(token domain.RefreshToken) {
+	if _, exists := s.byHash[token.TokenHash]; !exists {
+		s.families[token.SessionID] = append(s.families[token.SessionID], token.TokenHash)
+	}
+	s.byHash[token.TokenHash] = token
+}
+
+// sweepLocked drops tokens that can no longer be exchanged. A used token
+// that has not expired is kept, so presenting it again is still detected
+// as reuse.
+func (s *MemoryRefreshTokenStore) sweepLocked(now time.Time) {
+	for sessionID, hashes := range s.families {
+		live := hashes[:0]
+		for _, hash := range hashes {
+			token := s.byHash[hash]
+			if !token.RevokedAt.IsZero() || !now.Before(token.ExpiresAt) {
+				delete(s.byHash, hash)
+				continue
+			}
+			live = append(live, hash)
+		}
+		if len(live) == 0 {
+			delete(s.families, sessionID)
+			continue
+		}
+		s.families[sessionID] = live
+	}
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// MemoryReplayCache is an in-memory implementation of AssertionReplayCache.
+// It only protects a single instance; replicas need a shared store.
+type MemoryReplayCache struct {
+	mu        sync.Mutex
+	used      map[replayKey]time.Time
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+// replayKey identifies a used assertion.
+type replayKey struct {
+	provider string
+	jti      string
+}
+
+// replaySweepInterval bounds how often expired entries are dropped.
+const replaySweepInterval = time.Minute
+
+// NewMemoryReplayCache creates an in-memory replay cache.
+func NewMemoryReplayCache() *MemoryReplayCache {
+	return &MemoryReplayCache{
+		used: make(map[replayKey]time.Time),
+		now:  time.Now,
+	}
+}
+
+// MarkUsed implements interfaces.AssertionReplayCache.
+func (c *MemoryReplayCache) MarkUsed(_ context.Context, provider, jti string, expiresAt time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if now.After(c.nextSweep) {
+		c.sweepLocked(now)
+		c.nextSweep = now.Add(replaySweepInterval)
+	}
+
+	key := replayKey{provider: provider, jti: jti}
+	if _, ok := c.used[key]; ok {
+		return domain.ErrAssertionReplayed
+	}
+	c.used[key] = expiresAt
+	return nil
+}
+
+// contains reports whether a jti of a provider is recorded.
+func (c *MemoryReplayCache) contains(provider, jti string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.used[replayKey{provider: provider, jti: jti}]
+	return ok
+}
+
+// put records a jti as is.
+func (c *MemoryReplayCache) put(provider, jti string, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.used[replayKey{provider: provider, jti: jti}] = expiresAt
+}
+
+// size returns the number of recorded jtis.
+func (c *MemoryReplayCache) size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.used)
+}
+
+// sweep drops expired entries right away and passes the rest to keep.
+func (c *MemoryReplayCache) sweep(now time.Time, keep func(provider, jti string, expiresAt time.Time)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sweepLocked(now)
+	c.nextSweep = now.Add(replaySweepInterval)
+	for key, exp := range c.used {
+		keep(key.provider, key.jti, exp)
+	}
+}
+
+func (c *MemoryReplayCache) sweepLocked(now time.Time) {
+	for key, exp := range c.used {
+		if now.After(exp) {
+			delete(c.used, key)
+		}
+	}
+}
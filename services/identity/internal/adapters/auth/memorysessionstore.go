@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// sessionSweepInterval bounds how often long expired sessions are dropped.
+const sessionSweepInterval = time.Minute
+
+// MemorySessionStore is an in-memory implementation of SessionStore.
+// Sessions and their revocations do not survive a restart and are not
+// shared between replicas; IDENTITY_STORE=file and postgres persist them.
+// Sessions are dropped once they have been expired for the retention
+// window.
+type MemorySessionStore struct {
+	mu        sync.RWMutex
+	byID      map[string]domain.Session
+	byUserID  map[string][]string
+	retention time.Duration
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemorySessionStore creates an in-memory session store that keeps
+// expired sessions for retention, at least the longest access token
+// lifetime, before dropping them.
+func NewMemorySessionStore(retention time.Duration) *MemorySessionStore {
+	return &MemorySessionStore{
+		byID:      make(map[string]domain.Session),
+		byUserID:  make(map[string][]string),
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// Create implements interfaces.SessionStore.
+func (s *MemorySessionStore) Create(_ context.Context, session domain.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now := s.now(); now.After(s.nextSweep) {
+		s.sweepLocked(now)
+		s.nextSweep = now.Add(sessionSweepInterval)
+	}
+	s.putLocked(session)
+	return nil
+}
+
+// Get implements interfaces.SessionStore.
+func (s *MemorySessionStore) Get(_ context.Context, sessionID string) (domain.Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.byID[sessionID]
+	if !ok {
+		return domain.Session{}, domain.ErrSessionNotFound
+	}
+	return session, nil
+}
+
+// ListByUser implements interfaces.SessionStore.
+func (s *MemorySessionStore) ListByUser(_ context.Context, platformUserID string) ([]domain.Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := s.byUserID[platformUserID]
+	out := make([]domain.Session, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, s.byID[id])
+	}
+	return out, nil
+}
+
+// Extend implements interfaces.SessionStore.
+func (s *MemorySessionStore) Extend(_ context.Context, sessionID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.byID[sessionID]
+	if !ok {
+		return domain.ErrSessionNotFound
+	}
+	session.ExpiresAt = expiresAt
+	s.byID[sessionID] = session
+	return nil
+}
+
+// Revoke implements interfaces.SessionStore.
+func (s *MemorySessionStore) Revoke(_ context.Context, sessionID, reason string, at time.Time) (domain.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.byID[sessionID]
+	if !ok {
+		return domain.Session{}, domain.ErrSessionNotFound
+	}
+	if session.RevokedAt.IsZero() {
+		session.RevokedAt = at
+		session.RevokeReason = reason
+		s.byID[sessionID] = session
+	}
+	return session, nil
+}
+
+// RevokeAllForUser implements interfaces.SessionStore.
+func (s *MemorySessionStore) RevokeAllForUser(_ context.Context, platformUserID, reason string, at time.Time) ([]domain.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var revoked []domain.Session
+	for _, id := range s.byUserID[platformUserID] {
+		session := s.byID[id]
+		if !session.RevokedAt.IsZero() {
+			continue
+		}
+		session.RevokedAt = at
+		session.RevokeReason = reason
+		s.byID[id] = session
+		revoked = append(revoked, session)
+	}
+	return revoked, nil
+}
+
+// RevokedSince implements interfaces.SessionStore.
+func (s *MemorySessionStore) RevokedSince(_ context.Context, since time.Time) ([]domain.Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []domain.Session
+	for _, session := range s.byID {
+		if !session.RevokedAt.IsZero() && !session.RevokedAt.Before(since) {
+			out = append(out, session)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].RevokedAt.Before(out[j].RevokedAt) })
+	return out, nil
+}
+
+// put stores a session as is.
+func (s *MemorySessionStore) put(session domain.Session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.putLocked(session)
+}
+
+// size returns the number of stored sessions.
+func (s *MemorySessionStore) size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.byID)
+}
+
+// sweep drops long expired sessions right away and returns the sessions
+// left, oldest first.
+func (s *MemorySessionStore) sweep(now time.Time) []domain.Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweepLocked(now)
+	s.nextSweep = now.Add(sessionSweepInterval)
+	sessions := make([]domain.Session, 0, len(s.byID))
+	for _, session := range s.byID {
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].CreatedAt.Equal(sessions[j].CreatedAt) {
+			return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+		}
+		return sessions[i].ID < sessions[j].ID
+	})
+	return sessions
+}
+
+func (s *MemorySessionStore) putLocked(session domain.Session) {
+	if _, exists := s.byID[session.ID]; !exists {
+		s.byUserID[session.PlatformUserID] = append(s.byUserID[session.PlatformUserID], session.ID)
+	}
+	s.byID[session.ID] = session
+}
+
+// sweepLocked drops sessions that expired more than the retention window
+// ago. Their access tokens have all expired, so a revocation no longer
+// needs to be listed.
+func (s *MemorySessionStore) sweepLocked(now time.Time) {
+	cutoff := now.Add(-s.retention)
+	for userID, ids := range s.byUserID {
+		live := ids[:0]
+		for _, id := range ids {
+			if s.byID[id].ExpiresAt.Before(cutoff) {
+				delete(s.byID, id)
+				continue
+			}
+			live = append(live, id)
+		}
+		if len(live) == 0 {
+			delete(s.byUserID, userID)
+			continue
+		}
+		s.byUserID[userID] = live
+	}
+}
@@ -0,0 +1,213 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+var tokenStart = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type refreshStoreCase struct {
+	name string
+	open func(t *testing.T) interfaces.RefreshTokenStore
+}
+
+var refreshStores = []refreshStoreCase{
+	{"memory", func(*testing.T) interfaces.RefreshTokenStore { return NewMemoryRefreshTokenStore() }},
+	{"file", func(t *testing.T) interfaces.RefreshTokenStore {
+		s, err := NewFileRefreshTokenStore(t.TempDir())
+		if err != nil {
+			t.Fatalf("NewFileRefreshTokenStore: %v", err)
+		}
+		t.Cleanup(func() { s.Close() })
+		return s
+	}},
+}
+
+func refreshToken(hash, sessionID string, ttl time.Duration) domain.RefreshToken {
+	return domain.RefreshToken{
+		TokenHash:      hash,
+		SessionID:      sessionID,
+		PlatformUserID: "user-1",
+		IssuedAt:       tokenStart,
+		ExpiresAt:      tokenStart.Add(ttl),
+	}
+}
+
+func TestRefreshTokenStoreConsume(t *testing.T) {
+	tests := []struct {
+		name      string
+		consume   []string
+		wantErr   error
+		wantToken bool
+	}{
+		{name: "first use", consume: []string{"a"}, wantToken: true},
+		{name: "second use is reuse", consume: []string{"a", "a"}, wantErr: domain.ErrRefreshTokenReused, wantToken: true},
+		{name: "unknown token", consume: []string{"unknown"}, wantErr: domain.ErrInvalidRefreshToken},
+		{name: "rotated tokens are independent", consume: []string{"a", "b"}, wantToken: true},
+	}
+	for _, store := range refreshStores {
+		for _, tt := range tests {
+			t.Run(store.name+"/"+tt.name, func(t *testing.T) {
+				ctx := context.Background()
+				s := store.open(t)
+				for _, hash := range []string{"a", "b"} {
+					if err := s.Save(ctx, refreshToken(hash, "session-1", time.Hour)); err != nil {
+						t.Fatalf("Save: %v", err)
+					}
+				}
+
+				var (
+					token domain.RefreshToken
+					err   error
+				)
+				for i, hash := range tt.consume {
+					token, err = s.Consume(ctx, hash, tokenStart.Add(time.Duration(i+1)*time.Minute))
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Consume error = %v, want %v", err, tt.wantErr)
+				}
+				if got := token.SessionID == "session-1"; got != tt.wantToken {
+					t.Errorf("Consume returned %+v, want token %v", token, tt.wantToken)
+				}
+			})
+		}
+	}
+}
+
+func TestRefreshTokenStoreRevokeFamily(t *testing.T) {
+	for _, store := range refreshStores {
+		t.Run(store.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := store.open(t)
+			for _, tok := range []domain.RefreshToken{
+				refreshToken("a", "session-1", time.Hour),
+				refreshToken("b", "session-1", time.Hour),
+				refreshToken("c", "session-2", time.Hour),
+			} {
+				if err := s.Save(ctx, tok); err != nil {
+					t.Fatalf("Save: %v", err)
+				}
+			}
+			if err := s.RevokeFamily(ctx, "session-1", tokenStart); err != nil {
+				t.Fatalf("RevokeFamily: %v", err)
+			}
+
+			for hash, wantRevoked := range map[string]bool{"a": true, "b": true, "c": false} {
+				token, err := s.Consume(ctx, hash, tokenStart.Add(time.Minute))
+				if err != nil {
+					t.Fatalf("Consume %s: %v", hash, err)
+				}
+				if got := !token.RevokedAt.IsZero(); got != wantRevoked {
+					t.Errorf("token %s revoked = %v, want %v", hash, got, wantRevoked)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryRefreshTokenStoreSweep(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryRefreshTokenStore()
+	now := tokenStart
+	s.now = func() time.Time { return now }
+
+	for _, tok := range []domain.RefreshToken{
+		refreshToken("expired", "session-1", time.Minute),
+		refreshToken("used", "session-1", time.Hour),
+		refreshToken("revoked", "session-2", time.Hour),
+	} {
+		if err := s.Save(ctx, tok); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	if _, err := s.Consume(ctx, "used", tokenStart); err != nil {
+		t.Fatalf("Consume: %v", err)
+	}
+	if err := s.RevokeFamily(ctx, "session-2", tokenStart); err != nil {
+		t.Fatalf("RevokeFamily: %v", err)
+	}
+
+	// The next save after the sweep interval drops what can no longer be
+	// exchanged.
+	now = tokenStart.Add(2 * refreshSweepInterval)
+	if err := s.Save(ctx, refreshToken("fresh", "session-3", time.Hour)); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	tests := []struct {
+		hash    string
+		wantErr error
+	}{
+		{"expired", domain.ErrInvalidRefreshToken},
+		{"revoked", domain.ErrInvalidRefreshToken},
+		{"used", domain.ErrRefreshTokenReused},
+		{"fresh", nil},
+	}
+	for _, tt := range tests {
+		if _, err := s.Consume(ctx, tt.hash, now); !errors.Is(err, tt.wantErr) {
+			t.Errorf("Consume %s error = %v, want %v", tt.hash, err, tt.wantErr)
+		}
+	}
+	if _, ok := s.families["session-2"]; ok {
+		t.Error("revoked family still indexed")
+	}
+}
+
+func TestFileRefreshTokenStoreReopen(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := NewFileRefreshTokenStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileRefreshTokenStore: %v", err)
+	}
+	future := time.Now().Add(time.Hour)
+	for _, tok := range []domain.RefreshToken{
+		{TokenHash: "used", SessionID: "session-1", ExpiresAt: future},
+		{TokenHash: "unused", SessionID: "session-1", ExpiresAt: future},
+		{TokenHash: "revoked", SessionID: "session-2", ExpiresAt: future},
+		{TokenHash: "expired", SessionID: "session-3", ExpiresAt: time.Now().Add(-time.Minute)},
+	} {
+		if err := s.Save(ctx, tok); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	if _, err := s.Consume(ctx, "used", time.Now()); err != nil {
+		t.Fatalf("Consume: %v", err)
+	}
+	if err := s.RevokeFamily(ctx, "session-2", time.Now()); err != nil {
+		t.Fatalf("RevokeFamily: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened, err := NewFileRefreshTokenStore(dir)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+	if got := reopened.log.records; got != 2 {
+		t.Errorf("compacted log holds %d records, want 2", got)
+	}
+
+	tests := []struct {
+		hash    string
+		wantErr error
+	}{
+		{"used", domain.ErrRefreshTokenReused},
+		{"unused", nil},
+		{"revoked", domain.ErrInvalidRefreshToken},
+		{"expired", domain.ErrInvalidRefreshToken},
+	}
+	for _, tt := range tests {
+		if _, err := reopened.Consume(ctx, tt.hash, time.Now()); !errors.Is(err, tt.wantErr) {
+			t.Errorf("Consume %s after reopen error = %v, want %v", tt.hash, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+type replayCacheCase struct {
+	name string
+	open func(t *testing.T) interfaces.AssertionReplayCache
+}
+
+var replayCaches = []replayCacheCase{
+	{"memory", func(*testing.T) interfaces.AssertionReplayCache { return NewMemoryReplayCache() }},
+	{"file", func(t *testing.T) interfaces.AssertionReplayCache {
+		c, err := NewFileReplayCache(t.TempDir())
+		if err != nil {
+			t.Fatalf("NewFileReplayCache: %v", err)
+		}
+		t.Cleanup(func() { c.Close() })
+		return c
+	}},
+}
+
+func TestReplayCacheMarkUsed(t *testing.T) {
+	type use struct {
+		provider, jti string
+	}
+	tests := []struct {
+		name    string
+		uses    []use
+		wantErr error
+	}{
+		{name: "first use", uses: []use{{"p1", "a"}}},
+		{name: "second use", uses: []use{{"p1", "a"}, {"p1", "a"}}, wantErr: domain.ErrAssertionReplayed},
+		{name: "other jti", uses: []use{{"p1", "a"}, {"p1", "b"}}},
+		{name: "same jti of another provider", uses: []use{{"p1", "a"}, {"p2", "a"}}},
+	}
+	for _, cache := range replayCaches {
+		for _, tt := range tests {
+			t.Run(cache.name+"/"+tt.name, func(t *testing.T) {
+				c := cache.open(t)
+				var err error
+				for _, u := range tt.uses {
+					err = c.MarkUsed(context.Background(), u.provider, u.jti, time.Now().Add(time.Minute))
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("MarkUsed error = %v, want %v", err, tt.wantErr)
+				}
+			})
+		}
+	}
+}
+
+func TestMemoryReplayCacheSweep(t *testing.T) {
+	c := NewMemoryReplayCache()
+	now := tokenStart
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if err := c.MarkUsed(ctx, "p1", "a", now.Add(time.Minute)); err != nil {
+		t.Fatalf("MarkUsed: %v", err)
+	}
+	now = now.Add(30 * time.Second)
+	if err := c.MarkUsed(ctx, "p1", "a", now.Add(time.Minute)); !errors.Is(err, domain.ErrAssertionReplayed) {
+		t.Fatalf("MarkUsed before expiry = %v, want %v", err, domain.ErrAssertionReplayed)
+	}
+	now = now.Add(2 * time.Minute)
+	if err := c.MarkUsed(ctx, "p1", "b", now.Add(time.Minute)); err != nil {
+		t.Fatalf("MarkUsed: %v", err)
+	}
+	if c.contains("p1", "a") {
+		t.Error("expired jti was not swept")
+	}
+}
+
+func TestFileReplayCacheReopen(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	c, err := NewFileReplayCache(dir)
+	if err != nil {
+		t.Fatalf("NewFileReplayCache: %v", err)
+	}
+	if err := c.MarkUsed(ctx, "p1", "live", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("MarkUsed: %v", err)
+	}
+	if err := c.MarkUsed(ctx, "p1", "expired", time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("MarkUsed: %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	c, err = NewFileReplayCache(dir)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer c.Close()
+	if err := c.MarkUsed(ctx, "p1", "live", time.Now().Add(time.Hour)); !errors.Is(err, domain.ErrAssertionReplayed) {
+		t.Errorf("MarkUsed of a jti used before the restart = %v, want %v", err, domain.ErrAssertionReplayed)
+	}
+	if c.mem.contains("p1", "expired") {
+		t.Error("expired jti survived reopening")
+	}
+}
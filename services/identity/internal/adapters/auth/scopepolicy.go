@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// scopePolicyFile is the JSON representation of a scope policy:
+//
+//	{"rules": [{"provider": "acme-games", "subject_type": "player", "scopes": ["profile:read"]}]}
+//
+// Omitted match fields match any value.
+type scopePolicyFile struct {
+	Rules []struct {
+		Provider    string   `json:"provider"`
+		Tenant      string   `json:"tenant"`
+		SubjectType string   `json:"subject_type"`
+		Scopes      []string `json:"scopes"`
+	} `json:"rules"`
+}
+
+// LoadScopePolicyFile reads a JSON scope policy.
+func LoadScopePolicyFile(path string) (domain.ScopePolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.ScopePolicy{}, fmt.Errorf("read scope policy %s: %w", path, err)
+	}
+	policy, err := ParseScopePolicy(data)
+	if err != nil {
+		return domain.ScopePolicy{}, fmt.Errorf("parse scope policy %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// ParseScopePolicy parses a JSON scope policy. Unknown fields and scopes
+// that are empty or contain whitespace are rejected.
+func ParseScopePolicy(data []byte) (domain.ScopePolicy, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var f scopePolicyFile
+	if err := dec.Decode(&f); err != nil {
+		return domain.ScopePolicy{}, err
+	}
+
+	policy := domain.ScopePolicy{Rules: make([]domain.ScopeRule, 0, len(f.Rules))}
+	for i, r := range f.Rules {
+		if len(r.Scopes) == 0 {
+			return domain.ScopePolicy{}, fmt.Errorf("rule %d grants no scopes", i)
+		}
+		for _, scope := range r.Scopes {
+			if scope == "" || strings.ContainsAny(scope, " \t\r\n") {
+				return domain.ScopePolicy{}, fmt.Errorf("rule %d: invalid scope %q", i, scope)
+			}
+		}
+		policy.Rules = append(policy.Rules, domain.ScopeRule{
+			Provider:    r.Provider,
+			Tenant:      r.Tenant,
+			SubjectType: r.SubjectType,
+			Scopes:      r.Scopes,
+		})
+	}
+	return policy, nil
+}
@@ -0,0 +1,226 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+var sessionStart = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+const sessionRetention = 15 * time.Minute
+
+type sessionStoreCase struct {
+	name string
+	open func(t *testing.T) interfaces.SessionStore
+}
+
+var sessionStores = []sessionStoreCase{
+	{"memory", func(*testing.T) interfaces.SessionStore { return NewMemorySessionStore(sessionRetention) }},
+	{"file", func(t *testing.T) interfaces.SessionStore {
+		s, err := NewFileSessionStore(t.TempDir(), sessionRetention)
+		if err != nil {
+			t.Fatalf("NewFileSessionStore: %v", err)
+		}
+		t.Cleanup(func() { s.Close() })
+		return s
+	}},
+}
+
+func testSession(id, userID string, created time.Time, ttl time.Duration) domain.Session {
+	return domain.Session{
+		ID:             id,
+		PlatformUserID: userID,
+		SubjectType:    domain.SubjectTypePlayer,
+		Scopes:         []string{"profile:read"},
+		CreatedAt:      created,
+		ExpiresAt:      created.Add(ttl),
+	}
+}
+
+func createSessions(t *testing.T, s interfaces.SessionStore, sessions ...domain.Session) {
+	t.Helper()
+	for _, session := range sessions {
+		if err := s.Create(context.Background(), session); err != nil {
+			t.Fatalf("Create %s: %v", session.ID, err)
+		}
+	}
+}
+
+func TestSessionStoreRevoke(t *testing.T) {
+	tests := []struct {
+		name       string
+		revoke     []string
+		wantErr    error
+		wantReason string
+	}{
+		{name: "revoke", revoke: []string{"first"}, wantReason: "first"},
+		{name: "revoking again keeps the first revocation", revoke: []string{"first", "second"}, wantReason: "first"},
+	}
+	for _, store := range sessionStores {
+		for _, tt := range tests {
+			t.Run(store.name+"/"+tt.name, func(t *testing.T) {
+				ctx := context.Background()
+				s := store.open(t)
+				createSessions(t, s, testSession("s1", "user-1", sessionStart, time.Hour))
+
+				var (
+					session domain.Session
+					err     error
+				)
+				for i, reason := range tt.revoke {
+					session, err = s.Revoke(ctx, "s1", reason, sessionStart.Add(time.Duration(i+1)*time.Minute))
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Revoke error = %v, want %v", err, tt.wantErr)
+				}
+				if session.RevokeReason != tt.wantReason || !session.RevokedAt.Equal(sessionStart.Add(time.Minute)) {
+					t.Errorf("revoked session = %+v, want reason %q at the first revocation", session, tt.wantReason)
+				}
+			})
+		}
+	}
+}
+
+func TestSessionStoreNotFound(t *testing.T) {
+	for _, store := range sessionStores {
+		t.Run(store.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := store.open(t)
+			if _, err := s.Get(ctx, "unknown"); !errors.Is(err, domain.ErrSessionNotFound) {
+				t.Errorf("Get error = %v, want ErrSessionNotFound", err)
+			}
+			if err := s.Extend(ctx, "unknown", sessionStart); !errors.Is(err, domain.ErrSessionNotFound) {
+				t.Errorf("Extend error = %v, want ErrSessionNotFound", err)
+			}
+			if _, err := s.Revoke(ctx, "unknown", "test", sessionStart); !errors.Is(err, domain.ErrSessionNotFound) {
+				t.Errorf("Revoke error = %v, want ErrSessionNotFound", err)
+			}
+		})
+	}
+}
+
+func TestSessionStoreRevokeAllForUser(t *testing.T) {
+	for _, store := range sessionStores {
+		t.Run(store.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := store.open(t)
+			createSessions(t, s,
+				testSession("s1", "user-1", sessionStart, time.Hour),
+				testSession("s2", "user-1", sessionStart.Add(time.Second), time.Hour),
+				testSession("s3", "user-2", sessionStart, time.Hour),
+			)
+			if _, err := s.Revoke(ctx, "s1", "earlier", sessionStart.Add(time.Minute)); err != nil {
+				t.Fatalf("Revoke: %v", err)
+			}
+
+			revoked, err := s.RevokeAllForUser(ctx, "user-1", "all", sessionStart.Add(2*time.Minute))
+			if err != nil {
+				t.Fatalf("RevokeAllForUser: %v", err)
+			}
+			if len(revoked) != 1 || revoked[0].ID != "s2" {
+				t.Fatalf("RevokeAllForUser returned %+v, want only s2", revoked)
+			}
+
+			since, err := s.RevokedSince(ctx, sessionStart)
+			if err != nil {
+				t.Fatalf("RevokedSince: %v", err)
+			}
+			var ids []string
+			for _, session := range since {
+				ids = append(ids, session.ID)
+			}
+			if len(ids) != 2 || ids[0] != "s1" || ids[1] != "s2" {
+				t.Errorf("RevokedSince = %v, want [s1 s2] in revocation order", ids)
+			}
+			if other, _ := s.Get(ctx, "s3"); !other.RevokedAt.IsZero() {
+				t.Error("another user's session was revoked")
+			}
+		})
+	}
+}
+
+func TestMemorySessionStoreSweep(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		wantFound bool
+	}{
+		{name: "active", ttl: 2 * time.Hour, wantFound: true},
+		{name: "expired within the retention window", ttl: time.Hour - sessionRetention/2, wantFound: true},
+		{name: "expired before the retention window", ttl: time.Hour - 2*sessionRetention},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := NewMemorySessionStore(sessionRetention)
+			now := sessionStart
+			s.now = func() time.Time { return now }
+			createSessions(t, s, testSession("s1", "user-1", sessionStart, tt.ttl))
+
+			// The next create after the sweep interval drops long expired
+			// sessions.
+			now = sessionStart.Add(time.Hour)
+			createSessions(t, s, testSession("s2", "user-2", now, time.Hour))
+
+			_, err := s.Get(ctx, "s1")
+			if found := err == nil; found != tt.wantFound {
+				t.Fatalf("session found = %v (%v), want %v", found, err, tt.wantFound)
+			}
+			listed, _ := s.ListByUser(ctx, "user-1")
+			if got := len(listed) == 1; got != tt.wantFound {
+				t.Errorf("ListByUser = %+v, want listed %v", listed, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestFileSessionStoreReopen(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := NewFileSessionStore(dir, sessionRetention)
+	if err != nil {
+		t.Fatalf("NewFileSessionStore: %v", err)
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	createSessions(t, s,
+		testSession("revoked", "user-1", now, time.Hour),
+		testSession("extended", "user-1", now, time.Hour),
+		testSession("stale", "user-2", now.Add(-2*time.Hour), time.Hour),
+	)
+	if _, err := s.Revoke(ctx, "revoked", "logout", now); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if err := s.Extend(ctx, "extended", now.Add(3*time.Hour)); err != nil {
+		t.Fatalf("Extend: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened, err := NewFileSessionStore(dir, sessionRetention)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+
+	revoked, err := reopened.Get(ctx, "revoked")
+	if err != nil || revoked.RevokeReason != "logout" || !revoked.RevokedAt.Equal(now) {
+		t.Errorf("revoked session after reopen = %+v, %v", revoked, err)
+	}
+	extended, err := reopened.Get(ctx, "extended")
+	if err != nil || !extended.ExpiresAt.Equal(now.Add(3*time.Hour)) {
+		t.Errorf("extended session after reopen = %+v, %v", extended, err)
+	}
+	if _, err := reopened.Get(ctx, "stale"); !errors.Is(err, domain.ErrSessionNotFound) {
+		t.Errorf("long expired session kept across reopen: %v", err)
+	}
+	listed, _ := reopened.RevokedSince(ctx, now.Add(-time.Minute))
+	if len(listed) != 1 || listed[0].ID != "revoked" {
+		t.Errorf("RevokedSince after reopen = %+v, want the revoked session", listed)
+	}
+}
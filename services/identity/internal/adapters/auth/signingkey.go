@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
+)
+
+var ErrUnsupportedKey = errors.New("unsupported signing key")
+
+// rsaKeyBits is the size of generated RS256 keys.
+const rsaKeyBits = 3072
+
+// SigningKey is an Ed25519 (EdDSA), RSA (RS256) or P-256 (ES256) private
+// key together with its key ID and algorithm. The kid is the RFC 7638 JWK
+// thumbprint of the public key, so it is stable across restarts and
+// identical on every replica that loads the same key.
+type SigningKey struct {
+	Kid        string
+	Algorithm  string
+	PrivateKey crypto.Signer
+}
+
+// NewSigningKey wraps a private key and derives its algorithm and kid. RSA
+// keys must have at least jwtverifier.MinRSAKeyBits, EC keys must be on
+// P-256.
+func NewSigningKey(priv crypto.Signer) (SigningKey, error) {
+	var alg string
+	switch k := priv.(type) {
+	case ed25519.PrivateKey:
+		alg = jwtverifier.AlgEdDSA
+	case *rsa.PrivateKey:
+		alg = jwtverifier.AlgRS256
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return SigningKey{}, fmt.Errorf("%w: EC key on %s, want P-256", ErrUnsupportedKey, k.Curve.Params().Name)
+		}
+		alg = jwtverifier.AlgES256
+	default:
+		return SigningKey{}, fmt.Errorf("%w: %T", ErrUnsupportedKey, priv)
+	}
+	if err := (jwtverifier.Key{Algorithm: alg, PublicKey: priv.Public()}).Validate(); err != nil {
+		return SigningKey{}, fmt.Errorf("%w: %v", ErrUnsupportedKey, err)
+	}
+	return SigningKey{
+		Kid:        Thumbprint(priv.Public()),
+		Algorithm:  alg,
+		PrivateKey: priv,
+	}, nil
+}
+
+// PublicKey returns the public half of the key.
+func (k SigningKey) PublicKey() crypto.PublicKey {
+	return k.PrivateKey.Public()
+}
+
+// GenerateSigningKey creates a fresh key for alg (EdDSA, RS256 or ES256).
+// Only meant for ENV=dev or a persisted key ring: an unpersisted key does
+// not survive a restart.
+func GenerateSigningKey(alg string) (SigningKey, error) {
+	var (
+		priv crypto.Signer
+		err  error
+	)
+	switch alg {
+	case jwtverifier.AlgEdDSA:
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+	case jwtverifier.AlgRS256:
+		priv, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case jwtverifier.AlgES256:
+		priv, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return SigningKey{}, fmt.Errorf("%w: algorithm %q", ErrUnsupportedKey, alg)
+	}
+	if err != nil {
+		return SigningKey{}, err
+	}
+	return NewSigningKey(priv)
+}
+
+// LoadSigningKeyFile reads a PEM (PKCS#8) encoded Ed25519, RSA or P-256
+// private key or a JWK encoded Ed25519 private key.
+func LoadSigningKeyFile(path string) (SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("read signing key %s: %w", path, err)
+	}
+	key, err := ParseSigningKey(data)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("parse signing key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// ParseSigningKey parses a PEM (PKCS#8) encoded Ed25519, RSA or P-256
+// private key or a JWK encoded Ed25519 private key. The format is detected
+// from the content.
+func ParseSigningKey(data []byte) (SigningKey, error) {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(data, []byte("-----BEGIN")):
+		return parsePEMSigningKey(data)
+	case bytes.HasPrefix(data, []byte("{")):
+		return parseJWKSigningKey(data)
+	default:
+		return SigningKey{}, fmt.Errorf("%w: expected PEM or JWK", ErrUnsupportedKey)
+	}
+}
+
+func parsePEMSigningKey(data []byte) (SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return SigningKey{}, fmt.Errorf("%w: invalid PEM", ErrUnsupportedKey)
+	}
+	if block.Type != "PRIVATE KEY" {
+		return SigningKey{}, fmt.Errorf("%w: PEM type %q, want PRIVATE KEY", ErrUnsupportedKey, block.Type)
+	}
+	return parsePKCS8SigningKey(block.Bytes)
+}
+
+func parsePKCS8SigningKey(der []byte) (SigningKey, error) {
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("%w: %v", ErrUnsupportedKey, err)
+	}
+	priv, ok := parsed.(crypto.Signer)
+	if !ok {
+		return SigningKey{}, fmt.Errorf("%w: %T", ErrUnsupportedKey, parsed)
+	}
+	return NewSigningKey(priv)
+}
+
+type privateJWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	D   string `json:"d"`
+}
+
+func parseJWKSigningKey(data []byte) (SigningKey, error) {
+	var jwk privateJWK
+	if err := json.Unmarshal(data, &jwk); err != nil {
+		return SigningKey{}, fmt.Errorf("%w: %v", ErrUnsupportedKey, err)
+	}
+	if jwk.Kty != "OKP" || jwk.Crv != "Ed25519" {
+		return SigningKey{}, fmt.Errorf("%w: JWK kty=%q crv=%q, want OKP/Ed25519", ErrUnsupportedKey, jwk.Kty, jwk.Crv)
+	}
+	seed, err := base64.RawURLEncoding.DecodeString(jwk.D)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return SigningKey{}, fmt.Errorf("%w: invalid JWK private key", ErrUnsupportedKey)
+	}
+	key, err := NewSigningKey(ed25519.NewKeyFromSeed(seed))
+	if err != nil {
+		return SigningKey{}, err
+	}
+	if jwk.X != "" && jwk.X != base64.RawURLEncoding.EncodeToString(key.PublicKey().(ed25519.PublicKey)) {
+		return SigningKey{}, fmt.Errorf("%w: JWK public key does not match private key", ErrUnsupportedKey)
+	}
+	return key, nil
+}
+
+// Thumbprint returns the RFC 7638 JWK thumbprint (SHA-256, base64url) of an
+// Ed25519, RSA or P-256 public key; empty for other keys.
+func Thumbprint(pub crypto.PublicKey) string {
+	// Required members in lexicographic order, no whitespace (RFC 7638 §3.2).
+	var canonical string
+	switch k := pub.(type) {
+	case ed25519.PublicKey:
+		canonical = `{"crv":"Ed25519","kty":"OKP","x":"` + b64(k) + `"}`
+	case *rsa.PublicKey:
+		canonical = `{"e":"` + b64(big.NewInt(int64(k.E)).Bytes()) + `","kty":"RSA","n":"` + b64(k.N.Bytes()) + `"}`
+	case *ecdsa.PublicKey:
+		x, y := ecCoordinates(k)
+		canonical = `{"crv":"P-256","kty":"EC","x":"` + b64(x) + `","y":"` + b64(y) + `"}`
+	default:
+		return ""
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	return b64(sum[:])
+}
+
+// ecCoordinates returns the fixed-size big-endian x and y coordinates of a
+// P-256 public key, as JWKs carry them (RFC 7518 §6.2.1.2).
+func ecCoordinates(pub *ecdsa.PublicKey) (x, y []byte) {
+	point, err := pub.Bytes()
+	if err != nil {
+		return nil, nil
+	}
+	size := (len(point) - 1) / 2
+	return point[1 : 1+size], point[1+size:]
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// StaticClientAuthenticator authenticates clients against a fixed set of
+// client ID / secret pairs from configuration. Only secret hashes are kept
+// in memory.
+type StaticClientAuthenticator struct {
+	secrets map[string][sha256.Size]byte
+}
+
+// NewStaticClientAuthenticator creates an authenticator for the given
+// client ID -> secret map.
+func NewStaticClientAuthenticator(clients map[string]string) *StaticClientAuthenticator {
+	secrets := make(map[string][sha256.Size]byte, len(clients))
+	for id, secret := range clients {
+		secrets[id] = sha256.Sum256([]byte(secret))
+	}
+	return &StaticClientAuthenticator{secrets: secrets}
+}
+
+// Authenticate implements interfaces.ClientAuthenticator.
+func (a *StaticClientAuthenticator) Authenticate(_ context.Context, clientID, clientSecret string) error {
+	want, ok := a.secrets[clientID]
+	if !ok || clientSecret == "" {
+		return domain.ErrInvalidClient
+	}
+	got := sha256.Sum256([]byte(clientSecret))
+	if subtle.ConstantTimeCompare(got[:], want[:]) != 1 {
+		return domain.ErrInvalidClient
+	}
+	return nil
+}
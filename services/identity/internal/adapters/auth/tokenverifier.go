@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// TokenVerifier verifies access tokens against the published keys of the
+// key ring using the shared gateway verifier. The audience is not checked
+// because identity issues tokens for several audiences.
+type TokenVerifier struct {
+	verifier *jwtverifier.Verifier
+}
+
+// NewTokenVerifier creates a verifier for tokens issued by issuer and
+// signed with one of algs.
+func NewTokenVerifier(issuer string, keys *KeyRing, algs []string, leeway time.Duration) *TokenVerifier {
+	return &TokenVerifier{
+		verifier: jwtverifier.New(jwtverifier.Config{
+			Issuer:     issuer,
+			KeySource:  keys,
+			Algorithms: algs,
+			Leeway:     leeway,
+		}),
+	}
+}
+
+// Verify implements interfaces.TokenVerifier.
+func (v *TokenVerifier) Verify(_ context.Context, token string) (domain.TokenClaims, error) {
+	claims, err := v.verifier.Verify(token)
+	if err != nil {
+		return domain.TokenClaims{}, err
+	}
+	return domain.TokenClaims{
+		Subject:     claims.Subject,
+		Tenant:      claims.Tenant,
+		SubjectType: claims.SubjectType,
+		Audience:    claims.Audience,
+		Scopes:      claims.Scopes,
+		SessionID:   claims.SessionID,
+		Actor:       toActor(claims.Actor),
+		ExpiresAt:   claims.ExpiresAt,
+		IssuedAt:    claims.IssuedAt,
+	}, nil
+}
+
+func toActor(a *jwtverifier.Actor) *domain.Actor {
+	if a == nil {
+		return nil
+	}
+	return &domain.Actor{Subject: a.Subject, Actor: toActor(a.Actor)}
+}
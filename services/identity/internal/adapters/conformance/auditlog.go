@@ -0,0 +1,153 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// AuditHarness opens an audit log adapter for the checks.
+type AuditHarness struct {
+	Name string
+	// Open returns the log and a func releasing it. Checks use their own
+	// platform user IDs, so the log does not have to be empty.
+	Open func() (interfaces.AuditLog, func() error, error)
+	// Durable requires entries and replaced details to survive releasing
+	// and reopening the log.
+	Durable bool
+}
+
+type auditCheck struct {
+	name string
+	run  func(ctx context.Context, log interfaces.AuditLog, platformUserID string) error
+}
+
+var auditChecks = []auditCheck{
+	{"appended entries read back oldest first", checkAuditAppend},
+	{"replace details rewrites only the named entries", checkAuditReplaceDetails},
+	{"replace details ignores entries of other users", checkAuditReplaceOtherUser},
+}
+
+// RunAuditLog runs every check against the harness.
+func RunAuditLog(ctx context.Context, h AuditHarness) []Result {
+	log, release, err := h.Open()
+	if err != nil {
+		return []Result{{Check: "open", Err: err}}
+	}
+
+	results := make([]Result, 0, len(auditChecks)+2)
+	for _, c := range auditChecks {
+		results = append(results, Result{Check: c.name, Err: c.run(ctx, log, uuid.NewString())})
+	}
+
+	if !h.Durable {
+		return append(results, Result{Check: "release", Err: release()})
+	}
+	userID := uuid.NewString()
+	entries := []domain.AuditEntry{newAuditEntry(userID, 0), newAuditEntry(userID, 1)}
+	for _, entry := range entries {
+		if err == nil {
+			err = log.Append(ctx, entry)
+		}
+	}
+	if err == nil {
+		entries[0].Details = map[string]string{"note": domain.AuditRedacted}
+		err = log.ReplaceDetails(ctx, userID, map[string]map[string]string{entries[0].ID: entries[0].Details})
+	}
+	if err == nil {
+		err = release()
+	}
+	if err == nil {
+		err = checkAuditReopen(ctx, h, userID, entries)
+	}
+	return append(results, Result{Check: "entries survive reopening", Err: err})
+}
+
+func checkAuditAppend(ctx context.Context, log interfaces.AuditLog, platformUserID string) error {
+	entries := []domain.AuditEntry{newAuditEntry(platformUserID, 0), newAuditEntry(platformUserID, 1)}
+	for _, entry := range entries {
+		if err := log.Append(ctx, entry); err != nil {
+			return err
+		}
+	}
+	return sameAuditEntries(ctx, log, platformUserID, entries)
+}
+
+func checkAuditReplaceDetails(ctx context.Context, log interfaces.AuditLog, platformUserID string) error {
+	entries := []domain.AuditEntry{newAuditEntry(platformUserID, 0), newAuditEntry(platformUserID, 1)}
+	for _, entry := range entries {
+		if err := log.Append(ctx, entry); err != nil {
+			return err
+		}
+	}
+	entries[1].Details = map[string]string{"state": "banned", "note": domain.AuditRedacted}
+	err := log.ReplaceDetails(ctx, platformUserID, map[string]map[string]string{
+		entries[1].ID:    entries[1].Details,
+		uuid.NewString(): {"note": "unknown entry"},
+	})
+	if err != nil {
+		return err
+	}
+	return sameAuditEntries(ctx, log, platformUserID, entries)
+}
+
+func checkAuditReplaceOtherUser(ctx context.Context, log interfaces.AuditLog, platformUserID string) error {
+	entry := newAuditEntry(platformUserID, 0)
+	if err := log.Append(ctx, entry); err != nil {
+		return err
+	}
+	err := log.ReplaceDetails(ctx, uuid.NewString(), map[string]map[string]string{
+		entry.ID: {"note": domain.AuditRedacted},
+	})
+	if err != nil {
+		return err
+	}
+	return sameAuditEntries(ctx, log, platformUserID, []domain.AuditEntry{entry})
+}
+
+func checkAuditReopen(ctx context.Context, h AuditHarness, platformUserID string, entries []domain.AuditEntry) error {
+	log, release, err := h.Open()
+	if err != nil {
+		return fmt.Errorf("reopen: %w", err)
+	}
+	err = sameAuditEntries(ctx, log, platformUserID, entries)
+	return errors.Join(err, release())
+}
+
+// newAuditEntry returns the i-th status change of a user, with a free-text
+// note.
+func newAuditEntry(platformUserID string, i int) domain.AuditEntry {
+	return domain.AuditEntry{
+		ID:             uuid.NewString(),
+		PlatformUserID: platformUserID,
+		Action:         domain.AuditIdentityStatusChanged,
+		Actor:          "conformance",
+		Details:        map[string]string{"state": "banned", "note": fmt.Sprintf("note %d", i)},
+		At:             storeNow().Add(time.Duration(i) * time.Second),
+	}
+}
+
+func sameAuditEntries(ctx context.Context, log interfaces.AuditLog, platformUserID string, want []domain.AuditEntry) error {
+	got, err := log.ListByUser(ctx, platformUserID)
+	if err != nil {
+		return err
+	}
+	if len(got) != len(want) {
+		return fmt.Errorf("listed %d entries, want %d", len(got), len(want))
+	}
+	for i := range got {
+		g, w := got[i], want[i]
+		if g.ID != w.ID || g.PlatformUserID != w.PlatformUserID || g.Action != w.Action || g.Actor != w.Actor ||
+			!g.At.Equal(w.At) || !maps.Equal(g.Details, w.Details) {
+			return fmt.Errorf("entry %d is %+v, want %+v", i, g, w)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package conformance_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/auth"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/conformance"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/postgres"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+)
+
+// sessionRetention is how long the session stores under test keep expired
+// sessions.
+const sessionRetention = time.Hour
+
+// postgresDSN returns the database the Postgres store is checked against,
+// or skips the test without DB_DSN. The checks only add rows under unique
+// conformance-* providers and IDs.
+func postgresDSN(t *testing.T) string {
+	t.Helper()
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		t.Skip("DB_DSN not set")
+	}
+	return dsn
+}
+
+func runIdentityStore(t *testing.T, h conformance.IdentityHarness) {
+	t.Helper()
+	for _, r := range conformance.RunIdentityStore(context.Background(), h) {
+		t.Run(r.Check, func(t *testing.T) {
+			if r.Err != nil {
+				t.Error(r.Err)
+			}
+		})
+	}
+}
+
+func runSessionStores(t *testing.T, h conformance.SessionHarness) {
+	t.Helper()
+	for _, r := range conformance.RunSessionStores(context.Background(), h) {
+		t.Run(r.Check, func(t *testing.T) {
+			if r.Err != nil {
+				t.Error(r.Err)
+			}
+		})
+	}
+}
+
+func runProviderStore(t *testing.T, h conformance.ProviderHarness) {
+	t.Helper()
+	for _, r := range conformance.RunProviderStore(context.Background(), h) {
+		t.Run(r.Check, func(t *testing.T) {
+			if r.Err != nil {
+				t.Error(r.Err)
+			}
+		})
+	}
+}
+
+func runAuditLog(t *testing.T, h conformance.AuditHarness) {
+	t.Helper()
+	for _, r := range conformance.RunAuditLog(context.Background(), h) {
+		t.Run(r.Check, func(t *testing.T) {
+			if r.Err != nil {
+				t.Error(r.Err)
+			}
+		})
+	}
+}
+
+func TestMemoryIdentityStore(t *testing.T) {
+	runIdentityStore(t, conformance.IdentityHarness{
+		Name: "memory",
+		Open: func() (conformance.IdentityStore, func() error, error) {
+			return auth.NewMemoryIdentityStore(uuid.NewString), func() error { return nil }, nil
+		},
+	})
+}
+
+func TestFileIdentityStore(t *testing.T) {
+	tests := []struct {
+		name string
+		opts auth.FileIdentityStoreOptions
+	}{
+		{name: "fsync always", opts: auth.FileIdentityStoreOptions{Fsync: auth.FsyncAlways, SnapshotEvery: 4}},
+		{name: "fsync interval", opts: auth.FileIdentityStoreOptions{Fsync: auth.FsyncInterval, FsyncInterval: 10 * time.Millisecond}},
+		{name: "fsync never", opts: auth.FileIdentityStoreOptions{Fsync: auth.FsyncNever}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			runIdentityStore(t, conformance.IdentityHarness{
+				Name:    "file",
+				Durable: true,
+				Open: func() (conformance.IdentityStore, func() error, error) {
+					store, err := auth.NewFileIdentityStore(dir, uuid.NewString, tt.opts)
+					if err != nil {
+						return nil, nil, err
+					}
+					return store, store.Close, nil
+				},
+			})
+		})
+	}
+}
+
+func TestPostgresIdentityStore(t *testing.T) {
+	dsn := postgresDSN(t)
+	ctx := context.Background()
+	runIdentityStore(t, conformance.IdentityHarness{
+		Name:    "postgres",
+		Durable: true,
+		Open: func() (conformance.IdentityStore, func() error, error) {
+			pool, err := postgres.Open(ctx, dsn)
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := postgres.Migrate(ctx, pool); err != nil {
+				pool.Close()
+				return nil, nil, err
+			}
+			return postgres.NewIdentityStore(pool, uuid.NewString), func() error { pool.Close(); return nil }, nil
+		},
+	})
+}
+
+func TestMemorySessionStores(t *testing.T) {
+	runSessionStores(t, conformance.SessionHarness{
+		Name:      "memory",
+		Retention: sessionRetention,
+		Open: func() (interfaces.SessionStore, interfaces.RefreshTokenStore, func() error, error) {
+			return auth.NewMemorySessionStore(sessionRetention), auth.NewMemoryRefreshTokenStore(), func() error { return nil }, nil
+		},
+	})
+}
+
+func TestFileSessionStores(t *testing.T) {
+	dir := t.TempDir()
+	runSessionStores(t, conformance.SessionHarness{
+		Name:      "file",
+		Retention: sessionRetention,
+		Durable:   true,
+		Open: func() (interfaces.SessionStore, interfaces.RefreshTokenStore, func() error, error) {
+			sessions, err := auth.NewFileSessionStore(dir, sessionRetention)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			tokens, err := auth.NewFileRefreshTokenStore(dir)
+			if err != nil {
+				sessions.Close()
+				return nil, nil, nil, err
+			}
+			return sessions, tokens, func() error { return errors.Join(sessions.Close(), tokens.Close()) }, nil
+		},
+	})
+}
+
+func TestPostgresSessionStores(t *testing.T) {
+	dsn := postgresDSN(t)
+	ctx := context.Background()
+	runSessionStores(t, conformance.SessionHarness{
+		Name:      "postgres",
+		Retention: sessionRetention,
+		Durable:   true,
+		Open: func() (interfaces.SessionStore, interfaces.RefreshTokenStore, func() error, error) {
+			pool, err := postgres.Open(ctx, dsn)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if err := postgres.Migrate(ctx, pool); err != nil {
+				pool.Close()
+				return nil, nil, nil, err
+			}
+			release := func() error { pool.Close(); return nil }
+			return postgres.NewSessionStore(pool, sessionRetention), postgres.NewRefreshTokenStore(pool), release, nil
+		},
+	})
+}
+
+func TestMemoryProviderStore(t *testing.T) {
+	runProviderStore(t, conformance.ProviderHarness{
+		Name: "memory",
+		Open: func() (interfaces.ProviderStore, func() error, error) {
+			return auth.NewMemoryProviderStore(), func() error { return nil }, nil
+		},
+	})
+}
+
+func TestFileProviderStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "providers.json")
+	runProviderStore(t, conformance.ProviderHarness{
+		Name:    "file",
+		Durable: true,
+		Open: func() (interfaces.ProviderStore, func() error, error) {
+			store, err := auth.NewFileProviderStore(path)
+			if err != nil {
+				return nil, nil, err
+			}
+			return store, func() error { return nil }, nil
+		},
+	})
+}
+
+func TestPostgresProviderStore(t *testing.T) {
+	dsn := postgresDSN(t)
+	ctx := context.Background()
+	runProviderStore(t, conformance.ProviderHarness{
+		Name:    "postgres",
+		Durable: true,
+		Open: func() (interfaces.ProviderStore, func() error, error) {
+			pool, err := postgres.Open(ctx, dsn)
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := postgres.Migrate(ctx, pool); err != nil {
+				pool.Close()
+				return nil, nil, err
+			}
+			return postgres.NewProviderStore(pool), func() error { pool.Close(); return nil }, nil
+		},
+	})
+}
+
+func TestMemoryAuditLog(t *testing.T) {
+	runAuditLog(t, conformance.AuditHarness{
+		Name: "memory",
+		Open: func() (interfaces.AuditLog, func() error, error) {
+			return auth.NewMemoryAuditLog(), func() error { return nil }, nil
+		},
+	})
+}
+
+func TestFileAuditLog(t *testing.T) {
+	dir := t.TempDir()
+	runAuditLog(t, conformance.AuditHarness{
+		Name:    "file",
+		Durable: true,
+		Open: func() (interfaces.AuditLog, func() error, error) {
+			l, err := auth.NewFileAuditLog(dir)
+			if err != nil {
+				return nil, nil, err
+			}
+			return l, l.Close, nil
+		},
+	})
+}
+
+func TestPostgresAuditLog(t *testing.T) {
+	dsn := postgresDSN(t)
+	ctx := context.Background()
+	runAuditLog(t, conformance.AuditHarness{
+		Name:    "postgres",
+		Durable: true,
+		Open: func() (interfaces.AuditLog, func() error, error) {
+			pool, err := postgres.Open(ctx, dsn)
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := postgres.Migrate(ctx, pool); err != nil {
+				pool.Close()
+				return nil, nil, err
+			}
+			return postgres.NewAuditLog(pool), func() error { pool.Close(); return nil }, nil
+		},
+	})
+}
@@ -0,0 +1,1399 @@
+// Package conformance holds the contract checks every identity store
+// adapter must pass. cmd/identity-storecheck runs them against each
+// adapter; a new adapter gets a harness there.
+package conformance
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// IdentityStore is the port set under test.
+type IdentityStore interface {
+	interfaces.IdentityResolver
+	interfaces.IdentityLinker
+	interfaces.IdentityMerger
+	interfaces.IdentityEraser
+	interfaces.IdentityProfiles
+	interfaces.IdentityStatuses
+	interfaces.IdentityLookup
+	interfaces.IdentityQuery
+	interfaces.Outbox
+}
+
+// IdentityHarness opens an identity store adapter for the checks.
+type IdentityHarness struct {
+	Name string
+	// Open returns the store and a func releasing it. Checks use their own
+	// provider names, so the store does not have to be empty.
+	Open func() (IdentityStore, func() error, error)
+	// Durable requires identities to survive releasing and reopening the
+	// store.
+	Durable bool
+}
+
+// Result is the outcome of one check; Err is nil when it passed.
+type Result struct {
+	Check string
+	Err   error
+}
+
+type identityCheck struct {
+	name string
+	run  func(ctx context.Context, store IdentityStore, provider string) error
+}
+
+var identityChecks = []identityCheck{
+	{"resolve creates an identity", checkResolveCreates},
+	{"resolve returns the existing identity", checkResolveIdempotent},
+	{"linkage is keyed by provider and external user", checkLinkageKey},
+	{"lookup returns the resolved identity", checkLookup},
+	{"lookup of an unknown id is not found", checkLookupUnknown},
+	{"lookup by linkage returns the linked identity", checkLookupByLinkage},
+	{"lookup of an unlinked external identity creates nothing", checkLookupByLinkageUnknown},
+	{"concurrent resolves converge on one identity", checkConcurrentResolve},
+	{"linked identities resolve to the same user", checkLink},
+	{"linking an existing linkage is a no-op", checkLinkIdempotent},
+	{"a linkage belongs to one user only", checkLinkConflict},
+	{"link to an unknown user is not found", checkLinkUnknownUser},
+	{"unlink removes the linkage", checkUnlink},
+	{"unlink of a missing linkage is not found", checkUnlinkMissing},
+	{"the last linkage cannot be unlinked", checkUnlinkLast},
+	{"concurrent links of one external identity pick one user", checkConcurrentLink},
+	{"merge moves linkages and redirects the merged id", checkMerge},
+	{"a merged identity cannot be merged or linked", checkMergeMerged},
+	{"merging a survivor redirects its merged ids", checkMergeChain},
+	{"erase removes the identity and its linkages", checkErase},
+	{"erase takes merged ids along and can be repeated", checkEraseMerged},
+	{"profile updates respect source and time", checkProfile},
+	{"profile updates of merged and erased ids", checkProfileMergedErased},
+	{"a survivor adopts the profile fields it lacks", checkProfileMerge},
+	{"status changes are stored", checkStatus},
+	{"status of unknown, merged and erased ids", checkStatusMergedErased},
+	{"moved linkages take the survivor's status", checkStatusMerge},
+	{"list pages through identities in a stable order", checkListPages},
+	{"list filters by tenant, external id prefix and creation time", checkListFilters},
+	{"list omits merged identities", checkListMerged},
+	{"changes add their events to the outbox", checkOutboxEvents},
+	{"erase replaces the pending events of the erased ids", checkOutboxErase},
+	{"added events stay pending in order until published", checkOutboxAdd},
+}
+
+// RunIdentityStore runs every check against the harness.
+func RunIdentityStore(ctx context.Context, h IdentityHarness) []Result {
+	store, release, err := h.Open()
+	if err != nil {
+		return []Result{{Check: "open", Err: err}}
+	}
+
+	results := make([]Result, 0, len(identityChecks)+2)
+	for _, c := range identityChecks {
+		results = append(results, Result{Check: c.name, Err: c.run(ctx, store, newProvider())})
+	}
+
+	if !h.Durable {
+		return append(results, Result{Check: "release", Err: release()})
+	}
+	provider := newProvider()
+	created, err := store.Resolve(ctx, provider, "durable", "t1")
+	if err == nil {
+		created, err = store.Link(ctx, created.PlatformUserID, newLinkage(provider+"-linked", "durable"))
+	}
+	var merged domain.PlatformIdentity
+	if err == nil {
+		merged, err = store.Resolve(ctx, provider, "durable-merged", "t1")
+	}
+	if err == nil {
+		created, err = store.Merge(ctx, created.PlatformUserID, merged.PlatformUserID, time.Now())
+	}
+	if err == nil {
+		created, err = store.UpdateProfile(ctx, created.PlatformUserID, profileUpdate(domain.ProfileSourceAPI, time.Now(), map[domain.ProfileField]string{
+			domain.ProfileDisplayName: "Durable",
+			domain.ProfileBirthYear:   "1990",
+		}))
+	}
+	if err == nil {
+		created, err = store.SetStatus(ctx, created.PlatformUserID, banned(domain.ReasonFraud))
+	}
+	var erased domain.PlatformIdentity
+	if err == nil {
+		erased, err = store.Resolve(ctx, provider, "durable-erased", "t1")
+	}
+	if err == nil {
+		_, err = store.Erase(ctx, erased.PlatformUserID, time.Now())
+	}
+	published, pending := tokenIssued(created.PlatformUserID), tokenIssued(created.PlatformUserID)
+	for _, event := range []domain.Event{published, pending} {
+		if err == nil {
+			err = store.Add(ctx, event)
+		}
+	}
+	if err == nil {
+		err = store.MarkPublished(ctx, []string{published.ID})
+	}
+	if err == nil {
+		err = release()
+	}
+	if err == nil {
+		err = checkReopen(ctx, h, created, merged.PlatformUserID, erased, pending.ID)
+	}
+	return append(results, Result{Check: "identities survive reopening", Err: err})
+}
+
+func checkResolveCreates(ctx context.Context, store IdentityStore, provider string) error {
+	before := time.Now().Add(-time.Second)
+	identity, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	if identity.PlatformUserID == "" {
+		return errors.New("empty platform user id")
+	}
+	if len(identity.Linkages) != 1 {
+		return fmt.Errorf("got %d linkages, want 1", len(identity.Linkages))
+	}
+	l := identity.Primary()
+	if l.Provider != provider || l.ExternalUserID != "u1" || l.Tenant != "t1" || identity.Tenant != "t1" {
+		return fmt.Errorf("got linkage %s/%s tenant %q, want %s/u1 tenant t1",
+			l.Provider, l.ExternalUserID, identity.Tenant, provider)
+	}
+	if !l.LinkedAt.Equal(identity.CreatedAt) {
+		return fmt.Errorf("linked_at %s, want created_at %s", l.LinkedAt, identity.CreatedAt)
+	}
+	if identity.CreatedAt.Before(before) || identity.CreatedAt.After(time.Now().Add(time.Second)) {
+		return fmt.Errorf("created_at %s is not the time of creation", identity.CreatedAt)
+	}
+	return nil
+}
+
+func checkResolveIdempotent(ctx context.Context, store IdentityStore, provider string) error {
+	first, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	again, err := store.Resolve(ctx, provider, "u1", "t2")
+	if err != nil {
+		return err
+	}
+	if again.PlatformUserID != first.PlatformUserID {
+		return fmt.Errorf("second resolve returned %s, want %s", again.PlatformUserID, first.PlatformUserID)
+	}
+	if again.Tenant != "t1" || !again.CreatedAt.Equal(first.CreatedAt) {
+		return errors.New("second resolve changed the existing identity")
+	}
+	return nil
+}
+
+func checkLinkageKey(ctx context.Context, store IdentityStore, provider string) error {
+	a, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	b, err := store.Resolve(ctx, provider, "u2", "t1")
+	if err != nil {
+		return err
+	}
+	c, err := store.Resolve(ctx, provider+"-other", "u1", "t1")
+	if err != nil {
+		return err
+	}
+	if a.PlatformUserID == b.PlatformUserID || a.PlatformUserID == c.PlatformUserID || b.PlatformUserID == c.PlatformUserID {
+		return errors.New("distinct external identities share a platform user id")
+	}
+	return nil
+}
+
+func checkLookup(ctx context.Context, store IdentityStore, provider string) error {
+	created, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	found, err := store.GetByPlatformUserID(ctx, created.PlatformUserID)
+	if err != nil {
+		return err
+	}
+	return sameIdentity(found, created)
+}
+
+func checkLookupUnknown(ctx context.Context, store IdentityStore, _ string) error {
+	for _, id := range []string{uuid.NewString(), "not-a-platform-user-id"} {
+		if _, err := store.GetByPlatformUserID(ctx, id); !errors.Is(err, domain.ErrIdentityNotFound) {
+			return fmt.Errorf("lookup of %q: got %v, want %v", id, err, domain.ErrIdentityNotFound)
+		}
+	}
+	return nil
+}
+
+func checkLookupByLinkage(ctx context.Context, store IdentityStore, provider string) error {
+	identity, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	if identity, err = store.Link(ctx, identity.PlatformUserID, newLinkage(provider+"-linked", "u1")); err != nil {
+		return err
+	}
+	for _, l := range identity.Linkages {
+		found, err := store.GetByLinkage(ctx, l.Provider, l.ExternalUserID)
+		if err != nil {
+			return fmt.Errorf("lookup of %s/%s: %w", l.Provider, l.ExternalUserID, err)
+		}
+		if err := sameIdentity(found, identity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkLookupByLinkageUnknown(ctx context.Context, store IdentityStore, provider string) error {
+	if _, err := store.GetByLinkage(ctx, provider, "u1"); !errors.Is(err, domain.ErrIdentityNotFound) {
+		return fmt.Errorf("got %v, want %v", err, domain.ErrIdentityNotFound)
+	}
+	page, err := store.List(ctx, domain.IdentityFilter{Provider: provider}, nil, 1)
+	if err != nil {
+		return err
+	}
+	if len(page.Identities) != 0 {
+		return fmt.Errorf("lookup created %+v", page.Identities)
+	}
+	return nil
+}
+
+func checkConcurrentResolve(ctx context.Context, store IdentityStore, provider string) error {
+	const workers = 32
+
+	ids := make([]string, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			identity, err := store.Resolve(ctx, provider, "contended", "t1")
+			ids[i], errs[i] = identity.PlatformUserID, err
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	for _, id := range ids[1:] {
+		if id != ids[0] {
+			return fmt.Errorf("concurrent resolves returned %s and %s", ids[0], id)
+		}
+	}
+	return nil
+}
+
+func checkLink(ctx context.Context, store IdentityStore, provider string) error {
+	created, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	linked, err := store.Link(ctx, created.PlatformUserID, newLinkage(provider+"-other", "u9"))
+	if err != nil {
+		return err
+	}
+	if len(linked.Linkages) != 2 || linked.Primary() != created.Primary() {
+		return fmt.Errorf("after link got linkages %+v, want the created one and the new one", linked.Linkages)
+	}
+	if _, ok := linked.Linkage(provider+"-other", "u9"); !ok {
+		return errors.New("linked identity does not list the new linkage")
+	}
+
+	resolved, err := store.Resolve(ctx, provider+"-other", "u9", "t2")
+	if err != nil {
+		return err
+	}
+	if resolved.PlatformUserID != created.PlatformUserID {
+		return fmt.Errorf("resolve of the linked identity returned %s, want %s", resolved.PlatformUserID, created.PlatformUserID)
+	}
+	found, err := store.GetByPlatformUserID(ctx, created.PlatformUserID)
+	if err != nil {
+		return err
+	}
+	return sameIdentity(found, linked)
+}
+
+func checkLinkIdempotent(ctx context.Context, store IdentityStore, provider string) error {
+	created, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	again, err := store.Link(ctx, created.PlatformUserID, newLinkage(provider, "u1"))
+	if err != nil {
+		return err
+	}
+	return sameIdentity(again, created)
+}
+
+func checkLinkConflict(ctx context.Context, store IdentityStore, provider string) error {
+	a, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	b, err := store.Resolve(ctx, provider, "u2", "t1")
+	if err != nil {
+		return err
+	}
+	if _, err := store.Link(ctx, b.PlatformUserID, newLinkage(provider, "u1")); !errors.Is(err, domain.ErrLinkageConflict) {
+		return fmt.Errorf("link of another user's linkage: got %v, want %v", err, domain.ErrLinkageConflict)
+	}
+	found, err := store.GetByPlatformUserID(ctx, a.PlatformUserID)
+	if err != nil {
+		return err
+	}
+	return sameIdentity(found, a)
+}
+
+func checkLinkUnknownUser(ctx context.Context, store IdentityStore, provider string) error {
+	if _, err := store.Link(ctx, uuid.NewString(), newLinkage(provider, "u1")); !errors.Is(err, domain.ErrIdentityNotFound) {
+		return fmt.Errorf("link to an unknown user: got %v, want %v", err, domain.ErrIdentityNotFound)
+	}
+	// The failed link must not have claimed the external identity.
+	created, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	if len(created.Linkages) != 1 {
+		return fmt.Errorf("got %d linkages, want 1", len(created.Linkages))
+	}
+	return nil
+}
+
+func checkUnlink(ctx context.Context, store IdentityStore, provider string) error {
+	created, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	if _, err := store.Link(ctx, created.PlatformUserID, newLinkage(provider, "u2")); err != nil {
+		return err
+	}
+	unlinked, err := store.Unlink(ctx, created.PlatformUserID, provider, "u1")
+	if err != nil {
+		return err
+	}
+	if len(unlinked.Linkages) != 1 || unlinked.Primary().ExternalUserID != "u2" {
+		return fmt.Errorf("after unlink got linkages %+v, want only %s/u2", unlinked.Linkages, provider)
+	}
+	found, err := store.GetByPlatformUserID(ctx, created.PlatformUserID)
+	if err != nil {
+		return err
+	}
+	if err := sameIdentity(found, unlinked); err != nil {
+		return err
+	}
+
+	// The external identity is free again: resolving it creates a new user.
+	resolved, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	if resolved.PlatformUserID == created.PlatformUserID {
+		return errors.New("unlinked external identity still resolves to its old user")
+	}
+	return nil
+}
+
+func checkUnlinkMissing(ctx context.Context, store IdentityStore, provider string) error {
+	created, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	if _, err := store.Unlink(ctx, created.PlatformUserID, provider, "u2"); !errors.Is(err, domain.ErrLinkageNotFound) {
+		return fmt.Errorf("unlink of a missing linkage: got %v, want %v", err, domain.ErrLinkageNotFound)
+	}
+	if _, err := store.Unlink(ctx, uuid.NewString(), provider, "u1"); !errors.Is(err, domain.ErrIdentityNotFound) {
+		return fmt.Errorf("unlink from an unknown user: got %v, want %v", err, domain.ErrIdentityNotFound)
+	}
+	return nil
+}
+
+func checkUnlinkLast(ctx context.Context, store IdentityStore, provider string) error {
+	created, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	if _, err := store.Unlink(ctx, created.PlatformUserID, provider, "u1"); !errors.Is(err, domain.ErrLastLinkage) {
+		return fmt.Errorf("unlink of the last linkage: got %v, want %v", err, domain.ErrLastLinkage)
+	}
+	found, err := store.GetByPlatformUserID(ctx, created.PlatformUserID)
+	if err != nil {
+		return err
+	}
+	return sameIdentity(found, created)
+}
+
+func checkConcurrentLink(ctx context.Context, store IdentityStore, provider string) error {
+	const workers = 16
+
+	users := make([]string, workers)
+	for i := range users {
+		identity, err := store.Resolve(ctx, provider, fmt.Sprintf("user-%d", i), "t1")
+		if err != nil {
+			return err
+		}
+		users[i] = identity.PlatformUserID
+	}
+
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = store.Link(ctx, users[i], newLinkage(provider, "contended"))
+		}()
+	}
+	wg.Wait()
+
+	winner := ""
+	for i, err := range errs {
+		switch {
+		case err == nil && winner == "":
+			winner = users[i]
+		case err == nil:
+			return fmt.Errorf("both %s and %s linked the same external identity", winner, users[i])
+		case !errors.Is(err, domain.ErrLinkageConflict):
+			return err
+		}
+	}
+	if winner == "" {
+		return errors.New("no concurrent link succeeded")
+	}
+	resolved, err := store.Resolve(ctx, provider, "contended", "t1")
+	if err != nil {
+		return err
+	}
+	if resolved.PlatformUserID != winner {
+		return fmt.Errorf("resolve returned %s, want the winner %s", resolved.PlatformUserID, winner)
+	}
+	return nil
+}
+
+func checkMerge(ctx context.Context, store IdentityStore, provider string) error {
+	survivor, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	duplicate, err := store.Resolve(ctx, provider, "u2", "t1")
+	if err != nil {
+		return err
+	}
+	if duplicate, err = store.Link(ctx, duplicate.PlatformUserID, newLinkage(provider+"-other", "u3")); err != nil {
+		return err
+	}
+
+	merged, err := store.Merge(ctx, survivor.PlatformUserID, duplicate.PlatformUserID, time.Now())
+	if err != nil {
+		return err
+	}
+	if merged.PlatformUserID != survivor.PlatformUserID || len(merged.Linkages) != 3 || merged.Primary() != survivor.Primary() {
+		return fmt.Errorf("after merge got %+v, want the survivor with its linkage first and both moved ones", merged)
+	}
+	for _, l := range duplicate.Linkages {
+		if _, ok := merged.Linkage(l.Provider, l.ExternalUserID); !ok {
+			return fmt.Errorf("survivor does not list the moved linkage %s/%s", l.Provider, l.ExternalUserID)
+		}
+		resolved, err := store.Resolve(ctx, l.Provider, l.ExternalUserID, "t1")
+		if err != nil {
+			return err
+		}
+		if resolved.PlatformUserID != survivor.PlatformUserID {
+			return fmt.Errorf("resolve of the moved linkage %s/%s returned %s, want %s",
+				l.Provider, l.ExternalUserID, resolved.PlatformUserID, survivor.PlatformUserID)
+		}
+		found, err := store.GetByLinkage(ctx, l.Provider, l.ExternalUserID)
+		if err != nil {
+			return err
+		}
+		if found.PlatformUserID != survivor.PlatformUserID {
+			return fmt.Errorf("lookup of the moved linkage %s/%s returned %s, want %s",
+				l.Provider, l.ExternalUserID, found.PlatformUserID, survivor.PlatformUserID)
+		}
+	}
+	for _, id := range []string{survivor.PlatformUserID, duplicate.PlatformUserID} {
+		found, err := store.GetByPlatformUserID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("lookup of %s after merge: %w", id, err)
+		}
+		if err := sameIdentity(found, merged); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkMergeMerged(ctx context.Context, store IdentityStore, provider string) error {
+	survivor, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	duplicate, err := store.Resolve(ctx, provider, "u2", "t1")
+	if err != nil {
+		return err
+	}
+	other, err := store.Resolve(ctx, provider, "u3", "t1")
+	if err != nil {
+		return err
+	}
+	if _, err := store.Merge(ctx, survivor.PlatformUserID, survivor.PlatformUserID, time.Now()); !errors.Is(err, domain.ErrMergeSelf) {
+		return fmt.Errorf("merge into itself: got %v, want %v", err, domain.ErrMergeSelf)
+	}
+	if _, err := store.Merge(ctx, survivor.PlatformUserID, uuid.NewString(), time.Now()); !errors.Is(err, domain.ErrIdentityNotFound) {
+		return fmt.Errorf("merge of an unknown user: got %v, want %v", err, domain.ErrIdentityNotFound)
+	}
+	if _, err := store.Merge(ctx, survivor.PlatformUserID, duplicate.PlatformUserID, time.Now()); err != nil {
+		return err
+	}
+
+	for _, pair := range [][2]string{
+		{survivor.PlatformUserID, duplicate.PlatformUserID},
+		{other.PlatformUserID, duplicate.PlatformUserID},
+		{duplicate.PlatformUserID, other.PlatformUserID},
+	} {
+		if _, err := store.Merge(ctx, pair[0], pair[1], time.Now()); !errors.Is(err, domain.ErrIdentityNotFound) {
+			return fmt.Errorf("merge of %s into %s after it was merged: got %v, want %v", pair[1], pair[0], err, domain.ErrIdentityNotFound)
+		}
+	}
+	if _, err := store.Link(ctx, duplicate.PlatformUserID, newLinkage(provider, "u4")); !errors.Is(err, domain.ErrIdentityNotFound) {
+		return fmt.Errorf("link to a merged user: got %v, want %v", err, domain.ErrIdentityNotFound)
+	}
+	found, err := store.GetByPlatformUserID(ctx, other.PlatformUserID)
+	if err != nil {
+		return err
+	}
+	return sameIdentity(found, other)
+}
+
+func checkMergeChain(ctx context.Context, store IdentityStore, provider string) error {
+	first, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	second, err := store.Resolve(ctx, provider, "u2", "t1")
+	if err != nil {
+		return err
+	}
+	last, err := store.Resolve(ctx, provider, "u3", "t1")
+	if err != nil {
+		return err
+	}
+	if _, err := store.Merge(ctx, second.PlatformUserID, first.PlatformUserID, time.Now()); err != nil {
+		return err
+	}
+	merged, err := store.Merge(ctx, last.PlatformUserID, second.PlatformUserID, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(merged.Linkages) != 3 {
+		return fmt.Errorf("after both merges got %d linkages, want 3", len(merged.Linkages))
+	}
+	for _, id := range []string{first.PlatformUserID, second.PlatformUserID} {
+		found, err := store.GetByPlatformUserID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("lookup of %s: %w", id, err)
+		}
+		if err := sameIdentity(found, merged); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkErase(ctx context.Context, store IdentityStore, provider string) error {
+	identity, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	if identity, err = store.Link(ctx, identity.PlatformUserID, newLinkage(provider, "u2")); err != nil {
+		return err
+	}
+	if _, err := store.Erase(ctx, uuid.NewString(), time.Now()); !errors.Is(err, domain.ErrIdentityNotFound) {
+		return fmt.Errorf("erase of an unknown user: got %v, want %v", err, domain.ErrIdentityNotFound)
+	}
+
+	// Postgres keeps microseconds.
+	at := time.Now().Truncate(time.Microsecond)
+	erasure, err := store.Erase(ctx, identity.PlatformUserID, at)
+	if err != nil {
+		return err
+	}
+	if erasure.PlatformUserID != identity.PlatformUserID || len(erasure.MergedUserIDs) != 0 || !erasure.ErasedAt.Equal(at) {
+		return fmt.Errorf("got erasure %+v, want %s erased at %s alone", erasure, identity.PlatformUserID, at)
+	}
+	if _, err := store.GetByPlatformUserID(ctx, identity.PlatformUserID); !errors.Is(err, domain.ErrIdentityErased) {
+		return fmt.Errorf("lookup of an erased user: got %v, want %v", err, domain.ErrIdentityErased)
+	}
+	for _, l := range identity.Linkages {
+		if _, err := store.GetByLinkage(ctx, l.Provider, l.ExternalUserID); !errors.Is(err, domain.ErrIdentityNotFound) {
+			return fmt.Errorf("lookup of the erased linkage %s/%s: got %v, want %v", l.Provider, l.ExternalUserID, err, domain.ErrIdentityNotFound)
+		}
+	}
+	page, err := store.List(ctx, domain.IdentityFilter{Provider: provider}, nil, 1)
+	if err != nil {
+		return err
+	}
+	if len(page.Identities) != 0 {
+		return fmt.Errorf("an erased identity is listed: %+v", page.Identities)
+	}
+	if _, err := store.Link(ctx, identity.PlatformUserID, newLinkage(provider, "u3")); !errors.Is(err, domain.ErrIdentityNotFound) {
+		return fmt.Errorf("link to an erased user: got %v, want %v", err, domain.ErrIdentityNotFound)
+	}
+
+	// The external identity is free again and gets a new platform user.
+	again, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	if again.PlatformUserID == identity.PlatformUserID {
+		return errors.New("resolve after erasure returned the erased platform user id")
+	}
+	return nil
+}
+
+func checkEraseMerged(ctx context.Context, store IdentityStore, provider string) error {
+	survivor, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	duplicate, err := store.Resolve(ctx, provider, "u2", "t1")
+	if err != nil {
+		return err
+	}
+	if _, err := store.Merge(ctx, survivor.PlatformUserID, duplicate.PlatformUserID, time.Now()); err != nil {
+		return err
+	}
+	if _, err := store.Erase(ctx, duplicate.PlatformUserID, time.Now()); !errors.Is(err, domain.ErrIdentityNotFound) {
+		return fmt.Errorf("erase of a merged user: got %v, want %v", err, domain.ErrIdentityNotFound)
+	}
+
+	erasure, err := store.Erase(ctx, survivor.PlatformUserID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !slices.Equal(erasure.MergedUserIDs, []string{duplicate.PlatformUserID}) {
+		return fmt.Errorf("erasure lists merged ids %v, want [%s]", erasure.MergedUserIDs, duplicate.PlatformUserID)
+	}
+	if _, err := store.GetByPlatformUserID(ctx, duplicate.PlatformUserID); !errors.Is(err, domain.ErrIdentityErased) {
+		return fmt.Errorf("lookup of a merged id after erasure: got %v, want %v", err, domain.ErrIdentityErased)
+	}
+	for _, id := range []string{survivor.PlatformUserID, duplicate.PlatformUserID} {
+		again, err := store.Erase(ctx, id, time.Now())
+		if err != nil {
+			return fmt.Errorf("erase of %s again: %w", id, err)
+		}
+		if again.PlatformUserID != erasure.PlatformUserID || !again.ErasedAt.Equal(erasure.ErasedAt) ||
+			!slices.Equal(again.MergedUserIDs, erasure.MergedUserIDs) {
+			return fmt.Errorf("erase of %s again returned %+v, want %+v", id, again, erasure)
+		}
+	}
+	return nil
+}
+
+func checkProfile(ctx context.Context, store IdentityStore, provider string) error {
+	identity, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	if len(identity.Profile) != 0 {
+		return fmt.Errorf("new identity has profile %+v", identity.Profile)
+	}
+	t0 := time.Now().Add(-time.Hour)
+
+	got, err := store.UpdateProfile(ctx, identity.PlatformUserID, profileUpdate(domain.ProfileSourceExchange, t0, map[domain.ProfileField]string{
+		domain.ProfileDisplayName: "Ada",
+		domain.ProfileLocale:      "de-AT",
+	}))
+	if err != nil {
+		return err
+	}
+	if err := hasProfile(got, domain.ProfileDisplayName, "Ada", domain.ProfileSourceExchange, t0); err != nil {
+		return err
+	}
+
+	// An older exchange is stale.
+	got, err = store.UpdateProfile(ctx, identity.PlatformUserID, profileUpdate(domain.ProfileSourceExchange, t0.Add(-time.Minute), map[domain.ProfileField]string{
+		domain.ProfileDisplayName: "Old",
+	}))
+	if err != nil {
+		return err
+	}
+	if err := hasProfile(got, domain.ProfileDisplayName, "Ada", domain.ProfileSourceExchange, t0); err != nil {
+		return fmt.Errorf("stale exchange: %w", err)
+	}
+
+	// An API value is not replaced by later exchanges.
+	t1 := t0.Add(time.Minute)
+	if _, err := store.UpdateProfile(ctx, identity.PlatformUserID, profileUpdate(domain.ProfileSourceAPI, t1, map[domain.ProfileField]string{
+		domain.ProfileDisplayName: "Ada L.",
+	})); err != nil {
+		return err
+	}
+	t2 := t1.Add(time.Minute)
+	got, err = store.UpdateProfile(ctx, identity.PlatformUserID, profileUpdate(domain.ProfileSourceExchange, t2, map[domain.ProfileField]string{
+		domain.ProfileDisplayName: "Ada",
+		domain.ProfileLocale:      "en-GB",
+	}))
+	if err != nil {
+		return err
+	}
+	if err := hasProfile(got, domain.ProfileDisplayName, "Ada L.", domain.ProfileSourceAPI, t1); err != nil {
+		return fmt.Errorf("exchange after API update: %w", err)
+	}
+	if err := hasProfile(got, domain.ProfileLocale, "en-GB", domain.ProfileSourceExchange, t2); err != nil {
+		return err
+	}
+
+	// Clearing through the API lets exchanges fill the field again.
+	t3 := t2.Add(time.Minute)
+	got, err = store.UpdateProfile(ctx, identity.PlatformUserID, domain.ProfileUpdate{
+		Source: domain.ProfileSourceAPI,
+		At:     t3,
+		Clear:  []domain.ProfileField{domain.ProfileDisplayName},
+	})
+	if err != nil {
+		return err
+	}
+	if _, ok := got.Profile[domain.ProfileDisplayName]; ok {
+		return fmt.Errorf("cleared display name is still set: %+v", got.Profile)
+	}
+	t4 := t3.Add(time.Minute)
+	if _, err := store.UpdateProfile(ctx, identity.PlatformUserID, profileUpdate(domain.ProfileSourceExchange, t4, map[domain.ProfileField]string{
+		domain.ProfileDisplayName: "Ada",
+	})); err != nil {
+		return err
+	}
+
+	found, err := store.GetByPlatformUserID(ctx, identity.PlatformUserID)
+	if err != nil {
+		return err
+	}
+	if err := hasProfile(found, domain.ProfileDisplayName, "Ada", domain.ProfileSourceExchange, t4); err != nil {
+		return fmt.Errorf("lookup: %w", err)
+	}
+	return hasProfile(found, domain.ProfileLocale, "en-GB", domain.ProfileSourceExchange, t2)
+}
+
+func checkProfileMergedErased(ctx context.Context, store IdentityStore, provider string) error {
+	if _, err := store.UpdateProfile(ctx, uuid.NewString(), profileUpdate(domain.ProfileSourceAPI, time.Now(), map[domain.ProfileField]string{
+		domain.ProfileCountry: "AT",
+	})); !errors.Is(err, domain.ErrIdentityNotFound) {
+		return fmt.Errorf("update of an unknown user: got %v, want %v", err, domain.ErrIdentityNotFound)
+	}
+
+	survivor, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	duplicate, err := store.Resolve(ctx, provider, "u2", "t1")
+	if err != nil {
+		return err
+	}
+	if _, err := store.Merge(ctx, survivor.PlatformUserID, duplicate.PlatformUserID, time.Now()); err != nil {
+		return err
+	}
+	at := time.Now()
+	got, err := store.UpdateProfile(ctx, duplicate.PlatformUserID, profileUpdate(domain.ProfileSourceAPI, at, map[domain.ProfileField]string{
+		domain.ProfileCountry: "AT",
+	}))
+	if err != nil {
+		return err
+	}
+	if got.PlatformUserID != survivor.PlatformUserID {
+		return fmt.Errorf("update of a merged id returned %s, want the survivor %s", got.PlatformUserID, survivor.PlatformUserID)
+	}
+	if err := hasProfile(got, domain.ProfileCountry, "AT", domain.ProfileSourceAPI, at); err != nil {
+		return err
+	}
+
+	if _, err := store.Erase(ctx, survivor.PlatformUserID, time.Now()); err != nil {
+		return err
+	}
+	for _, id := range []string{survivor.PlatformUserID, duplicate.PlatformUserID} {
+		if _, err := store.UpdateProfile(ctx, id, profileUpdate(domain.ProfileSourceAPI, time.Now(), map[domain.ProfileField]string{
+			domain.ProfileCountry: "DE",
+		})); !errors.Is(err, domain.ErrIdentityErased) {
+			return fmt.Errorf("update of erased %s: got %v, want %v", id, err, domain.ErrIdentityErased)
+		}
+	}
+	return nil
+}
+
+func checkProfileMerge(ctx context.Context, store IdentityStore, provider string) error {
+	survivor, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	duplicate, err := store.Resolve(ctx, provider, "u2", "t1")
+	if err != nil {
+		return err
+	}
+	at := time.Now()
+	if _, err := store.UpdateProfile(ctx, survivor.PlatformUserID, profileUpdate(domain.ProfileSourceAPI, at, map[domain.ProfileField]string{
+		domain.ProfileDisplayName: "Survivor",
+	})); err != nil {
+		return err
+	}
+	if _, err := store.UpdateProfile(ctx, duplicate.PlatformUserID, profileUpdate(domain.ProfileSourceExchange, at, map[domain.ProfileField]string{
+		domain.ProfileDisplayName: "Duplicate",
+		domain.ProfileCountry:     "AT",
+	})); err != nil {
+		return err
+	}
+
+	merged, err := store.Merge(ctx, survivor.PlatformUserID, duplicate.PlatformUserID, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := hasProfile(merged, domain.ProfileDisplayName, "Survivor", domain.ProfileSourceAPI, at); err != nil {
+		return err
+	}
+	if err := hasProfile(merged, domain.ProfileCountry, "AT", domain.ProfileSourceExchange, at); err != nil {
+		return err
+	}
+	found, err := store.GetByPlatformUserID(ctx, survivor.PlatformUserID)
+	if err != nil {
+		return err
+	}
+	return sameIdentity(found, merged)
+}
+
+func checkListPages(ctx context.Context, store IdentityStore, provider string) error {
+	var want []string
+	for i := range 5 {
+		identity, err := store.Resolve(ctx, provider, fmt.Sprintf("u%d", i), "t1")
+		if err != nil {
+			return err
+		}
+		want = append(want, identity.PlatformUserID)
+	}
+
+	filter := domain.IdentityFilter{Provider: provider}
+	var (
+		got   []string
+		after *domain.IdentityCursor
+		last  domain.IdentityCursor
+	)
+	for pages := 0; ; pages++ {
+		if pages > len(want) {
+			return errors.New("listing does not end")
+		}
+		page, err := store.List(ctx, filter, after, 2)
+		if err != nil {
+			return err
+		}
+		if len(page.Identities) > 2 {
+			return fmt.Errorf("got %d identities, want at most 2", len(page.Identities))
+		}
+		for _, identity := range page.Identities {
+			c := domain.CursorOf(identity)
+			if len(got) > 0 && !last.Before(c) {
+				return fmt.Errorf("%s is listed after %s out of order", identity.PlatformUserID, last.PlatformUserID)
+			}
+			last = c
+			got = append(got, identity.PlatformUserID)
+		}
+		if pages == 0 {
+			// Identities created while paging are listed at the end.
+			identity, err := store.Resolve(ctx, provider, "late", "t1")
+			if err != nil {
+				return err
+			}
+			want = append(want, identity.PlatformUserID)
+		}
+		if page.Next == nil {
+			break
+		}
+		after = page.Next
+	}
+	if len(got) != len(want) {
+		return fmt.Errorf("listed %d identities, want %d", len(got), len(want))
+	}
+	for _, id := range want {
+		if !slices.Contains(got, id) {
+			return fmt.Errorf("%s is not listed", id)
+		}
+	}
+	if got[len(got)-1] != want[len(want)-1] {
+		return fmt.Errorf("the identity created while paging is not listed last")
+	}
+	return nil
+}
+
+func checkListFilters(ctx context.Context, store IdentityStore, provider string) error {
+	var created []domain.PlatformIdentity
+	for _, externalUserID := range []string{"ab_1", "ab%2", "b1"} {
+		identity, err := store.Resolve(ctx, provider, externalUserID, provider+"-tenant")
+		if err != nil {
+			return err
+		}
+		created = append(created, identity)
+		// Distinct creation times keep the time range checks exact.
+		time.Sleep(2 * time.Millisecond)
+	}
+	other, err := store.Resolve(ctx, provider, "x1", "t1")
+	if err != nil {
+		return err
+	}
+	// Provider and prefix have to match the same linkage.
+	if _, err := store.Link(ctx, other.PlatformUserID, newLinkage(provider+"-other", "ab_9")); err != nil {
+		return err
+	}
+
+	ids := func(identities ...domain.PlatformIdentity) []string {
+		out := make([]string, 0, len(identities))
+		for _, identity := range identities {
+			out = append(out, identity.PlatformUserID)
+		}
+		return out
+	}
+	for _, tc := range []struct {
+		name   string
+		filter domain.IdentityFilter
+		want   []string
+	}{
+		{"tenant", domain.IdentityFilter{Tenant: provider + "-tenant"}, ids(created...)},
+		{"prefix", domain.IdentityFilter{Provider: provider, ExternalUserIDPrefix: "ab"}, ids(created[0], created[1])},
+		{"literal prefix", domain.IdentityFilter{Provider: provider, ExternalUserIDPrefix: "ab%"}, ids(created[1])},
+		{"literal underscore", domain.IdentityFilter{Provider: provider, ExternalUserIDPrefix: "ab_"}, ids(created[0])},
+		{"created range", domain.IdentityFilter{
+			Provider:    provider,
+			CreatedFrom: created[1].CreatedAt,
+			CreatedTo:   created[2].CreatedAt,
+		}, ids(created[1])},
+	} {
+		page, err := store.List(ctx, tc.filter, nil, domain.MaxIdentityPageSize)
+		if err != nil {
+			return fmt.Errorf("%s: %w", tc.name, err)
+		}
+		if got := ids(page.Identities...); !slices.Equal(got, tc.want) {
+			return fmt.Errorf("%s: listed %v, want %v", tc.name, got, tc.want)
+		}
+		if page.Next != nil {
+			return fmt.Errorf("%s: a single page has a next cursor", tc.name)
+		}
+	}
+	return nil
+}
+
+func checkListMerged(ctx context.Context, store IdentityStore, provider string) error {
+	survivor, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	duplicate, err := store.Resolve(ctx, provider, "u2", "t1")
+	if err != nil {
+		return err
+	}
+	if _, err := store.Merge(ctx, survivor.PlatformUserID, duplicate.PlatformUserID, time.Now()); err != nil {
+		return err
+	}
+	page, err := store.List(ctx, domain.IdentityFilter{Provider: provider}, nil, domain.MaxIdentityPageSize)
+	if err != nil {
+		return err
+	}
+	if len(page.Identities) != 1 || page.Identities[0].PlatformUserID != survivor.PlatformUserID {
+		return fmt.Errorf("listed %+v, want only the survivor", page.Identities)
+	}
+	return nil
+}
+
+// checkReopen expects created, with mergedID merged into it, to be found
+// and erased to stay erased after reopening the store.
+func checkStatus(ctx context.Context, store IdentityStore, provider string) error {
+	identity, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	if identity.Status != (domain.IdentityStatus{}) {
+		return fmt.Errorf("new identity has status %+v, want none", identity.Status)
+	}
+
+	now := time.Now().Truncate(time.Microsecond)
+	for _, status := range []domain.IdentityStatus{
+		{State: domain.IdentitySuspended, Reason: domain.ReasonAbuse, Until: now.Add(time.Hour), ChangedAt: now},
+		banned(domain.ReasonCheating),
+		{State: domain.IdentityActive, Reason: domain.ReasonAppealGranted, ChangedAt: now.Add(time.Second)},
+	} {
+		got, err := store.SetStatus(ctx, identity.PlatformUserID, status)
+		if err != nil {
+			return err
+		}
+		if !sameStatus(got.Status, status) {
+			return fmt.Errorf("set status returned %+v, want %+v", got.Status, status)
+		}
+		found, err := store.GetByPlatformUserID(ctx, identity.PlatformUserID)
+		if err != nil {
+			return err
+		}
+		if err := sameIdentity(found, got); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkStatusMergedErased(ctx context.Context, store IdentityStore, provider string) error {
+	if _, err := store.SetStatus(ctx, uuid.NewString(), banned(domain.ReasonOther)); !errors.Is(err, domain.ErrIdentityNotFound) {
+		return fmt.Errorf("status of an unknown user: got %v, want %v", err, domain.ErrIdentityNotFound)
+	}
+
+	survivor, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	duplicate, err := store.Resolve(ctx, provider, "u2", "t1")
+	if err != nil {
+		return err
+	}
+	if _, err := store.SetStatus(ctx, duplicate.PlatformUserID, banned(domain.ReasonFraud)); err != nil {
+		return err
+	}
+	merged, err := store.Merge(ctx, survivor.PlatformUserID, duplicate.PlatformUserID, time.Now())
+	if err != nil {
+		return err
+	}
+	if merged.Status != survivor.Status {
+		return fmt.Errorf("survivor status after merge is %+v, want %+v", merged.Status, survivor.Status)
+	}
+	if _, err := store.SetStatus(ctx, duplicate.PlatformUserID, banned(domain.ReasonFraud)); !errors.Is(err, domain.ErrIdentityNotFound) {
+		return fmt.Errorf("status of a merged id: got %v, want %v", err, domain.ErrIdentityNotFound)
+	}
+
+	if _, err := store.Erase(ctx, survivor.PlatformUserID, time.Now()); err != nil {
+		return err
+	}
+	if _, err := store.SetStatus(ctx, survivor.PlatformUserID, banned(domain.ReasonFraud)); !errors.Is(err, domain.ErrIdentityErased) {
+		return fmt.Errorf("status of an erased id: got %v, want %v", err, domain.ErrIdentityErased)
+	}
+	return nil
+}
+
+func checkStatusMerge(ctx context.Context, store IdentityStore, provider string) error {
+	survivor, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	duplicate, err := store.Resolve(ctx, provider, "u2", "t1")
+	if err != nil {
+		return err
+	}
+	ban := banned(domain.ReasonCheating)
+	if _, err := store.SetStatus(ctx, survivor.PlatformUserID, ban); err != nil {
+		return err
+	}
+	if _, err := store.Merge(ctx, survivor.PlatformUserID, duplicate.PlatformUserID, time.Now()); err != nil {
+		return err
+	}
+
+	resolved, err := store.Resolve(ctx, provider, "u2", "t1")
+	if err != nil {
+		return err
+	}
+	if resolved.PlatformUserID != survivor.PlatformUserID || !sameStatus(resolved.Status, ban) {
+		return fmt.Errorf("resolve of the moved linkage returned %s with status %+v, want %s with %+v",
+			resolved.PlatformUserID, resolved.Status, survivor.PlatformUserID, ban)
+	}
+	found, err := store.GetByPlatformUserID(ctx, duplicate.PlatformUserID)
+	if err != nil {
+		return err
+	}
+	if !sameStatus(found.Status, ban) {
+		return fmt.Errorf("lookup of the merged id has status %+v, want %+v", found.Status, ban)
+	}
+	return nil
+}
+
+func checkReopen(ctx context.Context, h IdentityHarness, created domain.PlatformIdentity, mergedID string, erased domain.PlatformIdentity, pendingID string) error {
+	store, release, err := h.Open()
+	if err != nil {
+		return fmt.Errorf("reopen: %w", err)
+	}
+	defer release()
+
+	if _, err := store.GetByPlatformUserID(ctx, erased.PlatformUserID); !errors.Is(err, domain.ErrIdentityErased) {
+		return fmt.Errorf("lookup of an erased user after reopen: got %v, want %v", err, domain.ErrIdentityErased)
+	}
+	l := erased.Primary()
+	if _, err := store.GetByLinkage(ctx, l.Provider, l.ExternalUserID); !errors.Is(err, domain.ErrIdentityNotFound) {
+		return fmt.Errorf("lookup of an erased linkage after reopen: got %v, want %v", err, domain.ErrIdentityNotFound)
+	}
+
+	for _, id := range []string{created.PlatformUserID, mergedID} {
+		found, err := store.GetByPlatformUserID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("lookup of %s after reopen: %w", id, err)
+		}
+		if err := sameIdentity(found, created); err != nil {
+			return err
+		}
+	}
+	for _, l := range created.Linkages {
+		resolved, err := store.Resolve(ctx, l.Provider, l.ExternalUserID, l.Tenant)
+		if err != nil {
+			return err
+		}
+		if resolved.PlatformUserID != created.PlatformUserID {
+			return fmt.Errorf("resolve of %s/%s after reopen returned %s, want %s",
+				l.Provider, l.ExternalUserID, resolved.PlatformUserID, created.PlatformUserID)
+		}
+	}
+
+	msgs, err := pendingOf(ctx, store, erased.PlatformUserID)
+	if err != nil {
+		return err
+	}
+	if got := eventTypes(msgs); !slices.Equal(got, []domain.EventType{domain.EventIdentityErased}) {
+		return fmt.Errorf("pending events of an erased user after reopen are %v, want only %s", got, domain.EventIdentityErased)
+	}
+	if msgs, err = pendingOf(ctx, store, created.PlatformUserID); err != nil {
+		return err
+	}
+	if len(msgs) == 0 || msgs[len(msgs)-1].ID != pendingID || slices.ContainsFunc(msgs[:len(msgs)-1], func(m domain.OutboxMessage) bool {
+		return m.Type == domain.EventTokenIssued
+	}) {
+		return fmt.Errorf("pending events after reopen are %v, want the unpublished token.issued last", eventTypes(msgs))
+	}
+	return nil
+}
+
+// checkOutboxEvents expects creating, linking and status changes to add
+// one event each, in order, and no-op changes none.
+func checkOutboxEvents(ctx context.Context, store IdentityStore, provider string) error {
+	identity, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	if _, err := store.Resolve(ctx, provider, "u1", "t1"); err != nil {
+		return err
+	}
+	linkage := newLinkage(provider, "u2")
+	for range 2 {
+		if _, err := store.Link(ctx, identity.PlatformUserID, linkage); err != nil {
+			return err
+		}
+	}
+	if _, err := store.SetStatus(ctx, identity.PlatformUserID, banned(domain.ReasonFraud)); err != nil {
+		return err
+	}
+	if _, err := store.Unlink(ctx, identity.PlatformUserID, provider, "u2"); err != nil {
+		return err
+	}
+
+	msgs, err := pendingOf(ctx, store, identity.PlatformUserID)
+	if err != nil {
+		return err
+	}
+	want := []domain.EventType{domain.EventIdentityCreated, domain.EventIdentityLinked, domain.EventIdentityStatusChanged}
+	if got := eventTypes(msgs); !slices.Equal(got, want) {
+		return fmt.Errorf("pending events are %v, want %v", got, want)
+	}
+	for _, msg := range msgs {
+		var env struct {
+			EventID   string `json:"eventId"`
+			EventType string `json:"eventType"`
+			Payload   struct {
+				PlatformUserID string `json:"platform_user_id"`
+			} `json:"payload"`
+		}
+		if err := json.Unmarshal(msg.Data, &env); err != nil {
+			return fmt.Errorf("event %s: %w", msg.ID, err)
+		}
+		if env.EventID != msg.ID || env.EventType != string(msg.Type) || env.Payload.PlatformUserID != identity.PlatformUserID {
+			return fmt.Errorf("event %s %s has envelope %+v", msg.Type, msg.ID, env)
+		}
+	}
+
+	if err := store.MarkPublished(ctx, []string{msgs[0].ID, msgs[1].ID, msgs[2].ID}); err != nil {
+		return err
+	}
+	if msgs, err = pendingOf(ctx, store, identity.PlatformUserID); err != nil {
+		return err
+	}
+	if len(msgs) != 0 {
+		return fmt.Errorf("published events still pending: %v", eventTypes(msgs))
+	}
+	return nil
+}
+
+// checkOutboxErase expects an erasure to drop the pending events of the
+// erased ids and add one identity.erased event, also when repeated.
+func checkOutboxErase(ctx context.Context, store IdentityStore, provider string) error {
+	survivor, err := store.Resolve(ctx, provider, "u1", "t1")
+	if err != nil {
+		return err
+	}
+	duplicate, err := store.Resolve(ctx, provider, "u2", "t1")
+	if err != nil {
+		return err
+	}
+	if _, err := store.Merge(ctx, survivor.PlatformUserID, duplicate.PlatformUserID, time.Now()); err != nil {
+		return err
+	}
+	if err := store.Add(ctx, tokenIssued(duplicate.PlatformUserID)); err != nil {
+		return err
+	}
+	for range 2 {
+		if _, err := store.Erase(ctx, survivor.PlatformUserID, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	msgs, err := pendingOf(ctx, store, survivor.PlatformUserID)
+	if err != nil {
+		return err
+	}
+	if got := eventTypes(msgs); !slices.Equal(got, []domain.EventType{domain.EventIdentityErased}) {
+		return fmt.Errorf("pending events of the erased user are %v, want only %s", got, domain.EventIdentityErased)
+	}
+	if msgs, err = pendingOf(ctx, store, duplicate.PlatformUserID); err != nil {
+		return err
+	}
+	if len(msgs) != 0 {
+		return fmt.Errorf("pending events of an erased merged id: %v", eventTypes(msgs))
+	}
+	return nil
+}
+
+// checkOutboxAdd expects added events to stay pending, oldest first, until
+// marked published, and unknown ids to be ignored.
+func checkOutboxAdd(ctx context.Context, store IdentityStore, _ string) error {
+	userID := uuid.NewString()
+	first, second := tokenIssued(userID), tokenIssued(userID)
+	for _, event := range []domain.Event{first, second} {
+		if err := store.Add(ctx, event); err != nil {
+			return err
+		}
+	}
+	msgs, err := pendingOf(ctx, store, userID)
+	if err != nil {
+		return err
+	}
+	if len(msgs) != 2 || msgs[0].ID != first.ID || msgs[1].ID != second.ID {
+		return fmt.Errorf("pending events are %v, want %s then %s", msgs, first.ID, second.ID)
+	}
+	if err := store.MarkPublished(ctx, []string{first.ID, uuid.NewString()}); err != nil {
+		return err
+	}
+	if msgs, err = pendingOf(ctx, store, userID); err != nil {
+		return err
+	}
+	if len(msgs) != 1 || msgs[0].ID != second.ID {
+		return fmt.Errorf("pending events after publishing the first are %v, want %s", msgs, second.ID)
+	}
+	return store.MarkPublished(ctx, []string{second.ID})
+}
+
+// pendingOf returns the pending messages about a user, oldest first. Other
+// checks and services may have messages pending in the same outbox.
+func pendingOf(ctx context.Context, store IdentityStore, platformUserID string) ([]domain.OutboxMessage, error) {
+	msgs, err := store.Pending(ctx, 100000)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(msgs, func(m domain.OutboxMessage) bool {
+		return m.PlatformUserID != platformUserID
+	}), nil
+}
+
+func eventTypes(msgs []domain.OutboxMessage) []domain.EventType {
+	types := make([]domain.EventType, 0, len(msgs))
+	for _, m := range msgs {
+		types = append(types, m.Type)
+	}
+	return types
+}
+
+// tokenIssued returns a token.issued event about a user.
+func tokenIssued(platformUserID string) domain.Event {
+	now := time.Now()
+	return domain.Event{
+		ID:             uuid.NewString(),
+		Type:           domain.EventTokenIssued,
+		At:             now,
+		PlatformUserID: platformUserID,
+		Payload: domain.TokenIssued{
+			PlatformUserID: platformUserID,
+			Tenant:         "t1",
+			SubjectType:    domain.SubjectTypePlayer,
+			Grant:          domain.GrantExchange,
+			SessionID:      uuid.NewString(),
+			IssuedAt:       now,
+			ExpiresAt:      now.Add(time.Minute),
+		},
+	}
+}
+
+func sameIdentity(got, want domain.PlatformIdentity) error {
+	same := got.PlatformUserID == want.PlatformUserID && got.Tenant == want.Tenant &&
+		got.CreatedAt.Equal(want.CreatedAt) && len(got.Linkages) == len(want.Linkages)
+	for i := 0; same && i < len(got.Linkages); i++ {
+		g, w := got.Linkages[i], want.Linkages[i]
+		same = g.Provider == w.Provider && g.ExternalUserID == w.ExternalUserID &&
+			g.Tenant == w.Tenant && g.LinkedAt.Equal(w.LinkedAt)
+	}
+	same = same && len(got.Profile) == len(want.Profile)
+	for field, w := range want.Profile {
+		g, ok := got.Profile[field]
+		same = same && ok && g.Value == w.Value && g.Source == w.Source && g.UpdatedAt.Equal(w.UpdatedAt)
+	}
+	same = same && sameStatus(got.Status, want.Status)
+	if !same {
+		return fmt.Errorf("got %+v, want %+v", got, want)
+	}
+	return nil
+}
+
+// hasProfile checks one attribute of an identity's profile.
+func hasProfile(identity domain.PlatformIdentity, field domain.ProfileField, value string, source domain.ProfileSource, at time.Time) error {
+	a, ok := identity.Profile[field]
+	if !ok || a.Value != value || a.Source != source || !a.UpdatedAt.Equal(at) {
+		return fmt.Errorf("%s is %+v, want %q from %s at %s", field, a, value, source, at)
+	}
+	return nil
+}
+
+func sameStatus(got, want domain.IdentityStatus) bool {
+	return got.State == want.State && got.Reason == want.Reason &&
+		got.Until.Equal(want.Until) && got.ChangedAt.Equal(want.ChangedAt)
+}
+
+// banned returns a ban made now, at the precision every store keeps.
+func banned(reason domain.StatusReason) domain.IdentityStatus {
+	return domain.IdentityStatus{State: domain.IdentityBanned, Reason: reason, ChangedAt: time.Now().Truncate(time.Microsecond)}
+}
+
+// profileUpdate returns an update setting values.
+func profileUpdate(source domain.ProfileSource, at time.Time, values map[domain.ProfileField]string) domain.ProfileUpdate {
+	return domain.ProfileUpdate{Source: source, At: at, Set: values}
+}
+
+// newLinkage returns a linkage made now in tenant t1.
+func newLinkage(provider, externalUserID string) domain.Linkage {
+	return domain.Linkage{
+		Provider:       provider,
+		ExternalUserID: externalUserID,
+		Tenant:         "t1",
+		LinkedAt:       time.Now(),
+	}
+}
+
+// newProvider returns a provider name no other check or run uses.
+func newProvider() string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b)
+	return "conformance-" + hex.EncodeToString(b)
+}
@@ -0,0 +1,235 @@
+package conformance
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// ProviderHarness opens a provider store adapter for the checks.
+type ProviderHarness struct {
+	Name string
+	// Open returns the store and a func releasing it. Checks use their own
+	// provider IDs, so the store does not have to be empty.
+	Open func() (interfaces.ProviderStore, func() error, error)
+	// Durable requires providers to survive releasing and reopening the
+	// store.
+	Durable bool
+}
+
+type providerCheck struct {
+	name string
+	run  func(ctx context.Context, store interfaces.ProviderStore, id string) error
+}
+
+var providerChecks = []providerCheck{
+	{"a created provider reads back", checkProviderCreate},
+	{"creating an existing provider fails", checkProviderCreateTwice},
+	{"update replaces the provider", checkProviderUpdate},
+	{"unknown providers are not found", checkProviderUnknown},
+	{"delete removes the provider", checkProviderDelete},
+}
+
+// RunProviderStore runs every check against the harness.
+func RunProviderStore(ctx context.Context, h ProviderHarness) []Result {
+	store, release, err := h.Open()
+	if err != nil {
+		return []Result{{Check: "open", Err: err}}
+	}
+
+	results := make([]Result, 0, len(providerChecks)+2)
+	for _, c := range providerChecks {
+		results = append(results, Result{Check: c.name, Err: c.run(ctx, store, newProvider())})
+	}
+
+	if !h.Durable {
+		return append(results, Result{Check: "release", Err: release()})
+	}
+	created := newProviderRecord(newProvider())
+	if err == nil {
+		err = store.Create(ctx, created)
+	}
+	if err == nil {
+		err = release()
+	}
+	if err == nil {
+		err = checkProviderReopen(ctx, h, created)
+	}
+	return append(results, Result{Check: "providers survive reopening", Err: err})
+}
+
+func checkProviderCreate(ctx context.Context, store interfaces.ProviderStore, id string) error {
+	created := newProviderRecord(id)
+	if err := store.Create(ctx, created); err != nil {
+		return err
+	}
+	got, err := store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := sameProvider(got, created); err != nil {
+		return err
+	}
+
+	second := newProviderRecord(id + "-2")
+	if err := store.Create(ctx, second); err != nil {
+		return err
+	}
+	listed, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+	if !slices.IsSortedFunc(listed, func(a, b domain.Provider) int { return strings.Compare(a.ID, b.ID) }) {
+		return errors.New("providers are not listed in ID order")
+	}
+	var found []domain.Provider
+	for _, p := range listed {
+		if p.ID == id || p.ID == second.ID {
+			found = append(found, p)
+		}
+	}
+	if len(found) != 2 {
+		return fmt.Errorf("listed %d of the 2 created providers", len(found))
+	}
+	return sameProvider(found[0], created)
+}
+
+func checkProviderCreateTwice(ctx context.Context, store interfaces.ProviderStore, id string) error {
+	created := newProviderRecord(id)
+	if err := store.Create(ctx, created); err != nil {
+		return err
+	}
+	again := newProviderRecord(id)
+	again.DisplayName = "Again"
+	if err := store.Create(ctx, again); !errors.Is(err, domain.ErrProviderExists) {
+		return fmt.Errorf("second create: got %v, want %v", err, domain.ErrProviderExists)
+	}
+	got, err := store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return sameProvider(got, created)
+}
+
+func checkProviderUpdate(ctx context.Context, store interfaces.ProviderStore, id string) error {
+	created := newProviderRecord(id)
+	if err := store.Create(ctx, created); err != nil {
+		return err
+	}
+	updated := created
+	updated.DisplayName = "Updated"
+	updated.Status = domain.ProviderDisabled
+	updated.AllowedTenants = nil
+	updated.AssertionKeys = nil
+	updated.Tokens = domain.ProviderTokenSettings{}
+	updated.UpdatedAt = created.UpdatedAt.Add(time.Minute)
+	if err := store.Update(ctx, updated); err != nil {
+		return err
+	}
+	got, err := store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return sameProvider(got, updated)
+}
+
+func checkProviderUnknown(ctx context.Context, store interfaces.ProviderStore, id string) error {
+	if _, err := store.Get(ctx, id); !errors.Is(err, domain.ErrProviderNotFound) {
+		return fmt.Errorf("get: got %v, want %v", err, domain.ErrProviderNotFound)
+	}
+	if err := store.Update(ctx, newProviderRecord(id)); !errors.Is(err, domain.ErrProviderNotFound) {
+		return fmt.Errorf("update: got %v, want %v", err, domain.ErrProviderNotFound)
+	}
+	if err := store.Delete(ctx, id); !errors.Is(err, domain.ErrProviderNotFound) {
+		return fmt.Errorf("delete: got %v, want %v", err, domain.ErrProviderNotFound)
+	}
+	if _, err := store.Get(ctx, id); !errors.Is(err, domain.ErrProviderNotFound) {
+		return fmt.Errorf("get after the failed update: got %v, want %v", err, domain.ErrProviderNotFound)
+	}
+	return nil
+}
+
+func checkProviderDelete(ctx context.Context, store interfaces.ProviderStore, id string) error {
+	if err := store.Create(ctx, newProviderRecord(id)); err != nil {
+		return err
+	}
+	if err := store.Delete(ctx, id); err != nil {
+		return err
+	}
+	if _, err := store.Get(ctx, id); !errors.Is(err, domain.ErrProviderNotFound) {
+		return fmt.Errorf("get after delete: got %v, want %v", err, domain.ErrProviderNotFound)
+	}
+	listed, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range listed {
+		if p.ID == id {
+			return errors.New("deleted provider is still listed")
+		}
+	}
+	return nil
+}
+
+func checkProviderReopen(ctx context.Context, h ProviderHarness, created domain.Provider) error {
+	store, release, err := h.Open()
+	if err != nil {
+		return fmt.Errorf("reopen: %w", err)
+	}
+	got, err := store.Get(ctx, created.ID)
+	if err == nil {
+		err = sameProvider(got, created)
+	}
+	return errors.Join(err, release())
+}
+
+// newProviderRecord returns a valid provider with every field set.
+func newProviderRecord(id string) domain.Provider {
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+	now := storeNow()
+	return domain.Provider{
+		ID:             id,
+		DisplayName:    "Conformance",
+		Status:         domain.ProviderActive,
+		AllowedTenants: []string{"t1", "t2"},
+		AssertionKeys: []domain.PublicJWK{{
+			Kty: "OKP",
+			Kid: "k1",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(pub),
+		}},
+		Tokens: domain.ProviderTokenSettings{
+			AccessTTL:     5 * time.Minute,
+			RefreshTTL:    24 * time.Hour,
+			ProfileClaims: []domain.ProfileField{domain.ProfileDisplayName},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func sameProvider(got, want domain.Provider) error {
+	switch {
+	case got.ID != want.ID, got.DisplayName != want.DisplayName, got.Status != want.Status:
+		return fmt.Errorf("provider %s %q %s, want %s %q %s", got.ID, got.DisplayName, got.Status, want.ID, want.DisplayName, want.Status)
+	case !slices.Equal(got.AllowedTenants, want.AllowedTenants):
+		return fmt.Errorf("allowed tenants %v, want %v", got.AllowedTenants, want.AllowedTenants)
+	case !slices.Equal(got.AssertionKeys, want.AssertionKeys):
+		return fmt.Errorf("assertion keys %+v, want %+v", got.AssertionKeys, want.AssertionKeys)
+	case got.Tokens.AccessTTL != want.Tokens.AccessTTL, got.Tokens.RefreshTTL != want.Tokens.RefreshTTL,
+		!slices.Equal(got.Tokens.ProfileClaims, want.Tokens.ProfileClaims):
+		return fmt.Errorf("token settings %+v, want %+v", got.Tokens, want.Tokens)
+	case !got.CreatedAt.Equal(want.CreatedAt), !got.UpdatedAt.Equal(want.UpdatedAt):
+		return fmt.Errorf("created %v updated %v, want %v and %v", got.CreatedAt, got.UpdatedAt, want.CreatedAt, want.UpdatedAt)
+	}
+	return nil
+}
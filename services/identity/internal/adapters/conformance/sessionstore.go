@@ -0,0 +1,441 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// SessionHarness opens the session and refresh token stores of an identity
+// store backend for the checks.
+type SessionHarness struct {
+	Name string
+	// Open returns the stores and a func releasing them. Expired sessions
+	// must be kept for Retention. Checks use their own session and user
+	// IDs, so the stores do not have to be empty.
+	Open      func() (interfaces.SessionStore, interfaces.RefreshTokenStore, func() error, error)
+	Retention time.Duration
+	// Durable requires sessions and refresh tokens to survive releasing
+	// and reopening the stores, and long expired state to be swept by then.
+	Durable bool
+}
+
+type sessionCheck struct {
+	name string
+	run  func(ctx context.Context, sessions interfaces.SessionStore, tokens interfaces.RefreshTokenStore, prefix string) error
+}
+
+var sessionChecks = []sessionCheck{
+	{"a created session reads back", checkSessionCreate},
+	{"unknown sessions are not found", checkSessionUnknown},
+	{"extend moves the expiry", checkSessionExtend},
+	{"revoking a revoked session keeps the first revocation", checkSessionRevokeTwice},
+	{"revoke all revokes only the user's unrevoked sessions", checkSessionRevokeAll},
+	{"revoked since lists revocations in order", checkSessionRevokedSince},
+	{"a consumed refresh token reports reuse", checkRefreshConsume},
+	{"an unknown refresh token is invalid", checkRefreshUnknown},
+	{"concurrent consumes let one caller win", checkRefreshConcurrentConsume},
+	{"revoking a family revokes only its tokens", checkRefreshRevokeFamily},
+}
+
+// RunSessionStores runs every check against the harness.
+func RunSessionStores(ctx context.Context, h SessionHarness) []Result {
+	sessions, tokens, release, err := h.Open()
+	if err != nil {
+		return []Result{{Check: "open", Err: err}}
+	}
+
+	results := make([]Result, 0, len(sessionChecks)+2)
+	for _, c := range sessionChecks {
+		results = append(results, Result{Check: c.name, Err: c.run(ctx, sessions, tokens, newProvider())})
+	}
+
+	if !h.Durable {
+		return append(results, Result{Check: "release", Err: release()})
+	}
+	prefix := newProvider()
+	now := storeNow()
+	revoked := newSession(prefix, "revoked", now, time.Hour)
+	stale := newSession(prefix, "stale", now.Add(-2*h.Retention-2*time.Hour), time.Hour)
+	for _, s := range []domain.Session{revoked, stale} {
+		if err == nil {
+			err = sessions.Create(ctx, s)
+		}
+	}
+	if err == nil {
+		revoked, err = sessions.Revoke(ctx, revoked.ID, "conformance", now)
+	}
+	used := newRefreshToken(prefix, "used", revoked.ID, now, time.Hour)
+	expired := newRefreshToken(prefix, "expired", revoked.ID, now.Add(-2*time.Hour), time.Hour)
+	for _, t := range []domain.RefreshToken{used, expired} {
+		if err == nil {
+			err = tokens.Save(ctx, t)
+		}
+	}
+	if err == nil {
+		_, err = tokens.Consume(ctx, used.TokenHash, now)
+	}
+	if err == nil {
+		err = release()
+	}
+	if err == nil {
+		err = checkSessionReopen(ctx, h, prefix, revoked, stale.ID, used, expired.TokenHash)
+	}
+	return append(results, Result{Check: "sessions and refresh tokens survive reopening", Err: err})
+}
+
+func checkSessionCreate(ctx context.Context, sessions interfaces.SessionStore, _ interfaces.RefreshTokenStore, prefix string) error {
+	created := newSession(prefix, "s1", storeNow(), time.Hour)
+	created.Tenant = "t1"
+	created.Provider = prefix
+	if err := sessions.Create(ctx, created); err != nil {
+		return err
+	}
+	got, err := sessions.Get(ctx, created.ID)
+	if err != nil {
+		return err
+	}
+	if err := sameSession(got, created); err != nil {
+		return err
+	}
+	listed, err := sessions.ListByUser(ctx, created.PlatformUserID)
+	if err != nil {
+		return err
+	}
+	if len(listed) != 1 {
+		return fmt.Errorf("listed %d sessions, want 1", len(listed))
+	}
+	return sameSession(listed[0], created)
+}
+
+func checkSessionUnknown(ctx context.Context, sessions interfaces.SessionStore, _ interfaces.RefreshTokenStore, prefix string) error {
+	id := prefix + "-unknown"
+	if _, err := sessions.Get(ctx, id); !errors.Is(err, domain.ErrSessionNotFound) {
+		return fmt.Errorf("get: got %v, want %v", err, domain.ErrSessionNotFound)
+	}
+	if err := sessions.Extend(ctx, id, storeNow()); !errors.Is(err, domain.ErrSessionNotFound) {
+		return fmt.Errorf("extend: got %v, want %v", err, domain.ErrSessionNotFound)
+	}
+	if _, err := sessions.Revoke(ctx, id, "conformance", storeNow()); !errors.Is(err, domain.ErrSessionNotFound) {
+		return fmt.Errorf("revoke: got %v, want %v", err, domain.ErrSessionNotFound)
+	}
+	listed, err := sessions.ListByUser(ctx, prefix+"-nobody")
+	if err != nil {
+		return err
+	}
+	if len(listed) != 0 {
+		return fmt.Errorf("listed %+v for an unknown user", listed)
+	}
+	return nil
+}
+
+func checkSessionExtend(ctx context.Context, sessions interfaces.SessionStore, _ interfaces.RefreshTokenStore, prefix string) error {
+	session := newSession(prefix, "s1", storeNow(), time.Hour)
+	if err := sessions.Create(ctx, session); err != nil {
+		return err
+	}
+	extended := session.ExpiresAt.Add(time.Hour)
+	if err := sessions.Extend(ctx, session.ID, extended); err != nil {
+		return err
+	}
+	got, err := sessions.Get(ctx, session.ID)
+	if err != nil {
+		return err
+	}
+	if !got.ExpiresAt.Equal(extended) {
+		return fmt.Errorf("expires_at %s, want %s", got.ExpiresAt, extended)
+	}
+	return nil
+}
+
+func checkSessionRevokeTwice(ctx context.Context, sessions interfaces.SessionStore, _ interfaces.RefreshTokenStore, prefix string) error {
+	now := storeNow()
+	session := newSession(prefix, "s1", now, time.Hour)
+	if err := sessions.Create(ctx, session); err != nil {
+		return err
+	}
+	first, err := sessions.Revoke(ctx, session.ID, "first", now)
+	if err != nil {
+		return err
+	}
+	if first.RevokeReason != "first" || !first.RevokedAt.Equal(now) {
+		return fmt.Errorf("revoke returned reason %q at %s, want first at %s", first.RevokeReason, first.RevokedAt, now)
+	}
+	again, err := sessions.Revoke(ctx, session.ID, "second", now.Add(time.Minute))
+	if err != nil {
+		return err
+	}
+	if again.RevokeReason != "first" || !again.RevokedAt.Equal(now) {
+		return fmt.Errorf("second revoke returned reason %q at %s, want the first revocation", again.RevokeReason, again.RevokedAt)
+	}
+	return nil
+}
+
+func checkSessionRevokeAll(ctx context.Context, sessions interfaces.SessionStore, _ interfaces.RefreshTokenStore, prefix string) error {
+	now := storeNow()
+	earlier := newSession(prefix, "earlier", now, time.Hour)
+	open := newSession(prefix, "open", now.Add(time.Second), time.Hour)
+	other := newSession(prefix, "other", now, time.Hour)
+	other.PlatformUserID = prefix + "-other-user"
+	for _, s := range []domain.Session{earlier, open, other} {
+		if err := sessions.Create(ctx, s); err != nil {
+			return err
+		}
+	}
+	if _, err := sessions.Revoke(ctx, earlier.ID, "earlier", now); err != nil {
+		return err
+	}
+
+	revoked, err := sessions.RevokeAllForUser(ctx, earlier.PlatformUserID, "all", now.Add(time.Minute))
+	if err != nil {
+		return err
+	}
+	if len(revoked) != 1 || revoked[0].ID != open.ID || revoked[0].RevokeReason != "all" {
+		return fmt.Errorf("revoke all returned %+v, want only %s", revoked, open.ID)
+	}
+	got, err := sessions.Get(ctx, earlier.ID)
+	if err != nil {
+		return err
+	}
+	if got.RevokeReason != "earlier" {
+		return fmt.Errorf("revoke all changed an earlier revocation to %q", got.RevokeReason)
+	}
+	if got, err = sessions.Get(ctx, other.ID); err != nil {
+		return err
+	}
+	if !got.RevokedAt.IsZero() {
+		return errors.New("revoke all revoked another user's session")
+	}
+	return nil
+}
+
+func checkSessionRevokedSince(ctx context.Context, sessions interfaces.SessionStore, _ interfaces.RefreshTokenStore, prefix string) error {
+	since := storeNow()
+	var want []string
+	for i, name := range []string{"before", "at", "after"} {
+		session := newSession(prefix, name, since, time.Hour)
+		if err := sessions.Create(ctx, session); err != nil {
+			return err
+		}
+		at := since.Add(time.Duration(i-1) * time.Minute)
+		if _, err := sessions.Revoke(ctx, session.ID, "conformance", at); err != nil {
+			return err
+		}
+		if !at.Before(since) {
+			want = append(want, session.ID)
+		}
+	}
+	listed, err := sessions.RevokedSince(ctx, since)
+	if err != nil {
+		return err
+	}
+	// Stores may be shared, so only this check's sessions count.
+	var got []string
+	for _, s := range listed {
+		if strings.HasPrefix(s.ID, prefix+"-") {
+			got = append(got, s.ID)
+		}
+	}
+	if !slices.Equal(got, want) {
+		return fmt.Errorf("revoked since returned %v, want %v", got, want)
+	}
+	return nil
+}
+
+func checkRefreshConsume(ctx context.Context, _ interfaces.SessionStore, tokens interfaces.RefreshTokenStore, prefix string) error {
+	now := storeNow()
+	token := newRefreshToken(prefix, "t1", prefix+"-session", now, time.Hour)
+	if err := tokens.Save(ctx, token); err != nil {
+		return err
+	}
+	prior, err := tokens.Consume(ctx, token.TokenHash, now)
+	if err != nil {
+		return err
+	}
+	if err := sameRefreshToken(prior, token); err != nil {
+		return err
+	}
+	again, err := tokens.Consume(ctx, token.TokenHash, now.Add(time.Minute))
+	if !errors.Is(err, domain.ErrRefreshTokenReused) {
+		return fmt.Errorf("second consume: got %v, want %v", err, domain.ErrRefreshTokenReused)
+	}
+	if again.SessionID != token.SessionID || !again.UsedAt.Equal(now) {
+		return fmt.Errorf("second consume returned %+v, want the token used at %s", again, now)
+	}
+	return nil
+}
+
+func checkRefreshUnknown(ctx context.Context, _ interfaces.SessionStore, tokens interfaces.RefreshTokenStore, prefix string) error {
+	if _, err := tokens.Consume(ctx, prefix+"-unknown", storeNow()); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		return fmt.Errorf("got %v, want %v", err, domain.ErrInvalidRefreshToken)
+	}
+	return nil
+}
+
+func checkRefreshConcurrentConsume(ctx context.Context, _ interfaces.SessionStore, tokens interfaces.RefreshTokenStore, prefix string) error {
+	const workers = 32
+
+	token := newRefreshToken(prefix, "contended", prefix+"-session", storeNow(), time.Hour)
+	if err := tokens.Save(ctx, token); err != nil {
+		return err
+	}
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = tokens.Consume(ctx, token.TokenHash, storeNow())
+		}()
+	}
+	wg.Wait()
+
+	var won int
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			won++
+		case !errors.Is(err, domain.ErrRefreshTokenReused):
+			return err
+		}
+	}
+	if won != 1 {
+		return fmt.Errorf("%d of %d concurrent consumes succeeded, want 1", won, workers)
+	}
+	return nil
+}
+
+func checkRefreshRevokeFamily(ctx context.Context, _ interfaces.SessionStore, tokens interfaces.RefreshTokenStore, prefix string) error {
+	now := storeNow()
+	family := []domain.RefreshToken{
+		newRefreshToken(prefix, "a", prefix+"-family", now, time.Hour),
+		newRefreshToken(prefix, "b", prefix+"-family", now, time.Hour),
+	}
+	other := newRefreshToken(prefix, "c", prefix+"-other", now, time.Hour)
+	for _, t := range append(family, other) {
+		if err := tokens.Save(ctx, t); err != nil {
+			return err
+		}
+	}
+	if err := tokens.RevokeFamily(ctx, prefix+"-family", now); err != nil {
+		return err
+	}
+
+	for _, t := range family {
+		got, err := tokens.Consume(ctx, t.TokenHash, now)
+		// A revoked family may already be swept.
+		if errors.Is(err, domain.ErrInvalidRefreshToken) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if got.Usable(now) {
+			return fmt.Errorf("token %s of a revoked family is still usable", t.TokenHash)
+		}
+	}
+	got, err := tokens.Consume(ctx, other.TokenHash, now)
+	if err != nil {
+		return err
+	}
+	if !got.Usable(now) {
+		return errors.New("revoking a family revoked another family's token")
+	}
+	return nil
+}
+
+func checkSessionReopen(ctx context.Context, h SessionHarness, prefix string, revoked domain.Session, staleID string, used domain.RefreshToken, expiredHash string) error {
+	sessions, tokens, release, err := h.Open()
+	if err != nil {
+		return fmt.Errorf("reopen: %w", err)
+	}
+	defer release()
+
+	// Sweeps may wait for the next write.
+	now := storeNow()
+	if err := sessions.Create(ctx, newSession(prefix, "after-reopen", now, time.Hour)); err != nil {
+		return err
+	}
+	if err := tokens.Save(ctx, newRefreshToken(prefix, "after-reopen", revoked.ID+"-next", now, time.Hour)); err != nil {
+		return err
+	}
+
+	got, err := sessions.Get(ctx, revoked.ID)
+	if err != nil {
+		return fmt.Errorf("revoked session after reopen: %w", err)
+	}
+	if err := sameSession(got, revoked); err != nil {
+		return fmt.Errorf("revoked session after reopen: %w", err)
+	}
+	listed, err := sessions.RevokedSince(ctx, revoked.RevokedAt)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(listed, func(s domain.Session) bool { return s.ID == revoked.ID }) {
+		return errors.New("revocation not listed after reopen")
+	}
+	if _, err := sessions.Get(ctx, staleID); !errors.Is(err, domain.ErrSessionNotFound) {
+		return fmt.Errorf("long expired session after reopen: got %v, want %v", err, domain.ErrSessionNotFound)
+	}
+
+	if _, err := tokens.Consume(ctx, used.TokenHash, now); !errors.Is(err, domain.ErrRefreshTokenReused) {
+		return fmt.Errorf("used refresh token after reopen: got %v, want %v", err, domain.ErrRefreshTokenReused)
+	}
+	if _, err := tokens.Consume(ctx, expiredHash, now); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		return fmt.Errorf("expired refresh token after reopen: got %v, want it swept (%v)", err, domain.ErrInvalidRefreshToken)
+	}
+	return nil
+}
+
+func sameSession(got, want domain.Session) error {
+	if got.ID != want.ID || got.PlatformUserID != want.PlatformUserID || got.SubjectType != want.SubjectType ||
+		got.Tenant != want.Tenant || got.Provider != want.Provider || !slices.Equal(got.Scopes, want.Scopes) ||
+		!got.CreatedAt.Equal(want.CreatedAt) || !got.ExpiresAt.Equal(want.ExpiresAt) ||
+		!got.RevokedAt.Equal(want.RevokedAt) || got.RevokeReason != want.RevokeReason {
+		return fmt.Errorf("got session %+v, want %+v", got, want)
+	}
+	return nil
+}
+
+func sameRefreshToken(got, want domain.RefreshToken) error {
+	if got.TokenHash != want.TokenHash || got.SessionID != want.SessionID || got.PlatformUserID != want.PlatformUserID ||
+		got.Tenant != want.Tenant || !got.IssuedAt.Equal(want.IssuedAt) || !got.ExpiresAt.Equal(want.ExpiresAt) ||
+		!got.UsedAt.Equal(want.UsedAt) || !got.RevokedAt.Equal(want.RevokedAt) {
+		return fmt.Errorf("got refresh token %+v, want %+v", got, want)
+	}
+	return nil
+}
+
+func newSession(prefix, name string, created time.Time, ttl time.Duration) domain.Session {
+	return domain.Session{
+		ID:             prefix + "-" + name,
+		PlatformUserID: prefix + "-user",
+		SubjectType:    domain.SubjectTypePlayer,
+		Scopes:         []string{"conformance:read", "conformance:write"},
+		CreatedAt:      created,
+		ExpiresAt:      created.Add(ttl),
+	}
+}
+
+func newRefreshToken(prefix, name, sessionID string, issued time.Time, ttl time.Duration) domain.RefreshToken {
+	return domain.RefreshToken{
+		TokenHash:      prefix + "-" + name,
+		SessionID:      sessionID,
+		PlatformUserID: prefix + "-user",
+		Tenant:         "t1",
+		IssuedAt:       issued,
+		ExpiresAt:      issued.Add(ttl),
+	}
+}
+
+// storeNow returns the current time at the precision every store keeps.
+func storeNow() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
@@ -0,0 +1,155 @@
+// Package events encodes identity's domain events into the envelope
+// defined by the event contracts in contracts/events/identity/ and hands
+// them to a transport.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// producer names identity as the source of its events.
+const producer = "identity"
+
+// envelope is the wire format shared by every event.
+type envelope struct {
+	EventID      string    `json:"eventId"`
+	EventType    string    `json:"eventType"`
+	EventVersion int       `json:"eventVersion"`
+	Timestamp    time.Time `json:"timestamp"`
+	Producer     string    `json:"producer"`
+	Payload      any       `json:"payload"`
+}
+
+// identityCreatedV1 is the payload of identity.created, version 1.
+type identityCreatedV1 struct {
+	PlatformUserID string    `json:"platform_user_id"`
+	Tenant         string    `json:"tenant"`
+	Provider       string    `json:"provider"`
+	ExternalUserID string    `json:"external_user_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// identityLinkedV1 is the payload of identity.linked, version 1.
+type identityLinkedV1 struct {
+	PlatformUserID string    `json:"platform_user_id"`
+	Tenant         string    `json:"tenant"`
+	Provider       string    `json:"provider"`
+	ExternalUserID string    `json:"external_user_id"`
+	LinkedAt       time.Time `json:"linked_at"`
+}
+
+// identityStatusChangedV1 is the payload of identity.status_changed,
+// version 1.
+type identityStatusChangedV1 struct {
+	PlatformUserID string    `json:"platform_user_id"`
+	Tenant         string    `json:"tenant"`
+	State          string    `json:"state"`
+	Reason         string    `json:"reason,omitempty"`
+	Until          time.Time `json:"until,omitzero"`
+	ChangedAt      time.Time `json:"changed_at"`
+}
+
+// identityErasedV1 is the payload of identity.erased, version 1.
+type identityErasedV1 struct {
+	PlatformUserID string    `json:"platform_user_id"`
+	MergedUserIDs  []string  `json:"merged_user_ids"`
+	ErasedAt       time.Time `json:"erased_at"`
+}
+
+// tokenIssuedV1 is the payload of token.issued, version 1.
+type tokenIssuedV1 struct {
+	PlatformUserID string    `json:"platform_user_id"`
+	Tenant         string    `json:"tenant"`
+	SubjectType    string    `json:"subject_type"`
+	Grant          string    `json:"grant"`
+	SessionID      string    `json:"session_id"`
+	Audience       string    `json:"audience"`
+	Scopes         []string  `json:"scopes"`
+	Actors         []string  `json:"actors,omitempty"`
+	IssuedAt       time.Time `json:"issued_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// Encode returns the outbox message of an event, carrying its contract
+// representation.
+func Encode(event domain.Event) (domain.OutboxMessage, error) {
+	data, err := encode(event)
+	if err != nil {
+		return domain.OutboxMessage{}, err
+	}
+	return domain.OutboxMessage{
+		ID:             event.ID,
+		Type:           event.Type,
+		PlatformUserID: event.PlatformUserID,
+		Data:           data,
+		CreatedAt:      event.At,
+	}, nil
+}
+
+// encode returns the contract representation of an event.
+func encode(event domain.Event) ([]byte, error) {
+	env := envelope{
+		EventID:      event.ID,
+		EventType:    string(event.Type),
+		EventVersion: 1,
+		Timestamp:    event.At.UTC(),
+		Producer:     producer,
+	}
+	switch p := event.Payload.(type) {
+	case domain.IdentityCreated:
+		env.Payload = identityCreatedV1{
+			PlatformUserID: p.PlatformUserID,
+			Tenant:         p.Tenant,
+			Provider:       p.Linkage.Provider,
+			ExternalUserID: p.Linkage.ExternalUserID,
+			CreatedAt:      p.CreatedAt.UTC(),
+		}
+	case domain.IdentityLinked:
+		env.Payload = identityLinkedV1{
+			PlatformUserID: p.PlatformUserID,
+			Tenant:         p.Tenant,
+			Provider:       p.Linkage.Provider,
+			ExternalUserID: p.Linkage.ExternalUserID,
+			LinkedAt:       p.Linkage.LinkedAt.UTC(),
+		}
+	case domain.IdentityStatusChanged:
+		env.Payload = identityStatusChangedV1{
+			PlatformUserID: p.PlatformUserID,
+			Tenant:         p.Tenant,
+			State:          string(p.Status.State),
+			Reason:         string(p.Status.Reason),
+			Until:          p.Status.Until.UTC(),
+			ChangedAt:      p.Status.ChangedAt.UTC(),
+		}
+	case domain.IdentityErased:
+		merged := p.MergedUserIDs
+		if merged == nil {
+			merged = []string{}
+		}
+		env.Payload = identityErasedV1{PlatformUserID: p.PlatformUserID, MergedUserIDs: merged, ErasedAt: p.ErasedAt.UTC()}
+	case domain.TokenIssued:
+		scopes := p.Scopes
+		if scopes == nil {
+			scopes = []string{}
+		}
+		env.Payload = tokenIssuedV1{
+			PlatformUserID: p.PlatformUserID,
+			Tenant:         p.Tenant,
+			SubjectType:    p.SubjectType,
+			Grant:          p.Grant,
+			SessionID:      p.SessionID,
+			Audience:       p.Audience,
+			Scopes:         scopes,
+			Actors:         p.Actors,
+			IssuedAt:       p.IssuedAt.UTC(),
+			ExpiresAt:      p.ExpiresAt.UTC(),
+		}
+	default:
+		return nil, fmt.Errorf("event %s: unsupported payload %T", event.Type, event.Payload)
+	}
+	return json.Marshal(env)
+}
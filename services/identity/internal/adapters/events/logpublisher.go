@@ -0,0 +1,23 @@
+package events
+
+import (
+	"context"
+	"log"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// LogPublisher implements interfaces.EventPublisher by writing each event
+// envelope to the service log, for setups without a message broker.
+type LogPublisher struct{}
+
+// NewLogPublisher creates a log publisher.
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+// Publish implements interfaces.EventPublisher.
+func (p *LogPublisher) Publish(_ context.Context, msg domain.OutboxMessage) error {
+	log.Printf("event %s", msg.Data)
+	return nil
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
+	authadapter "github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/auth"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/signingkeys"
+)
+
+func TestDiscovery(t *testing.T) {
+	tests := []struct {
+		name      string
+		discovery Discovery
+		// algs are the algorithms of the seeded ring, the first one seeding it.
+		algs []string
+		// wantAuthorization expects the authorization code flow to be
+		// advertised.
+		wantAuthorization bool
+		wantAlgs          []string
+	}{
+		{
+			name:      "token exchange only",
+			discovery: Discovery{Issuer: "proteon.identity", BaseURL: "https://id.example.com/"},
+			algs:      []string{jwtverifier.AlgEdDSA},
+			wantAlgs:  []string{jwtverifier.AlgEdDSA},
+		},
+		{
+			name: "authorization code flow",
+			discovery: Discovery{
+				Issuer:                "proteon.identity",
+				BaseURL:               "https://id.example.com",
+				AuthorizationEndpoint: "https://bo.example.com/backoffice/authorize",
+				TokenEndpoint:         "https://bo.example.com/backoffice/token",
+			},
+			algs:              []string{jwtverifier.AlgEdDSA},
+			wantAuthorization: true,
+			wantAlgs:          []string{jwtverifier.AlgEdDSA},
+		},
+		{
+			name:      "several signing algorithms",
+			discovery: Discovery{Issuer: "proteon.identity", BaseURL: "https://id.example.com"},
+			algs:      []string{jwtverifier.AlgRS256, jwtverifier.AlgEdDSA},
+			wantAlgs:  []string{jwtverifier.AlgEdDSA, jwtverifier.AlgRS256},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ring, err := authadapter.NewKeyRing("")
+			if err != nil {
+				t.Fatalf("NewKeyRing: %v", err)
+			}
+			key, err := authadapter.GenerateSigningKey(tt.algs[0])
+			if err != nil {
+				t.Fatalf("GenerateSigningKey: %v", err)
+			}
+			now := time.Now()
+			if err := ring.Seed(key, now); err != nil {
+				t.Fatalf("Seed: %v", err)
+			}
+			for _, alg := range tt.algs[1:] {
+				if err := ring.AddAlgorithm(alg, now); err != nil {
+					t.Fatalf("AddAlgorithm: %v", err)
+				}
+			}
+			h := NewHandler(Services{SigningKeys: signingkeys.NewService(ring, signingkeys.Policy{})}, time.Minute, tt.discovery, "identity", "test")
+
+			resp, err := h.GetWellKnownOpenidConfiguration(context.Background(), server.GetWellKnownOpenidConfigurationRequestObject{})
+			if err != nil {
+				t.Fatalf("GetWellKnownOpenidConfiguration: %v", err)
+			}
+			doc, ok := resp.(server.GetWellKnownOpenidConfiguration200JSONResponse)
+			if !ok {
+				t.Fatalf("response = %T, want the document", resp)
+			}
+
+			if doc.Issuer != tt.discovery.Issuer {
+				t.Errorf("issuer = %q, want %q", doc.Issuer, tt.discovery.Issuer)
+			}
+			if want := "https://id.example.com/v1/.well-known/jwks.json"; doc.JwksUri != want {
+				t.Errorf("jwks_uri = %q, want %q", doc.JwksUri, want)
+			}
+			if want := "https://id.example.com" + tokenExchangePath; doc.TokenExchangeEndpoint != want {
+				t.Errorf("token_exchange_endpoint = %q, want %q", doc.TokenExchangeEndpoint, want)
+			}
+			if !slices.Equal(doc.AccessTokenSigningAlgValuesSupported, tt.wantAlgs) {
+				t.Errorf("access_token_signing_alg_values_supported = %v, want %v", doc.AccessTokenSigningAlgValuesSupported, tt.wantAlgs)
+			}
+
+			wantGrants := []string{grantTypeTokenExchange}
+			if tt.wantAuthorization {
+				wantGrants = []string{"authorization_code", grantTypeTokenExchange}
+			}
+			if !slices.Equal(doc.GrantTypesSupported, wantGrants) {
+				t.Errorf("grant_types_supported = %v, want %v", doc.GrantTypesSupported, wantGrants)
+			}
+			optional := map[string]bool{
+				"authorization_endpoint":           doc.AuthorizationEndpoint != nil,
+				"token_endpoint":                   doc.TokenEndpoint != nil,
+				"response_types_supported":         doc.ResponseTypesSupported != nil,
+				"subject_types_supported":          doc.SubjectTypesSupported != nil,
+				"code_challenge_methods_supported": doc.CodeChallengeMethodsSupported != nil,
+			}
+			for field, set := range optional {
+				if set != tt.wantAuthorization {
+					t.Errorf("%s set = %v, want %v", field, set, tt.wantAuthorization)
+				}
+			}
+			if !tt.wantAuthorization {
+				return
+			}
+			if *doc.AuthorizationEndpoint != tt.discovery.AuthorizationEndpoint || *doc.TokenEndpoint != tt.discovery.TokenEndpoint {
+				t.Errorf("endpoints = %s, %s; want %s, %s", *doc.AuthorizationEndpoint, *doc.TokenEndpoint,
+					tt.discovery.AuthorizationEndpoint, tt.discovery.TokenEndpoint)
+			}
+			if !slices.Equal(*doc.ResponseTypesSupported, []string{"code"}) || !slices.Equal(*doc.SubjectTypesSupported, []string{"public"}) ||
+				!slices.Equal(*doc.CodeChallengeMethodsSupported, []string{"S256"}) {
+				t.Errorf("response, subject types and challenge methods = %v, %v, %v; want [code], [public], [S256]",
+					*doc.ResponseTypesSupported, *doc.SubjectTypesSupported, *doc.CodeChallengeMethodsSupported)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+func (h *Handler) DeleteInternalV1UsersUserId(ctx context.Context, req server.DeleteInternalV1UsersUserIdRequestObject) (server.DeleteInternalV1UsersUserIdResponseObject, error) {
+	var reason string
+	if req.Params.Reason != nil {
+		reason = *req.Params.Reason
+	}
+
+	erasure, err := h.authSvc.Erase(ctx, req.UserId.String(), callerFrom(ctx), reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrIdentityMerged):
+			return server.DeleteInternalV1UsersUserId409JSONResponse{
+				ConflictJSONResponse: server.ConflictJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "IDENTITY_MERGED", Message: "platform identity was merged into another; erase the survivor"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrIdentityNotFound):
+			return server.DeleteInternalV1UsersUserId404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "NOT_FOUND", Message: "platform identity not found"},
+				}),
+			}, nil
+		}
+		return server.DeleteInternalV1UsersUserId500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp, err := toErasure(erasure)
+	if err != nil {
+		return server.DeleteInternalV1UsersUserId500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.DeleteInternalV1UsersUserId200JSONResponse(resp), nil
+}
+
+func (h *Handler) GetInternalV1UsersUserIdExport(ctx context.Context, req server.GetInternalV1UsersUserIdExportRequestObject) (server.GetInternalV1UsersUserIdExportResponseObject, error) {
+	export, err := h.authSvc.Export(ctx, req.UserId.String())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrIdentityErased):
+			return server.GetInternalV1UsersUserIdExport404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "IDENTITY_ERASED", Message: "platform identity was erased"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrIdentityNotFound):
+			return server.GetInternalV1UsersUserIdExport404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "NOT_FOUND", Message: "platform identity not found"},
+				}),
+			}, nil
+		}
+		return server.GetInternalV1UsersUserIdExport500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp, err := toExport(export)
+	if err != nil {
+		return server.GetInternalV1UsersUserIdExport500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.GetInternalV1UsersUserIdExport200JSONResponse(resp), nil
+}
+
+func toErasure(erasure domain.Erasure) (server.ErasureResponse, error) {
+	platformUserUUID, err := uuid.Parse(erasure.PlatformUserID)
+	if err != nil {
+		return server.ErasureResponse{}, err
+	}
+	merged, err := toUUIDs(erasure.MergedUserIDs)
+	if err != nil {
+		return server.ErasureResponse{}, err
+	}
+	return server.ErasureResponse{
+		PlatformUserId: platformUserUUID,
+		MergedUserIds:  merged,
+		ErasedAt:       erasure.ErasedAt,
+	}, nil
+}
+
+func toExport(export domain.IdentityExport) (server.UserExportResponse, error) {
+	user, err := toPlatformIdentity(export.Identity)
+	if err != nil {
+		return server.UserExportResponse{}, err
+	}
+	merged, err := toUUIDs(export.MergedUserIDs)
+	if err != nil {
+		return server.UserExportResponse{}, err
+	}
+	sessions, err := toSessionList(export.Sessions)
+	if err != nil {
+		return server.UserExportResponse{}, err
+	}
+
+	out := server.UserExportResponse{
+		User:          user,
+		MergedUserIds: merged,
+		Sessions:      sessions.Sessions,
+		AuditEntries:  make([]server.AuditEntry, 0, len(export.Audit)),
+		ExportedAt:    export.ExportedAt,
+	}
+	for _, e := range export.Audit {
+		platformUserUUID, err := uuid.Parse(e.PlatformUserID)
+		if err != nil {
+			return server.UserExportResponse{}, err
+		}
+		entry := server.AuditEntry{
+			Id:             e.ID,
+			PlatformUserId: platformUserUUID,
+			Action:         string(e.Action),
+			Actor:          optionalString(e.Actor),
+			RecordedAt:     e.At,
+		}
+		if len(e.Details) > 0 {
+			details := e.Details
+			entry.Details = &details
+		}
+		out.AuditEntries = append(out.AuditEntries, entry)
+	}
+	return out, nil
+}
+
+func toUUIDs(ids []string) ([]uuid.UUID, error) {
+	out := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		u, err := uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, u)
+	}
+	return out, nil
+}
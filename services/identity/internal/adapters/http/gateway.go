@@ -0,0 +1,29 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/woffVienna/proteon-cursor/libs/platform/httpcommon"
+)
+
+// Headers the API gateway sets from the access token it verified. They are
+// absent on direct service calls.
+const (
+	headerPlatformUserID = "X-Platform-User-Id"
+	headerPlatformTenant = "X-Platform-Tenant"
+)
+
+// callerTenant returns the tenant of the caller's access token, and false
+// on direct service calls. Tokens without a tenant yield "" and true.
+func callerTenant(ctx context.Context) (string, bool) {
+	r := httpcommon.HTTPRequestFromContext(ctx)
+	if r == nil {
+		return "", false
+	}
+	values, ok := r.Header[http.CanonicalHeaderKey(headerPlatformTenant)]
+	if !ok || len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
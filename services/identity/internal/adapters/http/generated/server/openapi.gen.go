@@ -16,6 +16,24 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	BasicAuthScopes = "basicAuth.Scopes"
+)
+
+// Defines values for AssertionKeyAlg.
+const (
+	ES256 AssertionKeyAlg = "ES256"
+	EdDSA AssertionKeyAlg = "EdDSA"
+	RS256 AssertionKeyAlg = "RS256"
+)
+
+// Defines values for AssertionKeyKty.
+const (
+	EC  AssertionKeyKty = "EC"
+	OKP AssertionKeyKty = "OKP"
+	RSA AssertionKeyKty = "RSA"
+)
+
 // Defines values for AuthExchangeResponseTokenType.
 const (
 	AuthExchangeResponseTokenTypeBearer AuthExchangeResponseTokenType = "Bearer"
@@ -37,16 +55,116 @@ const (
 	Ok HealthResponseStatus = "ok"
 )
 
-// AuthExchangeRequest defines model for AuthExchangeRequest.
-type AuthExchangeRequest struct {
-	// ExternalUserId User ID as known by the customer platform
-	ExternalUserId string `json:"external_user_id"`
+// Defines values for IdentityState.
+const (
+	IdentityStateActive    IdentityState = "active"
+	IdentityStateBanned    IdentityState = "banned"
+	IdentityStateSuspended IdentityState = "suspended"
+)
 
-	// Provider Identifier of the customer platform or auth provider
-	Provider string `json:"provider"`
+// Defines values for IntrospectionResponseSessionStatus.
+const (
+	IntrospectionResponseSessionStatusActive  IntrospectionResponseSessionStatus = "active"
+	IntrospectionResponseSessionStatusRevoked IntrospectionResponseSessionStatus = "revoked"
+	IntrospectionResponseSessionStatusUnknown IntrospectionResponseSessionStatus = "unknown"
+)
 
-	// Tenant Optional tenant context
-	Tenant *string `json:"tenant,omitempty"`
+// Defines values for ProfileAttributeSource.
+const (
+	Api      ProfileAttributeSource = "api"
+	Exchange ProfileAttributeSource = "exchange"
+)
+
+// Defines values for ProfileField.
+const (
+	BirthYear   ProfileField = "birth_year"
+	Country     ProfileField = "country"
+	DisplayName ProfileField = "display_name"
+	Locale      ProfileField = "locale"
+)
+
+// Defines values for ProviderStatus.
+const (
+	ProviderStatusActive   ProviderStatus = "active"
+	ProviderStatusDisabled ProviderStatus = "disabled"
+)
+
+// Defines values for SigningKeyState.
+const (
+	Active   SigningKeyState = "active"
+	Next     SigningKeyState = "next"
+	Retired  SigningKeyState = "retired"
+	Retiring SigningKeyState = "retiring"
+)
+
+// Defines values for StatusReason.
+const (
+	Abuse          StatusReason = "abuse"
+	AppealGranted  StatusReason = "appeal_granted"
+	Chargeback     StatusReason = "chargeback"
+	Cheating       StatusReason = "cheating"
+	Fraud          StatusReason = "fraud"
+	Other          StatusReason = "other"
+	TermsViolation StatusReason = "terms_violation"
+	Underage       StatusReason = "underage"
+)
+
+// Defines values for TokenExchangeResponseTokenType.
+const (
+	TokenExchangeResponseTokenTypeBearer TokenExchangeResponseTokenType = "Bearer"
+)
+
+// AssertionKey Public JWK (OKP Ed25519, EC P-256 or RSA of at least 2048 bits)
+type AssertionKey struct {
+	Alg *AssertionKeyAlg `json:"alg,omitempty"`
+	Crv *string          `json:"crv,omitempty"`
+	E   *string          `json:"e,omitempty"`
+
+	// Kid Required when the provider has several keys
+	Kid *string         `json:"kid,omitempty"`
+	Kty AssertionKeyKty `json:"kty"`
+	N   *string         `json:"n,omitempty"`
+	X   *string         `json:"x,omitempty"`
+	Y   *string         `json:"y,omitempty"`
+}
+
+// AssertionKeyAlg defines model for AssertionKey.Alg.
+type AssertionKeyAlg string
+
+// AssertionKeyKty defines model for AssertionKey.Kty.
+type AssertionKeyKty string
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	// Action identity.merged or identity.erased
+	Action string `json:"action"`
+
+	// Actor Internal caller that made the change
+	Actor          *string            `json:"actor,omitempty"`
+	Details        *map[string]string `json:"details,omitempty"`
+	Id             string             `json:"id"`
+	PlatformUserId openapi_types.UUID `json:"platform_user_id"`
+	RecordedAt     time.Time          `json:"recorded_at"`
+}
+
+// AuthExchangeRequest defines model for AuthExchangeRequest.
+type AuthExchangeRequest struct {
+	// Assertion Compact JWS signed by the customer backend with a key registered
+	// for its provider (EdDSA, ES256 or RS256). Claims: iss (provider),
+	// sub (external user ID), aud (the identity service), iat, exp, jti
+	// and an optional tenant. The lifetime exp - iat is capped and every
+	// jti is accepted only once.
+	Assertion string `json:"assertion"`
+
+	// Profile Optional profile attributes. Supplied with an auth exchange, they
+	// are recorded as of the assertion's iat with source exchange and
+	// only replace exchange values that are not newer.
+	Profile *UserProfile `json:"profile,omitempty"`
+
+	// Scope Optional space-separated subset of the scopes the scope policy
+	// grants. Defaults to every granted scope; requesting a scope that
+	// is not granted fails with INVALID_SCOPE.
+	Scope *string `json:"scope,omitempty"`
 }
 
 // AuthExchangeResponse defines model for AuthExchangeResponse.
@@ -57,18 +175,35 @@ type AuthExchangeResponse struct {
 	ExpiresIn int32 `json:"expires_in"`
 
 	// PlatformUserId Proteon platform user ID (stable across exchanges)
-	PlatformUserId openapi_types.UUID            `json:"platform_user_id"`
-	TokenType      AuthExchangeResponseTokenType `json:"token_type"`
+	PlatformUserId openapi_types.UUID `json:"platform_user_id"`
+
+	// RefreshExpiresIn Refresh token lifetime in seconds
+	RefreshExpiresIn int32 `json:"refresh_expires_in"`
+
+	// RefreshToken Opaque, single-use refresh token for POST /v1/auth/refresh
+	RefreshToken string `json:"refresh_token"`
+
+	// Scope Space-separated scopes granted to the token; omitted when none
+	Scope     *string                       `json:"scope,omitempty"`
+	TokenType AuthExchangeResponseTokenType `json:"token_type"`
 }
 
 // AuthExchangeResponseTokenType defines model for AuthExchangeResponse.TokenType.
 type AuthExchangeResponseTokenType string
 
+// AuthRefreshRequest defines model for AuthRefreshRequest.
+type AuthRefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
 // BackofficeTokenRequest defines model for BackofficeTokenRequest.
 type BackofficeTokenRequest struct {
 	// Audience Optional audience override (defaults to \"backoffice\")
 	Audience *string `json:"audience,omitempty"`
 
+	// Scope Optional space-separated subset of the granted scopes
+	Scope *string `json:"scope,omitempty"`
+
 	// SubjectType Type of backoffice user (operator or tenant_user)
 	SubjectType BackofficeTokenRequestSubjectType `json:"subject_type"`
 
@@ -87,13 +222,25 @@ type BackofficeTokenResponse struct {
 	AccessToken string `json:"access_token"`
 
 	// ExpiresIn Token lifetime in seconds
-	ExpiresIn int32                            `json:"expires_in"`
+	ExpiresIn int32 `json:"expires_in"`
+
+	// Scope Space-separated scopes granted to the token; omitted when none
+	Scope     *string                          `json:"scope,omitempty"`
 	TokenType BackofficeTokenResponseTokenType `json:"token_type"`
 }
 
 // BackofficeTokenResponseTokenType defines model for BackofficeTokenResponse.TokenType.
 type BackofficeTokenResponseTokenType string
 
+// ErasureResponse defines model for ErasureResponse.
+type ErasureResponse struct {
+	ErasedAt time.Time `json:"erased_at"`
+
+	// MergedUserIds Platform user IDs merged into the identity, erased with it
+	MergedUserIds  []openapi_types.UUID `json:"merged_user_ids"`
+	PlatformUserId openapi_types.UUID   `json:"platform_user_id"`
+}
+
 // ErrorBody defines model for ErrorBody.
 type ErrorBody struct {
 	// Code Stable machine-readable error code
@@ -124,6 +271,80 @@ type HealthResponse struct {
 // HealthResponseStatus defines model for HealthResponse.Status.
 type HealthResponseStatus string
 
+// IdentityProfile Profile attributes that are set, each with its source
+type IdentityProfile struct {
+	BirthYear   *ProfileAttribute `json:"birth_year,omitempty"`
+	Country     *ProfileAttribute `json:"country,omitempty"`
+	DisplayName *ProfileAttribute `json:"display_name,omitempty"`
+	Locale      *ProfileAttribute `json:"locale,omitempty"`
+}
+
+// IdentityState defines model for IdentityState.
+type IdentityState string
+
+// IdentityStatus Lifecycle status in effect; a suspension that has ended reads as active
+type IdentityStatus struct {
+	// ChangedAt When the status took effect; absent for identities that were
+	// never suspended or banned
+	ChangedAt *time.Time `json:"changed_at,omitempty"`
+
+	// Reason Reason code of a status change
+	Reason *StatusReason `json:"reason,omitempty"`
+	State  IdentityState `json:"state"`
+
+	// Until End of a suspension
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// IdentityStatusRequest defines model for IdentityStatusRequest.
+type IdentityStatusRequest struct {
+	// Note Free text recorded in the audit log
+	Note *string `json:"note,omitempty"`
+
+	// Reason Reason code of a status change
+	Reason *StatusReason `json:"reason,omitempty"`
+	State  IdentityState `json:"state"`
+
+	// Until End of the suspension; required for suspended, not allowed otherwise
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// IntrospectionRequest defines model for IntrospectionRequest.
+type IntrospectionRequest struct {
+	Token string `json:"token"`
+
+	// TokenTypeHint Ignored; only access tokens can be introspected
+	TokenTypeHint *string `json:"token_type_hint,omitempty"`
+}
+
+// IntrospectionResponse defines model for IntrospectionResponse.
+type IntrospectionResponse struct {
+	// Act act claim of a delegated token (RFC 8693): the service acting for
+	// the subject and, nested, the actor before it.
+	Act    *TokenActor `json:"act,omitempty"`
+	Active bool        `json:"active"`
+
+	// Exp Expiry as seconds since the epoch
+	Exp *int64 `json:"exp,omitempty"`
+	Iat *int64 `json:"iat,omitempty"`
+
+	// Scope Space-separated scopes
+	Scope *string `json:"scope,omitempty"`
+
+	// SessionStatus Revocation state of the token's session. unknown for tokens
+	// without sid or whose session this instance does not know.
+	SessionStatus *IntrospectionResponseSessionStatus `json:"session_status,omitempty"`
+	Sid           *string                             `json:"sid,omitempty"`
+	Sub           *string                             `json:"sub,omitempty"`
+	SubjectType   *string                             `json:"subject_type,omitempty"`
+	Tenant        *string                             `json:"tenant,omitempty"`
+	TokenType     *string                             `json:"token_type,omitempty"`
+}
+
+// IntrospectionResponseSessionStatus Revocation state of the token's session. unknown for tokens
+// without sid or whose session this instance does not know.
+type IntrospectionResponseSessionStatus string
+
 // Jwk defines model for Jwk.
 type Jwk struct {
 	Alg                  *string                `json:"alg,omitempty"`
@@ -138,18 +359,377 @@ type JwksResponse struct {
 	Keys []Jwk `json:"keys"`
 }
 
+// Linkage defines model for Linkage.
+type Linkage struct {
+	ExternalUserId string    `json:"external_user_id"`
+	LinkedAt       time.Time `json:"linked_at"`
+	Provider       string    `json:"provider"`
+
+	// Tenant Tenant asserted when the linkage was made
+	Tenant *string `json:"tenant,omitempty"`
+}
+
+// LinkageRequest defines model for LinkageRequest.
+type LinkageRequest struct {
+	ExternalUserId string `json:"external_user_id"`
+
+	// Provider ID of a registered provider
+	Provider string `json:"provider"`
+
+	// Tenant Tenant of the external identity; defaults to, and must equal,
+	// the tenant of the platform identity. Must be allowed by the
+	// provider when it restricts tenants.
+	Tenant *string `json:"tenant,omitempty"`
+}
+
+// MergeRequest defines model for MergeRequest.
+type MergeRequest struct {
+	// MergedUserId Platform user ID of the duplicate to merge into the user
+	MergedUserId openapi_types.UUID `json:"merged_user_id"`
+
+	// Reason Why the identities are merged; recorded in the audit log
+	Reason *string `json:"reason,omitempty"`
+}
+
+// OpenidConfiguration defines model for OpenidConfiguration.
+type OpenidConfiguration struct {
+	// AccessTokenSigningAlgValuesSupported Algorithms of the published signing keys. Identity issues no ID
+	// tokens, so id_token_signing_alg_values_supported is not
+	// advertised.
+	AccessTokenSigningAlgValuesSupported []string `json:"access_token_signing_alg_values_supported"`
+
+	// AuthorizationEndpoint The backoffice authorization code endpoint
+	// (OAUTH_AUTHORIZATION_ENDPOINT); omitted when not configured
+	AuthorizationEndpoint         *string   `json:"authorization_endpoint,omitempty"`
+	ClaimsSupported               []string  `json:"claims_supported"`
+	CodeChallengeMethodsSupported *[]string `json:"code_challenge_methods_supported,omitempty"`
+
+	// GrantTypesSupported urn:ietf:params:oauth:grant-type:token-exchange, and
+	// authorization_code when the authorization endpoint is configured
+	GrantTypesSupported                       []string `json:"grant_types_supported"`
+	IntrospectionEndpoint                     string   `json:"introspection_endpoint"`
+	IntrospectionEndpointAuthMethodsSupported []string `json:"introspection_endpoint_auth_methods_supported"`
+
+	// Issuer The iss claim of every access token (JWT_ISSUER)
+	Issuer  string `json:"issuer"`
+	JwksUri string `json:"jwks_uri"`
+
+	// ResponseTypesSupported code, when the authorization endpoint is configured; omitted
+	// otherwise
+	ResponseTypesSupported *[]string `json:"response_types_supported,omitempty"`
+
+	// SubjectTypesSupported public, when the authorization endpoint is configured; omitted
+	// otherwise
+	SubjectTypesSupported *[]string `json:"subject_types_supported,omitempty"`
+
+	// TokenEndpoint The backoffice token endpoint (OAUTH_TOKEN_ENDPOINT); omitted
+	// when not configured
+	TokenEndpoint *string `json:"token_endpoint,omitempty"`
+
+	// TokenExchangeEndpoint The RFC 8693 token exchange endpoint for internal services, which
+	// authenticate with their service credentials
+	TokenExchangeEndpoint string `json:"token_exchange_endpoint"`
+}
+
 // PlatformIdentityResponse defines model for PlatformIdentityResponse.
 type PlatformIdentityResponse struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExternalUserId External user ID of the primary linkage
+	// Deprecated: Use linkages, which lists every linked external identity
+	ExternalUserId string `json:"external_user_id"`
+
+	// Linkages Linked external identities, oldest first. The first is the
+	// primary linkage: the one the identity was created from, or the
+	// oldest remaining one.
+	Linkages       []Linkage          `json:"linkages"`
+	PlatformUserId openapi_types.UUID `json:"platform_user_id"`
+
+	// Profile Profile attributes that are set, each with its source
+	Profile *IdentityProfile `json:"profile,omitempty"`
+
+	// Provider Provider of the primary linkage
+	// Deprecated: Use linkages, which lists every linked external identity
+	Provider string `json:"provider"`
+
+	// Status Lifecycle status in effect; a suspension that has ended reads as active
+	Status IdentityStatus `json:"status"`
+
+	// Tenant Tenant the identity was created in
+	Tenant *string `json:"tenant,omitempty"`
+}
+
+// ProfileAttribute defines model for ProfileAttribute.
+type ProfileAttribute struct {
+	// Source exchange for values supplied with an auth exchange, api for
+	// values set through PATCH /v1/users/{userId}
+	Source ProfileAttributeSource `json:"source"`
+
+	// UpdatedAt Assertion iat for exchange values, update time for api values
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Value Attribute value; birth years are decimal
+	Value string `json:"value"`
+}
+
+// ProfileAttributeSource exchange for values supplied with an auth exchange, api for
+// values set through PATCH /v1/users/{userId}
+type ProfileAttributeSource string
+
+// ProfileField defines model for ProfileField.
+type ProfileField string
+
+// Provider defines model for Provider.
+type Provider struct {
+	// AllowedTenants Tenants assertions may carry; empty allows any tenant
+	AllowedTenants []string `json:"allowed_tenants"`
+
+	// AssertionKeys Public keys the provider signs identity assertions with
+	AssertionKeys []AssertionKey `json:"assertion_keys"`
+	CreatedAt     time.Time      `json:"created_at"`
+	DisplayName   string         `json:"display_name"`
+
+	// Id Value of the iss claim of the provider's assertions
+	Id string `json:"id"`
+
+	// Status Defaults to active on create
+	Status        ProviderStatus        `json:"status"`
+	TokenSettings ProviderTokenSettings `json:"token_settings"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+// ProviderCreateRequest defines model for ProviderCreateRequest.
+type ProviderCreateRequest struct {
+	// AllowedTenants Tenants assertions may carry; empty allows any tenant
+	AllowedTenants *[]string `json:"allowed_tenants,omitempty"`
+
+	// AssertionKeys Public keys the provider signs identity assertions with
+	AssertionKeys *[]AssertionKey `json:"assertion_keys,omitempty"`
+	DisplayName   *string         `json:"display_name,omitempty"`
+	Id            string          `json:"id"`
+
+	// Status Defaults to active on create
+	Status        *ProviderStatus        `json:"status,omitempty"`
+	TokenSettings *ProviderTokenSettings `json:"token_settings,omitempty"`
+}
+
+// ProviderListResponse defines model for ProviderListResponse.
+type ProviderListResponse struct {
+	Providers []Provider `json:"providers"`
+}
+
+// ProviderStatus Defaults to active on create
+type ProviderStatus string
+
+// ProviderTokenSettings defines model for ProviderTokenSettings.
+type ProviderTokenSettings struct {
+	// AccessTokenTtl Player access token lifetime in seconds; 0 or omitted uses the
+	// default. At least 60 and at most the longest configured access
+	// token lifetime.
+	AccessTokenTtl *int32 `json:"access_token_ttl,omitempty"`
+
+	// ProfileClaims Profile attributes copied into the provider's player access
+	// tokens when set: display_name as name, locale, country and
+	// birth_year (a number).
+	ProfileClaims *[]ProfileField `json:"profile_claims,omitempty"`
+
+	// RefreshTokenTtl Player refresh token and session lifetime in seconds; 0 or omitted
+	// uses the default. May shorten but not extend REFRESH_TOKEN_TTL.
+	RefreshTokenTtl *int32 `json:"refresh_token_ttl,omitempty"`
+}
+
+// ProviderUpdateRequest defines model for ProviderUpdateRequest.
+type ProviderUpdateRequest struct {
+	// AllowedTenants Tenants assertions may carry; empty allows any tenant
+	AllowedTenants *[]string `json:"allowed_tenants,omitempty"`
+
+	// AssertionKeys Public keys the provider signs identity assertions with
+	AssertionKeys *[]AssertionKey `json:"assertion_keys,omitempty"`
+	DisplayName   *string         `json:"display_name,omitempty"`
+
+	// Status Defaults to active on create
+	Status        *ProviderStatus        `json:"status,omitempty"`
+	TokenSettings *ProviderTokenSettings `json:"token_settings,omitempty"`
+}
+
+// Revocation defines model for Revocation.
+type Revocation struct {
+	// ExpiresAt After this time no access token of the session is valid anyway
+	ExpiresAt      time.Time          `json:"expires_at"`
+	PlatformUserId openapi_types.UUID `json:"platform_user_id"`
+	RevokedAt      time.Time          `json:"revoked_at"`
+	Sid            string             `json:"sid"`
+}
+
+// RevocationListResponse defines model for RevocationListResponse.
+type RevocationListResponse struct {
+	// AsOf Pass as since on the next poll
+	AsOf        time.Time    `json:"as_of"`
+	Revocations []Revocation `json:"revocations"`
+}
+
+// Session defines model for Session.
+type Session struct {
 	CreatedAt      time.Time          `json:"created_at"`
-	ExternalUserId string             `json:"external_user_id"`
+	ExpiresAt      time.Time          `json:"expires_at"`
 	PlatformUserId openapi_types.UUID `json:"platform_user_id"`
-	Provider       string             `json:"provider"`
-	Tenant         *string            `json:"tenant,omitempty"`
+
+	// Provider Provider of a player session
+	Provider     *string    `json:"provider,omitempty"`
+	RevokeReason *string    `json:"revoke_reason,omitempty"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+
+	// Scope Space-separated scopes of the session's tokens
+	Scope *string `json:"scope,omitempty"`
+
+	// SessionId Value of the "sid" claim of the session's access tokens
+	SessionId string `json:"session_id"`
+
+	// SubjectType player, operator or tenant_user
+	SubjectType string  `json:"subject_type"`
+	Tenant      *string `json:"tenant,omitempty"`
+}
+
+// SessionListResponse defines model for SessionListResponse.
+type SessionListResponse struct {
+	Sessions []Session `json:"sessions"`
+}
+
+// SigningKey defines model for SigningKey.
+type SigningKey struct {
+	ActivatedAt *time.Time `json:"activated_at,omitempty"`
+	Alg         string     `json:"alg"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	// Kid RFC 7638 JWK thumbprint of the public key
+	Kid        string          `json:"kid"`
+	RetiredAt  *time.Time      `json:"retired_at,omitempty"`
+	RetiringAt *time.Time      `json:"retiring_at,omitempty"`
+	State      SigningKeyState `json:"state"`
+}
+
+// SigningKeyState defines model for SigningKey.State.
+type SigningKeyState string
+
+// SigningKeyListResponse defines model for SigningKeyListResponse.
+type SigningKeyListResponse struct {
+	Keys []SigningKey `json:"keys"`
+}
+
+// StatusReason Reason code of a status change
+type StatusReason string
+
+// TokenActor act claim of a delegated token (RFC 8693): the service acting for
+// the subject and, nested, the actor before it.
+type TokenActor struct {
+	// Act act claim of a delegated token (RFC 8693): the service acting for
+	// the subject and, nested, the actor before it.
+	Act *TokenActor `json:"act,omitempty"`
+
+	// Sub Service name of the actor
+	Sub string `json:"sub"`
+}
+
+// TokenExchangeRequest defines model for TokenExchangeRequest.
+type TokenExchangeRequest struct {
+	// Audience Audience of the new token
+	Audience string `json:"audience"`
+
+	// GrantType Must be urn:ietf:params:oauth:grant-type:token-exchange
+	GrantType string `json:"grant_type"`
+
+	// RequestedTokenType Optional; only urn:ietf:params:oauth:token-type:access_token is issued
+	RequestedTokenType *string `json:"requested_token_type,omitempty"`
+
+	// Scope Space-separated subset of the allowed scopes
+	Scope *string `json:"scope,omitempty"`
+
+	// SubjectToken Proteon access token of the subject
+	SubjectToken string `json:"subject_token"`
+
+	// SubjectTokenType urn:ietf:params:oauth:token-type:access_token or
+	// urn:ietf:params:oauth:token-type:jwt
+	SubjectTokenType string `json:"subject_token_type"`
+}
+
+// TokenExchangeResponse defines model for TokenExchangeResponse.
+type TokenExchangeResponse struct {
+	AccessToken string `json:"access_token"`
+
+	// ExpiresIn Token lifetime in seconds
+	ExpiresIn       int32  `json:"expires_in"`
+	IssuedTokenType string `json:"issued_token_type"`
+
+	// Scope Space-separated scopes granted to the token; omitted when none
+	Scope     *string                        `json:"scope,omitempty"`
+	TokenType TokenExchangeResponseTokenType `json:"token_type"`
+}
+
+// TokenExchangeResponseTokenType defines model for TokenExchangeResponse.TokenType.
+type TokenExchangeResponseTokenType string
+
+// UserExportResponse defines model for UserExportResponse.
+type UserExportResponse struct {
+	// AuditEntries Audit entries of the user and of the merged IDs, oldest first
+	AuditEntries []AuditEntry `json:"audit_entries"`
+	ExportedAt   time.Time    `json:"exported_at"`
+
+	// MergedUserIds Platform user IDs merged into the user
+	MergedUserIds []openapi_types.UUID `json:"merged_user_ids"`
+
+	// Sessions Sessions of the user and of the merged IDs
+	Sessions []Session                `json:"sessions"`
+	User     PlatformIdentityResponse `json:"user"`
+}
+
+// UserListResponse defines model for UserListResponse.
+type UserListResponse struct {
+	// NextCursor Cursor of the next page; absent on the last page
+	NextCursor *string                    `json:"next_cursor,omitempty"`
+	Users      []PlatformIdentityResponse `json:"users"`
+}
+
+// UserProfile Optional profile attributes. Supplied with an auth exchange, they
+// are recorded as of the assertion's iat with source exchange and
+// only replace exchange values that are not newer.
+type UserProfile struct {
+	BirthYear *int `json:"birth_year,omitempty"`
+
+	// Country ISO 3166-1 alpha-2 country or market
+	Country     *string `json:"country,omitempty"`
+	DisplayName *string `json:"display_name,omitempty"`
+
+	// Locale BCP 47 language tag
+	Locale *string `json:"locale,omitempty"`
+}
+
+// UserProfileUpdateRequest defines model for UserProfileUpdateRequest.
+type UserProfileUpdateRequest struct {
+	BirthYear *int `json:"birth_year,omitempty"`
+
+	// Clear Attributes to remove; they may be set again by exchanges
+	Clear *[]ProfileField `json:"clear,omitempty"`
+
+	// Country ISO 3166-1 alpha-2 country or market
+	Country     *string `json:"country,omitempty"`
+	DisplayName *string `json:"display_name,omitempty"`
+
+	// Locale BCP 47 language tag
+	Locale *string `json:"locale,omitempty"`
 }
 
+// RevokeReason defines model for RevokeReason.
+type RevokeReason = string
+
 // BadRequest defines model for BadRequest.
 type BadRequest = ErrorResponse
 
+// Conflict defines model for Conflict.
+type Conflict = ErrorResponse
+
+// Forbidden defines model for Forbidden.
+type Forbidden = ErrorResponse
+
 // InternalError defines model for InternalError.
 type InternalError = ErrorResponse
 
@@ -162,12 +742,90 @@ type TooManyRequests = ErrorResponse
 // Unauthorized defines model for Unauthorized.
 type Unauthorized = ErrorResponse
 
+// GetInternalV1LinkagesParams defines parameters for GetInternalV1Linkages.
+type GetInternalV1LinkagesParams struct {
+	Provider       string  `form:"provider" json:"provider"`
+	ExternalUserId string  `form:"external_user_id" json:"external_user_id"`
+	Tenant         *string `form:"tenant,omitempty" json:"tenant,omitempty"`
+}
+
+// GetInternalV1RevocationsParams defines parameters for GetInternalV1Revocations.
+type GetInternalV1RevocationsParams struct {
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+}
+
+// GetInternalV1UsersParams defines parameters for GetInternalV1Users.
+type GetInternalV1UsersParams struct {
+	Provider             *string `form:"provider,omitempty" json:"provider,omitempty"`
+	Tenant               *string `form:"tenant,omitempty" json:"tenant,omitempty"`
+	ExternalUserIdPrefix *string `form:"external_user_id_prefix,omitempty" json:"external_user_id_prefix,omitempty"`
+	// CreatedFrom Inclusive lower bound of created_at
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+	// CreatedTo Exclusive upper bound of created_at
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+	// Cursor Opaque next_cursor of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+	// Limit Page size
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// DeleteInternalV1UsersUserIdParams defines parameters for DeleteInternalV1UsersUserId.
+type DeleteInternalV1UsersUserIdParams struct {
+	// Reason Free-form reason recorded in the audit log
+	Reason *string `form:"reason,omitempty" json:"reason,omitempty"`
+}
+
+// DeleteInternalV1UsersUserIdLinkagesParams defines parameters for DeleteInternalV1UsersUserIdLinkages.
+type DeleteInternalV1UsersUserIdLinkagesParams struct {
+	Provider       string `form:"provider" json:"provider"`
+	ExternalUserId string `form:"external_user_id" json:"external_user_id"`
+}
+
+// DeleteInternalV1UsersUserIdSessionsParams defines parameters for DeleteInternalV1UsersUserIdSessions.
+type DeleteInternalV1UsersUserIdSessionsParams struct {
+	// Reason Free-form reason recorded on the session (e.g. banned, offboarded)
+	Reason *RevokeReason `form:"reason,omitempty" json:"reason,omitempty"`
+}
+
+// DeleteInternalV1UsersUserIdSessionsSessionIdParams defines parameters for DeleteInternalV1UsersUserIdSessionsSessionId.
+type DeleteInternalV1UsersUserIdSessionsSessionIdParams struct {
+	// Reason Free-form reason recorded on the session (e.g. banned, offboarded)
+	Reason *RevokeReason `form:"reason,omitempty" json:"reason,omitempty"`
+}
+
 // PostInternalV1BackofficeTokensJSONRequestBody defines body for PostInternalV1BackofficeTokens for application/json ContentType.
 type PostInternalV1BackofficeTokensJSONRequestBody = BackofficeTokenRequest
 
+// PostInternalV1IntrospectFormdataRequestBody defines body for PostInternalV1Introspect for application/x-www-form-urlencoded ContentType.
+type PostInternalV1IntrospectFormdataRequestBody = IntrospectionRequest
+
+// PostInternalV1ProvidersJSONRequestBody defines body for PostInternalV1Providers for application/json ContentType.
+type PostInternalV1ProvidersJSONRequestBody = ProviderCreateRequest
+
+// PutInternalV1ProvidersProviderIdJSONRequestBody defines body for PutInternalV1ProvidersProviderId for application/json ContentType.
+type PutInternalV1ProvidersProviderIdJSONRequestBody = ProviderUpdateRequest
+
+// PostInternalV1TokenExchangeFormdataRequestBody defines body for PostInternalV1TokenExchange for application/x-www-form-urlencoded ContentType.
+type PostInternalV1TokenExchangeFormdataRequestBody = TokenExchangeRequest
+
+// PostInternalV1UsersUserIdLinkagesJSONRequestBody defines body for PostInternalV1UsersUserIdLinkages for application/json ContentType.
+type PostInternalV1UsersUserIdLinkagesJSONRequestBody = LinkageRequest
+
+// PostInternalV1UsersUserIdMergesJSONRequestBody defines body for PostInternalV1UsersUserIdMerges for application/json ContentType.
+type PostInternalV1UsersUserIdMergesJSONRequestBody = MergeRequest
+
+// PutInternalV1UsersUserIdStatusJSONRequestBody defines body for PutInternalV1UsersUserIdStatus for application/json ContentType.
+type PutInternalV1UsersUserIdStatusJSONRequestBody = IdentityStatusRequest
+
 // PostV1AuthExchangeJSONRequestBody defines body for PostV1AuthExchange for application/json ContentType.
 type PostV1AuthExchangeJSONRequestBody = AuthExchangeRequest
 
+// PostV1AuthRefreshJSONRequestBody defines body for PostV1AuthRefresh for application/json ContentType.
+type PostV1AuthRefreshJSONRequestBody = AuthRefreshRequest
+
+// PatchV1UsersUserIdJSONRequestBody defines body for PatchV1UsersUserId for application/json ContentType.
+type PatchV1UsersUserIdJSONRequestBody = UserProfileUpdateRequest
+
 // Getter for additional properties for Jwk. Returns the specified
 // element and whether it was found
 func (a Jwk) Get(fieldName string) (value interface{}, found bool) {
@@ -279,99 +937,287 @@ func (a Jwk) MarshalJSON() ([]byte, error) {
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// OpenID Connect discovery document
+	// (GET /.well-known/openid-configuration)
+	GetWellKnownOpenidConfiguration(w http.ResponseWriter, r *http.Request)
 	// Issue backoffice access token for a known user
 	// (POST /internal/v1/backoffice-tokens)
 	PostInternalV1BackofficeTokens(w http.ResponseWriter, r *http.Request)
+	// Introspect an access token (RFC 7662)
+	// (POST /internal/v1/introspect)
+	PostInternalV1Introspect(w http.ResponseWriter, r *http.Request)
+	// Look up a platform identity by external identity
+	// (GET /internal/v1/linkages)
+	GetInternalV1Linkages(w http.ResponseWriter, r *http.Request, params GetInternalV1LinkagesParams)
+	// List providers
+	// (GET /internal/v1/providers)
+	GetInternalV1Providers(w http.ResponseWriter, r *http.Request)
+	// Register a provider
+	// (POST /internal/v1/providers)
+	PostInternalV1Providers(w http.ResponseWriter, r *http.Request)
+	// Delete a provider
+	// (DELETE /internal/v1/providers/{providerId})
+	DeleteInternalV1ProvidersProviderId(w http.ResponseWriter, r *http.Request, providerId string)
+	// Get a provider
+	// (GET /internal/v1/providers/{providerId})
+	GetInternalV1ProvidersProviderId(w http.ResponseWriter, r *http.Request, providerId string)
+	// Update a provider
+	// (PUT /internal/v1/providers/{providerId})
+	PutInternalV1ProvidersProviderId(w http.ResponseWriter, r *http.Request, providerId string)
+	// List session revocations
+	// (GET /internal/v1/revocations)
+	GetInternalV1Revocations(w http.ResponseWriter, r *http.Request, params GetInternalV1RevocationsParams)
+	// List token signing keys
+	// (GET /internal/v1/signing-keys)
+	GetInternalV1SigningKeys(w http.ResponseWriter, r *http.Request)
+	// Rotate the token signing key
+	// (POST /internal/v1/signing-keys/rotate)
+	PostInternalV1SigningKeysRotate(w http.ResponseWriter, r *http.Request)
+	// Exchange an access token for a delegated one (RFC 8693)
+	// (POST /internal/v1/token-exchange)
+	PostInternalV1TokenExchange(w http.ResponseWriter, r *http.Request)
+	// List platform identities
+	// (GET /internal/v1/users)
+	GetInternalV1Users(w http.ResponseWriter, r *http.Request, params GetInternalV1UsersParams)
+	// Erase a platform identity
+	// (DELETE /internal/v1/users/{userId})
+	DeleteInternalV1UsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, params DeleteInternalV1UsersUserIdParams)
+	// Export the data held about a user
+	// (GET /internal/v1/users/{userId}/export)
+	GetInternalV1UsersUserIdExport(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	// Unlink an external identity from a user
+	// (DELETE /internal/v1/users/{userId}/linkages)
+	DeleteInternalV1UsersUserIdLinkages(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, params DeleteInternalV1UsersUserIdLinkagesParams)
+	// Link an external identity to a user
+	// (POST /internal/v1/users/{userId}/linkages)
+	PostInternalV1UsersUserIdLinkages(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	// Merge another platform identity into a user
+	// (POST /internal/v1/users/{userId}/merges)
+	PostInternalV1UsersUserIdMerges(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	// Revoke all sessions of a user
+	// (DELETE /internal/v1/users/{userId}/sessions)
+	DeleteInternalV1UsersUserIdSessions(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, params DeleteInternalV1UsersUserIdSessionsParams)
+	// List sessions of a user
+	// (GET /internal/v1/users/{userId}/sessions)
+	GetInternalV1UsersUserIdSessions(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	// Revoke a session
+	// (DELETE /internal/v1/users/{userId}/sessions/{sessionId})
+	DeleteInternalV1UsersUserIdSessionsSessionId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, sessionId string, params DeleteInternalV1UsersUserIdSessionsSessionIdParams)
+	// Suspend, ban or reactivate a user
+	// (PUT /internal/v1/users/{userId}/status)
+	PutInternalV1UsersUserIdStatus(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
 	// JSON Web Key Set (JWKS)
 	// (GET /v1/.well-known/jwks.json)
 	GetV1WellKnownJwks(w http.ResponseWriter, r *http.Request)
 	// Exchange external identity assertion for a Proteon access token
 	// (POST /v1/auth/exchange)
 	PostV1AuthExchange(w http.ResponseWriter, r *http.Request)
+	// Exchange a refresh token for a new access token
+	// (POST /v1/auth/refresh)
+	PostV1AuthRefresh(w http.ResponseWriter, r *http.Request)
 	// Health check
 	// (GET /v1/health)
 	GetV1Health(w http.ResponseWriter, r *http.Request)
 	// Get platform identity by user ID
 	// (GET /v1/users/{userId})
 	GetV1UsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	// Update profile attributes
+	// (PATCH /v1/users/{userId})
+	PatchV1UsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// OpenID Connect discovery document
+// (GET /.well-known/openid-configuration)
+func (_ Unimplemented) GetWellKnownOpenidConfiguration(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Issue backoffice access token for a known user
 // (POST /internal/v1/backoffice-tokens)
 func (_ Unimplemented) PostInternalV1BackofficeTokens(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// JSON Web Key Set (JWKS)
-// (GET /v1/.well-known/jwks.json)
-func (_ Unimplemented) GetV1WellKnownJwks(w http.ResponseWriter, r *http.Request) {
+// Introspect an access token (RFC 7662)
+// (POST /internal/v1/introspect)
+func (_ Unimplemented) PostInternalV1Introspect(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Exchange external identity assertion for a Proteon access token
-// (POST /v1/auth/exchange)
-func (_ Unimplemented) PostV1AuthExchange(w http.ResponseWriter, r *http.Request) {
+// Look up a platform identity by external identity
+// (GET /internal/v1/linkages)
+func (_ Unimplemented) GetInternalV1Linkages(w http.ResponseWriter, r *http.Request, params GetInternalV1LinkagesParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Health check
-// (GET /v1/health)
-func (_ Unimplemented) GetV1Health(w http.ResponseWriter, r *http.Request) {
+// List providers
+// (GET /internal/v1/providers)
+func (_ Unimplemented) GetInternalV1Providers(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Get platform identity by user ID
-// (GET /v1/users/{userId})
-func (_ Unimplemented) GetV1UsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+// Register a provider
+// (POST /internal/v1/providers)
+func (_ Unimplemented) PostInternalV1Providers(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// Delete a provider
+// (DELETE /internal/v1/providers/{providerId})
+func (_ Unimplemented) DeleteInternalV1ProvidersProviderId(w http.ResponseWriter, r *http.Request, providerId string) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// Get a provider
+// (GET /internal/v1/providers/{providerId})
+func (_ Unimplemented) GetInternalV1ProvidersProviderId(w http.ResponseWriter, r *http.Request, providerId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-// PostInternalV1BackofficeTokens operation middleware
-func (siw *ServerInterfaceWrapper) PostInternalV1BackofficeTokens(w http.ResponseWriter, r *http.Request) {
+// Update a provider
+// (PUT /internal/v1/providers/{providerId})
+func (_ Unimplemented) PutInternalV1ProvidersProviderId(w http.ResponseWriter, r *http.Request, providerId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostInternalV1BackofficeTokens(w, r)
-	}))
+// List session revocations
+// (GET /internal/v1/revocations)
+func (_ Unimplemented) GetInternalV1Revocations(w http.ResponseWriter, r *http.Request, params GetInternalV1RevocationsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// List token signing keys
+// (GET /internal/v1/signing-keys)
+func (_ Unimplemented) GetInternalV1SigningKeys(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler.ServeHTTP(w, r)
+// Rotate the token signing key
+// (POST /internal/v1/signing-keys/rotate)
+func (_ Unimplemented) PostInternalV1SigningKeysRotate(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// GetV1WellKnownJwks operation middleware
-func (siw *ServerInterfaceWrapper) GetV1WellKnownJwks(w http.ResponseWriter, r *http.Request) {
+// Exchange an access token for a delegated one (RFC 8693)
+// (POST /internal/v1/token-exchange)
+func (_ Unimplemented) PostInternalV1TokenExchange(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetV1WellKnownJwks(w, r)
-	}))
+// List platform identities
+// (GET /internal/v1/users)
+func (_ Unimplemented) GetInternalV1Users(w http.ResponseWriter, r *http.Request, params GetInternalV1UsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// Erase a platform identity
+// (DELETE /internal/v1/users/{userId})
+func (_ Unimplemented) DeleteInternalV1UsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, params DeleteInternalV1UsersUserIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler.ServeHTTP(w, r)
+// Export the data held about a user
+// (GET /internal/v1/users/{userId}/export)
+func (_ Unimplemented) GetInternalV1UsersUserIdExport(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// PostV1AuthExchange operation middleware
-func (siw *ServerInterfaceWrapper) PostV1AuthExchange(w http.ResponseWriter, r *http.Request) {
+// Unlink an external identity from a user
+// (DELETE /internal/v1/users/{userId}/linkages)
+func (_ Unimplemented) DeleteInternalV1UsersUserIdLinkages(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, params DeleteInternalV1UsersUserIdLinkagesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Link an external identity to a user
+// (POST /internal/v1/users/{userId}/linkages)
+func (_ Unimplemented) PostInternalV1UsersUserIdLinkages(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Merge another platform identity into a user
+// (POST /internal/v1/users/{userId}/merges)
+func (_ Unimplemented) PostInternalV1UsersUserIdMerges(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke all sessions of a user
+// (DELETE /internal/v1/users/{userId}/sessions)
+func (_ Unimplemented) DeleteInternalV1UsersUserIdSessions(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, params DeleteInternalV1UsersUserIdSessionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List sessions of a user
+// (GET /internal/v1/users/{userId}/sessions)
+func (_ Unimplemented) GetInternalV1UsersUserIdSessions(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke a session
+// (DELETE /internal/v1/users/{userId}/sessions/{sessionId})
+func (_ Unimplemented) DeleteInternalV1UsersUserIdSessionsSessionId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, sessionId string, params DeleteInternalV1UsersUserIdSessionsSessionIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Suspend, ban or reactivate a user
+// (PUT /internal/v1/users/{userId}/status)
+func (_ Unimplemented) PutInternalV1UsersUserIdStatus(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// JSON Web Key Set (JWKS)
+// (GET /v1/.well-known/jwks.json)
+func (_ Unimplemented) GetV1WellKnownJwks(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Exchange external identity assertion for a Proteon access token
+// (POST /v1/auth/exchange)
+func (_ Unimplemented) PostV1AuthExchange(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Exchange a refresh token for a new access token
+// (POST /v1/auth/refresh)
+func (_ Unimplemented) PostV1AuthRefresh(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Health check
+// (GET /v1/health)
+func (_ Unimplemented) GetV1Health(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get platform identity by user ID
+// (GET /v1/users/{userId})
+func (_ Unimplemented) GetV1UsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update profile attributes
+// (PATCH /v1/users/{userId})
+func (_ Unimplemented) PatchV1UsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetWellKnownOpenidConfiguration operation middleware
+func (siw *ServerInterfaceWrapper) GetWellKnownOpenidConfiguration(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostV1AuthExchange(w, r)
+		siw.Handler.GetWellKnownOpenidConfiguration(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -381,11 +1227,11 @@ func (siw *ServerInterfaceWrapper) PostV1AuthExchange(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// GetV1Health operation middleware
-func (siw *ServerInterfaceWrapper) GetV1Health(w http.ResponseWriter, r *http.Request) {
+// PostInternalV1BackofficeTokens operation middleware
+func (siw *ServerInterfaceWrapper) PostInternalV1BackofficeTokens(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetV1Health(w, r)
+		siw.Handler.PostInternalV1BackofficeTokens(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -395,22 +1241,17 @@ func (siw *ServerInterfaceWrapper) GetV1Health(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetV1UsersUserId operation middleware
-func (siw *ServerInterfaceWrapper) GetV1UsersUserId(w http.ResponseWriter, r *http.Request) {
+// PostInternalV1Introspect operation middleware
+func (siw *ServerInterfaceWrapper) PostInternalV1Introspect(w http.ResponseWriter, r *http.Request) {
 
-	var err error
+	ctx := r.Context()
 
-	// ------------- Path parameter "userId" -------------
-	var userId openapi_types.UUID
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
 
-	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
-		return
-	}
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetV1UsersUserId(w, r, userId)
+		siw.Handler.PostInternalV1Introspect(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -420,374 +1261,3218 @@ func (siw *ServerInterfaceWrapper) GetV1UsersUserId(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// GetInternalV1Linkages operation middleware
+func (siw *ServerInterfaceWrapper) GetInternalV1Linkages(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetInternalV1LinkagesParams
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	// ------------- Required query parameter "provider" -------------
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	if paramValue := r.URL.Query().Get("provider"); paramValue != "" {
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "provider"})
+		return
+	}
 
-type RequiredParamError struct {
-	ParamName string
-}
+	err = runtime.BindQueryParameter("form", true, true, "provider", r.URL.Query(), &params.Provider)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	// ------------- Required query parameter "external_user_id" -------------
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	if paramValue := r.URL.Query().Get("external_user_id"); paramValue != "" {
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "external_user_id"})
+		return
+	}
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindQueryParameter("form", true, true, "external_user_id", r.URL.Query(), &params.ExternalUserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "external_user_id", Err: err})
+		return
+	}
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	// ------------- Optional query parameter "tenant" -------------
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	err = runtime.BindQueryParameter("form", true, false, "tenant", r.URL.Query(), &params.Tenant)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tenant", Err: err})
+		return
+	}
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInternalV1Linkages(w, r, params)
+	}))
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+	handler.ServeHTTP(w, r)
 }
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+// GetInternalV1Providers operation middleware
+func (siw *ServerInterfaceWrapper) GetInternalV1Providers(w http.ResponseWriter, r *http.Request) {
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInternalV1Providers(w, r)
+	}))
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+	handler.ServeHTTP(w, r)
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// PostInternalV1Providers operation middleware
+func (siw *ServerInterfaceWrapper) PostInternalV1Providers(w http.ResponseWriter, r *http.Request) {
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostInternalV1Providers(w, r)
+	}))
 
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/internal/v1/backoffice-tokens", wrapper.PostInternalV1BackofficeTokens)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/.well-known/jwks.json", wrapper.GetV1WellKnownJwks)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/v1/auth/exchange", wrapper.PostV1AuthExchange)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/health", wrapper.GetV1Health)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/v1/users/{userId}", wrapper.GetV1UsersUserId)
-	})
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return r
+	handler.ServeHTTP(w, r)
 }
 
-type BadRequestJSONResponse ErrorResponse
+// DeleteInternalV1ProvidersProviderId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteInternalV1ProvidersProviderId(w http.ResponseWriter, r *http.Request) {
 
-type InternalErrorJSONResponse ErrorResponse
+	var err error
 
-type NotFoundJSONResponse ErrorResponse
+	// ------------- Path parameter "providerId" -------------
+	var providerId string
 
-type TooManyRequestsJSONResponse ErrorResponse
+	err = runtime.BindStyledParameterWithOptions("simple", "providerId", chi.URLParam(r, "providerId"), &providerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "providerId", Err: err})
+		return
+	}
 
-type UnauthorizedJSONResponse ErrorResponse
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteInternalV1ProvidersProviderId(w, r, providerId)
+	}))
 
-type PostInternalV1BackofficeTokensRequestObject struct {
-	Body *PostInternalV1BackofficeTokensJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type PostInternalV1BackofficeTokensResponseObject interface {
-	VisitPostInternalV1BackofficeTokensResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type PostInternalV1BackofficeTokens200JSONResponse BackofficeTokenResponse
-
-func (response PostInternalV1BackofficeTokens200JSONResponse) VisitPostInternalV1BackofficeTokensResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// GetInternalV1ProvidersProviderId operation middleware
+func (siw *ServerInterfaceWrapper) GetInternalV1ProvidersProviderId(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type PostInternalV1BackofficeTokens400JSONResponse struct{ BadRequestJSONResponse }
+	// ------------- Path parameter "providerId" -------------
+	var providerId string
 
-func (response PostInternalV1BackofficeTokens400JSONResponse) VisitPostInternalV1BackofficeTokensResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindStyledParameterWithOptions("simple", "providerId", chi.URLParam(r, "providerId"), &providerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "providerId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInternalV1ProvidersProviderId(w, r, providerId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutInternalV1ProvidersProviderId operation middleware
+func (siw *ServerInterfaceWrapper) PutInternalV1ProvidersProviderId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "providerId" -------------
+	var providerId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "providerId", chi.URLParam(r, "providerId"), &providerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "providerId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutInternalV1ProvidersProviderId(w, r, providerId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetInternalV1Revocations operation middleware
+func (siw *ServerInterfaceWrapper) GetInternalV1Revocations(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetInternalV1RevocationsParams
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInternalV1Revocations(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetInternalV1SigningKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetInternalV1SigningKeys(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInternalV1SigningKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostInternalV1SigningKeysRotate operation middleware
+func (siw *ServerInterfaceWrapper) PostInternalV1SigningKeysRotate(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostInternalV1SigningKeysRotate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostInternalV1TokenExchange operation middleware
+func (siw *ServerInterfaceWrapper) PostInternalV1TokenExchange(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostInternalV1TokenExchange(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetInternalV1Users operation middleware
+func (siw *ServerInterfaceWrapper) GetInternalV1Users(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetInternalV1UsersParams
+
+	// ------------- Optional query parameter "provider" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "provider", r.URL.Query(), &params.Provider)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tenant" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tenant", r.URL.Query(), &params.Tenant)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tenant", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "external_user_id_prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "external_user_id_prefix", r.URL.Query(), &params.ExternalUserIdPrefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "external_user_id_prefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", r.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", r.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInternalV1Users(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteInternalV1UsersUserId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteInternalV1UsersUserId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteInternalV1UsersUserIdParams
+
+	// ------------- Optional query parameter "reason" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reason", r.URL.Query(), &params.Reason)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reason", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteInternalV1UsersUserId(w, r, userId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetInternalV1UsersUserIdExport operation middleware
+func (siw *ServerInterfaceWrapper) GetInternalV1UsersUserIdExport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInternalV1UsersUserIdExport(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteInternalV1UsersUserIdLinkages operation middleware
+func (siw *ServerInterfaceWrapper) DeleteInternalV1UsersUserIdLinkages(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteInternalV1UsersUserIdLinkagesParams
+
+	// ------------- Required query parameter "provider" -------------
+
+	if paramValue := r.URL.Query().Get("provider"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "provider"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "provider", r.URL.Query(), &params.Provider)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "external_user_id" -------------
+
+	if paramValue := r.URL.Query().Get("external_user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "external_user_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "external_user_id", r.URL.Query(), &params.ExternalUserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "external_user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteInternalV1UsersUserIdLinkages(w, r, userId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostInternalV1UsersUserIdLinkages operation middleware
+func (siw *ServerInterfaceWrapper) PostInternalV1UsersUserIdLinkages(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostInternalV1UsersUserIdLinkages(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostInternalV1UsersUserIdMerges operation middleware
+func (siw *ServerInterfaceWrapper) PostInternalV1UsersUserIdMerges(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostInternalV1UsersUserIdMerges(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteInternalV1UsersUserIdSessions operation middleware
+func (siw *ServerInterfaceWrapper) DeleteInternalV1UsersUserIdSessions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteInternalV1UsersUserIdSessionsParams
+
+	// ------------- Optional query parameter "reason" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reason", r.URL.Query(), &params.Reason)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reason", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteInternalV1UsersUserIdSessions(w, r, userId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetInternalV1UsersUserIdSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetInternalV1UsersUserIdSessions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInternalV1UsersUserIdSessions(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteInternalV1UsersUserIdSessionsSessionId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteInternalV1UsersUserIdSessionsSessionId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "sessionId" -------------
+	var sessionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sessionId", chi.URLParam(r, "sessionId"), &sessionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteInternalV1UsersUserIdSessionsSessionIdParams
+
+	// ------------- Optional query parameter "reason" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reason", r.URL.Query(), &params.Reason)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reason", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteInternalV1UsersUserIdSessionsSessionId(w, r, userId, sessionId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutInternalV1UsersUserIdStatus operation middleware
+func (siw *ServerInterfaceWrapper) PutInternalV1UsersUserIdStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutInternalV1UsersUserIdStatus(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetV1WellKnownJwks operation middleware
+func (siw *ServerInterfaceWrapper) GetV1WellKnownJwks(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetV1WellKnownJwks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostV1AuthExchange operation middleware
+func (siw *ServerInterfaceWrapper) PostV1AuthExchange(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostV1AuthExchange(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostV1AuthRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostV1AuthRefresh(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostV1AuthRefresh(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetV1Health operation middleware
+func (siw *ServerInterfaceWrapper) GetV1Health(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetV1Health(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetV1UsersUserId operation middleware
+func (siw *ServerInterfaceWrapper) GetV1UsersUserId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetV1UsersUserId(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchV1UsersUserId operation middleware
+func (siw *ServerInterfaceWrapper) PatchV1UsersUserId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchV1UsersUserId(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/.well-known/openid-configuration", wrapper.GetWellKnownOpenidConfiguration)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/internal/v1/backoffice-tokens", wrapper.PostInternalV1BackofficeTokens)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/internal/v1/introspect", wrapper.PostInternalV1Introspect)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/internal/v1/linkages", wrapper.GetInternalV1Linkages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/internal/v1/providers", wrapper.GetInternalV1Providers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/internal/v1/providers", wrapper.PostInternalV1Providers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/internal/v1/providers/{providerId}", wrapper.DeleteInternalV1ProvidersProviderId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/internal/v1/providers/{providerId}", wrapper.GetInternalV1ProvidersProviderId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/internal/v1/providers/{providerId}", wrapper.PutInternalV1ProvidersProviderId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/internal/v1/revocations", wrapper.GetInternalV1Revocations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/internal/v1/signing-keys", wrapper.GetInternalV1SigningKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/internal/v1/signing-keys/rotate", wrapper.PostInternalV1SigningKeysRotate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/internal/v1/token-exchange", wrapper.PostInternalV1TokenExchange)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/internal/v1/users", wrapper.GetInternalV1Users)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/internal/v1/users/{userId}", wrapper.DeleteInternalV1UsersUserId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/internal/v1/users/{userId}/export", wrapper.GetInternalV1UsersUserIdExport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/internal/v1/users/{userId}/linkages", wrapper.DeleteInternalV1UsersUserIdLinkages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/internal/v1/users/{userId}/linkages", wrapper.PostInternalV1UsersUserIdLinkages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/internal/v1/users/{userId}/merges", wrapper.PostInternalV1UsersUserIdMerges)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/internal/v1/users/{userId}/sessions", wrapper.DeleteInternalV1UsersUserIdSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/internal/v1/users/{userId}/sessions", wrapper.GetInternalV1UsersUserIdSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/internal/v1/users/{userId}/sessions/{sessionId}", wrapper.DeleteInternalV1UsersUserIdSessionsSessionId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/internal/v1/users/{userId}/status", wrapper.PutInternalV1UsersUserIdStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/.well-known/jwks.json", wrapper.GetV1WellKnownJwks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/auth/exchange", wrapper.PostV1AuthExchange)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/auth/refresh", wrapper.PostV1AuthRefresh)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/health", wrapper.GetV1Health)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/users/{userId}", wrapper.GetV1UsersUserId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/v1/users/{userId}", wrapper.PatchV1UsersUserId)
+	})
+
+	return r
+}
+
+type BadRequestJSONResponse ErrorResponse
+
+type ConflictJSONResponse ErrorResponse
+
+type ForbiddenJSONResponse ErrorResponse
+
+type InternalErrorJSONResponse ErrorResponse
+
+type NotFoundJSONResponse ErrorResponse
+
+type TooManyRequestsJSONResponse ErrorResponse
+
+type UnauthorizedJSONResponse ErrorResponse
+
+type GetWellKnownOpenidConfigurationRequestObject struct {
+}
+
+type GetWellKnownOpenidConfigurationResponseObject interface {
+	VisitGetWellKnownOpenidConfigurationResponse(w http.ResponseWriter) error
+}
+
+type GetWellKnownOpenidConfiguration200JSONResponse OpenidConfiguration
+
+func (response GetWellKnownOpenidConfiguration200JSONResponse) VisitGetWellKnownOpenidConfigurationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWellKnownOpenidConfiguration500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetWellKnownOpenidConfiguration500JSONResponse) VisitGetWellKnownOpenidConfigurationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1BackofficeTokensRequestObject struct {
+	Body *PostInternalV1BackofficeTokensJSONRequestBody
+}
+
+type PostInternalV1BackofficeTokensResponseObject interface {
+	VisitPostInternalV1BackofficeTokensResponse(w http.ResponseWriter) error
+}
+
+type PostInternalV1BackofficeTokens200JSONResponse BackofficeTokenResponse
+
+func (response PostInternalV1BackofficeTokens200JSONResponse) VisitPostInternalV1BackofficeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1BackofficeTokens400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PostInternalV1BackofficeTokens400JSONResponse) VisitPostInternalV1BackofficeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1BackofficeTokens401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PostInternalV1BackofficeTokens401JSONResponse) VisitPostInternalV1BackofficeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1BackofficeTokens403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PostInternalV1BackofficeTokens403JSONResponse) VisitPostInternalV1BackofficeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1BackofficeTokens404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response PostInternalV1BackofficeTokens404JSONResponse) VisitPostInternalV1BackofficeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1BackofficeTokens500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response PostInternalV1BackofficeTokens500JSONResponse) VisitPostInternalV1BackofficeTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1IntrospectRequestObject struct {
+	Body *PostInternalV1IntrospectFormdataRequestBody
+}
+
+type PostInternalV1IntrospectResponseObject interface {
+	VisitPostInternalV1IntrospectResponse(w http.ResponseWriter) error
+}
+
+type PostInternalV1Introspect200JSONResponse IntrospectionResponse
+
+func (response PostInternalV1Introspect200JSONResponse) VisitPostInternalV1IntrospectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1Introspect400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PostInternalV1Introspect400JSONResponse) VisitPostInternalV1IntrospectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1Introspect401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PostInternalV1Introspect401JSONResponse) VisitPostInternalV1IntrospectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1Introspect500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response PostInternalV1Introspect500JSONResponse) VisitPostInternalV1IntrospectResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1LinkagesRequestObject struct {
+	Params GetInternalV1LinkagesParams
+}
+
+type GetInternalV1LinkagesResponseObject interface {
+	VisitGetInternalV1LinkagesResponse(w http.ResponseWriter) error
+}
+
+type GetInternalV1Linkages200JSONResponse PlatformIdentityResponse
+
+func (response GetInternalV1Linkages200JSONResponse) VisitGetInternalV1LinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Linkages400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetInternalV1Linkages400JSONResponse) VisitGetInternalV1LinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Linkages401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetInternalV1Linkages401JSONResponse) VisitGetInternalV1LinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Linkages403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetInternalV1Linkages403JSONResponse) VisitGetInternalV1LinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Linkages404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetInternalV1Linkages404JSONResponse) VisitGetInternalV1LinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Linkages500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetInternalV1Linkages500JSONResponse) VisitGetInternalV1LinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1ProvidersRequestObject struct {
+}
+
+type GetInternalV1ProvidersResponseObject interface {
+	VisitGetInternalV1ProvidersResponse(w http.ResponseWriter) error
+}
+
+type GetInternalV1Providers200JSONResponse ProviderListResponse
+
+func (response GetInternalV1Providers200JSONResponse) VisitGetInternalV1ProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Providers401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetInternalV1Providers401JSONResponse) VisitGetInternalV1ProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Providers403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetInternalV1Providers403JSONResponse) VisitGetInternalV1ProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Providers500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetInternalV1Providers500JSONResponse) VisitGetInternalV1ProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1ProvidersRequestObject struct {
+	Body *PostInternalV1ProvidersJSONRequestBody
+}
+
+type PostInternalV1ProvidersResponseObject interface {
+	VisitPostInternalV1ProvidersResponse(w http.ResponseWriter) error
+}
+
+type PostInternalV1Providers201JSONResponse Provider
+
+func (response PostInternalV1Providers201JSONResponse) VisitPostInternalV1ProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1Providers400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PostInternalV1Providers400JSONResponse) VisitPostInternalV1ProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1Providers401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PostInternalV1Providers401JSONResponse) VisitPostInternalV1ProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1Providers403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PostInternalV1Providers403JSONResponse) VisitPostInternalV1ProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1Providers409JSONResponse struct{ ConflictJSONResponse }
+
+func (response PostInternalV1Providers409JSONResponse) VisitPostInternalV1ProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1Providers500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response PostInternalV1Providers500JSONResponse) VisitPostInternalV1ProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1ProvidersProviderIdRequestObject struct {
+	ProviderId string `json:"providerId"`
+}
+
+type DeleteInternalV1ProvidersProviderIdResponseObject interface {
+	VisitDeleteInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteInternalV1ProvidersProviderId204Response struct {
+}
+
+func (response DeleteInternalV1ProvidersProviderId204Response) VisitDeleteInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteInternalV1ProvidersProviderId401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteInternalV1ProvidersProviderId401JSONResponse) VisitDeleteInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1ProvidersProviderId403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteInternalV1ProvidersProviderId403JSONResponse) VisitDeleteInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1ProvidersProviderId404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteInternalV1ProvidersProviderId404JSONResponse) VisitDeleteInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1ProvidersProviderId500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response DeleteInternalV1ProvidersProviderId500JSONResponse) VisitDeleteInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1ProvidersProviderIdRequestObject struct {
+	ProviderId string `json:"providerId"`
+}
+
+type GetInternalV1ProvidersProviderIdResponseObject interface {
+	VisitGetInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error
+}
+
+type GetInternalV1ProvidersProviderId200JSONResponse Provider
+
+func (response GetInternalV1ProvidersProviderId200JSONResponse) VisitGetInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1ProvidersProviderId401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetInternalV1ProvidersProviderId401JSONResponse) VisitGetInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1ProvidersProviderId403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetInternalV1ProvidersProviderId403JSONResponse) VisitGetInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1ProvidersProviderId404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetInternalV1ProvidersProviderId404JSONResponse) VisitGetInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1ProvidersProviderId500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetInternalV1ProvidersProviderId500JSONResponse) VisitGetInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutInternalV1ProvidersProviderIdRequestObject struct {
+	ProviderId string `json:"providerId"`
+	Body       *PutInternalV1ProvidersProviderIdJSONRequestBody
+}
+
+type PutInternalV1ProvidersProviderIdResponseObject interface {
+	VisitPutInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error
+}
+
+type PutInternalV1ProvidersProviderId200JSONResponse Provider
+
+func (response PutInternalV1ProvidersProviderId200JSONResponse) VisitPutInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutInternalV1ProvidersProviderId400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PutInternalV1ProvidersProviderId400JSONResponse) VisitPutInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutInternalV1ProvidersProviderId401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PutInternalV1ProvidersProviderId401JSONResponse) VisitPutInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutInternalV1ProvidersProviderId403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PutInternalV1ProvidersProviderId403JSONResponse) VisitPutInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutInternalV1ProvidersProviderId404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response PutInternalV1ProvidersProviderId404JSONResponse) VisitPutInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutInternalV1ProvidersProviderId500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response PutInternalV1ProvidersProviderId500JSONResponse) VisitPutInternalV1ProvidersProviderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1RevocationsRequestObject struct {
+	Params GetInternalV1RevocationsParams
+}
+
+type GetInternalV1RevocationsResponseObject interface {
+	VisitGetInternalV1RevocationsResponse(w http.ResponseWriter) error
+}
+
+type GetInternalV1Revocations200JSONResponse RevocationListResponse
+
+func (response GetInternalV1Revocations200JSONResponse) VisitGetInternalV1RevocationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Revocations400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetInternalV1Revocations400JSONResponse) VisitGetInternalV1RevocationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Revocations401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetInternalV1Revocations401JSONResponse) VisitGetInternalV1RevocationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Revocations403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetInternalV1Revocations403JSONResponse) VisitGetInternalV1RevocationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Revocations500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetInternalV1Revocations500JSONResponse) VisitGetInternalV1RevocationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1SigningKeysRequestObject struct {
+}
+
+type GetInternalV1SigningKeysResponseObject interface {
+	VisitGetInternalV1SigningKeysResponse(w http.ResponseWriter) error
+}
+
+type GetInternalV1SigningKeys200JSONResponse SigningKeyListResponse
+
+func (response GetInternalV1SigningKeys200JSONResponse) VisitGetInternalV1SigningKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1SigningKeys401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetInternalV1SigningKeys401JSONResponse) VisitGetInternalV1SigningKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1SigningKeys403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetInternalV1SigningKeys403JSONResponse) VisitGetInternalV1SigningKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1SigningKeys500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetInternalV1SigningKeys500JSONResponse) VisitGetInternalV1SigningKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1SigningKeysRotateRequestObject struct {
+}
+
+type PostInternalV1SigningKeysRotateResponseObject interface {
+	VisitPostInternalV1SigningKeysRotateResponse(w http.ResponseWriter) error
+}
+
+type PostInternalV1SigningKeysRotate200JSONResponse SigningKeyListResponse
+
+func (response PostInternalV1SigningKeysRotate200JSONResponse) VisitPostInternalV1SigningKeysRotateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1SigningKeysRotate401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PostInternalV1SigningKeysRotate401JSONResponse) VisitPostInternalV1SigningKeysRotateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1SigningKeysRotate403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PostInternalV1SigningKeysRotate403JSONResponse) VisitPostInternalV1SigningKeysRotateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1SigningKeysRotate409JSONResponse struct{ ConflictJSONResponse }
+
+func (response PostInternalV1SigningKeysRotate409JSONResponse) VisitPostInternalV1SigningKeysRotateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1SigningKeysRotate500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response PostInternalV1SigningKeysRotate500JSONResponse) VisitPostInternalV1SigningKeysRotateResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1TokenExchangeRequestObject struct {
+	Body *PostInternalV1TokenExchangeFormdataRequestBody
+}
+
+type PostInternalV1TokenExchangeResponseObject interface {
+	VisitPostInternalV1TokenExchangeResponse(w http.ResponseWriter) error
+}
+
+type PostInternalV1TokenExchange200JSONResponse TokenExchangeResponse
+
+func (response PostInternalV1TokenExchange200JSONResponse) VisitPostInternalV1TokenExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1TokenExchange400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PostInternalV1TokenExchange400JSONResponse) VisitPostInternalV1TokenExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1TokenExchange401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PostInternalV1TokenExchange401JSONResponse) VisitPostInternalV1TokenExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1TokenExchange403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PostInternalV1TokenExchange403JSONResponse) VisitPostInternalV1TokenExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1TokenExchange500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response PostInternalV1TokenExchange500JSONResponse) VisitPostInternalV1TokenExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1UsersRequestObject struct {
+	Params GetInternalV1UsersParams
+}
+
+type GetInternalV1UsersResponseObject interface {
+	VisitGetInternalV1UsersResponse(w http.ResponseWriter) error
+}
+
+type GetInternalV1Users200JSONResponse UserListResponse
+
+func (response GetInternalV1Users200JSONResponse) VisitGetInternalV1UsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Users400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetInternalV1Users400JSONResponse) VisitGetInternalV1UsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Users401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetInternalV1Users401JSONResponse) VisitGetInternalV1UsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Users403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetInternalV1Users403JSONResponse) VisitGetInternalV1UsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1Users500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetInternalV1Users500JSONResponse) VisitGetInternalV1UsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdRequestObject struct {
+	UserId openapi_types.UUID `json:"userId"`
+	Params DeleteInternalV1UsersUserIdParams
+}
+
+type DeleteInternalV1UsersUserIdResponseObject interface {
+	VisitDeleteInternalV1UsersUserIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteInternalV1UsersUserId200JSONResponse ErasureResponse
+
+func (response DeleteInternalV1UsersUserId200JSONResponse) VisitDeleteInternalV1UsersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserId401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteInternalV1UsersUserId401JSONResponse) VisitDeleteInternalV1UsersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserId403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteInternalV1UsersUserId403JSONResponse) VisitDeleteInternalV1UsersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserId404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteInternalV1UsersUserId404JSONResponse) VisitDeleteInternalV1UsersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserId409JSONResponse struct{ ConflictJSONResponse }
+
+func (response DeleteInternalV1UsersUserId409JSONResponse) VisitDeleteInternalV1UsersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserId500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response DeleteInternalV1UsersUserId500JSONResponse) VisitDeleteInternalV1UsersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1UsersUserIdExportRequestObject struct {
+	UserId openapi_types.UUID `json:"userId"`
+}
+
+type GetInternalV1UsersUserIdExportResponseObject interface {
+	VisitGetInternalV1UsersUserIdExportResponse(w http.ResponseWriter) error
+}
+
+type GetInternalV1UsersUserIdExport200JSONResponse UserExportResponse
+
+func (response GetInternalV1UsersUserIdExport200JSONResponse) VisitGetInternalV1UsersUserIdExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1UsersUserIdExport401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetInternalV1UsersUserIdExport401JSONResponse) VisitGetInternalV1UsersUserIdExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1UsersUserIdExport403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetInternalV1UsersUserIdExport403JSONResponse) VisitGetInternalV1UsersUserIdExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1UsersUserIdExport404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetInternalV1UsersUserIdExport404JSONResponse) VisitGetInternalV1UsersUserIdExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1UsersUserIdExport500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetInternalV1UsersUserIdExport500JSONResponse) VisitGetInternalV1UsersUserIdExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdLinkagesRequestObject struct {
+	UserId openapi_types.UUID `json:"userId"`
+	Params DeleteInternalV1UsersUserIdLinkagesParams
+}
+
+type DeleteInternalV1UsersUserIdLinkagesResponseObject interface {
+	VisitDeleteInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error
+}
+
+type DeleteInternalV1UsersUserIdLinkages200JSONResponse PlatformIdentityResponse
+
+func (response DeleteInternalV1UsersUserIdLinkages200JSONResponse) VisitDeleteInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdLinkages400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response DeleteInternalV1UsersUserIdLinkages400JSONResponse) VisitDeleteInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdLinkages401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteInternalV1UsersUserIdLinkages401JSONResponse) VisitDeleteInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdLinkages403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteInternalV1UsersUserIdLinkages403JSONResponse) VisitDeleteInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdLinkages404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteInternalV1UsersUserIdLinkages404JSONResponse) VisitDeleteInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdLinkages409JSONResponse struct{ ConflictJSONResponse }
+
+func (response DeleteInternalV1UsersUserIdLinkages409JSONResponse) VisitDeleteInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdLinkages500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response DeleteInternalV1UsersUserIdLinkages500JSONResponse) VisitDeleteInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1UsersUserIdLinkagesRequestObject struct {
+	UserId openapi_types.UUID `json:"userId"`
+	Body   *PostInternalV1UsersUserIdLinkagesJSONRequestBody
+}
+
+type PostInternalV1UsersUserIdLinkagesResponseObject interface {
+	VisitPostInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error
+}
+
+type PostInternalV1UsersUserIdLinkages200JSONResponse PlatformIdentityResponse
+
+func (response PostInternalV1UsersUserIdLinkages200JSONResponse) VisitPostInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1UsersUserIdLinkages400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PostInternalV1UsersUserIdLinkages400JSONResponse) VisitPostInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1UsersUserIdLinkages401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PostInternalV1UsersUserIdLinkages401JSONResponse) VisitPostInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1UsersUserIdLinkages403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PostInternalV1UsersUserIdLinkages403JSONResponse) VisitPostInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1UsersUserIdLinkages404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response PostInternalV1UsersUserIdLinkages404JSONResponse) VisitPostInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1UsersUserIdLinkages409JSONResponse struct{ ConflictJSONResponse }
+
+func (response PostInternalV1UsersUserIdLinkages409JSONResponse) VisitPostInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1UsersUserIdLinkages500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response PostInternalV1UsersUserIdLinkages500JSONResponse) VisitPostInternalV1UsersUserIdLinkagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1UsersUserIdMergesRequestObject struct {
+	UserId openapi_types.UUID `json:"userId"`
+	Body   *PostInternalV1UsersUserIdMergesJSONRequestBody
+}
+
+type PostInternalV1UsersUserIdMergesResponseObject interface {
+	VisitPostInternalV1UsersUserIdMergesResponse(w http.ResponseWriter) error
+}
+
+type PostInternalV1UsersUserIdMerges200JSONResponse PlatformIdentityResponse
+
+func (response PostInternalV1UsersUserIdMerges200JSONResponse) VisitPostInternalV1UsersUserIdMergesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1UsersUserIdMerges400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PostInternalV1UsersUserIdMerges400JSONResponse) VisitPostInternalV1UsersUserIdMergesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1UsersUserIdMerges401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PostInternalV1UsersUserIdMerges401JSONResponse) VisitPostInternalV1UsersUserIdMergesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1UsersUserIdMerges403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PostInternalV1UsersUserIdMerges403JSONResponse) VisitPostInternalV1UsersUserIdMergesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1UsersUserIdMerges404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response PostInternalV1UsersUserIdMerges404JSONResponse) VisitPostInternalV1UsersUserIdMergesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1UsersUserIdMerges409JSONResponse struct{ ConflictJSONResponse }
+
+func (response PostInternalV1UsersUserIdMerges409JSONResponse) VisitPostInternalV1UsersUserIdMergesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostInternalV1UsersUserIdMerges500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response PostInternalV1UsersUserIdMerges500JSONResponse) VisitPostInternalV1UsersUserIdMergesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdSessionsRequestObject struct {
+	UserId openapi_types.UUID `json:"userId"`
+	Params DeleteInternalV1UsersUserIdSessionsParams
+}
+
+type DeleteInternalV1UsersUserIdSessionsResponseObject interface {
+	VisitDeleteInternalV1UsersUserIdSessionsResponse(w http.ResponseWriter) error
+}
+
+type DeleteInternalV1UsersUserIdSessions200JSONResponse SessionListResponse
+
+func (response DeleteInternalV1UsersUserIdSessions200JSONResponse) VisitDeleteInternalV1UsersUserIdSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdSessions401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteInternalV1UsersUserIdSessions401JSONResponse) VisitDeleteInternalV1UsersUserIdSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdSessions403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteInternalV1UsersUserIdSessions403JSONResponse) VisitDeleteInternalV1UsersUserIdSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdSessions500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response DeleteInternalV1UsersUserIdSessions500JSONResponse) VisitDeleteInternalV1UsersUserIdSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1UsersUserIdSessionsRequestObject struct {
+	UserId openapi_types.UUID `json:"userId"`
+}
+
+type GetInternalV1UsersUserIdSessionsResponseObject interface {
+	VisitGetInternalV1UsersUserIdSessionsResponse(w http.ResponseWriter) error
+}
+
+type GetInternalV1UsersUserIdSessions200JSONResponse SessionListResponse
+
+func (response GetInternalV1UsersUserIdSessions200JSONResponse) VisitGetInternalV1UsersUserIdSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1UsersUserIdSessions401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetInternalV1UsersUserIdSessions401JSONResponse) VisitGetInternalV1UsersUserIdSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1UsersUserIdSessions403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetInternalV1UsersUserIdSessions403JSONResponse) VisitGetInternalV1UsersUserIdSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInternalV1UsersUserIdSessions500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetInternalV1UsersUserIdSessions500JSONResponse) VisitGetInternalV1UsersUserIdSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdSessionsSessionIdRequestObject struct {
+	UserId    openapi_types.UUID `json:"userId"`
+	SessionId string             `json:"sessionId"`
+	Params    DeleteInternalV1UsersUserIdSessionsSessionIdParams
+}
+
+type DeleteInternalV1UsersUserIdSessionsSessionIdResponseObject interface {
+	VisitDeleteInternalV1UsersUserIdSessionsSessionIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteInternalV1UsersUserIdSessionsSessionId200JSONResponse Session
+
+func (response DeleteInternalV1UsersUserIdSessionsSessionId200JSONResponse) VisitDeleteInternalV1UsersUserIdSessionsSessionIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdSessionsSessionId401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteInternalV1UsersUserIdSessionsSessionId401JSONResponse) VisitDeleteInternalV1UsersUserIdSessionsSessionIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdSessionsSessionId403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteInternalV1UsersUserIdSessionsSessionId403JSONResponse) VisitDeleteInternalV1UsersUserIdSessionsSessionIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdSessionsSessionId404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteInternalV1UsersUserIdSessionsSessionId404JSONResponse) VisitDeleteInternalV1UsersUserIdSessionsSessionIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteInternalV1UsersUserIdSessionsSessionId500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response DeleteInternalV1UsersUserIdSessionsSessionId500JSONResponse) VisitDeleteInternalV1UsersUserIdSessionsSessionIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutInternalV1UsersUserIdStatusRequestObject struct {
+	UserId openapi_types.UUID `json:"userId"`
+	Body   *PutInternalV1UsersUserIdStatusJSONRequestBody
+}
+
+type PutInternalV1UsersUserIdStatusResponseObject interface {
+	VisitPutInternalV1UsersUserIdStatusResponse(w http.ResponseWriter) error
+}
+
+type PutInternalV1UsersUserIdStatus200JSONResponse PlatformIdentityResponse
+
+func (response PutInternalV1UsersUserIdStatus200JSONResponse) VisitPutInternalV1UsersUserIdStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutInternalV1UsersUserIdStatus400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PutInternalV1UsersUserIdStatus400JSONResponse) VisitPutInternalV1UsersUserIdStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutInternalV1UsersUserIdStatus401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PutInternalV1UsersUserIdStatus401JSONResponse) VisitPutInternalV1UsersUserIdStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutInternalV1UsersUserIdStatus403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PutInternalV1UsersUserIdStatus403JSONResponse) VisitPutInternalV1UsersUserIdStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutInternalV1UsersUserIdStatus404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response PutInternalV1UsersUserIdStatus404JSONResponse) VisitPutInternalV1UsersUserIdStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutInternalV1UsersUserIdStatus409JSONResponse struct{ ConflictJSONResponse }
+
+func (response PutInternalV1UsersUserIdStatus409JSONResponse) VisitPutInternalV1UsersUserIdStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutInternalV1UsersUserIdStatus500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response PutInternalV1UsersUserIdStatus500JSONResponse) VisitPutInternalV1UsersUserIdStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetV1WellKnownJwksRequestObject struct {
+}
+
+type GetV1WellKnownJwksResponseObject interface {
+	VisitGetV1WellKnownJwksResponse(w http.ResponseWriter) error
+}
+
+type GetV1WellKnownJwks200ResponseHeaders struct {
+	CacheControl string
+}
+
+type GetV1WellKnownJwks200JSONResponse struct {
+	Body    JwksResponse
+	Headers GetV1WellKnownJwks200ResponseHeaders
+}
+
+func (response GetV1WellKnownJwks200JSONResponse) VisitGetV1WellKnownJwksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetV1WellKnownJwks500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetV1WellKnownJwks500JSONResponse) VisitGetV1WellKnownJwksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostV1AuthExchangeRequestObject struct {
+	Body *PostV1AuthExchangeJSONRequestBody
+}
+
+type PostV1AuthExchangeResponseObject interface {
+	VisitPostV1AuthExchangeResponse(w http.ResponseWriter) error
+}
+
+type PostV1AuthExchange200JSONResponse AuthExchangeResponse
+
+func (response PostV1AuthExchange200JSONResponse) VisitPostV1AuthExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostV1AuthExchange400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PostV1AuthExchange400JSONResponse) VisitPostV1AuthExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostV1AuthExchange401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PostV1AuthExchange401JSONResponse) VisitPostV1AuthExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostV1AuthExchange403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PostV1AuthExchange403JSONResponse) VisitPostV1AuthExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostV1AuthExchange409JSONResponse struct{ ConflictJSONResponse }
+
+func (response PostV1AuthExchange409JSONResponse) VisitPostV1AuthExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostV1AuthExchange429JSONResponse struct{ TooManyRequestsJSONResponse }
+
+func (response PostV1AuthExchange429JSONResponse) VisitPostV1AuthExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostV1AuthExchange500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response PostV1AuthExchange500JSONResponse) VisitPostV1AuthExchangeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostV1AuthRefreshRequestObject struct {
+	Body *PostV1AuthRefreshJSONRequestBody
+}
+
+type PostV1AuthRefreshResponseObject interface {
+	VisitPostV1AuthRefreshResponse(w http.ResponseWriter) error
+}
+
+type PostV1AuthRefresh200JSONResponse AuthExchangeResponse
+
+func (response PostV1AuthRefresh200JSONResponse) VisitPostV1AuthRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostV1AuthRefresh400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PostV1AuthRefresh400JSONResponse) VisitPostV1AuthRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostV1AuthRefresh401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PostV1AuthRefresh401JSONResponse) VisitPostV1AuthRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostV1AuthRefresh429JSONResponse struct{ TooManyRequestsJSONResponse }
+
+func (response PostV1AuthRefresh429JSONResponse) VisitPostV1AuthRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostV1AuthRefresh500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response PostV1AuthRefresh500JSONResponse) VisitPostV1AuthRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetV1HealthRequestObject struct {
+}
+
+type GetV1HealthResponseObject interface {
+	VisitGetV1HealthResponse(w http.ResponseWriter) error
+}
+
+type GetV1Health200JSONResponse HealthResponse
+
+func (response GetV1Health200JSONResponse) VisitGetV1HealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetV1Health500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetV1Health500JSONResponse) VisitGetV1HealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetV1UsersUserIdRequestObject struct {
+	UserId openapi_types.UUID `json:"userId"`
+}
+
+type GetV1UsersUserIdResponseObject interface {
+	VisitGetV1UsersUserIdResponse(w http.ResponseWriter) error
+}
+
+type GetV1UsersUserId200JSONResponse PlatformIdentityResponse
+
+func (response GetV1UsersUserId200JSONResponse) VisitGetV1UsersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetV1UsersUserId404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetV1UsersUserId404JSONResponse) VisitGetV1UsersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetV1UsersUserId500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetV1UsersUserId500JSONResponse) VisitGetV1UsersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchV1UsersUserIdRequestObject struct {
+	UserId openapi_types.UUID `json:"userId"`
+	Body   *PatchV1UsersUserIdJSONRequestBody
+}
+
+type PatchV1UsersUserIdResponseObject interface {
+	VisitPatchV1UsersUserIdResponse(w http.ResponseWriter) error
+}
+
+type PatchV1UsersUserId200JSONResponse PlatformIdentityResponse
+
+func (response PatchV1UsersUserId200JSONResponse) VisitPatchV1UsersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchV1UsersUserId400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PatchV1UsersUserId400JSONResponse) VisitPatchV1UsersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchV1UsersUserId403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PatchV1UsersUserId403JSONResponse) VisitPatchV1UsersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchV1UsersUserId404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response PatchV1UsersUserId404JSONResponse) VisitPatchV1UsersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchV1UsersUserId500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response PatchV1UsersUserId500JSONResponse) VisitPatchV1UsersUserIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostInternalV1BackofficeTokens500JSONResponse struct{ InternalErrorJSONResponse }
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// OpenID Connect discovery document
+	// (GET /.well-known/openid-configuration)
+	GetWellKnownOpenidConfiguration(ctx context.Context, request GetWellKnownOpenidConfigurationRequestObject) (GetWellKnownOpenidConfigurationResponseObject, error)
+	// Issue backoffice access token for a known user
+	// (POST /internal/v1/backoffice-tokens)
+	PostInternalV1BackofficeTokens(ctx context.Context, request PostInternalV1BackofficeTokensRequestObject) (PostInternalV1BackofficeTokensResponseObject, error)
+	// Introspect an access token (RFC 7662)
+	// (POST /internal/v1/introspect)
+	PostInternalV1Introspect(ctx context.Context, request PostInternalV1IntrospectRequestObject) (PostInternalV1IntrospectResponseObject, error)
+	// Look up a platform identity by external identity
+	// (GET /internal/v1/linkages)
+	GetInternalV1Linkages(ctx context.Context, request GetInternalV1LinkagesRequestObject) (GetInternalV1LinkagesResponseObject, error)
+	// List providers
+	// (GET /internal/v1/providers)
+	GetInternalV1Providers(ctx context.Context, request GetInternalV1ProvidersRequestObject) (GetInternalV1ProvidersResponseObject, error)
+	// Register a provider
+	// (POST /internal/v1/providers)
+	PostInternalV1Providers(ctx context.Context, request PostInternalV1ProvidersRequestObject) (PostInternalV1ProvidersResponseObject, error)
+	// Delete a provider
+	// (DELETE /internal/v1/providers/{providerId})
+	DeleteInternalV1ProvidersProviderId(ctx context.Context, request DeleteInternalV1ProvidersProviderIdRequestObject) (DeleteInternalV1ProvidersProviderIdResponseObject, error)
+	// Get a provider
+	// (GET /internal/v1/providers/{providerId})
+	GetInternalV1ProvidersProviderId(ctx context.Context, request GetInternalV1ProvidersProviderIdRequestObject) (GetInternalV1ProvidersProviderIdResponseObject, error)
+	// Update a provider
+	// (PUT /internal/v1/providers/{providerId})
+	PutInternalV1ProvidersProviderId(ctx context.Context, request PutInternalV1ProvidersProviderIdRequestObject) (PutInternalV1ProvidersProviderIdResponseObject, error)
+	// List session revocations
+	// (GET /internal/v1/revocations)
+	GetInternalV1Revocations(ctx context.Context, request GetInternalV1RevocationsRequestObject) (GetInternalV1RevocationsResponseObject, error)
+	// List token signing keys
+	// (GET /internal/v1/signing-keys)
+	GetInternalV1SigningKeys(ctx context.Context, request GetInternalV1SigningKeysRequestObject) (GetInternalV1SigningKeysResponseObject, error)
+	// Rotate the token signing key
+	// (POST /internal/v1/signing-keys/rotate)
+	PostInternalV1SigningKeysRotate(ctx context.Context, request PostInternalV1SigningKeysRotateRequestObject) (PostInternalV1SigningKeysRotateResponseObject, error)
+	// Exchange an access token for a delegated one (RFC 8693)
+	// (POST /internal/v1/token-exchange)
+	PostInternalV1TokenExchange(ctx context.Context, request PostInternalV1TokenExchangeRequestObject) (PostInternalV1TokenExchangeResponseObject, error)
+	// List platform identities
+	// (GET /internal/v1/users)
+	GetInternalV1Users(ctx context.Context, request GetInternalV1UsersRequestObject) (GetInternalV1UsersResponseObject, error)
+	// Erase a platform identity
+	// (DELETE /internal/v1/users/{userId})
+	DeleteInternalV1UsersUserId(ctx context.Context, request DeleteInternalV1UsersUserIdRequestObject) (DeleteInternalV1UsersUserIdResponseObject, error)
+	// Export the data held about a user
+	// (GET /internal/v1/users/{userId}/export)
+	GetInternalV1UsersUserIdExport(ctx context.Context, request GetInternalV1UsersUserIdExportRequestObject) (GetInternalV1UsersUserIdExportResponseObject, error)
+	// Unlink an external identity from a user
+	// (DELETE /internal/v1/users/{userId}/linkages)
+	DeleteInternalV1UsersUserIdLinkages(ctx context.Context, request DeleteInternalV1UsersUserIdLinkagesRequestObject) (DeleteInternalV1UsersUserIdLinkagesResponseObject, error)
+	// Link an external identity to a user
+	// (POST /internal/v1/users/{userId}/linkages)
+	PostInternalV1UsersUserIdLinkages(ctx context.Context, request PostInternalV1UsersUserIdLinkagesRequestObject) (PostInternalV1UsersUserIdLinkagesResponseObject, error)
+	// Merge another platform identity into a user
+	// (POST /internal/v1/users/{userId}/merges)
+	PostInternalV1UsersUserIdMerges(ctx context.Context, request PostInternalV1UsersUserIdMergesRequestObject) (PostInternalV1UsersUserIdMergesResponseObject, error)
+	// Revoke all sessions of a user
+	// (DELETE /internal/v1/users/{userId}/sessions)
+	DeleteInternalV1UsersUserIdSessions(ctx context.Context, request DeleteInternalV1UsersUserIdSessionsRequestObject) (DeleteInternalV1UsersUserIdSessionsResponseObject, error)
+	// List sessions of a user
+	// (GET /internal/v1/users/{userId}/sessions)
+	GetInternalV1UsersUserIdSessions(ctx context.Context, request GetInternalV1UsersUserIdSessionsRequestObject) (GetInternalV1UsersUserIdSessionsResponseObject, error)
+	// Revoke a session
+	// (DELETE /internal/v1/users/{userId}/sessions/{sessionId})
+	DeleteInternalV1UsersUserIdSessionsSessionId(ctx context.Context, request DeleteInternalV1UsersUserIdSessionsSessionIdRequestObject) (DeleteInternalV1UsersUserIdSessionsSessionIdResponseObject, error)
+	// Suspend, ban or reactivate a user
+	// (PUT /internal/v1/users/{userId}/status)
+	PutInternalV1UsersUserIdStatus(ctx context.Context, request PutInternalV1UsersUserIdStatusRequestObject) (PutInternalV1UsersUserIdStatusResponseObject, error)
+	// JSON Web Key Set (JWKS)
+	// (GET /v1/.well-known/jwks.json)
+	GetV1WellKnownJwks(ctx context.Context, request GetV1WellKnownJwksRequestObject) (GetV1WellKnownJwksResponseObject, error)
+	// Exchange external identity assertion for a Proteon access token
+	// (POST /v1/auth/exchange)
+	PostV1AuthExchange(ctx context.Context, request PostV1AuthExchangeRequestObject) (PostV1AuthExchangeResponseObject, error)
+	// Exchange a refresh token for a new access token
+	// (POST /v1/auth/refresh)
+	PostV1AuthRefresh(ctx context.Context, request PostV1AuthRefreshRequestObject) (PostV1AuthRefreshResponseObject, error)
+	// Health check
+	// (GET /v1/health)
+	GetV1Health(ctx context.Context, request GetV1HealthRequestObject) (GetV1HealthResponseObject, error)
+	// Get platform identity by user ID
+	// (GET /v1/users/{userId})
+	GetV1UsersUserId(ctx context.Context, request GetV1UsersUserIdRequestObject) (GetV1UsersUserIdResponseObject, error)
+	// Update profile attributes
+	// (PATCH /v1/users/{userId})
+	PatchV1UsersUserId(ctx context.Context, request PatchV1UsersUserIdRequestObject) (PatchV1UsersUserIdResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetWellKnownOpenidConfiguration operation middleware
+func (sh *strictHandler) GetWellKnownOpenidConfiguration(w http.ResponseWriter, r *http.Request) {
+	var request GetWellKnownOpenidConfigurationRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWellKnownOpenidConfiguration(ctx, request.(GetWellKnownOpenidConfigurationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWellKnownOpenidConfiguration")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetWellKnownOpenidConfigurationResponseObject); ok {
+		if err := validResponse.VisitGetWellKnownOpenidConfigurationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostInternalV1BackofficeTokens operation middleware
+func (sh *strictHandler) PostInternalV1BackofficeTokens(w http.ResponseWriter, r *http.Request) {
+	var request PostInternalV1BackofficeTokensRequestObject
+
+	var body PostInternalV1BackofficeTokensJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostInternalV1BackofficeTokens(ctx, request.(PostInternalV1BackofficeTokensRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostInternalV1BackofficeTokens")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostInternalV1BackofficeTokensResponseObject); ok {
+		if err := validResponse.VisitPostInternalV1BackofficeTokensResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostInternalV1Introspect operation middleware
+func (sh *strictHandler) PostInternalV1Introspect(w http.ResponseWriter, r *http.Request) {
+	var request PostInternalV1IntrospectRequestObject
+
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
+	}
+	var body PostInternalV1IntrospectFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostInternalV1Introspect(ctx, request.(PostInternalV1IntrospectRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostInternalV1Introspect")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostInternalV1IntrospectResponseObject); ok {
+		if err := validResponse.VisitPostInternalV1IntrospectResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetInternalV1Linkages operation middleware
+func (sh *strictHandler) GetInternalV1Linkages(w http.ResponseWriter, r *http.Request, params GetInternalV1LinkagesParams) {
+	var request GetInternalV1LinkagesRequestObject
 
-func (response PostInternalV1BackofficeTokens500JSONResponse) VisitPostInternalV1BackofficeTokensResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInternalV1Linkages(ctx, request.(GetInternalV1LinkagesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInternalV1Linkages")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInternalV1LinkagesResponseObject); ok {
+		if err := validResponse.VisitGetInternalV1LinkagesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetV1WellKnownJwksRequestObject struct {
+// GetInternalV1Providers operation middleware
+func (sh *strictHandler) GetInternalV1Providers(w http.ResponseWriter, r *http.Request) {
+	var request GetInternalV1ProvidersRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInternalV1Providers(ctx, request.(GetInternalV1ProvidersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInternalV1Providers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInternalV1ProvidersResponseObject); ok {
+		if err := validResponse.VisitGetInternalV1ProvidersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetV1WellKnownJwksResponseObject interface {
-	VisitGetV1WellKnownJwksResponse(w http.ResponseWriter) error
+// PostInternalV1Providers operation middleware
+func (sh *strictHandler) PostInternalV1Providers(w http.ResponseWriter, r *http.Request) {
+	var request PostInternalV1ProvidersRequestObject
+
+	var body PostInternalV1ProvidersJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostInternalV1Providers(ctx, request.(PostInternalV1ProvidersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostInternalV1Providers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostInternalV1ProvidersResponseObject); ok {
+		if err := validResponse.VisitPostInternalV1ProvidersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetV1WellKnownJwks200JSONResponse JwksResponse
+// DeleteInternalV1ProvidersProviderId operation middleware
+func (sh *strictHandler) DeleteInternalV1ProvidersProviderId(w http.ResponseWriter, r *http.Request, providerId string) {
+	var request DeleteInternalV1ProvidersProviderIdRequestObject
 
-func (response GetV1WellKnownJwks200JSONResponse) VisitGetV1WellKnownJwksResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.ProviderId = providerId
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteInternalV1ProvidersProviderId(ctx, request.(DeleteInternalV1ProvidersProviderIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteInternalV1ProvidersProviderId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteInternalV1ProvidersProviderIdResponseObject); ok {
+		if err := validResponse.VisitDeleteInternalV1ProvidersProviderIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetV1WellKnownJwks500JSONResponse struct{ InternalErrorJSONResponse }
+// GetInternalV1ProvidersProviderId operation middleware
+func (sh *strictHandler) GetInternalV1ProvidersProviderId(w http.ResponseWriter, r *http.Request, providerId string) {
+	var request GetInternalV1ProvidersProviderIdRequestObject
 
-func (response GetV1WellKnownJwks500JSONResponse) VisitGetV1WellKnownJwksResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.ProviderId = providerId
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInternalV1ProvidersProviderId(ctx, request.(GetInternalV1ProvidersProviderIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInternalV1ProvidersProviderId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInternalV1ProvidersProviderIdResponseObject); ok {
+		if err := validResponse.VisitGetInternalV1ProvidersProviderIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostV1AuthExchangeRequestObject struct {
-	Body *PostV1AuthExchangeJSONRequestBody
+// PutInternalV1ProvidersProviderId operation middleware
+func (sh *strictHandler) PutInternalV1ProvidersProviderId(w http.ResponseWriter, r *http.Request, providerId string) {
+	var request PutInternalV1ProvidersProviderIdRequestObject
+
+	request.ProviderId = providerId
+
+	var body PutInternalV1ProvidersProviderIdJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutInternalV1ProvidersProviderId(ctx, request.(PutInternalV1ProvidersProviderIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutInternalV1ProvidersProviderId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutInternalV1ProvidersProviderIdResponseObject); ok {
+		if err := validResponse.VisitPutInternalV1ProvidersProviderIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostV1AuthExchangeResponseObject interface {
-	VisitPostV1AuthExchangeResponse(w http.ResponseWriter) error
+// GetInternalV1Revocations operation middleware
+func (sh *strictHandler) GetInternalV1Revocations(w http.ResponseWriter, r *http.Request, params GetInternalV1RevocationsParams) {
+	var request GetInternalV1RevocationsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInternalV1Revocations(ctx, request.(GetInternalV1RevocationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInternalV1Revocations")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInternalV1RevocationsResponseObject); ok {
+		if err := validResponse.VisitGetInternalV1RevocationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostV1AuthExchange200JSONResponse AuthExchangeResponse
+// GetInternalV1SigningKeys operation middleware
+func (sh *strictHandler) GetInternalV1SigningKeys(w http.ResponseWriter, r *http.Request) {
+	var request GetInternalV1SigningKeysRequestObject
 
-func (response PostV1AuthExchange200JSONResponse) VisitPostV1AuthExchangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInternalV1SigningKeys(ctx, request.(GetInternalV1SigningKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInternalV1SigningKeys")
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInternalV1SigningKeysResponseObject); ok {
+		if err := validResponse.VisitGetInternalV1SigningKeysResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostV1AuthExchange400JSONResponse struct{ BadRequestJSONResponse }
+// PostInternalV1SigningKeysRotate operation middleware
+func (sh *strictHandler) PostInternalV1SigningKeysRotate(w http.ResponseWriter, r *http.Request) {
+	var request PostInternalV1SigningKeysRotateRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostInternalV1SigningKeysRotate(ctx, request.(PostInternalV1SigningKeysRotateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostInternalV1SigningKeysRotate")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostInternalV1SigningKeysRotateResponseObject); ok {
+		if err := validResponse.VisitPostInternalV1SigningKeysRotateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostInternalV1TokenExchange operation middleware
+func (sh *strictHandler) PostInternalV1TokenExchange(w http.ResponseWriter, r *http.Request) {
+	var request PostInternalV1TokenExchangeRequestObject
+
+	if err := r.ParseForm(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode formdata: %w", err))
+		return
+	}
+	var body PostInternalV1TokenExchangeFormdataRequestBody
+	if err := runtime.BindForm(&body, r.Form, nil, nil); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't bind formdata: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostInternalV1TokenExchange(ctx, request.(PostInternalV1TokenExchangeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostInternalV1TokenExchange")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostInternalV1TokenExchangeResponseObject); ok {
+		if err := validResponse.VisitPostInternalV1TokenExchangeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetInternalV1Users operation middleware
+func (sh *strictHandler) GetInternalV1Users(w http.ResponseWriter, r *http.Request, params GetInternalV1UsersParams) {
+	var request GetInternalV1UsersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInternalV1Users(ctx, request.(GetInternalV1UsersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInternalV1Users")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInternalV1UsersResponseObject); ok {
+		if err := validResponse.VisitGetInternalV1UsersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteInternalV1UsersUserId operation middleware
+func (sh *strictHandler) DeleteInternalV1UsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, params DeleteInternalV1UsersUserIdParams) {
+	var request DeleteInternalV1UsersUserIdRequestObject
+
+	request.UserId = userId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteInternalV1UsersUserId(ctx, request.(DeleteInternalV1UsersUserIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteInternalV1UsersUserId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteInternalV1UsersUserIdResponseObject); ok {
+		if err := validResponse.VisitDeleteInternalV1UsersUserIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetInternalV1UsersUserIdExport operation middleware
+func (sh *strictHandler) GetInternalV1UsersUserIdExport(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	var request GetInternalV1UsersUserIdExportRequestObject
+
+	request.UserId = userId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInternalV1UsersUserIdExport(ctx, request.(GetInternalV1UsersUserIdExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInternalV1UsersUserIdExport")
+	}
 
-func (response PostV1AuthExchange400JSONResponse) VisitPostV1AuthExchangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInternalV1UsersUserIdExportResponseObject); ok {
+		if err := validResponse.VisitGetInternalV1UsersUserIdExportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostV1AuthExchange401JSONResponse struct{ UnauthorizedJSONResponse }
-
-func (response PostV1AuthExchange401JSONResponse) VisitPostV1AuthExchangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+// DeleteInternalV1UsersUserIdLinkages operation middleware
+func (sh *strictHandler) DeleteInternalV1UsersUserIdLinkages(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, params DeleteInternalV1UsersUserIdLinkagesParams) {
+	var request DeleteInternalV1UsersUserIdLinkagesRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.UserId = userId
+	request.Params = params
 
-type PostV1AuthExchange429JSONResponse struct{ TooManyRequestsJSONResponse }
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteInternalV1UsersUserIdLinkages(ctx, request.(DeleteInternalV1UsersUserIdLinkagesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteInternalV1UsersUserIdLinkages")
+	}
 
-func (response PostV1AuthExchange429JSONResponse) VisitPostV1AuthExchangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(429)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteInternalV1UsersUserIdLinkagesResponseObject); ok {
+		if err := validResponse.VisitDeleteInternalV1UsersUserIdLinkagesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PostV1AuthExchange500JSONResponse struct{ InternalErrorJSONResponse }
+// PostInternalV1UsersUserIdLinkages operation middleware
+func (sh *strictHandler) PostInternalV1UsersUserIdLinkages(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	var request PostInternalV1UsersUserIdLinkagesRequestObject
 
-func (response PostV1AuthExchange500JSONResponse) VisitPostV1AuthExchangeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.UserId = userId
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body PostInternalV1UsersUserIdLinkagesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type GetV1HealthRequestObject struct {
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostInternalV1UsersUserIdLinkages(ctx, request.(PostInternalV1UsersUserIdLinkagesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostInternalV1UsersUserIdLinkages")
+	}
 
-type GetV1HealthResponseObject interface {
-	VisitGetV1HealthResponse(w http.ResponseWriter) error
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostInternalV1UsersUserIdLinkagesResponseObject); ok {
+		if err := validResponse.VisitPostInternalV1UsersUserIdLinkagesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetV1Health200JSONResponse HealthResponse
+// PostInternalV1UsersUserIdMerges operation middleware
+func (sh *strictHandler) PostInternalV1UsersUserIdMerges(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	var request PostInternalV1UsersUserIdMergesRequestObject
 
-func (response GetV1Health200JSONResponse) VisitGetV1HealthResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.UserId = userId
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body PostInternalV1UsersUserIdMergesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type GetV1Health500JSONResponse struct{ InternalErrorJSONResponse }
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostInternalV1UsersUserIdMerges(ctx, request.(PostInternalV1UsersUserIdMergesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostInternalV1UsersUserIdMerges")
+	}
 
-func (response GetV1Health500JSONResponse) VisitGetV1HealthResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostInternalV1UsersUserIdMergesResponseObject); ok {
+		if err := validResponse.VisitPostInternalV1UsersUserIdMergesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetV1UsersUserIdRequestObject struct {
-	UserId openapi_types.UUID `json:"userId"`
-}
+// DeleteInternalV1UsersUserIdSessions operation middleware
+func (sh *strictHandler) DeleteInternalV1UsersUserIdSessions(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, params DeleteInternalV1UsersUserIdSessionsParams) {
+	var request DeleteInternalV1UsersUserIdSessionsRequestObject
 
-type GetV1UsersUserIdResponseObject interface {
-	VisitGetV1UsersUserIdResponse(w http.ResponseWriter) error
-}
+	request.UserId = userId
+	request.Params = params
 
-type GetV1UsersUserId200JSONResponse PlatformIdentityResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteInternalV1UsersUserIdSessions(ctx, request.(DeleteInternalV1UsersUserIdSessionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteInternalV1UsersUserIdSessions")
+	}
 
-func (response GetV1UsersUserId200JSONResponse) VisitGetV1UsersUserIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteInternalV1UsersUserIdSessionsResponseObject); ok {
+		if err := validResponse.VisitDeleteInternalV1UsersUserIdSessionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetV1UsersUserId404JSONResponse struct{ NotFoundJSONResponse }
-
-func (response GetV1UsersUserId404JSONResponse) VisitGetV1UsersUserIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+// GetInternalV1UsersUserIdSessions operation middleware
+func (sh *strictHandler) GetInternalV1UsersUserIdSessions(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	var request GetInternalV1UsersUserIdSessionsRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.UserId = userId
 
-type GetV1UsersUserId500JSONResponse struct{ InternalErrorJSONResponse }
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInternalV1UsersUserIdSessions(ctx, request.(GetInternalV1UsersUserIdSessionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInternalV1UsersUserIdSessions")
+	}
 
-func (response GetV1UsersUserId500JSONResponse) VisitGetV1UsersUserIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInternalV1UsersUserIdSessionsResponseObject); ok {
+		if err := validResponse.VisitGetInternalV1UsersUserIdSessionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Issue backoffice access token for a known user
-	// (POST /internal/v1/backoffice-tokens)
-	PostInternalV1BackofficeTokens(ctx context.Context, request PostInternalV1BackofficeTokensRequestObject) (PostInternalV1BackofficeTokensResponseObject, error)
-	// JSON Web Key Set (JWKS)
-	// (GET /v1/.well-known/jwks.json)
-	GetV1WellKnownJwks(ctx context.Context, request GetV1WellKnownJwksRequestObject) (GetV1WellKnownJwksResponseObject, error)
-	// Exchange external identity assertion for a Proteon access token
-	// (POST /v1/auth/exchange)
-	PostV1AuthExchange(ctx context.Context, request PostV1AuthExchangeRequestObject) (PostV1AuthExchangeResponseObject, error)
-	// Health check
-	// (GET /v1/health)
-	GetV1Health(ctx context.Context, request GetV1HealthRequestObject) (GetV1HealthResponseObject, error)
-	// Get platform identity by user ID
-	// (GET /v1/users/{userId})
-	GetV1UsersUserId(ctx context.Context, request GetV1UsersUserIdRequestObject) (GetV1UsersUserIdResponseObject, error)
-}
+// DeleteInternalV1UsersUserIdSessionsSessionId operation middleware
+func (sh *strictHandler) DeleteInternalV1UsersUserIdSessionsSessionId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, sessionId string, params DeleteInternalV1UsersUserIdSessionsSessionIdParams) {
+	var request DeleteInternalV1UsersUserIdSessionsSessionIdRequestObject
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+	request.UserId = userId
+	request.SessionId = sessionId
+	request.Params = params
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteInternalV1UsersUserIdSessionsSessionId(ctx, request.(DeleteInternalV1UsersUserIdSessionsSessionIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteInternalV1UsersUserIdSessionsSessionId")
+	}
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteInternalV1UsersUserIdSessionsSessionIdResponseObject); ok {
+		if err := validResponse.VisitDeleteInternalV1UsersUserIdSessionsSessionIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
+// PutInternalV1UsersUserIdStatus operation middleware
+func (sh *strictHandler) PutInternalV1UsersUserIdStatus(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	var request PutInternalV1UsersUserIdStatusRequestObject
 
-// PostInternalV1BackofficeTokens operation middleware
-func (sh *strictHandler) PostInternalV1BackofficeTokens(w http.ResponseWriter, r *http.Request) {
-	var request PostInternalV1BackofficeTokensRequestObject
+	request.UserId = userId
 
-	var body PostInternalV1BackofficeTokensJSONRequestBody
+	var body PutInternalV1UsersUserIdStatusJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -795,18 +4480,18 @@ func (sh *strictHandler) PostInternalV1BackofficeTokens(w http.ResponseWriter, r
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostInternalV1BackofficeTokens(ctx, request.(PostInternalV1BackofficeTokensRequestObject))
+		return sh.ssi.PutInternalV1UsersUserIdStatus(ctx, request.(PutInternalV1UsersUserIdStatusRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostInternalV1BackofficeTokens")
+		handler = middleware(handler, "PutInternalV1UsersUserIdStatus")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostInternalV1BackofficeTokensResponseObject); ok {
-		if err := validResponse.VisitPostInternalV1BackofficeTokensResponse(w); err != nil {
+	} else if validResponse, ok := response.(PutInternalV1UsersUserIdStatusResponseObject); ok {
+		if err := validResponse.VisitPutInternalV1UsersUserIdStatusResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -869,6 +4554,37 @@ func (sh *strictHandler) PostV1AuthExchange(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// PostV1AuthRefresh operation middleware
+func (sh *strictHandler) PostV1AuthRefresh(w http.ResponseWriter, r *http.Request) {
+	var request PostV1AuthRefreshRequestObject
+
+	var body PostV1AuthRefreshJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostV1AuthRefresh(ctx, request.(PostV1AuthRefreshRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostV1AuthRefresh")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostV1AuthRefreshResponseObject); ok {
+		if err := validResponse.VisitPostV1AuthRefreshResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetV1Health operation middleware
 func (sh *strictHandler) GetV1Health(w http.ResponseWriter, r *http.Request) {
 	var request GetV1HealthRequestObject
@@ -918,3 +4634,36 @@ func (sh *strictHandler) GetV1UsersUserId(w http.ResponseWriter, r *http.Request
 		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
 	}
 }
+
+// PatchV1UsersUserId operation middleware
+func (sh *strictHandler) PatchV1UsersUserId(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+	var request PatchV1UsersUserIdRequestObject
+
+	request.UserId = userId
+
+	var body PatchV1UsersUserIdJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchV1UsersUserId(ctx, request.(PatchV1UsersUserIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchV1UsersUserId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchV1UsersUserIdResponseObject); ok {
+		if err := validResponse.VisitPatchV1UsersUserIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
@@ -2,37 +2,73 @@ package http
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
 	authapp "github.com/woffVienna/proteon-cursor/services/identity/internal/application/auth"
-	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/identities"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/introspection"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/providers"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/sessions"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/signingkeys"
 	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
 )
 
+// Services are the application services the HTTP adapter exposes.
+type Services struct {
+	Auth          *authapp.Service
+	SigningKeys   *signingkeys.Service
+	Sessions      *sessions.Service
+	Introspection *introspection.Service
+	Providers     *providers.Service
+	Identities    *identities.Service
+}
+
 // Handler implements server.StrictServerInterface.
 type Handler struct {
-	authSvc     *authapp.Service
-	issuer      interfaces.TokenIssuer
-	serviceName string
-	version     string
+	authSvc          *authapp.Service
+	keysSvc          *signingkeys.Service
+	sessionsSvc      *sessions.Service
+	introspectionSvc *introspection.Service
+	providersSvc     *providers.Service
+	identitiesSvc    *identities.Service
+	jwksMaxAge       time.Duration
+	discovery        Discovery
+	serviceName      string
+	version          string
 }
 
 // NewHandler creates an HTTP handler.
 func NewHandler(
-	authSvc *authapp.Service,
-	issuer interfaces.TokenIssuer,
+	svcs Services,
+	jwksMaxAge time.Duration,
+	discovery Discovery,
 	serviceName string,
 	version string,
 ) *Handler {
 	return &Handler{
-		authSvc:     authSvc,
-		issuer:      issuer,
-		serviceName: serviceName,
-		version:     version,
+		authSvc:          svcs.Auth,
+		keysSvc:          svcs.SigningKeys,
+		sessionsSvc:      svcs.Sessions,
+		introspectionSvc: svcs.Introspection,
+		providersSvc:     svcs.Providers,
+		identitiesSvc:    svcs.Identities,
+		jwksMaxAge:       jwksMaxAge,
+		discovery:        discovery,
+		serviceName:      serviceName,
+		version:          version,
 	}
 }
 
@@ -48,18 +84,104 @@ func (h *Handler) GetV1Health(_ context.Context, _ server.GetV1HealthRequestObje
 	}), nil
 }
 
-func (h *Handler) GetV1WellKnownJwks(_ context.Context, _ server.GetV1WellKnownJwksRequestObject) (server.GetV1WellKnownJwksResponseObject, error) {
-	alg := "EdDSA"
+func (h *Handler) GetV1WellKnownJwks(ctx context.Context, _ server.GetV1WellKnownJwksRequestObject) (server.GetV1WellKnownJwksResponseObject, error) {
+	keys, err := h.keysSvc.PublishedKeys(ctx)
+	if err != nil {
+		return server.GetV1WellKnownJwks500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
 	use := "sig"
-	jwk := server.Jwk{
-		Kty: "OKP",
-		Kid: h.issuer.Kid(),
-		Alg: &alg,
-		Use: &use,
-	}
-	jwk.Set("crv", "Ed25519")
-	jwk.Set("x", base64.RawURLEncoding.EncodeToString(h.issuer.PublicKey()))
-	return server.GetV1WellKnownJwks200JSONResponse{Keys: []server.Jwk{jwk}}, nil
+	jwks := make([]server.Jwk, 0, len(keys))
+	for _, k := range keys {
+		alg := k.Algorithm
+		jwk := server.Jwk{
+			Kid: k.Kid,
+			Alg: &alg,
+			Use: &use,
+		}
+		switch pub := k.PublicKey.(type) {
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Set("crv", "Ed25519")
+			jwk.Set("x", base64.RawURLEncoding.EncodeToString(pub))
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.Set("n", base64.RawURLEncoding.EncodeToString(pub.N.Bytes()))
+			jwk.Set("e", base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()))
+		case *ecdsa.PublicKey:
+			point, err := pub.Bytes()
+			if err != nil {
+				continue
+			}
+			size := (len(point) - 1) / 2
+			jwk.Kty = "EC"
+			jwk.Set("crv", "P-256")
+			jwk.Set("x", base64.RawURLEncoding.EncodeToString(point[1:1+size]))
+			jwk.Set("y", base64.RawURLEncoding.EncodeToString(point[1+size:]))
+		default:
+			continue
+		}
+		jwks = append(jwks, jwk)
+	}
+
+	return server.GetV1WellKnownJwks200JSONResponse{
+		Body: server.JwksResponse{Keys: jwks},
+		Headers: server.GetV1WellKnownJwks200ResponseHeaders{
+			CacheControl: fmt.Sprintf("public, max-age=%d", int(h.jwksMaxAge.Seconds())),
+		},
+	}, nil
+}
+
+func (h *Handler) GetWellKnownOpenidConfiguration(ctx context.Context, _ server.GetWellKnownOpenidConfigurationRequestObject) (server.GetWellKnownOpenidConfigurationResponseObject, error) {
+	keys, err := h.keysSvc.PublishedKeys(ctx)
+	if err != nil {
+		return server.GetWellKnownOpenidConfiguration500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	algs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if !slices.Contains(algs, k.Algorithm) {
+			algs = append(algs, k.Algorithm)
+		}
+	}
+	slices.Sort(algs)
+
+	claims := []string{"iss", "sub", "aud", "exp", "iat", "nbf", "tenant", "subject_type", "sid", "scope"}
+	for _, field := range domain.ProfileFields {
+		claims = append(claims, field.Claim())
+	}
+
+	base := strings.TrimSuffix(h.discovery.BaseURL, "/")
+	doc := server.GetWellKnownOpenidConfiguration200JSONResponse{
+		Issuer:                h.discovery.Issuer,
+		JwksUri:               base + "/v1/.well-known/jwks.json",
+		GrantTypesSupported:   []string{grantTypeTokenExchange},
+		TokenExchangeEndpoint: base + tokenExchangePath,
+		IntrospectionEndpoint: base + introspectPath,
+		IntrospectionEndpointAuthMethodsSupported: []string{"client_secret_basic"},
+		AccessTokenSigningAlgValuesSupported:      algs,
+		ClaimsSupported:                           claims,
+	}
+	// The authorization code flow is served by the auth service; its
+	// endpoints are only known when configured.
+	if h.discovery.AuthorizationEndpoint != "" {
+		authorize, token := h.discovery.AuthorizationEndpoint, h.discovery.TokenEndpoint
+		responseTypes, subjectTypes, methods := []string{"code"}, []string{"public"}, []string{"S256"}
+		doc.AuthorizationEndpoint = &authorize
+		doc.TokenEndpoint = &token
+		doc.ResponseTypesSupported = &responseTypes
+		doc.SubjectTypesSupported = &subjectTypes
+		doc.GrantTypesSupported = append([]string{"authorization_code"}, doc.GrantTypesSupported...)
+		doc.CodeChallengeMethodsSupported = &methods
+	}
+	return doc, nil
 }
 
 func (h *Handler) PostV1AuthExchange(ctx context.Context, req server.PostV1AuthExchangeRequestObject) (server.PostV1AuthExchangeResponseObject, error) {
@@ -71,17 +193,62 @@ func (h *Handler) PostV1AuthExchange(ctx context.Context, req server.PostV1AuthE
 		}, nil
 	}
 
-	tenant := ""
-	if req.Body.Tenant != nil {
-		tenant = *req.Body.Tenant
+	var scope string
+	if req.Body.Scope != nil {
+		scope = *req.Body.Scope
+	}
+
+	var profile map[domain.ProfileField]string
+	if p := req.Body.Profile; p != nil {
+		profile = profileValues(p.DisplayName, p.Locale, p.Country, p.BirthYear)
 	}
 
-	result, err := h.authSvc.Exchange(ctx, req.Body.Provider, req.Body.ExternalUserId, tenant)
+	result, err := h.authSvc.Exchange(ctx, req.Body.Assertion, domain.ParseScope(scope), profile)
 	if err != nil {
-		if err == domain.ErrInvalidAssertion {
+		if errors.Is(err, domain.ErrInvalidAssertion) {
+			return server.PostV1AuthExchange401JSONResponse{
+				UnauthorizedJSONResponse: server.UnauthorizedJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_ASSERTION", Message: "invalid external identity assertion"},
+				}),
+			}, nil
+		}
+		if errors.Is(err, domain.ErrProviderDisabled) {
+			return server.PostV1AuthExchange403JSONResponse{
+				ForbiddenJSONResponse: server.ForbiddenJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "PROVIDER_DISABLED", Message: "provider is disabled"},
+				}),
+			}, nil
+		}
+		if errors.Is(err, domain.ErrTenantNotAllowed) {
+			return server.PostV1AuthExchange403JSONResponse{
+				ForbiddenJSONResponse: server.ForbiddenJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "TENANT_NOT_ALLOWED", Message: "tenant not allowed for provider"},
+				}),
+			}, nil
+		}
+		if errors.Is(err, domain.ErrIdentityInactive) {
+			return server.PostV1AuthExchange403JSONResponse{
+				ForbiddenJSONResponse: server.ForbiddenJSONResponse(inactiveError(err)),
+			}, nil
+		}
+		if errors.Is(err, domain.ErrTenantMismatch) {
+			return server.PostV1AuthExchange409JSONResponse{
+				ConflictJSONResponse: server.ConflictJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "TENANT_MISMATCH", Message: "external identity belongs to another tenant"},
+				}),
+			}, nil
+		}
+		if errors.Is(err, domain.ErrInvalidScope) {
 			return server.PostV1AuthExchange400JSONResponse{
 				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
-					Error: server.ErrorBody{Code: "INVALID_ASSERTION", Message: "invalid external identity assertion"},
+					Error: server.ErrorBody{Code: "INVALID_SCOPE", Message: "requested scope is not granted"},
+				}),
+			}, nil
+		}
+		if errors.Is(err, domain.ErrInvalidProfile) {
+			return server.PostV1AuthExchange400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_PROFILE", Message: err.Error()},
 				}),
 			}, nil
 		}
@@ -92,7 +259,7 @@ func (h *Handler) PostV1AuthExchange(ctx context.Context, req server.PostV1AuthE
 		}, nil
 	}
 
-	platformUserUUID, err := uuid.Parse(result.PlatformUserID)
+	resp, err := toAuthExchangeResponse(result)
 	if err != nil {
 		return server.PostV1AuthExchange500JSONResponse{
 			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
@@ -101,18 +268,81 @@ func (h *Handler) PostV1AuthExchange(ctx context.Context, req server.PostV1AuthE
 		}, nil
 	}
 
-	return server.PostV1AuthExchange200JSONResponse(server.AuthExchangeResponse{
-		AccessToken:    result.AccessToken,
-		TokenType:      server.AuthExchangeResponseTokenTypeBearer,
-		ExpiresIn:      result.ExpiresIn,
-		PlatformUserId: platformUserUUID,
-	}), nil
+	return server.PostV1AuthExchange200JSONResponse(resp), nil
+}
+
+func (h *Handler) PostV1AuthRefresh(ctx context.Context, req server.PostV1AuthRefreshRequestObject) (server.PostV1AuthRefreshResponseObject, error) {
+	if req.Body == nil {
+		return server.PostV1AuthRefresh400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "BAD_REQUEST", Message: "missing request body"},
+			}),
+		}, nil
+	}
+
+	result, err := h.authSvc.Refresh(ctx, req.Body.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRefreshTokenReused):
+			return server.PostV1AuthRefresh401JSONResponse{
+				UnauthorizedJSONResponse: server.UnauthorizedJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "REFRESH_TOKEN_REUSED", Message: "refresh token was already used; token family revoked"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrInvalidRefreshToken):
+			return server.PostV1AuthRefresh401JSONResponse{
+				UnauthorizedJSONResponse: server.UnauthorizedJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_REFRESH_TOKEN", Message: "invalid refresh token"},
+				}),
+			}, nil
+		}
+		return server.PostV1AuthRefresh500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp, err := toAuthExchangeResponse(result)
+	if err != nil {
+		return server.PostV1AuthRefresh500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	return server.PostV1AuthRefresh200JSONResponse(resp), nil
+}
+
+func toAuthExchangeResponse(result *domain.TokenResult) (server.AuthExchangeResponse, error) {
+	platformUserUUID, err := uuid.Parse(result.PlatformUserID)
+	if err != nil {
+		return server.AuthExchangeResponse{}, err
+	}
+	return server.AuthExchangeResponse{
+		AccessToken:      result.AccessToken,
+		TokenType:        server.AuthExchangeResponseTokenTypeBearer,
+		ExpiresIn:        result.ExpiresIn,
+		PlatformUserId:   platformUserUUID,
+		RefreshToken:     result.RefreshToken,
+		RefreshExpiresIn: result.RefreshExpiresIn,
+		Scope:            optionalString(domain.FormatScope(result.Scopes)),
+	}, nil
 }
 
 func (h *Handler) GetV1UsersUserId(ctx context.Context, req server.GetV1UsersUserIdRequestObject) (server.GetV1UsersUserIdResponseObject, error) {
-	identity, err := h.authSvc.GetIdentity(ctx, req.UserId.String())
+	var (
+		identity *domain.PlatformIdentity
+		err      error
+	)
+	if tenant, ok := callerTenant(ctx); ok {
+		identity, err = h.authSvc.GetIdentityInTenant(ctx, req.UserId.String(), tenant)
+	} else {
+		identity, err = h.authSvc.GetIdentity(ctx, req.UserId.String())
+	}
 	if err != nil {
-		if err == domain.ErrIdentityNotFound {
+		if errors.Is(err, domain.ErrIdentityNotFound) {
 			return server.GetV1UsersUserId404JSONResponse{
 				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
 					Error: server.ErrorBody{Code: "NOT_FOUND", Message: "platform identity not found"},
@@ -126,7 +356,7 @@ func (h *Handler) GetV1UsersUserId(ctx context.Context, req server.GetV1UsersUse
 		}, nil
 	}
 
-	platformUserUUID, err := uuid.Parse(identity.PlatformUserID)
+	resp, err := toPlatformIdentity(*identity)
 	if err != nil {
 		return server.GetV1UsersUserId500JSONResponse{
 			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
@@ -134,19 +364,7 @@ func (h *Handler) GetV1UsersUserId(ctx context.Context, req server.GetV1UsersUse
 			}),
 		}, nil
 	}
-
-	var tenant *string
-	if identity.Tenant != "" {
-		tenant = &identity.Tenant
-	}
-
-	return server.GetV1UsersUserId200JSONResponse(server.PlatformIdentityResponse{
-		PlatformUserId: platformUserUUID,
-		Provider:       identity.Provider,
-		ExternalUserId: identity.ExternalUserID,
-		Tenant:         tenant,
-		CreatedAt:      identity.CreatedAt,
-	}), nil
+	return server.GetV1UsersUserId200JSONResponse(resp), nil
 }
 
 func (h *Handler) PostInternalV1BackofficeTokens(ctx context.Context, req server.PostInternalV1BackofficeTokensRequestObject) (server.PostInternalV1BackofficeTokensResponseObject, error) {
@@ -165,13 +383,36 @@ func (h *Handler) PostInternalV1BackofficeTokens(ctx context.Context, req server
 		tenant = *body.TenantId
 	}
 
-	aud := "backoffice"
-	if body.Audience != nil && *body.Audience != "" {
+	var aud string
+	if body.Audience != nil {
 		aud = *body.Audience
 	}
+	var scope string
+	if body.Scope != nil {
+		scope = *body.Scope
+	}
 
-	result, err := h.authSvc.IssueBackofficeToken(ctx, body.UserId.String(), string(body.SubjectType), tenant, aud)
+	result, err := h.authSvc.IssueBackofficeToken(ctx, body.UserId.String(), string(body.SubjectType), tenant, aud, domain.ParseScope(scope))
 	if err != nil {
+		if errors.Is(err, domain.ErrIdentityInactive) {
+			return server.PostInternalV1BackofficeTokens403JSONResponse{
+				ForbiddenJSONResponse: server.ForbiddenJSONResponse(inactiveError(err)),
+			}, nil
+		}
+		if errors.Is(err, domain.ErrIdentityErased) {
+			return server.PostInternalV1BackofficeTokens404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "IDENTITY_ERASED", Message: "platform identity was erased"},
+				}),
+			}, nil
+		}
+		if errors.Is(err, domain.ErrInvalidScope) {
+			return server.PostInternalV1BackofficeTokens400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_SCOPE", Message: "requested scope is not granted"},
+				}),
+			}, nil
+		}
 		return server.PostInternalV1BackofficeTokens500JSONResponse{
 			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
 				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
@@ -183,6 +424,7 @@ func (h *Handler) PostInternalV1BackofficeTokens(ctx context.Context, req server
 		AccessToken: result.AccessToken,
 		TokenType:   server.BackofficeTokenResponseTokenTypeBearer,
 		ExpiresIn:   result.ExpiresIn,
+		Scope:       optionalString(domain.FormatScope(result.Scopes)),
 	}), nil
 }
 
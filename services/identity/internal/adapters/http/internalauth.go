@@ -0,0 +1,94 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/serviceauth"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
+)
+
+const (
+	internalPathPrefix = "/internal/"
+	// introspectPath authenticates its callers itself (RFC 7662 client
+	// credentials) and is not subject to service caller authentication.
+	introspectPath = "/internal/v1/introspect"
+)
+
+// InternalAuth authenticates and authorizes service callers of the
+// /internal routes.
+type InternalAuth struct {
+	// Tokens verifies service tokens; nil accepts none.
+	Tokens *serviceauth.Verifier
+	// Allow maps an operation ID, or "*" for every internal operation, to
+	// the callers allowed to invoke it. Operations without an entry are
+	// denied.
+	Allow map[string][]string
+	// Open leaves the /internal routes unauthenticated (ENV=dev only).
+	Open bool
+}
+
+type callerKey struct{}
+
+// authenticateCaller rejects /internal requests without valid service
+// credentials and records the caller for authorizeCaller. A verified client
+// certificate takes precedence over a service token.
+func (a InternalAuth) authenticateCaller(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.Open || !strings.HasPrefix(r.URL.Path, internalPathPrefix) || r.URL.Path == introspectPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		caller := serviceauth.CallerFromTLS(r)
+		if caller == "" && a.Tokens != nil {
+			if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+				caller, _ = a.Tokens.Verify(token)
+			}
+		}
+		if caller == "" {
+			writeJSONError(w, http.StatusUnauthorized, "UNAUTHENTICATED_CALLER", "service credentials required")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), callerKey{}, caller)))
+	})
+}
+
+// authorizeCaller is a strict middleware checking the authenticated caller
+// against the allowlist of the operation.
+func (a InternalAuth) authorizeCaller(f server.StrictHandlerFunc, operationID string) server.StrictHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		if a.Open || !strings.HasPrefix(r.URL.Path, internalPathPrefix) || r.URL.Path == introspectPath {
+			return f(ctx, w, r, request)
+		}
+
+		if !a.allowed(operationID, callerFrom(ctx)) {
+			writeJSONError(w, http.StatusForbidden, "CALLER_NOT_ALLOWED", "caller is not allowed to call this operation")
+			return nil, nil
+		}
+		return f(ctx, w, r, request)
+	}
+}
+
+// callerFrom returns the authenticated internal caller, or "" when
+// /internal is open.
+func callerFrom(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+// allowed matches operation IDs case-insensitively so the allowlist can use
+// the operationId of the OpenAPI spec.
+func (a InternalAuth) allowed(operationID, caller string) bool {
+	if caller == "" {
+		return false
+	}
+	for op, callers := range a.Allow {
+		if (op == "*" || strings.EqualFold(op, operationID)) && slices.Contains(callers, caller) {
+			return true
+		}
+	}
+	return false
+}
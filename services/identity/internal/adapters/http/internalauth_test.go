@@ -0,0 +1,155 @@
+package http
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/serviceauth"
+)
+
+func TestInternalAuth(t *testing.T) {
+	const audience = "identity"
+	authPub, authKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gatewayPub, gatewayKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, unknownKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier := serviceauth.NewVerifier(audience, map[string]ed25519.PublicKey{
+		"auth":        authPub,
+		"api-gateway": gatewayPub,
+	}, time.Minute)
+	token := func(caller string, key ed25519.PrivateKey) string {
+		tok, err := serviceauth.NewSigner(caller, audience, key, time.Minute).Token()
+		if err != nil {
+			t.Fatalf("sign service token: %v", err)
+		}
+		return tok
+	}
+	cert := func(caller string) *tls.ConnectionState {
+		return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: caller}}}}}
+	}
+	allow := map[string][]string{
+		"ResolveIdentity": {"auth"},
+		"*":               {"backoffice-gateway"},
+	}
+
+	tests := []struct {
+		name       string
+		auth       InternalAuth
+		path       string
+		operation  string
+		token      string
+		tls        *tls.ConnectionState
+		wantStatus int
+		wantCaller string
+	}{
+		{
+			name: "allowed service token", auth: InternalAuth{Tokens: verifier, Allow: allow},
+			path: "/internal/v1/resolve", operation: "ResolveIdentity", token: token("auth", authKey),
+			wantStatus: http.StatusOK, wantCaller: "auth",
+		},
+		{
+			name: "operation ID matched case-insensitively", auth: InternalAuth{Tokens: verifier, Allow: allow},
+			path: "/internal/v1/resolve", operation: "resolveIdentity", token: token("auth", authKey),
+			wantStatus: http.StatusOK, wantCaller: "auth",
+		},
+		{
+			name: "caller not on the allowlist", auth: InternalAuth{Tokens: verifier, Allow: allow},
+			path: "/internal/v1/resolve", operation: "ResolveIdentity", token: token("api-gateway", gatewayKey),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "operation without an entry", auth: InternalAuth{Tokens: verifier, Allow: allow},
+			path: "/internal/v1/merges", operation: "MergeIdentities", token: token("auth", authKey),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "wildcard entry", auth: InternalAuth{Tokens: verifier, Allow: allow},
+			path: "/internal/v1/merges", operation: "MergeIdentities", tls: cert("backoffice-gateway"),
+			wantStatus: http.StatusOK, wantCaller: "backoffice-gateway",
+		},
+		{
+			name: "no credentials", auth: InternalAuth{Tokens: verifier, Allow: allow},
+			path: "/internal/v1/resolve", operation: "ResolveIdentity",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "token of an unknown key", auth: InternalAuth{Tokens: verifier, Allow: allow},
+			path: "/internal/v1/resolve", operation: "ResolveIdentity", token: token("auth", unknownKey),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "token without a verifier", auth: InternalAuth{Allow: allow},
+			path: "/internal/v1/resolve", operation: "ResolveIdentity", token: token("auth", authKey),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "client certificate", auth: InternalAuth{Allow: allow},
+			path: "/internal/v1/resolve", operation: "ResolveIdentity", tls: cert("auth"),
+			wantStatus: http.StatusOK, wantCaller: "auth",
+		},
+		{
+			name: "client certificate takes precedence over a token", auth: InternalAuth{Tokens: verifier, Allow: allow},
+			path: "/internal/v1/resolve", operation: "ResolveIdentity", tls: cert("api-gateway"), token: token("auth", authKey),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "open internal routes", auth: InternalAuth{Open: true},
+			path: "/internal/v1/resolve", operation: "ResolveIdentity",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "public route", auth: InternalAuth{Tokens: verifier, Allow: allow},
+			path: "/v1/token", operation: "ExchangeToken",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "introspection authenticates itself", auth: InternalAuth{Tokens: verifier, Allow: allow},
+			path: introspectPath, operation: "IntrospectToken",
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var caller string
+			strict := tt.auth.authorizeCaller(func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+				caller = callerFrom(ctx)
+				w.WriteHeader(http.StatusOK)
+				return nil, nil
+			}, tt.operation)
+			h := tt.auth.authenticateCaller(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = strict(r.Context(), w, r, nil)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			req.TLS = tt.tls
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if caller != tt.wantCaller {
+				t.Errorf("caller = %q, want %q", caller, tt.wantCaller)
+			}
+		})
+	}
+}
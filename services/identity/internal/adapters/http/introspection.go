@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/woffVienna/proteon-cursor/libs/platform/httpcommon"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+func (h *Handler) PostInternalV1Introspect(ctx context.Context, req server.PostInternalV1IntrospectRequestObject) (server.PostInternalV1IntrospectResponseObject, error) {
+	if req.Body == nil || req.Body.Token == "" {
+		return server.PostInternalV1Introspect400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "BAD_REQUEST", Message: "missing token"},
+			}),
+		}, nil
+	}
+
+	var clientID, clientSecret string
+	if r := httpcommon.HTTPRequestFromContext(ctx); r != nil {
+		clientID, clientSecret, _ = r.BasicAuth()
+	}
+
+	result, err := h.introspectionSvc.Introspect(ctx, clientID, clientSecret, req.Body.Token)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidClient) {
+			return server.PostInternalV1Introspect401JSONResponse{
+				UnauthorizedJSONResponse: server.UnauthorizedJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_CLIENT", Message: "client authentication failed"},
+				}),
+			}, nil
+		}
+		return server.PostInternalV1Introspect500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	return server.PostInternalV1Introspect200JSONResponse(toIntrospectionResponse(result)), nil
+}
+
+func toIntrospectionResponse(result domain.Introspection) server.IntrospectionResponse {
+	var status *server.IntrospectionResponseSessionStatus
+	if result.SessionStatus != "" {
+		s := server.IntrospectionResponseSessionStatus(result.SessionStatus)
+		status = &s
+	}
+	if !result.Active {
+		return server.IntrospectionResponse{Active: false, SessionStatus: status}
+	}
+
+	c := result.Claims
+	tokenType := "Bearer"
+	resp := server.IntrospectionResponse{
+		Active:        true,
+		TokenType:     &tokenType,
+		Sub:           optionalString(c.Subject),
+		Tenant:        optionalString(c.Tenant),
+		SubjectType:   optionalString(c.SubjectType),
+		Scope:         optionalString(strings.Join(c.Scopes, " ")),
+		Sid:           optionalString(c.SessionID),
+		Act:           toTokenActor(c.Actor),
+		SessionStatus: status,
+	}
+	if !c.ExpiresAt.IsZero() {
+		exp := c.ExpiresAt.Unix()
+		resp.Exp = &exp
+	}
+	if !c.IssuedAt.IsZero() {
+		iat := c.IssuedAt.Unix()
+		resp.Iat = &iat
+	}
+	return resp
+}
+
+func toTokenActor(a *domain.Actor) *server.TokenActor {
+	if a == nil {
+		return nil
+	}
+	return &server.TokenActor{Sub: a.Subject, Act: toTokenActor(a.Actor)}
+}
@@ -0,0 +1,193 @@
+package http
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+func (h *Handler) PostInternalV1UsersUserIdLinkages(ctx context.Context, req server.PostInternalV1UsersUserIdLinkagesRequestObject) (server.PostInternalV1UsersUserIdLinkagesResponseObject, error) {
+	if req.Body == nil {
+		return server.PostInternalV1UsersUserIdLinkages400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "BAD_REQUEST", Message: "missing request body"},
+			}),
+		}, nil
+	}
+
+	var tenant string
+	if req.Body.Tenant != nil {
+		tenant = *req.Body.Tenant
+	}
+
+	identity, err := h.authSvc.Link(ctx, req.UserId.String(), req.Body.Provider, req.Body.ExternalUserId, tenant)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidLinkage):
+			return server.PostInternalV1UsersUserIdLinkages400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_LINKAGE", Message: err.Error()},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrProviderNotFound):
+			return server.PostInternalV1UsersUserIdLinkages400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "UNKNOWN_PROVIDER", Message: "provider is not registered"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrTenantNotAllowed):
+			return server.PostInternalV1UsersUserIdLinkages400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "TENANT_NOT_ALLOWED", Message: "tenant is not allowed for this provider"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrIdentityNotFound):
+			return server.PostInternalV1UsersUserIdLinkages404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "NOT_FOUND", Message: "platform identity not found"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrLinkageConflict):
+			return server.PostInternalV1UsersUserIdLinkages409JSONResponse{
+				ConflictJSONResponse: server.ConflictJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "LINKAGE_CONFLICT", Message: "external identity is linked to another platform user"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrTenantMismatch):
+			return server.PostInternalV1UsersUserIdLinkages409JSONResponse{
+				ConflictJSONResponse: server.ConflictJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "TENANT_MISMATCH", Message: "platform identity belongs to another tenant"},
+				}),
+			}, nil
+		}
+		return server.PostInternalV1UsersUserIdLinkages500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp, err := toPlatformIdentity(*identity)
+	if err != nil {
+		return server.PostInternalV1UsersUserIdLinkages500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.PostInternalV1UsersUserIdLinkages200JSONResponse(resp), nil
+}
+
+func (h *Handler) DeleteInternalV1UsersUserIdLinkages(ctx context.Context, req server.DeleteInternalV1UsersUserIdLinkagesRequestObject) (server.DeleteInternalV1UsersUserIdLinkagesResponseObject, error) {
+	identity, err := h.authSvc.Unlink(ctx, req.UserId.String(), req.Params.Provider, req.Params.ExternalUserId)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidLinkage):
+			return server.DeleteInternalV1UsersUserIdLinkages400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_LINKAGE", Message: err.Error()},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrIdentityNotFound):
+			return server.DeleteInternalV1UsersUserIdLinkages404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "NOT_FOUND", Message: "platform identity not found"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrLinkageNotFound):
+			return server.DeleteInternalV1UsersUserIdLinkages404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "NOT_FOUND", Message: "linkage not found"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrLastLinkage):
+			return server.DeleteInternalV1UsersUserIdLinkages409JSONResponse{
+				ConflictJSONResponse: server.ConflictJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "LAST_LINKAGE", Message: "the last linkage of a platform identity cannot be removed"},
+				}),
+			}, nil
+		}
+		return server.DeleteInternalV1UsersUserIdLinkages500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp, err := toPlatformIdentity(*identity)
+	if err != nil {
+		return server.DeleteInternalV1UsersUserIdLinkages500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.DeleteInternalV1UsersUserIdLinkages200JSONResponse(resp), nil
+}
+
+func (h *Handler) GetInternalV1Linkages(ctx context.Context, req server.GetInternalV1LinkagesRequestObject) (server.GetInternalV1LinkagesResponseObject, error) {
+	var (
+		identity *domain.PlatformIdentity
+		err      error
+	)
+	if req.Params.Tenant != nil {
+		identity, err = h.authSvc.FindIdentityInTenant(ctx, req.Params.Provider, req.Params.ExternalUserId, *req.Params.Tenant)
+	} else {
+		identity, err = h.authSvc.FindIdentity(ctx, req.Params.Provider, req.Params.ExternalUserId)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrIdentityNotFound) {
+			return server.GetInternalV1Linkages404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "NOT_FOUND", Message: "external identity is not linked"},
+				}),
+			}, nil
+		}
+		return server.GetInternalV1Linkages500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp, err := toPlatformIdentity(*identity)
+	if err != nil {
+		return server.GetInternalV1Linkages500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.GetInternalV1Linkages200JSONResponse(resp), nil
+}
+
+func toPlatformIdentity(identity domain.PlatformIdentity) (server.PlatformIdentityResponse, error) {
+	platformUserUUID, err := uuid.Parse(identity.PlatformUserID)
+	if err != nil {
+		return server.PlatformIdentityResponse{}, err
+	}
+
+	primary := identity.Primary()
+	resp := server.PlatformIdentityResponse{
+		PlatformUserId: platformUserUUID,
+		Provider:       primary.Provider,
+		ExternalUserId: primary.ExternalUserID,
+		Tenant:         optionalString(identity.Tenant),
+		Linkages:       make([]server.Linkage, 0, len(identity.Linkages)),
+		Profile:        toIdentityProfile(identity.Profile),
+		Status:         toIdentityStatus(identity.Status),
+		CreatedAt:      identity.CreatedAt,
+	}
+	for _, l := range identity.Linkages {
+		resp.Linkages = append(resp.Linkages, server.Linkage{
+			Provider:       l.Provider,
+			ExternalUserId: l.ExternalUserID,
+			Tenant:         optionalString(l.Tenant),
+			LinkedAt:       l.LinkedAt,
+		})
+	}
+	return resp, nil
+}
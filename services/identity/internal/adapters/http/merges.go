@@ -0,0 +1,75 @@
+package http
+
+import (
+	"context"
+	"errors"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+func (h *Handler) PostInternalV1UsersUserIdMerges(ctx context.Context, req server.PostInternalV1UsersUserIdMergesRequestObject) (server.PostInternalV1UsersUserIdMergesResponseObject, error) {
+	if req.Body == nil {
+		return server.PostInternalV1UsersUserIdMerges400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "BAD_REQUEST", Message: "missing request body"},
+			}),
+		}, nil
+	}
+
+	var reason string
+	if req.Body.Reason != nil {
+		reason = *req.Body.Reason
+	}
+
+	identity, err := h.authSvc.Merge(ctx, req.UserId.String(), req.Body.MergedUserId.String(), callerFrom(ctx), reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrMergeSelf):
+			return server.PostInternalV1UsersUserIdMerges400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_MERGE", Message: "cannot merge a platform identity into itself"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrIdentityNotFound):
+			return server.PostInternalV1UsersUserIdMerges404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "NOT_FOUND", Message: "platform identity not found"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrIdentityMerged):
+			return server.PostInternalV1UsersUserIdMerges409JSONResponse{
+				ConflictJSONResponse: server.ConflictJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "IDENTITY_MERGED", Message: "platform identity was already merged into another"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrMergeTenantMismatch):
+			return server.PostInternalV1UsersUserIdMerges409JSONResponse{
+				ConflictJSONResponse: server.ConflictJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "TENANT_MISMATCH", Message: "platform identities belong to different tenants"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrMergeInactive):
+			return server.PostInternalV1UsersUserIdMerges409JSONResponse{
+				ConflictJSONResponse: server.ConflictJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "IDENTITY_INACTIVE", Message: "cannot merge a suspended or banned platform identity"},
+				}),
+			}, nil
+		}
+		return server.PostInternalV1UsersUserIdMerges500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp, err := toPlatformIdentity(*identity)
+	if err != nil {
+		return server.PostInternalV1UsersUserIdMerges500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.PostInternalV1UsersUserIdMerges200JSONResponse(resp), nil
+}
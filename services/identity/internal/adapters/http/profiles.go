@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/woffVienna/proteon-cursor/libs/platform/httpcommon"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+func (h *Handler) PatchV1UsersUserId(ctx context.Context, req server.PatchV1UsersUserIdRequestObject) (server.PatchV1UsersUserIdResponseObject, error) {
+	if req.Body == nil {
+		return server.PatchV1UsersUserId400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "BAD_REQUEST", Message: "missing request body"},
+			}),
+		}, nil
+	}
+	if r := httpcommon.HTTPRequestFromContext(ctx); r != nil {
+		if caller := r.Header.Get(headerPlatformUserID); caller != "" && caller != req.UserId.String() {
+			return server.PatchV1UsersUserId403JSONResponse{
+				ForbiddenJSONResponse: server.ForbiddenJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "FORBIDDEN", Message: "cannot update the profile of another user"},
+				}),
+			}, nil
+		}
+	}
+
+	body := req.Body
+	var clear []domain.ProfileField
+	if body.Clear != nil {
+		for _, f := range *body.Clear {
+			clear = append(clear, domain.ProfileField(f))
+		}
+	}
+
+	identity, err := h.identitiesSvc.UpdateProfile(ctx, req.UserId.String(),
+		profileValues(body.DisplayName, body.Locale, body.Country, body.BirthYear), clear)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidProfile):
+			return server.PatchV1UsersUserId400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_PROFILE", Message: err.Error()},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrIdentityNotFound):
+			return server.PatchV1UsersUserId404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "NOT_FOUND", Message: "platform identity not found"},
+				}),
+			}, nil
+		}
+		return server.PatchV1UsersUserId500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp, err := toPlatformIdentity(identity)
+	if err != nil {
+		return server.PatchV1UsersUserId500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.PatchV1UsersUserId200JSONResponse(resp), nil
+}
+
+// profileValues collects the profile attributes present in a request.
+func profileValues(displayName, locale, country *string, birthYear *int) map[domain.ProfileField]string {
+	values := make(map[domain.ProfileField]string)
+	if displayName != nil {
+		values[domain.ProfileDisplayName] = *displayName
+	}
+	if locale != nil {
+		values[domain.ProfileLocale] = *locale
+	}
+	if country != nil {
+		values[domain.ProfileCountry] = *country
+	}
+	if birthYear != nil {
+		values[domain.ProfileBirthYear] = strconv.Itoa(*birthYear)
+	}
+	return values
+}
+
+func toIdentityProfile(profile domain.Profile) *server.IdentityProfile {
+	if len(profile) == 0 {
+		return nil
+	}
+	attribute := func(field domain.ProfileField) *server.ProfileAttribute {
+		a, ok := profile[field]
+		if !ok {
+			return nil
+		}
+		return &server.ProfileAttribute{
+			Value:     a.Value,
+			Source:    server.ProfileAttributeSource(a.Source),
+			UpdatedAt: a.UpdatedAt,
+		}
+	}
+	return &server.IdentityProfile{
+		DisplayName: attribute(domain.ProfileDisplayName),
+		Locale:      attribute(domain.ProfileLocale),
+		Country:     attribute(domain.ProfileCountry),
+		BirthYear:   attribute(domain.ProfileBirthYear),
+	}
+}
@@ -0,0 +1,234 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+func (h *Handler) GetInternalV1Providers(ctx context.Context, _ server.GetInternalV1ProvidersRequestObject) (server.GetInternalV1ProvidersResponseObject, error) {
+	providers, err := h.providersSvc.List(ctx)
+	if err != nil {
+		return server.GetInternalV1Providers500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp := server.ProviderListResponse{Providers: make([]server.Provider, 0, len(providers))}
+	for _, p := range providers {
+		resp.Providers = append(resp.Providers, toProvider(p))
+	}
+	return server.GetInternalV1Providers200JSONResponse(resp), nil
+}
+
+func (h *Handler) PostInternalV1Providers(ctx context.Context, req server.PostInternalV1ProvidersRequestObject) (server.PostInternalV1ProvidersResponseObject, error) {
+	if req.Body == nil {
+		return server.PostInternalV1Providers400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "BAD_REQUEST", Message: "missing request body"},
+			}),
+		}, nil
+	}
+
+	provider := fromProviderRequest(req.Body.Id, server.ProviderUpdateRequest{
+		DisplayName:    req.Body.DisplayName,
+		Status:         req.Body.Status,
+		AllowedTenants: req.Body.AllowedTenants,
+		AssertionKeys:  req.Body.AssertionKeys,
+		TokenSettings:  req.Body.TokenSettings,
+	})
+	created, err := h.providersSvc.Create(ctx, provider)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidProvider):
+			return server.PostInternalV1Providers400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_PROVIDER", Message: err.Error()},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrProviderExists):
+			return server.PostInternalV1Providers409JSONResponse{
+				ConflictJSONResponse: server.ConflictJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "PROVIDER_EXISTS", Message: "provider already exists"},
+				}),
+			}, nil
+		}
+		return server.PostInternalV1Providers500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.PostInternalV1Providers201JSONResponse(toProvider(created)), nil
+}
+
+func (h *Handler) GetInternalV1ProvidersProviderId(ctx context.Context, req server.GetInternalV1ProvidersProviderIdRequestObject) (server.GetInternalV1ProvidersProviderIdResponseObject, error) {
+	provider, err := h.providersSvc.Get(ctx, req.ProviderId)
+	if err != nil {
+		if errors.Is(err, domain.ErrProviderNotFound) {
+			return server.GetInternalV1ProvidersProviderId404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "NOT_FOUND", Message: "provider not found"},
+				}),
+			}, nil
+		}
+		return server.GetInternalV1ProvidersProviderId500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.GetInternalV1ProvidersProviderId200JSONResponse(toProvider(provider)), nil
+}
+
+func (h *Handler) PutInternalV1ProvidersProviderId(ctx context.Context, req server.PutInternalV1ProvidersProviderIdRequestObject) (server.PutInternalV1ProvidersProviderIdResponseObject, error) {
+	if req.Body == nil {
+		return server.PutInternalV1ProvidersProviderId400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "BAD_REQUEST", Message: "missing request body"},
+			}),
+		}, nil
+	}
+
+	updated, err := h.providersSvc.Update(ctx, fromProviderRequest(req.ProviderId, *req.Body))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidProvider):
+			return server.PutInternalV1ProvidersProviderId400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_PROVIDER", Message: err.Error()},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrProviderNotFound):
+			return server.PutInternalV1ProvidersProviderId404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "NOT_FOUND", Message: "provider not found"},
+				}),
+			}, nil
+		}
+		return server.PutInternalV1ProvidersProviderId500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.PutInternalV1ProvidersProviderId200JSONResponse(toProvider(updated)), nil
+}
+
+func (h *Handler) DeleteInternalV1ProvidersProviderId(ctx context.Context, req server.DeleteInternalV1ProvidersProviderIdRequestObject) (server.DeleteInternalV1ProvidersProviderIdResponseObject, error) {
+	if err := h.providersSvc.Delete(ctx, req.ProviderId); err != nil {
+		if errors.Is(err, domain.ErrProviderNotFound) {
+			return server.DeleteInternalV1ProvidersProviderId404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "NOT_FOUND", Message: "provider not found"},
+				}),
+			}, nil
+		}
+		return server.DeleteInternalV1ProvidersProviderId500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.DeleteInternalV1ProvidersProviderId204Response{}, nil
+}
+
+// fromProviderRequest maps a create or update body to a provider. Omitted
+// fields are empty; an omitted status is left for the service to default.
+func fromProviderRequest(id string, body server.ProviderUpdateRequest) domain.Provider {
+	p := domain.Provider{ID: id}
+	if body.DisplayName != nil {
+		p.DisplayName = *body.DisplayName
+	}
+	if body.Status != nil {
+		p.Status = domain.ProviderStatus(*body.Status)
+	}
+	if body.AllowedTenants != nil {
+		p.AllowedTenants = *body.AllowedTenants
+	}
+	if body.AssertionKeys != nil {
+		for _, k := range *body.AssertionKeys {
+			jwk := domain.PublicJWK{Kty: string(k.Kty)}
+			if k.Alg != nil {
+				jwk.Alg = string(*k.Alg)
+			}
+			jwk.Kid = derefString(k.Kid)
+			jwk.Crv = derefString(k.Crv)
+			jwk.X = derefString(k.X)
+			jwk.Y = derefString(k.Y)
+			jwk.N = derefString(k.N)
+			jwk.E = derefString(k.E)
+			p.AssertionKeys = append(p.AssertionKeys, jwk)
+		}
+	}
+	if body.TokenSettings != nil {
+		if body.TokenSettings.AccessTokenTtl != nil {
+			p.Tokens.AccessTTL = time.Duration(*body.TokenSettings.AccessTokenTtl) * time.Second
+		}
+		if body.TokenSettings.RefreshTokenTtl != nil {
+			p.Tokens.RefreshTTL = time.Duration(*body.TokenSettings.RefreshTokenTtl) * time.Second
+		}
+		if body.TokenSettings.ProfileClaims != nil {
+			for _, f := range *body.TokenSettings.ProfileClaims {
+				p.Tokens.ProfileClaims = append(p.Tokens.ProfileClaims, domain.ProfileField(f))
+			}
+		}
+	}
+	return p
+}
+
+func toProvider(p domain.Provider) server.Provider {
+	out := server.Provider{
+		Id:             p.ID,
+		DisplayName:    p.DisplayName,
+		Status:         server.ProviderStatus(p.Status),
+		AllowedTenants: append([]string{}, p.AllowedTenants...),
+		AssertionKeys:  make([]server.AssertionKey, 0, len(p.AssertionKeys)),
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+	}
+	for _, k := range p.AssertionKeys {
+		key := server.AssertionKey{
+			Kty: server.AssertionKeyKty(k.Kty),
+			Kid: optionalString(k.Kid),
+			Crv: optionalString(k.Crv),
+			X:   optionalString(k.X),
+			Y:   optionalString(k.Y),
+			N:   optionalString(k.N),
+			E:   optionalString(k.E),
+		}
+		if k.Alg != "" {
+			alg := server.AssertionKeyAlg(k.Alg)
+			key.Alg = &alg
+		}
+		out.AssertionKeys = append(out.AssertionKeys, key)
+	}
+	if p.Tokens.AccessTTL > 0 {
+		ttl := int32(p.Tokens.AccessTTL.Seconds())
+		out.TokenSettings.AccessTokenTtl = &ttl
+	}
+	if p.Tokens.RefreshTTL > 0 {
+		ttl := int32(p.Tokens.RefreshTTL.Seconds())
+		out.TokenSettings.RefreshTokenTtl = &ttl
+	}
+	if len(p.Tokens.ProfileClaims) > 0 {
+		claims := make([]server.ProfileField, 0, len(p.Tokens.ProfileClaims))
+		for _, f := range p.Tokens.ProfileClaims {
+			claims = append(claims, server.ProfileField(f))
+		}
+		out.TokenSettings.ProfileClaims = &claims
+	}
+	return out
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -1,14 +1,13 @@
 package http
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/woffVienna/proteon-cursor/libs/platform/httpcommon"
 	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
-	authapp "github.com/woffVienna/proteon-cursor/services/identity/internal/application/auth"
-	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -20,6 +19,30 @@ type Config struct {
 	OpenAPIBundlePath string
 	ServiceName       string
 	Version           string
+	// JWKSMaxAge is advertised as Cache-Control max-age on the JWKS.
+	JWKSMaxAge time.Duration
+	// Discovery is published at /.well-known/openid-configuration.
+	Discovery Discovery
+	// Internal authenticates and authorizes callers of the /internal routes.
+	Internal InternalAuth
+	// InternalTLSPort and InternalTLS enable a second, TLS listener serving
+	// the same routes, on which callers may authenticate with client
+	// certificates.
+	InternalTLSPort string
+	InternalTLS     *tls.Config
+}
+
+// Discovery is the issuer configuration the OpenID Connect discovery
+// document is built from.
+type Discovery struct {
+	// Issuer is the iss claim of the issued tokens.
+	Issuer string
+	// BaseURL is the public URL the endpoints are advertised under.
+	BaseURL string
+	// AuthorizationEndpoint and TokenEndpoint are the public URLs of the
+	// backoffice authorization code flow. Empty omits them.
+	AuthorizationEndpoint string
+	TokenEndpoint         string
 }
 
 // Server is the HTTP adapter.
@@ -29,10 +52,10 @@ type Server struct {
 }
 
 // NewServer creates an HTTP server with the given dependencies.
-func NewServer(cfg Config, authSvc *authapp.Service, issuer interfaces.TokenIssuer) *Server {
+func NewServer(cfg Config, svcs Services) *Server {
 	return &Server{
 		cfg:     cfg,
-		handler: NewHandler(authSvc, issuer, cfg.ServiceName, cfg.Version),
+		handler: NewHandler(svcs, cfg.JWKSMaxAge, cfg.Discovery, cfg.ServiceName, cfg.Version),
 	}
 }
 
@@ -46,6 +69,7 @@ func (s *Server) Router() http.Handler {
 	r.Use(middleware.Timeout(30 * time.Second))
 	r.Use(middleware.Logger)
 	r.Use(httpcommon.WithHTTPRequest)
+	r.Use(s.cfg.Internal.authenticateCaller)
 
 	httpcommon.MountDocsAndHealth(r, httpcommon.DocsOptions{
 		Title:        "Proteon Identity Service - Swagger UI",
@@ -61,18 +85,40 @@ func (s *Server) Router() http.Handler {
 		},
 	}
 
-	handler := server.NewStrictHandlerWithOptions(s.handler, nil, strictOpts)
+	middlewares := []server.StrictMiddlewareFunc{s.cfg.Internal.authorizeCaller}
+	handler := server.NewStrictHandlerWithOptions(s.handler, middlewares, strictOpts)
 	r.Mount("/", server.HandlerFromMux(handler, chi.NewRouter()))
 
 	return r
 }
 
-// ListenAndServe starts the HTTP server.
+// ListenAndServe starts the HTTP server and, when configured, the internal
+// TLS listener. It returns when either listener fails.
 func (s *Server) ListenAndServe() error {
-	return http.ListenAndServe(":"+s.cfg.Port, s.Router())
+	router := s.Router()
+	if s.cfg.InternalTLSPort == "" {
+		return http.ListenAndServe(":"+s.cfg.Port, router)
+	}
+
+	errs := make(chan error, 2)
+	go func() {
+		errs <- http.ListenAndServe(":"+s.cfg.Port, router)
+	}()
+	go func() {
+		tlsSrv := &http.Server{
+			Addr:      ":" + s.cfg.InternalTLSPort,
+			Handler:   router,
+			TLSConfig: s.cfg.InternalTLS,
+		}
+		errs <- tlsSrv.ListenAndServeTLS("", "")
+	}()
+	return <-errs
 }
 
 // String implements fmt.Stringer.
 func (s *Server) String() string {
+	if s.cfg.InternalTLSPort != "" {
+		return fmt.Sprintf("identity-service(port=%s, internal-tls-port=%s)", s.cfg.Port, s.cfg.InternalTLSPort)
+	}
 	return fmt.Sprintf("identity-service(port=%s)", s.cfg.Port)
 }
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+func (h *Handler) GetInternalV1UsersUserIdSessions(ctx context.Context, req server.GetInternalV1UsersUserIdSessionsRequestObject) (server.GetInternalV1UsersUserIdSessionsResponseObject, error) {
+	sessions, err := h.sessionsSvc.List(ctx, req.UserId.String())
+	if err != nil {
+		return server.GetInternalV1UsersUserIdSessions500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp, err := toSessionList(sessions)
+	if err != nil {
+		return server.GetInternalV1UsersUserIdSessions500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.GetInternalV1UsersUserIdSessions200JSONResponse(resp), nil
+}
+
+func (h *Handler) DeleteInternalV1UsersUserIdSessions(ctx context.Context, req server.DeleteInternalV1UsersUserIdSessionsRequestObject) (server.DeleteInternalV1UsersUserIdSessionsResponseObject, error) {
+	var reason string
+	if req.Params.Reason != nil {
+		reason = *req.Params.Reason
+	}
+
+	revoked, err := h.sessionsSvc.RevokeAll(ctx, req.UserId.String(), reason)
+	if err != nil {
+		return server.DeleteInternalV1UsersUserIdSessions500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp, err := toSessionList(revoked)
+	if err != nil {
+		return server.DeleteInternalV1UsersUserIdSessions500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.DeleteInternalV1UsersUserIdSessions200JSONResponse(resp), nil
+}
+
+func (h *Handler) DeleteInternalV1UsersUserIdSessionsSessionId(ctx context.Context, req server.DeleteInternalV1UsersUserIdSessionsSessionIdRequestObject) (server.DeleteInternalV1UsersUserIdSessionsSessionIdResponseObject, error) {
+	var reason string
+	if req.Params.Reason != nil {
+		reason = *req.Params.Reason
+	}
+
+	session, err := h.sessionsSvc.Revoke(ctx, req.UserId.String(), req.SessionId, reason)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return server.DeleteInternalV1UsersUserIdSessionsSessionId404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "NOT_FOUND", Message: "session not found"},
+				}),
+			}, nil
+		}
+		return server.DeleteInternalV1UsersUserIdSessionsSessionId500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp, err := toSession(session)
+	if err != nil {
+		return server.DeleteInternalV1UsersUserIdSessionsSessionId500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.DeleteInternalV1UsersUserIdSessionsSessionId200JSONResponse(resp), nil
+}
+
+func (h *Handler) GetInternalV1Revocations(ctx context.Context, req server.GetInternalV1RevocationsRequestObject) (server.GetInternalV1RevocationsResponseObject, error) {
+	var since time.Time
+	if req.Params.Since != nil {
+		since = *req.Params.Since
+	}
+
+	revocations, asOf, err := h.sessionsSvc.Revocations(ctx, since)
+	if err != nil {
+		return server.GetInternalV1Revocations500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp := server.RevocationListResponse{
+		Revocations: make([]server.Revocation, 0, len(revocations)),
+		AsOf:        asOf,
+	}
+	for _, r := range revocations {
+		platformUserUUID, err := uuid.Parse(r.PlatformUserID)
+		if err != nil {
+			return server.GetInternalV1Revocations500JSONResponse{
+				InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+				}),
+			}, nil
+		}
+		resp.Revocations = append(resp.Revocations, server.Revocation{
+			Sid:            r.SessionID,
+			PlatformUserId: platformUserUUID,
+			RevokedAt:      r.RevokedAt,
+			ExpiresAt:      r.ExpiresAt,
+		})
+	}
+	return server.GetInternalV1Revocations200JSONResponse(resp), nil
+}
+
+func toSessionList(sessions []domain.Session) (server.SessionListResponse, error) {
+	out := server.SessionListResponse{Sessions: make([]server.Session, 0, len(sessions))}
+	for _, s := range sessions {
+		session, err := toSession(s)
+		if err != nil {
+			return server.SessionListResponse{}, err
+		}
+		out.Sessions = append(out.Sessions, session)
+	}
+	return out, nil
+}
+
+func toSession(s domain.Session) (server.Session, error) {
+	platformUserUUID, err := uuid.Parse(s.PlatformUserID)
+	if err != nil {
+		return server.Session{}, err
+	}
+	return server.Session{
+		SessionId:      s.ID,
+		PlatformUserId: platformUserUUID,
+		SubjectType:    s.SubjectType,
+		Tenant:         optionalString(s.Tenant),
+		Provider:       optionalString(s.Provider),
+		Scope:          optionalString(domain.FormatScope(s.Scopes)),
+		CreatedAt:      s.CreatedAt,
+		ExpiresAt:      s.ExpiresAt,
+		RevokedAt:      optionalTime(s.RevokedAt),
+		RevokeReason:   optionalString(s.RevokeReason),
+	}, nil
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
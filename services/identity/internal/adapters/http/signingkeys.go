@@ -0,0 +1,79 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+func (h *Handler) GetInternalV1SigningKeys(ctx context.Context, _ server.GetInternalV1SigningKeysRequestObject) (server.GetInternalV1SigningKeysResponseObject, error) {
+	keys, err := h.keysSvc.List(ctx)
+	if err != nil {
+		return server.GetInternalV1SigningKeys500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.GetInternalV1SigningKeys200JSONResponse(toSigningKeyList(keys)), nil
+}
+
+func (h *Handler) PostInternalV1SigningKeysRotate(ctx context.Context, _ server.PostInternalV1SigningKeysRotateRequestObject) (server.PostInternalV1SigningKeysRotateResponseObject, error) {
+	if _, err := h.keysSvc.Rotate(ctx); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRotationTooSoon):
+			return server.PostInternalV1SigningKeysRotate409JSONResponse{
+				ConflictJSONResponse: server.ConflictJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "ROTATION_TOO_SOON", Message: "next signing key has not been published long enough"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrKeyRingChanged):
+			return server.PostInternalV1SigningKeysRotate409JSONResponse{
+				ConflictJSONResponse: server.ConflictJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "KEY_RING_CHANGED", Message: "signing key ring was changed concurrently, retry"},
+				}),
+			}, nil
+		}
+		return server.PostInternalV1SigningKeysRotate500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	keys, err := h.keysSvc.List(ctx)
+	if err != nil {
+		return server.PostInternalV1SigningKeysRotate500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.PostInternalV1SigningKeysRotate200JSONResponse(toSigningKeyList(keys)), nil
+}
+
+func toSigningKeyList(keys []domain.SigningKeyInfo) server.SigningKeyListResponse {
+	out := server.SigningKeyListResponse{Keys: make([]server.SigningKey, 0, len(keys))}
+	for _, k := range keys {
+		out.Keys = append(out.Keys, server.SigningKey{
+			Kid:         k.Kid,
+			Alg:         k.Algorithm,
+			State:       server.SigningKeyState(k.State),
+			CreatedAt:   k.CreatedAt,
+			ActivatedAt: optionalTime(k.ActivatedAt),
+			RetiringAt:  optionalTime(k.RetiringAt),
+			RetiredAt:   optionalTime(k.RetiredAt),
+		})
+	}
+	return out
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
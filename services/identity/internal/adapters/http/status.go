@@ -0,0 +1,98 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+func (h *Handler) PutInternalV1UsersUserIdStatus(ctx context.Context, req server.PutInternalV1UsersUserIdStatusRequestObject) (server.PutInternalV1UsersUserIdStatusResponseObject, error) {
+	if req.Body == nil {
+		return server.PutInternalV1UsersUserIdStatus400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "BAD_REQUEST", Message: "missing request body"},
+			}),
+		}, nil
+	}
+
+	body := req.Body
+	status := domain.IdentityStatus{State: domain.IdentityState(body.State)}
+	if body.Reason != nil {
+		status.Reason = domain.StatusReason(*body.Reason)
+	}
+	if body.Until != nil {
+		status.Until = *body.Until
+	}
+	var note string
+	if body.Note != nil {
+		note = *body.Note
+	}
+
+	identity, err := h.authSvc.SetStatus(ctx, req.UserId.String(), status, callerFrom(ctx), note)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidStatus):
+			return server.PutInternalV1UsersUserIdStatus400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_STATUS", Message: err.Error()},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrIdentityNotFound):
+			return server.PutInternalV1UsersUserIdStatus404JSONResponse{
+				NotFoundJSONResponse: server.NotFoundJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "NOT_FOUND", Message: "platform identity not found"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrIdentityMerged):
+			return server.PutInternalV1UsersUserIdStatus409JSONResponse{
+				ConflictJSONResponse: server.ConflictJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "IDENTITY_MERGED", Message: "platform identity was merged into another; change the survivor"},
+				}),
+			}, nil
+		}
+		return server.PutInternalV1UsersUserIdStatus500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp, err := toPlatformIdentity(*identity)
+	if err != nil {
+		return server.PutInternalV1UsersUserIdStatus500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+	return server.PutInternalV1UsersUserIdStatus200JSONResponse(resp), nil
+}
+
+// inactiveError is the error body for tokens refused with an error wrapping
+// domain.ErrIdentityInactive.
+func inactiveError(err error) server.ErrorResponse {
+	if errors.Is(err, domain.ErrIdentityBanned) {
+		return server.ErrorResponse{Error: server.ErrorBody{Code: "IDENTITY_BANNED", Message: "platform identity is banned"}}
+	}
+	return server.ErrorResponse{Error: server.ErrorBody{Code: "IDENTITY_SUSPENDED", Message: "platform identity is suspended"}}
+}
+
+// toIdentityStatus returns the status in effect now.
+func toIdentityStatus(status domain.IdentityStatus) server.IdentityStatus {
+	status = status.At(time.Now())
+	out := server.IdentityStatus{State: server.IdentityState(status.State)}
+	if status.Reason != "" {
+		reason := server.StatusReason(status.Reason)
+		out.Reason = &reason
+	}
+	if !status.Until.IsZero() {
+		out.Until = &status.Until
+	}
+	if !status.ChangedAt.IsZero() {
+		out.ChangedAt = &status.ChangedAt
+	}
+	return out
+}
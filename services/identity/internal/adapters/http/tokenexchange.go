@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+	"errors"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+const (
+	// grantTypeTokenExchange is the grant_type of RFC 8693 requests.
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	// tokenExchangePath is advertised in the discovery document.
+	tokenExchangePath = "/internal/v1/token-exchange"
+)
+
+func (h *Handler) PostInternalV1TokenExchange(ctx context.Context, req server.PostInternalV1TokenExchangeRequestObject) (server.PostInternalV1TokenExchangeResponseObject, error) {
+	if req.Body == nil {
+		return server.PostInternalV1TokenExchange400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "BAD_REQUEST", Message: "missing request body"},
+			}),
+		}, nil
+	}
+	body := req.Body
+	if body.GrantType != grantTypeTokenExchange {
+		return server.PostInternalV1TokenExchange400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "UNSUPPORTED_GRANT_TYPE", Message: "grant_type must be " + grantTypeTokenExchange},
+			}),
+		}, nil
+	}
+	if body.SubjectTokenType != domain.TokenTypeAccessToken && body.SubjectTokenType != domain.TokenTypeJWT {
+		return server.PostInternalV1TokenExchange400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "UNSUPPORTED_TOKEN_TYPE", Message: "subject_token_type must be an access token or JWT"},
+			}),
+		}, nil
+	}
+	if body.RequestedTokenType != nil && *body.RequestedTokenType != domain.TokenTypeAccessToken {
+		return server.PostInternalV1TokenExchange400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "UNSUPPORTED_TOKEN_TYPE", Message: "only access tokens can be requested"},
+			}),
+		}, nil
+	}
+	if body.Audience == "" {
+		return server.PostInternalV1TokenExchange400JSONResponse{
+			BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "BAD_REQUEST", Message: "missing audience"},
+			}),
+		}, nil
+	}
+
+	// The caller becomes the actor of the new token, so the exchange needs
+	// an authenticated caller; open /internal routes have none.
+	actor := callerFrom(ctx)
+	if actor == "" {
+		return server.PostInternalV1TokenExchange401JSONResponse{
+			UnauthorizedJSONResponse: server.UnauthorizedJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "UNAUTHENTICATED_CALLER", Message: "service credentials required"},
+			}),
+		}, nil
+	}
+
+	result, err := h.authSvc.ExchangeToken(ctx, domain.TokenExchange{
+		SubjectToken: body.SubjectToken,
+		Actor:        actor,
+		Audience:     body.Audience,
+		Scopes:       domain.ParseScope(derefString(body.Scope)),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidSubjectToken):
+			return server.PostInternalV1TokenExchange401JSONResponse{
+				UnauthorizedJSONResponse: server.UnauthorizedJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_SUBJECT_TOKEN", Message: "subject token is not active"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrDelegationNotAllowed):
+			return server.PostInternalV1TokenExchange403JSONResponse{
+				ForbiddenJSONResponse: server.ForbiddenJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "DELEGATION_NOT_ALLOWED", Message: "caller may not act for this token at the audience"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrIdentityInactive):
+			return server.PostInternalV1TokenExchange403JSONResponse{
+				ForbiddenJSONResponse: server.ForbiddenJSONResponse(inactiveError(err)),
+			}, nil
+		case errors.Is(err, domain.ErrInvalidScope):
+			return server.PostInternalV1TokenExchange400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_SCOPE", Message: "requested scope is not allowed"},
+				}),
+			}, nil
+		}
+		return server.PostInternalV1TokenExchange500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	return server.PostInternalV1TokenExchange200JSONResponse(server.TokenExchangeResponse{
+		AccessToken:     result.AccessToken,
+		IssuedTokenType: domain.TokenTypeAccessToken,
+		TokenType:       server.TokenExchangeResponseTokenTypeBearer,
+		ExpiresIn:       result.ExpiresIn,
+		Scope:           optionalString(domain.FormatScope(result.Scopes)),
+	}), nil
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/http/generated/server"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+func (h *Handler) GetInternalV1Users(ctx context.Context, req server.GetInternalV1UsersRequestObject) (server.GetInternalV1UsersResponseObject, error) {
+	params := req.Params
+	var filter domain.IdentityFilter
+	if params.Provider != nil {
+		filter.Provider = *params.Provider
+	}
+	if params.Tenant != nil {
+		filter.Tenant = *params.Tenant
+	}
+	if params.ExternalUserIdPrefix != nil {
+		filter.ExternalUserIDPrefix = *params.ExternalUserIdPrefix
+	}
+	if params.CreatedFrom != nil {
+		filter.CreatedFrom = *params.CreatedFrom
+	}
+	if params.CreatedTo != nil {
+		filter.CreatedTo = *params.CreatedTo
+	}
+
+	var cursor string
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	var limit int
+	if params.Limit != nil {
+		limit = *params.Limit
+		if limit < 1 || limit > domain.MaxIdentityPageSize {
+			return server.GetInternalV1Users400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "BAD_REQUEST", Message: fmt.Sprintf("limit must be between 1 and %d", domain.MaxIdentityPageSize)},
+				}),
+			}, nil
+		}
+	}
+
+	page, err := h.identitiesSvc.List(ctx, filter, cursor, limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCursor):
+			return server.GetInternalV1Users400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_CURSOR", Message: "invalid page cursor"},
+				}),
+			}, nil
+		case errors.Is(err, domain.ErrInvalidFilter):
+			return server.GetInternalV1Users400JSONResponse{
+				BadRequestJSONResponse: server.BadRequestJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INVALID_FILTER", Message: err.Error()},
+				}),
+			}, nil
+		}
+		return server.GetInternalV1Users500JSONResponse{
+			InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+				Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+			}),
+		}, nil
+	}
+
+	resp := server.UserListResponse{Users: make([]server.PlatformIdentityResponse, 0, len(page.Identities))}
+	for _, identity := range page.Identities {
+		user, err := toPlatformIdentity(identity)
+		if err != nil {
+			return server.GetInternalV1Users500JSONResponse{
+				InternalErrorJSONResponse: server.InternalErrorJSONResponse(server.ErrorResponse{
+					Error: server.ErrorBody{Code: "INTERNAL_ERROR", Message: "internal error"},
+				}),
+			}, nil
+		}
+		resp.Users = append(resp.Users, user)
+	}
+	if page.NextCursor != "" {
+		next := page.NextCursor
+		resp.NextCursor = &next
+	}
+	return server.GetInternalV1Users200JSONResponse(resp), nil
+}
@@ -0,0 +1,71 @@
+// Package nats publishes identity's events to NATS JetStream.
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// streamSubjects are the subjects of identity's events; each event is
+// published on the subject named by its type.
+var streamSubjects = []string{"identity.>", "token.>"}
+
+// Publisher implements interfaces.EventPublisher on JetStream. Messages
+// carry their event ID as Nats-Msg-Id, so the stream drops a message
+// published again within its duplicate window.
+type Publisher struct {
+	conn *nats.Conn
+	js   jetstream.JetStream
+}
+
+// NewPublisher connects to url and creates stream, bound to identity's
+// subjects, if it does not exist. An existing stream is left as it is.
+func NewPublisher(ctx context.Context, url, stream string) (*Publisher, error) {
+	conn, err := nats.Connect(url, nats.Name("identity-service"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("connect to nats %s: %w", url, err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("open jetstream: %w", err)
+	}
+	if _, err := js.Stream(ctx, stream); err != nil {
+		if !errors.Is(err, jetstream.ErrStreamNotFound) {
+			conn.Close()
+			return nil, fmt.Errorf("look up stream %s: %w", stream, err)
+		}
+		if _, err := js.CreateStream(ctx, jetstream.StreamConfig{
+			Name:       stream,
+			Subjects:   streamSubjects,
+			Storage:    jetstream.FileStorage,
+			Duplicates: 10 * time.Minute,
+		}); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("create stream %s: %w", stream, err)
+		}
+	}
+	return &Publisher{conn: conn, js: js}, nil
+}
+
+// Publish implements interfaces.EventPublisher. It returns once the stream
+// has acknowledged the message.
+func (p *Publisher) Publish(ctx context.Context, msg domain.OutboxMessage) error {
+	_, err := p.js.PublishMsg(ctx, &nats.Msg{
+		Subject: string(msg.Type),
+		Data:    msg.Data,
+	}, jetstream.WithMsgID(msg.ID))
+	return err
+}
+
+// Close drains the connection.
+func (p *Publisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// AuditLog is a Postgres implementation of interfaces.AuditLog.
+type AuditLog struct {
+	pool *pgxpool.Pool
+}
+
+// NewAuditLog creates an audit log on a migrated database.
+func NewAuditLog(pool *pgxpool.Pool) *AuditLog {
+	return &AuditLog{pool: pool}
+}
+
+// Append implements interfaces.AuditLog.
+func (l *AuditLog) Append(ctx context.Context, entry domain.AuditEntry) error {
+	details := entry.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	if _, err := l.pool.Exec(ctx, `
+		INSERT INTO identity_audit_log (id, platform_user_id, action, actor, details, recorded_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		entry.ID, entry.PlatformUserID, string(entry.Action), entry.Actor, details, entry.At.UTC()); err != nil {
+		return fmt.Errorf("insert audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListByUser implements interfaces.AuditLog.
+func (l *AuditLog) ListByUser(ctx context.Context, platformUserID string) ([]domain.AuditEntry, error) {
+	if _, err := uuid.Parse(platformUserID); err != nil {
+		// Not a platform user ID, so there are no entries.
+		return nil, nil
+	}
+	rows, err := l.pool.Query(ctx, `
+		SELECT id, platform_user_id::text, action, actor, details, recorded_at
+		FROM identity_audit_log
+		WHERE platform_user_id = $1
+		ORDER BY recorded_at, id`,
+		platformUserID)
+	if err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []domain.AuditEntry
+	for rows.Next() {
+		var (
+			entry  domain.AuditEntry
+			action string
+		)
+		if err := rows.Scan(&entry.ID, &entry.PlatformUserID, &action, &entry.Actor, &entry.Details, &entry.At); err != nil {
+			return nil, fmt.Errorf("read audit log: %w", err)
+		}
+		entry.Action = domain.AuditAction(action)
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// ReplaceDetails implements interfaces.AuditLog.
+func (l *AuditLog) ReplaceDetails(ctx context.Context, platformUserID string, details map[string]map[string]string) error {
+	if _, err := uuid.Parse(platformUserID); err != nil {
+		// Not a platform user ID, so there are no entries.
+		return nil
+	}
+	return pgx.BeginTxFunc(ctx, l.pool, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		for id, d := range details {
+			if d == nil {
+				d = map[string]string{}
+			}
+			if _, err := tx.Exec(ctx, `
+				UPDATE identity_audit_log SET details = $3
+				WHERE platform_user_id = $1 AND id = $2`,
+				platformUserID, id, d); err != nil {
+				return fmt.Errorf("update audit entry: %w", err)
+			}
+		}
+		return nil
+	})
+}
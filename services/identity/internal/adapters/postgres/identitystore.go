@@ -0,0 +1,716 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// selectIdentity reads an identity with its linkages, oldest first, in one
+// statement so the result is a consistent snapshot. Callers append the
+// WHERE clause on i.platform_user_id.
+const selectIdentity = `
+	SELECT i.platform_user_id, i.tenant, i.created_at, i.profile,
+	       i.status, i.status_reason, i.status_until, i.status_changed_at,
+	       l.provider, l.external_user_id, l.tenant, l.linked_at
+	FROM platform_identities i
+	LEFT JOIN identity_linkages l USING (platform_user_id)
+	WHERE i.platform_user_id = `
+
+const orderLinkages = ` ORDER BY l.linked_at, l.provider, l.external_user_id`
+
+// querier is satisfied by both the pool and a transaction.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// IdentityStore is a Postgres implementation of IdentityResolver,
+// IdentityLinker, IdentityMerger, IdentityEraser, IdentityProfiles,
+// IdentityStatuses, IdentityLookup, IdentityQuery and Outbox, shared by all
+// identity replicas. Events are written in the transaction of their change.
+type IdentityStore struct {
+	pool  *pgxpool.Pool
+	idGen func() string
+}
+
+// NewIdentityStore creates a store on a migrated database.
+// idGen provides platform user IDs and event IDs and must return UUIDs.
+func NewIdentityStore(pool *pgxpool.Pool, idGen func() string) *IdentityStore {
+	return &IdentityStore{pool: pool, idGen: idGen}
+}
+
+// Resolve implements interfaces.IdentityResolver.
+// Returns an existing platform identity or creates a new one. Concurrent
+// calls for the same external identity, on any replica, return the same
+// platform user ID: the linkage insert yields to the identity_linkages
+// primary key, the new identity is rolled back and the winner's is read
+// instead.
+func (s *IdentityStore) Resolve(ctx context.Context, provider, externalUserID, tenant string) (domain.PlatformIdentity, error) {
+	var identity domain.PlatformIdentity
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		id, now := s.idGen(), time.Now().UTC()
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO platform_identities (platform_user_id, tenant, created_at)
+			VALUES ($1, $2, $3)`,
+			id, tenant, now); err != nil {
+			return fmt.Errorf("insert identity: %w", err)
+		}
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO identity_linkages (provider, external_user_id, platform_user_id, tenant, linked_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (provider, external_user_id) DO NOTHING`,
+			provider, externalUserID, id, tenant, now)
+		if err != nil {
+			return fmt.Errorf("insert linkage: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			// Already linked: roll back the new identity.
+			return errAlreadyLinked
+		}
+		identity, err = getIdentity(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, domain.NewIdentityCreatedEvent(s.idGen(), identity))
+	})
+	if err == nil {
+		return identity, nil
+	}
+	if !errors.Is(err, errAlreadyLinked) {
+		return domain.PlatformIdentity{}, err
+	}
+
+	identity, err = queryIdentity(ctx, s.pool, selectIdentity+`(
+		SELECT platform_user_id FROM identity_linkages
+		WHERE provider = $1 AND external_user_id = $2)`+orderLinkages,
+		provider, externalUserID)
+	if err != nil {
+		return domain.PlatformIdentity{}, fmt.Errorf("read identity: %w", err)
+	}
+	return identity, nil
+}
+
+// errAlreadyLinked aborts the Resolve transaction when another identity
+// holds the linkage.
+var errAlreadyLinked = errors.New("external identity already linked")
+
+// Link implements interfaces.IdentityLinker.
+func (s *IdentityStore) Link(ctx context.Context, platformUserID string, linkage domain.Linkage) (domain.PlatformIdentity, error) {
+	if err := linkage.Validate(); err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	id, err := uuid.Parse(platformUserID)
+	if err != nil {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	platformUserID = id.String()
+
+	var identity domain.PlatformIdentity
+	err = pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if err := lockIdentity(ctx, tx, platformUserID); err != nil {
+			return err
+		}
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO identity_linkages (provider, external_user_id, platform_user_id, tenant, linked_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (provider, external_user_id) DO NOTHING`,
+			linkage.Provider, linkage.ExternalUserID, platformUserID, linkage.Tenant, linkage.LinkedAt.UTC())
+		if err != nil {
+			return fmt.Errorf("insert linkage: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			var owner string
+			err := tx.QueryRow(ctx, `
+				SELECT platform_user_id::text FROM identity_linkages
+				WHERE provider = $1 AND external_user_id = $2`,
+				linkage.Provider, linkage.ExternalUserID).Scan(&owner)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("read linkage: %w", err)
+			}
+			// A linkage removed since the insert was someone else's.
+			if owner != platformUserID {
+				return domain.ErrLinkageConflict
+			}
+		}
+		identity, err = getIdentity(ctx, tx, platformUserID)
+		if err != nil || tag.RowsAffected() == 0 {
+			return err
+		}
+		linkage.LinkedAt = linkage.LinkedAt.UTC()
+		return insertOutbox(ctx, tx, domain.NewIdentityLinkedEvent(s.idGen(), identity, linkage))
+	})
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	return identity, nil
+}
+
+// Unlink implements interfaces.IdentityLinker.
+func (s *IdentityStore) Unlink(ctx context.Context, platformUserID, provider, externalUserID string) (domain.PlatformIdentity, error) {
+	id, err := uuid.Parse(platformUserID)
+	if err != nil {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	platformUserID = id.String()
+
+	var identity domain.PlatformIdentity
+	err = pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		// The row lock keeps concurrent unlinks from removing the last two
+		// linkages at once; the last one is put back by the rollback.
+		if err := lockIdentity(ctx, tx, platformUserID); err != nil {
+			return err
+		}
+		tag, err := tx.Exec(ctx, `
+			DELETE FROM identity_linkages
+			WHERE platform_user_id = $1 AND provider = $2 AND external_user_id = $3`,
+			platformUserID, provider, externalUserID)
+		if err != nil {
+			return fmt.Errorf("delete linkage: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return domain.ErrLinkageNotFound
+		}
+		identity, err = getIdentity(ctx, tx, platformUserID)
+		if err != nil {
+			return err
+		}
+		if len(identity.Linkages) == 0 {
+			return domain.ErrLastLinkage
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	return identity, nil
+}
+
+// Merge implements interfaces.IdentityMerger.
+func (s *IdentityStore) Merge(ctx context.Context, survivorID, mergedID string, at time.Time) (domain.PlatformIdentity, error) {
+	survivor, err := uuid.Parse(survivorID)
+	if err != nil {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	merged, err := uuid.Parse(mergedID)
+	if err != nil {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	if survivor == merged {
+		return domain.PlatformIdentity{}, domain.ErrMergeSelf
+	}
+	survivorID, mergedID = survivor.String(), merged.String()
+
+	var identity domain.PlatformIdentity
+	err = pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		// Lock in ID order so merges of the same pair in both directions
+		// cannot deadlock. A merged identity no longer has a row.
+		first, second := survivorID, mergedID
+		if second < first {
+			first, second = second, first
+		}
+		for _, id := range []string{first, second} {
+			if err := lockIdentity(ctx, tx, id); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.Exec(ctx, `
+			UPDATE identity_linkages SET platform_user_id = $1, linked_at = $3
+			WHERE platform_user_id = $2`,
+			survivorID, mergedID, at.UTC()); err != nil {
+			return fmt.Errorf("move linkages: %w", err)
+		}
+		// jsonb || keeps the right-hand value of a field both profiles have.
+		if _, err := tx.Exec(ctx, `
+			UPDATE platform_identities s SET profile = m.profile || s.profile
+			FROM platform_identities m
+			WHERE s.platform_user_id = $1 AND m.platform_user_id = $2`,
+			survivorID, mergedID); err != nil {
+			return fmt.Errorf("adopt profile: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `
+			UPDATE identity_tombstones SET merged_into = $1
+			WHERE merged_into = $2`,
+			survivorID, mergedID); err != nil {
+			return fmt.Errorf("retarget tombstones: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO identity_tombstones (platform_user_id, merged_into, merged_at)
+			VALUES ($1, $2, $3)`,
+			mergedID, survivorID, at.UTC()); err != nil {
+			return fmt.Errorf("insert tombstone: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `
+			DELETE FROM platform_identities WHERE platform_user_id = $1`,
+			mergedID); err != nil {
+			return fmt.Errorf("delete identity: %w", err)
+		}
+		identity, err = getIdentity(ctx, tx, survivorID)
+		return err
+	})
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	return identity, nil
+}
+
+// Erase implements interfaces.IdentityEraser. Linkages go with the
+// identity row (ON DELETE CASCADE).
+func (s *IdentityStore) Erase(ctx context.Context, platformUserID string, at time.Time) (domain.Erasure, error) {
+	if _, err := uuid.Parse(platformUserID); err != nil {
+		return domain.Erasure{}, domain.ErrIdentityNotFound
+	}
+	var erasure domain.Erasure
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if err := lockIdentity(ctx, tx, platformUserID); err != nil {
+			if !errors.Is(err, domain.ErrIdentityNotFound) {
+				return err
+			}
+			erasure, err = getErasure(ctx, tx, platformUserID)
+			return err
+		}
+
+		rows, err := tx.Query(ctx, `
+			DELETE FROM identity_tombstones
+			WHERE merged_into = $1
+			RETURNING platform_user_id::text`,
+			platformUserID)
+		if err != nil {
+			return fmt.Errorf("delete tombstones: %w", err)
+		}
+		merged, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return fmt.Errorf("delete tombstones: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO identity_erasures (platform_user_id, erased_at, erased_with)
+			SELECT id, $2, CASE WHEN id = $1 THEN NULL ELSE $1::uuid END
+			FROM unnest($1::uuid || $3::uuid[]) AS id`,
+			platformUserID, at.UTC(), merged); err != nil {
+			return fmt.Errorf("insert erasures: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `
+			DELETE FROM platform_identities WHERE platform_user_id = $1`,
+			platformUserID); err != nil {
+			return fmt.Errorf("delete identity: %w", err)
+		}
+		erasure, err = getErasure(ctx, tx, platformUserID)
+		if err != nil {
+			return err
+		}
+		// Unpublished events must not outlive the data they describe.
+		if _, err := tx.Exec(ctx, `
+			DELETE FROM identity_outbox
+			WHERE platform_user_id = $1 OR platform_user_id = ANY($2)`,
+			erasure.PlatformUserID, erasure.MergedUserIDs); err != nil {
+			return fmt.Errorf("delete outbox messages: %w", err)
+		}
+		return insertOutbox(ctx, tx, domain.NewIdentityErasedEvent(s.idGen(), erasure))
+	})
+	if err != nil {
+		return domain.Erasure{}, err
+	}
+	return erasure, nil
+}
+
+// UpdateProfile implements interfaces.IdentityProfiles.
+func (s *IdentityStore) UpdateProfile(ctx context.Context, platformUserID string, update domain.ProfileUpdate) (domain.PlatformIdentity, error) {
+	if _, err := uuid.Parse(platformUserID); err != nil {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	var identity domain.PlatformIdentity
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		var id string
+		if err := tx.QueryRow(ctx, `
+			SELECT coalesce(
+				(SELECT merged_into FROM identity_tombstones WHERE platform_user_id = $1),
+				$1)::text`,
+			platformUserID).Scan(&id); err != nil {
+			return fmt.Errorf("read tombstone: %w", err)
+		}
+		if err := lockIdentity(ctx, tx, id); err != nil {
+			if !errors.Is(err, domain.ErrIdentityNotFound) {
+				return err
+			}
+			if _, err := getErasure(ctx, tx, platformUserID); err == nil {
+				return domain.ErrIdentityErased
+			}
+			return err
+		}
+		var err error
+		identity, err = getIdentity(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		profile, changed := identity.Profile.Apply(update)
+		if !changed {
+			return nil
+		}
+		data, err := encodeProfile(profile)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			UPDATE platform_identities SET profile = $2
+			WHERE platform_user_id = $1`,
+			id, data); err != nil {
+			return fmt.Errorf("update profile: %w", err)
+		}
+		identity.Profile = profile
+		return nil
+	})
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	return identity, nil
+}
+
+// SetStatus implements interfaces.IdentityStatuses.
+func (s *IdentityStore) SetStatus(ctx context.Context, platformUserID string, status domain.IdentityStatus) (domain.PlatformIdentity, error) {
+	if _, err := uuid.Parse(platformUserID); err != nil {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	var identity domain.PlatformIdentity
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `
+			UPDATE platform_identities
+			SET status = $2, status_reason = $3, status_until = $4, status_changed_at = $5
+			WHERE platform_user_id = $1`,
+			platformUserID,
+			nullString(string(status.State)),
+			nullString(string(status.Reason)),
+			nullTime(status.Until),
+			nullTime(status.ChangedAt))
+		if err != nil {
+			return fmt.Errorf("update status: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			if _, err := getErasure(ctx, tx, platformUserID); err == nil {
+				return domain.ErrIdentityErased
+			}
+			return domain.ErrIdentityNotFound
+		}
+		identity, err = getIdentity(ctx, tx, platformUserID)
+		if err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, domain.NewIdentityStatusChangedEvent(s.idGen(), identity))
+	})
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	return identity, nil
+}
+
+// GetByPlatformUserID implements interfaces.IdentityLookup.
+func (s *IdentityStore) GetByPlatformUserID(ctx context.Context, platformUserID string) (domain.PlatformIdentity, error) {
+	if _, err := uuid.Parse(platformUserID); err != nil {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	identity, err := queryIdentity(ctx, s.pool, selectIdentity+`coalesce(
+		(SELECT merged_into FROM identity_tombstones WHERE platform_user_id = $1),
+		$1)`+orderLinkages, platformUserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		var erased bool
+		if err := s.pool.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM identity_erasures WHERE platform_user_id = $1)`,
+			platformUserID).Scan(&erased); err != nil {
+			return domain.PlatformIdentity{}, fmt.Errorf("read erasure: %w", err)
+		}
+		if erased {
+			return domain.PlatformIdentity{}, domain.ErrIdentityErased
+		}
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	if err != nil {
+		return domain.PlatformIdentity{}, fmt.Errorf("read identity: %w", err)
+	}
+	return identity, nil
+}
+
+// GetByLinkage implements interfaces.IdentityLookup.
+func (s *IdentityStore) GetByLinkage(ctx context.Context, provider, externalUserID string) (domain.PlatformIdentity, error) {
+	identity, err := queryIdentity(ctx, s.pool, selectIdentity+`(
+		SELECT platform_user_id FROM identity_linkages
+		WHERE provider = $1 AND external_user_id = $2)`+orderLinkages,
+		provider, externalUserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	if err != nil {
+		return domain.PlatformIdentity{}, fmt.Errorf("read identity: %w", err)
+	}
+	return identity, nil
+}
+
+// List implements interfaces.IdentityQuery.
+func (s *IdentityStore) List(ctx context.Context, filter domain.IdentityFilter, after *domain.IdentityCursor, limit int) (domain.IdentityPage, error) {
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if after != nil {
+		if _, err := uuid.Parse(after.PlatformUserID); err != nil {
+			return domain.IdentityPage{}, domain.ErrInvalidCursor
+		}
+		where = append(where, fmt.Sprintf("(i.created_at, i.platform_user_id) > (%s, %s)",
+			arg(after.CreatedAt.UTC()), arg(after.PlatformUserID)))
+	}
+	if filter.Tenant != "" {
+		where = append(where, "i.tenant = "+arg(filter.Tenant))
+	}
+	if !filter.CreatedFrom.IsZero() {
+		where = append(where, "i.created_at >= "+arg(filter.CreatedFrom.UTC()))
+	}
+	if !filter.CreatedTo.IsZero() {
+		where = append(where, "i.created_at < "+arg(filter.CreatedTo.UTC()))
+	}
+	if filter.Provider != "" || filter.ExternalUserIDPrefix != "" {
+		linkage := []string{"l.platform_user_id = i.platform_user_id"}
+		if filter.Provider != "" {
+			linkage = append(linkage, "l.provider = "+arg(filter.Provider))
+		}
+		if filter.ExternalUserIDPrefix != "" {
+			linkage = append(linkage, "l.external_user_id LIKE "+arg(likePrefix(filter.ExternalUserIDPrefix)))
+		}
+		where = append(where, "EXISTS (SELECT 1 FROM identity_linkages l WHERE "+strings.Join(linkage, " AND ")+")")
+	}
+
+	sql := `SELECT i.platform_user_id::text FROM platform_identities i`
+	if len(where) > 0 {
+		sql += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	// One more than the page tells whether another page follows.
+	sql += ` ORDER BY i.created_at, i.platform_user_id LIMIT ` + arg(limit+1)
+
+	var page domain.IdentityPage
+	err := pgx.BeginTxFunc(ctx, s.pool, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("list identities: %w", err)
+		}
+		ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return fmt.Errorf("list identities: %w", err)
+		}
+		more := len(ids) > limit
+		if more {
+			ids = ids[:limit]
+		}
+		for _, id := range ids {
+			identity, err := getIdentity(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			page.Identities = append(page.Identities, identity)
+		}
+		if more {
+			next := domain.CursorOf(page.Identities[len(page.Identities)-1])
+			page.Next = &next
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.IdentityPage{}, err
+	}
+	return page, nil
+}
+
+// likePrefix returns a LIKE pattern matching strings that start with prefix.
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
+}
+
+func lockIdentity(ctx context.Context, tx pgx.Tx, platformUserID string) error {
+	err := tx.QueryRow(ctx, `
+		SELECT 1 FROM platform_identities
+		WHERE platform_user_id = $1
+		FOR UPDATE`,
+		platformUserID).Scan(new(int))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrIdentityNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("lock identity: %w", err)
+	}
+	return nil
+}
+
+// getErasure returns the erasure an erased platform user ID belongs to, or
+// domain.ErrIdentityNotFound if it was not erased.
+func getErasure(ctx context.Context, tx pgx.Tx, platformUserID string) (domain.Erasure, error) {
+	var erasure domain.Erasure
+	err := tx.QueryRow(ctx, `
+		SELECT e.platform_user_id::text, e.erased_at, coalesce(
+			(SELECT array_agg(m.platform_user_id::text ORDER BY m.platform_user_id::text)
+			 FROM identity_erasures m WHERE m.erased_with = e.platform_user_id),
+			'{}')
+		FROM identity_erasures e
+		WHERE e.platform_user_id = coalesce(
+			(SELECT erased_with FROM identity_erasures WHERE platform_user_id = $1),
+			$1)`,
+		platformUserID).Scan(&erasure.PlatformUserID, &erasure.ErasedAt, &erasure.MergedUserIDs)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Erasure{}, domain.ErrIdentityNotFound
+	}
+	if err != nil {
+		return domain.Erasure{}, fmt.Errorf("read erasure: %w", err)
+	}
+	if len(erasure.MergedUserIDs) == 0 {
+		erasure.MergedUserIDs = nil
+	}
+	return erasure, nil
+}
+
+func getIdentity(ctx context.Context, q querier, platformUserID string) (domain.PlatformIdentity, error) {
+	identity, err := queryIdentity(ctx, q, selectIdentity+`$1`+orderLinkages, platformUserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.PlatformIdentity{}, domain.ErrIdentityNotFound
+	}
+	if err != nil {
+		return domain.PlatformIdentity{}, fmt.Errorf("read identity: %w", err)
+	}
+	return identity, nil
+}
+
+// queryIdentity runs a selectIdentity query and folds its rows, one per
+// linkage, into an identity. It returns pgx.ErrNoRows if there is none.
+func queryIdentity(ctx context.Context, q querier, sql string, args ...any) (domain.PlatformIdentity, error) {
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	defer rows.Close()
+
+	var identity domain.PlatformIdentity
+	found := false
+	for rows.Next() {
+		var (
+			profile                          []byte
+			state, reason                    *string
+			until, changedAt                 *time.Time
+			provider, externalUserID, tenant *string
+			linkedAt                         *time.Time
+		)
+		if err := rows.Scan(
+			&identity.PlatformUserID,
+			&identity.Tenant,
+			&identity.CreatedAt,
+			&profile,
+			&state,
+			&reason,
+			&until,
+			&changedAt,
+			&provider,
+			&externalUserID,
+			&tenant,
+			&linkedAt,
+		); err != nil {
+			return domain.PlatformIdentity{}, err
+		}
+		if !found {
+			if identity.Profile, err = decodeProfile(profile); err != nil {
+				return domain.PlatformIdentity{}, err
+			}
+			identity.Status = decodeStatus(state, reason, until, changedAt)
+		}
+		found = true
+		if provider == nil {
+			continue
+		}
+		identity.Linkages = append(identity.Linkages, domain.Linkage{
+			Provider:       *provider,
+			ExternalUserID: *externalUserID,
+			Tenant:         *tenant,
+			LinkedAt:       *linkedAt,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	if !found {
+		return domain.PlatformIdentity{}, pgx.ErrNoRows
+	}
+	return identity, nil
+}
+
+// profileAttribute is the JSON form of a domain.ProfileAttribute in the
+// profile column.
+type profileAttribute struct {
+	Value     string               `json:"value"`
+	Source    domain.ProfileSource `json:"source"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+func encodeProfile(profile domain.Profile) ([]byte, error) {
+	out := make(map[domain.ProfileField]profileAttribute, len(profile))
+	for field, a := range profile {
+		out[field] = profileAttribute(a)
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return nil, fmt.Errorf("encode profile: %w", err)
+	}
+	return data, nil
+}
+
+func decodeProfile(data []byte) (domain.Profile, error) {
+	var attrs map[domain.ProfileField]profileAttribute
+	if err := json.Unmarshal(data, &attrs); err != nil {
+		return nil, fmt.Errorf("decode profile: %w", err)
+	}
+	if len(attrs) == 0 {
+		return nil, nil
+	}
+	profile := make(domain.Profile, len(attrs))
+	for field, a := range attrs {
+		profile[field] = domain.ProfileAttribute(a)
+	}
+	return profile, nil
+}
+
+// decodeStatus builds a domain.IdentityStatus from the nullable status
+// columns.
+func decodeStatus(state, reason *string, until, changedAt *time.Time) domain.IdentityStatus {
+	var status domain.IdentityStatus
+	if state != nil {
+		status.State = domain.IdentityState(*state)
+	}
+	if reason != nil {
+		status.Reason = domain.StatusReason(*reason)
+	}
+	if until != nil {
+		status.Until = *until
+	}
+	if changedAt != nil {
+		status.ChangedAt = *changedAt
+	}
+	return status
+}
+
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// KeyRingStore is a Postgres implementation of auth.KeyRingStore, so all
+// identity replicas sign with and publish the same keys.
+type KeyRingStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewKeyRingStore creates a key ring store on a migrated database.
+func NewKeyRingStore(pool *pgxpool.Pool) *KeyRingStore {
+	return &KeyRingStore{pool: pool}
+}
+
+// LoadKeyRing implements auth.KeyRingStore.
+func (s *KeyRingStore) LoadKeyRing(ctx context.Context) ([]byte, int64, error) {
+	var (
+		data    []byte
+		version int64
+	)
+	err := s.pool.QueryRow(ctx, `
+		SELECT ring, version FROM identity_signing_key_ring
+		WHERE id = 1`).Scan(&data, &version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("select signing key ring: %w", err)
+	}
+	return data, version, nil
+}
+
+// SaveKeyRing implements auth.KeyRingStore. Version 0 stands for no stored
+// ring; only one replica storing the first ring succeeds.
+func (s *KeyRingStore) SaveKeyRing(ctx context.Context, data []byte, version int64) (int64, error) {
+	now := time.Now().UTC()
+	if version == 0 {
+		tag, err := s.pool.Exec(ctx, `
+			INSERT INTO identity_signing_key_ring (id, version, ring, updated_at)
+			VALUES (1, 1, $1, $2)
+			ON CONFLICT (id) DO NOTHING`,
+			data, now)
+		if err != nil {
+			return 0, fmt.Errorf("insert signing key ring: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return 0, domain.ErrKeyRingChanged
+		}
+		return 1, nil
+	}
+
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE identity_signing_key_ring
+		SET version = version + 1, ring = $1, updated_at = $2
+		WHERE id = 1 AND version = $3`,
+		data, now, version)
+	if err != nil {
+		return 0, fmt.Errorf("update signing key ring: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return 0, domain.ErrKeyRingChanged
+	}
+	return version + 1, nil
+}
+
+func (s *KeyRingStore) String() string {
+	return "postgres"
+}
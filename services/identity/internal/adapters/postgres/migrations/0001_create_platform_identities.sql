@@ -0,0 +1,12 @@
+-- Platform identities and their external identity linkage. The unique
+-- constraint makes concurrent Resolve calls for the same external identity
+-- converge on one platform user ID.
+CREATE TABLE platform_identities (
+    platform_user_id uuid        PRIMARY KEY,
+    provider         text        NOT NULL,
+    external_user_id text        NOT NULL,
+    tenant           text        NOT NULL DEFAULT '',
+    created_at       timestamptz NOT NULL,
+    CONSTRAINT platform_identities_provider_external_user_id_key
+        UNIQUE (provider, external_user_id)
+);
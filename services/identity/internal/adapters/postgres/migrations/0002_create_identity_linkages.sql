@@ -0,0 +1,24 @@
+-- Linked external identities move to their own table so a platform identity
+-- can have several. The primary key keeps an external identity linked to
+-- at most one platform user and makes concurrent Resolve calls converge.
+CREATE TABLE identity_linkages (
+    provider         text        NOT NULL,
+    external_user_id text        NOT NULL,
+    platform_user_id uuid        NOT NULL
+        REFERENCES platform_identities (platform_user_id) ON DELETE CASCADE,
+    tenant           text        NOT NULL DEFAULT '',
+    linked_at        timestamptz NOT NULL,
+    PRIMARY KEY (provider, external_user_id)
+);
+
+CREATE INDEX identity_linkages_platform_user_id_idx
+    ON identity_linkages (platform_user_id, linked_at);
+
+INSERT INTO identity_linkages (provider, external_user_id, platform_user_id, tenant, linked_at)
+SELECT provider, external_user_id, platform_user_id, tenant, created_at
+FROM platform_identities;
+
+ALTER TABLE platform_identities
+    DROP CONSTRAINT platform_identities_provider_external_user_id_key,
+    DROP COLUMN provider,
+    DROP COLUMN external_user_id;
@@ -0,0 +1,12 @@
+-- A merged platform identity leaves a tombstone naming the identity it was
+-- merged into, so its platform user ID keeps resolving. Tombstones always
+-- point at a live identity: merging the survivor again retargets them.
+CREATE TABLE identity_tombstones (
+    platform_user_id uuid        PRIMARY KEY,
+    merged_into      uuid        NOT NULL
+        REFERENCES platform_identities (platform_user_id),
+    merged_at        timestamptz NOT NULL
+);
+
+CREATE INDEX identity_tombstones_merged_into_idx
+    ON identity_tombstones (merged_into);
@@ -0,0 +1,13 @@
+-- Administrative changes to platform identities. Entries outlive the
+-- identities they describe, so platform_user_id has no foreign key.
+CREATE TABLE identity_audit_log (
+    id               text        PRIMARY KEY,
+    platform_user_id uuid        NOT NULL,
+    action           text        NOT NULL,
+    actor            text        NOT NULL DEFAULT '',
+    details          jsonb       NOT NULL DEFAULT '{}',
+    recorded_at      timestamptz NOT NULL
+);
+
+CREATE INDEX identity_audit_log_platform_user_id_idx
+    ON identity_audit_log (platform_user_id, recorded_at);
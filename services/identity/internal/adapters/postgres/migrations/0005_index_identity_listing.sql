@@ -0,0 +1,7 @@
+-- Listing pages through identities in (created_at, platform_user_id) order
+-- and filters linkages by provider and external user ID prefix.
+CREATE INDEX platform_identities_created_at_idx
+    ON platform_identities (created_at, platform_user_id);
+
+CREATE INDEX identity_linkages_external_user_id_prefix_idx
+    ON identity_linkages (provider, external_user_id text_pattern_ops);
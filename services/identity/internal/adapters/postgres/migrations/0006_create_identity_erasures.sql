@@ -0,0 +1,11 @@
+-- An erased platform identity leaves only its ID and the erasure time, so
+-- lookups can tell it from an unknown one. IDs merged into an erased
+-- identity are erased with it and name it in erased_with.
+CREATE TABLE identity_erasures (
+    platform_user_id uuid        PRIMARY KEY,
+    erased_at        timestamptz NOT NULL,
+    erased_with      uuid
+);
+
+CREATE INDEX identity_erasures_erased_with_idx
+    ON identity_erasures (erased_with);
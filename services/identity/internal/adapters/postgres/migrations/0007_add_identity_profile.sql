@@ -0,0 +1,5 @@
+-- Optional profile attributes, keyed by field. Each attribute is an object
+-- {"value", "source", "updated_at"} so updates can be checked against the
+-- source and time of the current value.
+ALTER TABLE platform_identities
+    ADD COLUMN profile jsonb NOT NULL DEFAULT '{}';
@@ -0,0 +1,7 @@
+-- Lifecycle status. NULL status is an identity that was never suspended or
+-- banned; status_until is only set for suspensions.
+ALTER TABLE platform_identities
+    ADD COLUMN status text,
+    ADD COLUMN status_reason text,
+    ADD COLUMN status_until timestamptz,
+    ADD COLUMN status_changed_at timestamptz;
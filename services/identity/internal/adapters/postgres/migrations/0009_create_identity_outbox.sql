@@ -0,0 +1,14 @@
+-- Events waiting to be relayed to the broker, written in the transaction of
+-- the change they describe. seq keeps them in write order; platform_user_id
+-- lets an erasure drop the unpublished events about the erased IDs.
+CREATE TABLE identity_outbox (
+    seq              bigserial   PRIMARY KEY,
+    id               text        NOT NULL UNIQUE,
+    event_type       text        NOT NULL,
+    platform_user_id text        NOT NULL,
+    data             jsonb       NOT NULL,
+    created_at       timestamptz NOT NULL
+);
+
+CREATE INDEX identity_outbox_platform_user_id_idx
+    ON identity_outbox (platform_user_id);
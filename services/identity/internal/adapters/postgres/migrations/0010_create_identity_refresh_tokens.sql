@@ -0,0 +1,23 @@
+-- Refresh token state, keyed by the SHA-256 of the opaque token. A
+-- session's tokens form its family. Used tokens stay until they expire so
+-- presenting one again is detected as reuse; expired tokens and revoked
+-- families are deleted.
+CREATE TABLE identity_refresh_tokens (
+    token_hash       text        PRIMARY KEY,
+    session_id       text        NOT NULL,
+    platform_user_id text        NOT NULL,
+    tenant           text        NOT NULL DEFAULT '',
+    issued_at        timestamptz NOT NULL,
+    expires_at       timestamptz NOT NULL,
+    used_at          timestamptz,
+    revoked_at       timestamptz
+);
+
+CREATE INDEX identity_refresh_tokens_session_id_idx
+    ON identity_refresh_tokens (session_id);
+
+CREATE INDEX identity_refresh_tokens_expires_at_idx
+    ON identity_refresh_tokens (expires_at);
+
+CREATE INDEX identity_refresh_tokens_revoked_idx
+    ON identity_refresh_tokens (revoked_at) WHERE revoked_at IS NOT NULL;
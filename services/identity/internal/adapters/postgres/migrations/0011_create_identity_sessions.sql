@@ -0,0 +1,25 @@
+-- Sessions of player exchanges and backoffice logins with their revocation
+-- state. expires_at is when the last token of the session expires; a
+-- session is deleted once it has been expired for the retention window, by
+-- when every revocation has stopped mattering to verifiers.
+CREATE TABLE identity_sessions (
+    id               text        PRIMARY KEY,
+    platform_user_id text        NOT NULL,
+    subject_type     text        NOT NULL,
+    tenant           text        NOT NULL DEFAULT '',
+    provider         text        NOT NULL DEFAULT '',
+    scopes           text[]      NOT NULL DEFAULT '{}',
+    created_at       timestamptz NOT NULL,
+    expires_at       timestamptz NOT NULL,
+    revoked_at       timestamptz,
+    revoke_reason    text        NOT NULL DEFAULT ''
+);
+
+CREATE INDEX identity_sessions_platform_user_id_idx
+    ON identity_sessions (platform_user_id, created_at);
+
+CREATE INDEX identity_sessions_expires_at_idx
+    ON identity_sessions (expires_at);
+
+CREATE INDEX identity_sessions_revoked_at_idx
+    ON identity_sessions (revoked_at) WHERE revoked_at IS NOT NULL;
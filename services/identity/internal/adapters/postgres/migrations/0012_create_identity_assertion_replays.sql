@@ -0,0 +1,12 @@
+-- Used identity assertion IDs (jti) per provider, so an assertion is
+-- exchanged once whichever replica it reaches. Rows are deleted once the
+-- assertion has expired, when it is rejected for that alone.
+CREATE TABLE identity_assertion_replays (
+    provider   text        NOT NULL,
+    jti        text        NOT NULL,
+    expires_at timestamptz NOT NULL,
+    PRIMARY KEY (provider, jti)
+);
+
+CREATE INDEX identity_assertion_replays_expires_at_idx
+    ON identity_assertion_replays (expires_at);
@@ -0,0 +1,10 @@
+-- The signing key ring shared by all replicas, in the JSON format of
+-- JWT_KEY_RING_FILE including the private keys of non-retired keys. The
+-- single row carries a version so replicas changing the ring at the same
+-- time do not overwrite each other.
+CREATE TABLE identity_signing_key_ring (
+    id         smallint    PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    version    bigint      NOT NULL,
+    ring       jsonb       NOT NULL,
+    updated_at timestamptz NOT NULL
+);
@@ -0,0 +1,14 @@
+-- The provider registry shared by all replicas. Assertion keys are public
+-- JWKs; token lifetimes of zero keep the configured defaults.
+CREATE TABLE identity_providers (
+    id                   text        PRIMARY KEY,
+    display_name         text        NOT NULL,
+    status               text        NOT NULL,
+    allowed_tenants      text[]      NOT NULL,
+    assertion_keys       jsonb       NOT NULL,
+    access_token_ttl_ms  bigint      NOT NULL,
+    refresh_token_ttl_ms bigint      NOT NULL,
+    profile_claims       text[]      NOT NULL,
+    created_at           timestamptz NOT NULL,
+    updated_at           timestamptz NOT NULL
+);
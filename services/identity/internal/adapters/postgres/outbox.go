@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/events"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// execer is satisfied by both the pool and a transaction.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// Add implements interfaces.Outbox.
+func (s *IdentityStore) Add(ctx context.Context, event domain.Event) error {
+	return insertOutbox(ctx, s.pool, event)
+}
+
+// Pending implements interfaces.Outbox.
+func (s *IdentityStore) Pending(ctx context.Context, limit int) ([]domain.OutboxMessage, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, event_type, platform_user_id, data, created_at
+		FROM identity_outbox
+		ORDER BY seq
+		LIMIT $1`,
+		limit)
+	if err != nil {
+		return nil, fmt.Errorf("read outbox: %w", err)
+	}
+	msgs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.OutboxMessage, error) {
+		var (
+			msg       domain.OutboxMessage
+			eventType string
+		)
+		err := row.Scan(&msg.ID, &eventType, &msg.PlatformUserID, &msg.Data, &msg.CreatedAt)
+		msg.Type = domain.EventType(eventType)
+		return msg, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read outbox: %w", err)
+	}
+	return msgs, nil
+}
+
+// MarkPublished implements interfaces.Outbox.
+func (s *IdentityStore) MarkPublished(ctx context.Context, ids []string) error {
+	if _, err := s.pool.Exec(ctx, `
+		DELETE FROM identity_outbox WHERE id = ANY($1)`,
+		ids); err != nil {
+		return fmt.Errorf("delete outbox messages: %w", err)
+	}
+	return nil
+}
+
+// insertOutbox adds an event to the outbox; pass the transaction of the
+// change the event describes.
+func insertOutbox(ctx context.Context, q execer, event domain.Event) error {
+	msg, err := events.Encode(event)
+	if err != nil {
+		return err
+	}
+	if _, err := q.Exec(ctx, `
+		INSERT INTO identity_outbox (id, event_type, platform_user_id, data, created_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		msg.ID, string(msg.Type), msg.PlatformUserID, msg.Data, msg.CreatedAt.UTC()); err != nil {
+		return fmt.Errorf("insert outbox message: %w", err)
+	}
+	return nil
+}
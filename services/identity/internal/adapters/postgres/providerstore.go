@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// ProviderStore is a Postgres implementation of interfaces.ProviderStore,
+// so providers registered through any replica are known to all of them.
+type ProviderStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewProviderStore creates a provider store on a migrated database.
+func NewProviderStore(pool *pgxpool.Pool) *ProviderStore {
+	return &ProviderStore{pool: pool}
+}
+
+// providerKey is the JSON form of domain.PublicJWK in assertion_keys.
+type providerKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+const providerColumns = `id, display_name, status, allowed_tenants, assertion_keys,
+	access_token_ttl_ms, refresh_token_ttl_ms, profile_claims, created_at, updated_at`
+
+// Create implements interfaces.ProviderStore.
+func (s *ProviderStore) Create(ctx context.Context, provider domain.Provider) error {
+	tag, err := s.pool.Exec(ctx, `
+		INSERT INTO identity_providers (`+providerColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO NOTHING`,
+		providerArgs(provider)...)
+	if err != nil {
+		return fmt.Errorf("insert provider: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrProviderExists
+	}
+	return nil
+}
+
+// Get implements interfaces.ProviderStore.
+func (s *ProviderStore) Get(ctx context.Context, id string) (domain.Provider, error) {
+	provider, err := scanProvider(s.pool.QueryRow(ctx, `
+		SELECT `+providerColumns+`
+		FROM identity_providers
+		WHERE id = $1`,
+		id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Provider{}, domain.ErrProviderNotFound
+	}
+	if err != nil {
+		return domain.Provider{}, fmt.Errorf("select provider: %w", err)
+	}
+	return provider, nil
+}
+
+// List implements interfaces.ProviderStore.
+func (s *ProviderStore) List(ctx context.Context) ([]domain.Provider, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+providerColumns+`
+		FROM identity_providers
+		ORDER BY id COLLATE "C"`)
+	if err != nil {
+		return nil, fmt.Errorf("list providers: %w", err)
+	}
+	defer rows.Close()
+
+	providers := []domain.Provider{}
+	for rows.Next() {
+		provider, err := scanProvider(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan provider: %w", err)
+		}
+		providers = append(providers, provider)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list providers: %w", err)
+	}
+	return providers, nil
+}
+
+// Update implements interfaces.ProviderStore.
+func (s *ProviderStore) Update(ctx context.Context, provider domain.Provider) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE identity_providers
+		SET display_name = $2, status = $3, allowed_tenants = $4, assertion_keys = $5,
+			access_token_ttl_ms = $6, refresh_token_ttl_ms = $7, profile_claims = $8,
+			created_at = $9, updated_at = $10
+		WHERE id = $1`,
+		providerArgs(provider)...)
+	if err != nil {
+		return fmt.Errorf("update provider: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrProviderNotFound
+	}
+	return nil
+}
+
+// Delete implements interfaces.ProviderStore.
+func (s *ProviderStore) Delete(ctx context.Context, id string) error {
+	tag, err := s.pool.Exec(ctx, `
+		DELETE FROM identity_providers WHERE id = $1`,
+		id)
+	if err != nil {
+		return fmt.Errorf("delete provider: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrProviderNotFound
+	}
+	return nil
+}
+
+// providerArgs returns the values of providerColumns.
+func providerArgs(p domain.Provider) []any {
+	tenants := append([]string{}, p.AllowedTenants...)
+	keys := make([]providerKey, 0, len(p.AssertionKeys))
+	for _, k := range p.AssertionKeys {
+		keys = append(keys, providerKey(k))
+	}
+	claims := make([]string, 0, len(p.Tokens.ProfileClaims))
+	for _, c := range p.Tokens.ProfileClaims {
+		claims = append(claims, string(c))
+	}
+	return []any{
+		p.ID, p.DisplayName, string(p.Status), tenants, keys,
+		p.Tokens.AccessTTL.Milliseconds(), p.Tokens.RefreshTTL.Milliseconds(), claims,
+		p.CreatedAt.UTC(), p.UpdatedAt.UTC(),
+	}
+}
+
+func scanProvider(row pgx.Row) (domain.Provider, error) {
+	var (
+		p                     domain.Provider
+		status                string
+		keys                  []providerKey
+		accessTTL, refreshTTL int64
+		claims                []string
+	)
+	if err := row.Scan(&p.ID, &p.DisplayName, &status, &p.AllowedTenants, &keys,
+		&accessTTL, &refreshTTL, &claims, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return domain.Provider{}, err
+	}
+	p.Status = domain.ProviderStatus(status)
+	if len(p.AllowedTenants) == 0 {
+		p.AllowedTenants = nil
+	}
+	for _, k := range keys {
+		p.AssertionKeys = append(p.AssertionKeys, domain.PublicJWK(k))
+	}
+	p.Tokens.AccessTTL = time.Duration(accessTTL) * time.Millisecond
+	p.Tokens.RefreshTTL = time.Duration(refreshTTL) * time.Millisecond
+	for _, c := range claims {
+		p.Tokens.ProfileClaims = append(p.Tokens.ProfileClaims, domain.ProfileField(c))
+	}
+	return p, nil
+}
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// sweepInterval bounds how often a replica deletes expired session,
+// refresh token and assertion replay state.
+const sweepInterval = time.Minute
+
+// RefreshTokenStore is a Postgres implementation of
+// interfaces.RefreshTokenStore, shared by all identity replicas so reuse is
+// detected whichever replica a token is presented to. Expired tokens and
+// revoked families are deleted periodically.
+type RefreshTokenStore struct {
+	pool *pgxpool.Pool
+
+	mu        sync.Mutex
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+// NewRefreshTokenStore creates a refresh token store on a migrated
+// database.
+func NewRefreshTokenStore(pool *pgxpool.Pool) *RefreshTokenStore {
+	return &RefreshTokenStore{pool: pool, now: time.Now}
+}
+
+// Save implements interfaces.RefreshTokenStore.
+func (s *RefreshTokenStore) Save(ctx context.Context, token domain.RefreshToken) error {
+	if err := s.maybeSweep(ctx); err != nil {
+		return err
+	}
+	if _, err := s.pool.Exec(ctx, `
+		INSERT INTO identity_refresh_tokens
+			(token_hash, session_id, platform_user_id, tenant, issued_at, expires_at, used_at, revoked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (token_hash) DO UPDATE SET
+			session_id = EXCLUDED.session_id,
+			platform_user_id = EXCLUDED.platform_user_id,
+			tenant = EXCLUDED.tenant,
+			issued_at = EXCLUDED.issued_at,
+			expires_at = EXCLUDED.expires_at,
+			used_at = EXCLUDED.used_at,
+			revoked_at = EXCLUDED.revoked_at`,
+		token.TokenHash, token.SessionID, token.PlatformUserID, token.Tenant,
+		token.IssuedAt.UTC(), token.ExpiresAt.UTC(), nullTime(token.UsedAt), nullTime(token.RevokedAt)); err != nil {
+		return fmt.Errorf("insert refresh token: %w", err)
+	}
+	return nil
+}
+
+// Consume implements interfaces.RefreshTokenStore. The conditional update
+// lets exactly one of several concurrent consumers mark the token used.
+func (s *RefreshTokenStore) Consume(ctx context.Context, tokenHash string, at time.Time) (domain.RefreshToken, error) {
+	token, err := scanRefreshToken(s.pool.QueryRow(ctx, `
+		UPDATE identity_refresh_tokens SET used_at = $2
+		WHERE token_hash = $1 AND used_at IS NULL
+		RETURNING token_hash, session_id, platform_user_id, tenant, issued_at, expires_at, NULL::timestamptz, revoked_at`,
+		tokenHash, at.UTC()))
+	if err == nil {
+		return token, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return domain.RefreshToken{}, fmt.Errorf("consume refresh token: %w", err)
+	}
+
+	token, err = scanRefreshToken(s.pool.QueryRow(ctx, `
+		SELECT token_hash, session_id, platform_user_id, tenant, issued_at, expires_at, used_at, revoked_at
+		FROM identity_refresh_tokens
+		WHERE token_hash = $1`,
+		tokenHash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.RefreshToken{}, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return domain.RefreshToken{}, fmt.Errorf("read refresh token: %w", err)
+	}
+	return token, domain.ErrRefreshTokenReused
+}
+
+// RevokeFamily implements interfaces.RefreshTokenStore.
+func (s *RefreshTokenStore) RevokeFamily(ctx context.Context, sessionID string, at time.Time) error {
+	if _, err := s.pool.Exec(ctx, `
+		UPDATE identity_refresh_tokens SET revoked_at = $2
+		WHERE session_id = $1 AND revoked_at IS NULL`,
+		sessionID, at.UTC()); err != nil {
+		return fmt.Errorf("revoke refresh token family: %w", err)
+	}
+	return nil
+}
+
+// maybeSweep deletes tokens that can no longer be exchanged, at most once
+// per sweepInterval. A used token that has not expired is kept, so
+// presenting it again is still detected as reuse.
+func (s *RefreshTokenStore) maybeSweep(ctx context.Context) error {
+	s.mu.Lock()
+	now := s.now()
+	due := now.After(s.nextSweep)
+	if due {
+		s.nextSweep = now.Add(sweepInterval)
+	}
+	s.mu.Unlock()
+	if !due {
+		return nil
+	}
+
+	if _, err := s.pool.Exec(ctx, `
+		DELETE FROM identity_refresh_tokens
+		WHERE expires_at <= $1 OR revoked_at IS NOT NULL`,
+		now.UTC()); err != nil {
+		return fmt.Errorf("sweep refresh tokens: %w", err)
+	}
+	return nil
+}
+
+func scanRefreshToken(row pgx.Row) (domain.RefreshToken, error) {
+	var (
+		token             domain.RefreshToken
+		usedAt, revokedAt *time.Time
+	)
+	if err := row.Scan(&token.TokenHash, &token.SessionID, &token.PlatformUserID, &token.Tenant,
+		&token.IssuedAt, &token.ExpiresAt, &usedAt, &revokedAt); err != nil {
+		return domain.RefreshToken{}, err
+	}
+	if usedAt != nil {
+		token.UsedAt = *usedAt
+	}
+	if revokedAt != nil {
+		token.RevokedAt = *revokedAt
+	}
+	return token, nil
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// ReplayCache is a Postgres implementation of
+// interfaces.AssertionReplayCache, shared by all identity replicas so an
+// assertion is exchanged once whichever replica it reaches. Expired jtis
+// are deleted periodically.
+type ReplayCache struct {
+	pool *pgxpool.Pool
+
+	mu        sync.Mutex
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+// NewReplayCache creates a replay cache on a migrated database.
+func NewReplayCache(pool *pgxpool.Pool) *ReplayCache {
+	return &ReplayCache{pool: pool, now: time.Now}
+}
+
+// MarkUsed implements interfaces.AssertionReplayCache. The primary key
+// lets exactly one of several concurrent exchanges record the jti.
+func (c *ReplayCache) MarkUsed(ctx context.Context, provider, jti string, expiresAt time.Time) error {
+	if err := c.maybeSweep(ctx); err != nil {
+		return err
+	}
+	tag, err := c.pool.Exec(ctx, `
+		INSERT INTO identity_assertion_replays (provider, jti, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (provider, jti) DO NOTHING`,
+		provider, jti, expiresAt.UTC())
+	if err != nil {
+		return fmt.Errorf("insert assertion jti: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAssertionReplayed
+	}
+	return nil
+}
+
+// maybeSweep deletes expired jtis, at most once per sweepInterval.
+func (c *ReplayCache) maybeSweep(ctx context.Context) error {
+	c.mu.Lock()
+	now := c.now()
+	due := now.After(c.nextSweep)
+	if due {
+		c.nextSweep = now.Add(sweepInterval)
+	}
+	c.mu.Unlock()
+	if !due {
+		return nil
+	}
+
+	if _, err := c.pool.Exec(ctx, `
+		DELETE FROM identity_assertion_replays
+		WHERE expires_at < $1`,
+		now.UTC()); err != nil {
+		return fmt.Errorf("sweep assertion jtis: %w", err)
+	}
+	return nil
+}
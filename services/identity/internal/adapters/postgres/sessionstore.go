@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+const sessionColumns = `id, platform_user_id, subject_type, tenant, provider, scopes,
+	created_at, expires_at, revoked_at, revoke_reason`
+
+// SessionStore is a Postgres implementation of interfaces.SessionStore,
+// shared by all identity replicas so every replica lists the same
+// revocations. Sessions are deleted once they have been expired for the
+// retention window.
+type SessionStore struct {
+	pool      *pgxpool.Pool
+	retention time.Duration
+
+	mu        sync.Mutex
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+// NewSessionStore creates a session store on a migrated database that
+// keeps expired sessions for retention, at least the longest access token
+// lifetime.
+func NewSessionStore(pool *pgxpool.Pool, retention time.Duration) *SessionStore {
+	return &SessionStore{pool: pool, retention: retention, now: time.Now}
+}
+
+// Create implements interfaces.SessionStore.
+func (s *SessionStore) Create(ctx context.Context, session domain.Session) error {
+	if err := s.maybeSweep(ctx); err != nil {
+		return err
+	}
+	scopes := session.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	if _, err := s.pool.Exec(ctx, `
+		INSERT INTO identity_sessions (`+sessionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO UPDATE SET
+			platform_user_id = EXCLUDED.platform_user_id,
+			subject_type = EXCLUDED.subject_type,
+			tenant = EXCLUDED.tenant,
+			provider = EXCLUDED.provider,
+			scopes = EXCLUDED.scopes,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at,
+			revoked_at = EXCLUDED.revoked_at,
+			revoke_reason = EXCLUDED.revoke_reason`,
+		session.ID, session.PlatformUserID, session.SubjectType, session.Tenant, session.Provider, scopes,
+		session.CreatedAt.UTC(), session.ExpiresAt.UTC(), nullTime(session.RevokedAt), session.RevokeReason); err != nil {
+		return fmt.Errorf("insert session: %w", err)
+	}
+	return nil
+}
+
+// Get implements interfaces.SessionStore.
+func (s *SessionStore) Get(ctx context.Context, sessionID string) (domain.Session, error) {
+	session, err := scanSession(s.pool.QueryRow(ctx, `
+		SELECT `+sessionColumns+` FROM identity_sessions WHERE id = $1`,
+		sessionID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Session{}, domain.ErrSessionNotFound
+	}
+	if err != nil {
+		return domain.Session{}, fmt.Errorf("read session: %w", err)
+	}
+	return session, nil
+}
+
+// ListByUser implements interfaces.SessionStore.
+func (s *SessionStore) ListByUser(ctx context.Context, platformUserID string) ([]domain.Session, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+sessionColumns+` FROM identity_sessions
+		WHERE platform_user_id = $1
+		ORDER BY created_at, id`,
+		platformUserID)
+	if err != nil {
+		return nil, fmt.Errorf("read sessions: %w", err)
+	}
+	sessions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Session, error) {
+		return scanSession(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// Extend implements interfaces.SessionStore.
+func (s *SessionStore) Extend(ctx context.Context, sessionID string, expiresAt time.Time) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE identity_sessions SET expires_at = $2 WHERE id = $1`,
+		sessionID, expiresAt.UTC())
+	if err != nil {
+		return fmt.Errorf("extend session: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSessionNotFound
+	}
+	return nil
+}
+
+// Revoke implements interfaces.SessionStore.
+func (s *SessionStore) Revoke(ctx context.Context, sessionID, reason string, at time.Time) (domain.Session, error) {
+	session, err := scanSession(s.pool.QueryRow(ctx, `
+		UPDATE identity_sessions SET revoked_at = $3, revoke_reason = $2
+		WHERE id = $1 AND revoked_at IS NULL
+		RETURNING `+sessionColumns,
+		sessionID, reason, at.UTC()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Unknown or already revoked.
+		return s.Get(ctx, sessionID)
+	}
+	if err != nil {
+		return domain.Session{}, fmt.Errorf("revoke session: %w", err)
+	}
+	return session, nil
+}
+
+// RevokeAllForUser implements interfaces.SessionStore.
+func (s *SessionStore) RevokeAllForUser(ctx context.Context, platformUserID, reason string, at time.Time) ([]domain.Session, error) {
+	rows, err := s.pool.Query(ctx, `
+		UPDATE identity_sessions SET revoked_at = $3, revoke_reason = $2
+		WHERE platform_user_id = $1 AND revoked_at IS NULL
+		RETURNING `+sessionColumns,
+		platformUserID, reason, at.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("revoke sessions: %w", err)
+	}
+	sessions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Session, error) {
+		return scanSession(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("revoke sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// RevokedSince implements interfaces.SessionStore.
+func (s *SessionStore) RevokedSince(ctx context.Context, since time.Time) ([]domain.Session, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+sessionColumns+` FROM identity_sessions
+		WHERE revoked_at >= $1
+		ORDER BY revoked_at, id`,
+		since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("read revoked sessions: %w", err)
+	}
+	sessions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Session, error) {
+		return scanSession(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read revoked sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// maybeSweep deletes sessions expired for longer than the retention
+// window, at most once per sweepInterval.
+func (s *SessionStore) maybeSweep(ctx context.Context) error {
+	s.mu.Lock()
+	now := s.now()
+	due := now.After(s.nextSweep)
+	if due {
+		s.nextSweep = now.Add(sweepInterval)
+	}
+	s.mu.Unlock()
+	if !due {
+		return nil
+	}
+
+	if _, err := s.pool.Exec(ctx, `
+		DELETE FROM identity_sessions WHERE expires_at < $1`,
+		now.Add(-s.retention).UTC()); err != nil {
+		return fmt.Errorf("sweep sessions: %w", err)
+	}
+	return nil
+}
+
+func scanSession(row pgx.Row) (domain.Session, error) {
+	var (
+		session   domain.Session
+		revokedAt *time.Time
+	)
+	if err := row.Scan(&session.ID, &session.PlatformUserID, &session.SubjectType, &session.Tenant, &session.Provider,
+		&session.Scopes, &session.CreatedAt, &session.ExpiresAt, &revokedAt, &session.RevokeReason); err != nil {
+		return domain.Session{}, err
+	}
+	if revokedAt != nil {
+		session.RevokedAt = *revokedAt
+	}
+	return session, nil
+}
@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// revokeReasonIdentityErased is recorded on the sessions of an erased user.
+const revokeReasonIdentityErased = "identity_erased"
+
+// Export returns everything identity holds about a platform user: the
+// identity with its linkages, the platform user IDs merged into it, and
+// the sessions and audit entries of all of them. The ID of a merged
+// identity exports its survivor; an erased one returns
+// domain.ErrIdentityErased.
+func (s *Service) Export(ctx context.Context, platformUserID string) (domain.IdentityExport, error) {
+	identity, err := s.lookup.GetByPlatformUserID(ctx, platformUserID)
+	if err != nil {
+		return domain.IdentityExport{}, err
+	}
+	export := domain.IdentityExport{Identity: identity, ExportedAt: s.now()}
+
+	// Merges are recorded on the survivor; follow them to the merged IDs,
+	// which may have been survivors of earlier merges themselves.
+	for ids := []string{identity.PlatformUserID}; len(ids) > 0; ids = ids[1:] {
+		id := ids[0]
+		sessions, err := s.sessions.ListByUser(ctx, id)
+		if err != nil {
+			return domain.IdentityExport{}, err
+		}
+		export.Sessions = append(export.Sessions, sessions...)
+
+		entries, err := s.audit.ListByUser(ctx, id)
+		if err != nil {
+			return domain.IdentityExport{}, err
+		}
+		export.Audit = append(export.Audit, entries...)
+		for _, entry := range entries {
+			merged := entry.Details["merged_platform_user_id"]
+			if entry.Action == domain.AuditIdentityMerged && merged != "" && !slices.Contains(export.MergedUserIDs, merged) {
+				export.MergedUserIDs = append(export.MergedUserIDs, merged)
+				ids = append(ids, merged)
+			}
+		}
+	}
+	slices.SortStableFunc(export.Audit, func(a, b domain.AuditEntry) int {
+		return a.At.Compare(b.At)
+	})
+	return export, nil
+}
+
+// Erase erases a platform identity on behalf of actor: the identity and
+// its linkages are deleted, leaving only its platform user ID and the
+// erasure time; the sessions and refresh tokens of the identity and of
+// every ID merged into it are revoked; operator free text in their earlier
+// audit entries is redacted; the erasure is recorded in the audit log. The
+// store announces it to other services with an identity.erased event.
+// Sessions and audit entries stay, keyed by the platform user ID alone,
+// which nothing links to the person any more.
+//
+// Erasing again repeats the revocations, so a failed call can be retried.
+// The ID of a merged identity returns domain.ErrIdentityMerged: erase the
+// survivor instead.
+func (s *Service) Erase(ctx context.Context, platformUserID, actor, reason string) (domain.Erasure, error) {
+	if _, err := s.live(ctx, platformUserID); err != nil && !errors.Is(err, domain.ErrIdentityErased) {
+		return domain.Erasure{}, err
+	}
+
+	now := s.now()
+	erasure, err := s.eraser.Erase(ctx, platformUserID, now)
+	if err != nil {
+		return domain.Erasure{}, err
+	}
+
+	revoked := 0
+	for _, id := range append([]string{erasure.PlatformUserID}, erasure.MergedUserIDs...) {
+		sessions, err := s.sessions.RevokeAllForUser(ctx, id, revokeReasonIdentityErased, now)
+		if err != nil {
+			return domain.Erasure{}, err
+		}
+		for _, session := range sessions {
+			if err := s.refreshTokens.RevokeFamily(ctx, session.ID, now); err != nil {
+				return domain.Erasure{}, err
+			}
+		}
+		revoked += len(sessions)
+		if err := s.redactAudit(ctx, id); err != nil {
+			return domain.Erasure{}, err
+		}
+	}
+
+	entryID, err := newAuditEntryID()
+	if err != nil {
+		return domain.Erasure{}, err
+	}
+	details := map[string]string{
+		"erased_user_ids":  strconv.Itoa(1 + len(erasure.MergedUserIDs)),
+		"revoked_sessions": strconv.Itoa(revoked),
+	}
+	if reason != "" {
+		details["reason"] = reason
+	}
+	err = s.audit.Append(ctx, domain.AuditEntry{
+		ID:             entryID,
+		PlatformUserID: erasure.PlatformUserID,
+		Action:         domain.AuditIdentityErased,
+		Actor:          actor,
+		Details:        details,
+		At:             now,
+	})
+	if err != nil {
+		return domain.Erasure{}, err
+	}
+	return erasure, nil
+}
+
+// redactAudit replaces operator free text in the audit entries of a
+// platform user ID, since operators may mention the person in it.
+func (s *Service) redactAudit(ctx context.Context, platformUserID string) error {
+	entries, err := s.audit.ListByUser(ctx, platformUserID)
+	if err != nil {
+		return err
+	}
+	redacted := make(map[string]map[string]string)
+	for _, entry := range entries {
+		if details, ok := entry.RedactedDetails(); ok {
+			redacted[entry.ID] = details
+		}
+	}
+	if len(redacted) == 0 {
+		return nil
+	}
+	return s.audit.ReplaceDetails(ctx, platformUserID, redacted)
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+func TestErase(t *testing.T) {
+	// The identities are named: "player" has a session and "duplicate", with
+	// a session of its own, was merged into it in the store alone, as by a
+	// merge that failed before revoking. "unknown" does not exist.
+	tests := []struct {
+		name string
+		id   string
+		// again erases twice, as a retry would.
+		again      bool
+		wantErr    error
+		wantErased string
+	}{
+		{name: "identity with a merged ID", id: "player", wantErased: "2"},
+		{name: "erased again", id: "player", again: true, wantErased: "2"},
+		{name: "merged ID", id: "duplicate", wantErr: domain.ErrIdentityMerged},
+		{name: "unknown identity", id: "unknown", wantErr: domain.ErrIdentityNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(t, Policies{})
+			player, session, token := env.playerSession(t, "player-1")
+			duplicate, mergedSession, mergedToken := env.playerSession(t, "player-2")
+			if _, err := env.identities.Merge(ctx, player.PlatformUserID, duplicate.PlatformUserID, env.now); err != nil {
+				t.Fatalf("store merge: %v", err)
+			}
+			// The note is free text with personal data in it.
+			active := domain.IdentityStatus{State: domain.IdentityActive}
+			if _, err := env.svc.SetStatus(ctx, player.PlatformUserID, active, "backoffice", "asked by jane@example.com"); err != nil {
+				t.Fatalf("set status: %v", err)
+			}
+			ids := map[string]string{
+				"player":    player.PlatformUserID,
+				"duplicate": duplicate.PlatformUserID,
+				"unknown":   "00000000-0000-0000-0000-999999999999",
+			}
+
+			env.now = env.now.Add(time.Minute)
+			erasure, err := env.svc.Erase(ctx, ids[tt.id], "backoffice", "erasure request")
+			if tt.again && err == nil {
+				env.now = env.now.Add(time.Minute)
+				erasure, err = env.svc.Erase(ctx, ids[tt.id], "backoffice", "erasure request")
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Erase error = %v, want %v", err, tt.wantErr)
+			}
+			entries, err := env.audit.ListByUser(ctx, player.PlatformUserID)
+			if err != nil {
+				t.Fatalf("list audit: %v", err)
+			}
+			if tt.wantErr != nil {
+				if len(entries) != 1 || entries[0].Details["note"] != "asked by jane@example.com" {
+					t.Errorf("refused erasure changed the audit log to %+v", entries)
+				}
+				return
+			}
+
+			if erasure.PlatformUserID != player.PlatformUserID || !slices.Equal(erasure.MergedUserIDs, []string{duplicate.PlatformUserID}) {
+				t.Errorf("erasure = %+v, want %s with merged %s", erasure, player.PlatformUserID, duplicate.PlatformUserID)
+			}
+			for _, id := range []string{player.PlatformUserID, duplicate.PlatformUserID} {
+				if _, err := env.identities.GetByPlatformUserID(ctx, id); !errors.Is(err, domain.ErrIdentityErased) {
+					t.Errorf("lookup of %s after erasure = %v, want %v", id, err, domain.ErrIdentityErased)
+				}
+			}
+			for _, s := range []struct{ id, token string }{{session.ID, token}, {mergedSession.ID, mergedToken}} {
+				got, err := env.sessions.Get(ctx, s.id)
+				if err != nil {
+					t.Fatalf("get session: %v", err)
+				}
+				if got.RevokeReason != revokeReasonIdentityErased {
+					t.Errorf("session %s revoke reason = %q, want %q", s.id, got.RevokeReason, revokeReasonIdentityErased)
+				}
+				refresh, _ := env.refreshTokens.Consume(ctx, hashRefreshToken(s.token), env.now)
+				if refresh.RevokedAt.IsZero() {
+					t.Errorf("refresh token family of session %s was not revoked", s.id)
+				}
+			}
+			if note := entries[0].Details["note"]; note != domain.AuditRedacted {
+				t.Errorf("note of the earlier audit entry = %q, want it redacted", note)
+			}
+			last := entries[len(entries)-1]
+			if last.Action != domain.AuditIdentityErased || last.Actor != "backoffice" || last.Details["erased_user_ids"] != tt.wantErased {
+				t.Errorf("last audit entry = %+v, want the erasure of %s IDs", last, tt.wantErased)
+			}
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		name string
+		// merge is the number of duplicates merged into the exported identity.
+		merge      int
+		erase      bool
+		wantErr    error
+		wantMerged int
+	}{
+		{name: "identity"},
+		{name: "survivor of merges", merge: 2, wantMerged: 2},
+		{name: "erased identity", erase: true, wantErr: domain.ErrIdentityErased},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(t, Policies{})
+			player, _, _ := env.playerSession(t, "player-1")
+			for i := range tt.merge {
+				duplicate, _, _ := env.playerSession(t, fmt.Sprintf("duplicate-%d", i))
+				if _, err := env.svc.Merge(ctx, player.PlatformUserID, duplicate.PlatformUserID, "backoffice", ""); err != nil {
+					t.Fatalf("merge: %v", err)
+				}
+			}
+			if tt.erase {
+				if _, err := env.svc.Erase(ctx, player.PlatformUserID, "backoffice", ""); err != nil {
+					t.Fatalf("erase: %v", err)
+				}
+			}
+
+			export, err := env.svc.Export(ctx, player.PlatformUserID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Export error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if export.Identity.PlatformUserID != player.PlatformUserID || len(export.MergedUserIDs) != tt.wantMerged {
+				t.Errorf("export of %s with merged %v, want %s with %d merged IDs",
+					export.Identity.PlatformUserID, export.MergedUserIDs, player.PlatformUserID, tt.wantMerged)
+			}
+			if len(export.Sessions) != 1+tt.wantMerged || len(export.Audit) != tt.wantMerged {
+				t.Errorf("export holds %d sessions and %d audit entries, want %d and %d",
+					len(export.Sessions), len(export.Audit), 1+tt.wantMerged, tt.wantMerged)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// Link attaches an external identity to an existing platform identity, so
+// exchanging an assertion for it resolves to that user. An empty tenant
+// defaults to the identity's; any other tenant returns
+// domain.ErrTenantMismatch. The provider must be registered
+// (domain.ErrProviderNotFound) and allow the tenant
+// (domain.ErrTenantNotAllowed). An external identity linked to another
+// user returns domain.ErrLinkageConflict; one already linked to this user
+// is left as it is.
+func (s *Service) Link(ctx context.Context, platformUserID, provider, externalUserID, tenant string) (*domain.PlatformIdentity, error) {
+	identity, err := s.lookup.GetByPlatformUserID(ctx, platformUserID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == "" {
+		tenant = identity.Tenant
+	}
+	if tenant != identity.Tenant {
+		return nil, domain.ErrTenantMismatch
+	}
+	linkage := domain.Linkage{
+		Provider:       provider,
+		ExternalUserID: externalUserID,
+		Tenant:         tenant,
+		LinkedAt:       s.now(),
+	}
+	if err := linkage.Validate(); err != nil {
+		return nil, err
+	}
+	p, err := s.providers.Get(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	if !p.AllowsTenant(tenant) {
+		return nil, domain.ErrTenantNotAllowed
+	}
+
+	linked, err := s.linker.Link(ctx, platformUserID, linkage)
+	if err != nil {
+		return nil, err
+	}
+	return &linked, nil
+}
+
+// Unlink detaches an external identity from a platform identity; its next
+// exchange creates a new user. The last linkage cannot be removed
+// (domain.ErrLastLinkage). Sessions of the user are left alone.
+func (s *Service) Unlink(ctx context.Context, platformUserID, provider, externalUserID string) (*domain.PlatformIdentity, error) {
+	if err := (domain.Linkage{Provider: provider, ExternalUserID: externalUserID}).Validate(); err != nil {
+		return nil, err
+	}
+	identity, err := s.linker.Unlink(ctx, platformUserID, provider, externalUserID)
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
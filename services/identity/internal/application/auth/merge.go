@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// revokeReasonIdentityMerged is recorded on the sessions of a merged user.
+const revokeReasonIdentityMerged = "identity_merged"
+
+// Merge folds the duplicate identity mergedID into survivorID: its
+// linkages move to the survivor, its platform user ID resolves to the
+// survivor from then on, its sessions and refresh tokens are revoked and
+// the merge is recorded in the survivor's audit log on behalf of actor.
+// Both identities must belong to the same tenant
+// (domain.ErrMergeTenantMismatch) and the merged one must be active
+// (domain.ErrMergeInactive); the survivor keeps its own status, so the
+// moved linkages share a suspension or ban of the survivor. Merging an
+// identity into itself returns domain.ErrMergeSelf and naming one merged
+// into another survivor domain.ErrIdentityMerged.
+//
+// Merging an ID that already resolves to the survivor repeats the
+// revocations and the audit entry, so a call that failed after the store
+// merge can be retried.
+func (s *Service) Merge(ctx context.Context, survivorID, mergedID, actor, reason string) (*domain.PlatformIdentity, error) {
+	if survivorID == mergedID {
+		return nil, domain.ErrMergeSelf
+	}
+	survivor, err := s.live(ctx, survivorID)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := s.lookup.GetByPlatformUserID(ctx, mergedID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	identity := survivor
+	details := map[string]string{"merged_platform_user_id": mergedID}
+	retry := merged.PlatformUserID == survivor.PlatformUserID
+	if !retry {
+		if merged.PlatformUserID != mergedID {
+			return nil, domain.ErrIdentityMerged
+		}
+		if survivor.Tenant != merged.Tenant {
+			return nil, domain.ErrMergeTenantMismatch
+		}
+		if !merged.Status.ActiveAt(now) {
+			return nil, domain.ErrMergeInactive
+		}
+		if identity, err = s.merger.Merge(ctx, survivor.PlatformUserID, mergedID, now); err != nil {
+			return nil, err
+		}
+		details["moved_linkages"] = strconv.Itoa(len(merged.Linkages))
+	}
+
+	revoked, err := s.sessions.RevokeAllForUser(ctx, mergedID, revokeReasonIdentityMerged, now)
+	if err != nil {
+		return nil, err
+	}
+	families := revoked
+	if retry {
+		// The failed call may have revoked sessions but not all of their
+		// refresh token families.
+		if families, err = s.sessions.ListByUser(ctx, mergedID); err != nil {
+			return nil, err
+		}
+	}
+	for _, session := range families {
+		if err := s.refreshTokens.RevokeFamily(ctx, session.ID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	entryID, err := newAuditEntryID()
+	if err != nil {
+		return nil, err
+	}
+	details["revoked_sessions"] = strconv.Itoa(len(revoked))
+	if reason != "" {
+		details["reason"] = reason
+	}
+	err = s.audit.Append(ctx, domain.AuditEntry{
+		ID:             entryID,
+		PlatformUserID: identity.PlatformUserID,
+		Action:         domain.AuditIdentityMerged,
+		Actor:          actor,
+		Details:        details,
+		At:             now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// live looks up an identity that has not been merged into another.
+func (s *Service) live(ctx context.Context, platformUserID string) (domain.PlatformIdentity, error) {
+	identity, err := s.lookup.GetByPlatformUserID(ctx, platformUserID)
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	if identity.PlatformUserID != platformUserID {
+		return domain.PlatformIdentity{}, domain.ErrIdentityMerged
+	}
+	return identity, nil
+}
+
+// newAuditEntryID returns a random audit entry identifier.
+func newAuditEntryID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
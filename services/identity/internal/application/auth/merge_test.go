@@ -0,0 +1,256 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+func TestMerge(t *testing.T) {
+	// The identities are named: "survivor" and "duplicate" are players of
+	// testTenant with a session each, "foreign" belongs to another tenant,
+	// "absorbed" was merged into a third identity and "unknown" does not
+	// exist.
+	tests := []struct {
+		name     string
+		survivor string
+		merged   string
+		wantErr  error
+	}{
+		{name: "duplicate into survivor", survivor: "survivor", merged: "duplicate"},
+		{name: "into itself", survivor: "survivor", merged: "survivor", wantErr: domain.ErrMergeSelf},
+		{name: "unknown survivor", survivor: "unknown", merged: "duplicate", wantErr: domain.ErrIdentityNotFound},
+		{name: "unknown duplicate", survivor: "survivor", merged: "unknown", wantErr: domain.ErrIdentityNotFound},
+		{name: "other tenant", survivor: "survivor", merged: "foreign", wantErr: domain.ErrMergeTenantMismatch},
+		{name: "survivor merged into another", survivor: "absorbed", merged: "duplicate", wantErr: domain.ErrIdentityMerged},
+		{name: "duplicate merged into another", survivor: "survivor", merged: "absorbed", wantErr: domain.ErrIdentityMerged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(t, Policies{})
+			survivor, survivorSession, _ := env.playerSession(t, "player-1")
+			duplicate, session, token := env.playerSession(t, "player-2")
+			foreign, err := env.identities.Resolve(ctx, testProvider, "player-foreign", "tenant-b")
+			if err != nil {
+				t.Fatalf("resolve identity: %v", err)
+			}
+			absorbed, _, _ := env.playerSession(t, "player-3")
+			other, _, _ := env.playerSession(t, "player-4")
+			if _, err := env.identities.Merge(ctx, other.PlatformUserID, absorbed.PlatformUserID, env.now); err != nil {
+				t.Fatalf("store merge: %v", err)
+			}
+			ids := map[string]string{
+				"survivor":  survivor.PlatformUserID,
+				"duplicate": duplicate.PlatformUserID,
+				"foreign":   foreign.PlatformUserID,
+				"absorbed":  absorbed.PlatformUserID,
+				"unknown":   "00000000-0000-0000-0000-999999999999",
+			}
+
+			env.now = env.now.Add(time.Minute)
+			identity, err := env.svc.Merge(ctx, ids[tt.survivor], ids[tt.merged], "backoffice", "duplicate account")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Merge error = %v, want %v", err, tt.wantErr)
+			}
+
+			got, err := env.sessions.Get(ctx, session.ID)
+			if err != nil {
+				t.Fatalf("get session: %v", err)
+			}
+			entries, err := env.audit.ListByUser(ctx, survivor.PlatformUserID)
+			if err != nil {
+				t.Fatalf("list audit: %v", err)
+			}
+			if tt.wantErr != nil {
+				if !got.RevokedAt.IsZero() || len(entries) != 0 {
+					t.Errorf("refused merge revoked the session at %v and wrote audit entries %+v", got.RevokedAt, entries)
+				}
+				return
+			}
+
+			if identity.PlatformUserID != survivor.PlatformUserID || len(identity.Linkages) != 2 {
+				t.Errorf("Merge returned %s with %d linkages, want the survivor with 2", identity.PlatformUserID, len(identity.Linkages))
+			}
+			if got.RevokeReason != revokeReasonIdentityMerged {
+				t.Errorf("session revoke reason = %q, want %q", got.RevokeReason, revokeReasonIdentityMerged)
+			}
+			refresh, _ := env.refreshTokens.Consume(ctx, hashRefreshToken(token), env.now)
+			if refresh.RevokedAt.IsZero() {
+				t.Error("refresh token family of the merged user was not revoked")
+			}
+			kept, err := env.sessions.Get(ctx, survivorSession.ID)
+			if err != nil {
+				t.Fatalf("get session: %v", err)
+			}
+			if !kept.RevokedAt.IsZero() {
+				t.Error("session of the survivor was revoked")
+			}
+			want := map[string]string{
+				"merged_platform_user_id": duplicate.PlatformUserID,
+				"moved_linkages":          "1",
+				"revoked_sessions":        "1",
+				"reason":                  "duplicate account",
+			}
+			if len(entries) != 1 || entries[0].Action != domain.AuditIdentityMerged || entries[0].Actor != "backoffice" ||
+				!maps.Equal(entries[0].Details, want) {
+				t.Errorf("audit entries = %+v, want the merge with %v", entries, want)
+			}
+		})
+	}
+}
+
+func TestMergeStatus(t *testing.T) {
+	suspended := func(until time.Duration) domain.IdentityStatus {
+		return domain.IdentityStatus{
+			State:     domain.IdentitySuspended,
+			Reason:    domain.ReasonAbuse,
+			Until:     testStart.Add(until),
+			ChangedAt: testStart,
+		}
+	}
+	banned := domain.IdentityStatus{State: domain.IdentityBanned, Reason: domain.ReasonFraud, ChangedAt: testStart}
+
+	tests := []struct {
+		name           string
+		survivorStatus domain.IdentityStatus
+		mergedStatus   domain.IdentityStatus
+		wantErr        error
+	}{
+		{name: "both active"},
+		{name: "banned duplicate", mergedStatus: banned, wantErr: domain.ErrMergeInactive},
+		{name: "suspended duplicate", mergedStatus: suspended(time.Hour), wantErr: domain.ErrMergeInactive},
+		{name: "suspension of the duplicate ended", mergedStatus: suspended(time.Minute)},
+		{name: "banned survivor", survivorStatus: banned},
+		{name: "suspended survivor", survivorStatus: suspended(time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(t, Policies{})
+			survivor, _, _ := env.playerSession(t, "player-1")
+			duplicate, session, _ := env.playerSession(t, "player-2")
+			for id, status := range map[string]domain.IdentityStatus{
+				survivor.PlatformUserID:  tt.survivorStatus,
+				duplicate.PlatformUserID: tt.mergedStatus,
+			} {
+				if status.State == "" {
+					continue
+				}
+				if _, err := env.identities.SetStatus(ctx, id, status); err != nil {
+					t.Fatalf("set status: %v", err)
+				}
+			}
+			env.now = env.now.Add(10 * time.Minute)
+
+			_, err := env.svc.Merge(ctx, survivor.PlatformUserID, duplicate.PlatformUserID, "backoffice", "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Merge error = %v, want %v", err, tt.wantErr)
+			}
+
+			found, err := env.identities.GetByPlatformUserID(ctx, duplicate.PlatformUserID)
+			if err != nil {
+				t.Fatalf("lookup of the duplicate: %v", err)
+			}
+			got, err := env.sessions.Get(ctx, session.ID)
+			if err != nil {
+				t.Fatalf("get session: %v", err)
+			}
+			if tt.wantErr != nil {
+				if found.PlatformUserID != duplicate.PlatformUserID || !got.RevokedAt.IsZero() {
+					t.Errorf("refused merge changed the duplicate: resolves to %s, session revoked at %v", found.PlatformUserID, got.RevokedAt)
+				}
+				return
+			}
+			if found.PlatformUserID != survivor.PlatformUserID || found.Status != tt.survivorStatus {
+				t.Errorf("duplicate resolves to %s with status %+v, want %s with %+v",
+					found.PlatformUserID, found.Status, survivor.PlatformUserID, tt.survivorStatus)
+			}
+		})
+	}
+}
+
+func TestMergeRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		// failed leaves what a merge that failed half-way left behind.
+		failed  func(t *testing.T, env *testEnv, survivor, duplicate domain.PlatformIdentity)
+		wantErr error
+	}{
+		{
+			name: "failed after the store merge",
+			failed: func(t *testing.T, env *testEnv, survivor, duplicate domain.PlatformIdentity) {
+				if _, err := env.identities.Merge(context.Background(), survivor.PlatformUserID, duplicate.PlatformUserID, env.now); err != nil {
+					t.Fatalf("store merge: %v", err)
+				}
+			},
+		},
+		{
+			name: "failed after revoking the sessions",
+			failed: func(t *testing.T, env *testEnv, survivor, duplicate domain.PlatformIdentity) {
+				ctx := context.Background()
+				if _, err := env.identities.Merge(ctx, survivor.PlatformUserID, duplicate.PlatformUserID, env.now); err != nil {
+					t.Fatalf("store merge: %v", err)
+				}
+				if _, err := env.sessions.RevokeAllForUser(ctx, duplicate.PlatformUserID, revokeReasonIdentityMerged, env.now); err != nil {
+					t.Fatalf("revoke sessions: %v", err)
+				}
+			},
+		},
+		{
+			name: "merged into another identity",
+			failed: func(t *testing.T, env *testEnv, _, duplicate domain.PlatformIdentity) {
+				other, _, _ := env.playerSession(t, "player-3")
+				if _, err := env.identities.Merge(context.Background(), other.PlatformUserID, duplicate.PlatformUserID, env.now); err != nil {
+					t.Fatalf("store merge: %v", err)
+				}
+			},
+			wantErr: domain.ErrIdentityMerged,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(t, Policies{})
+			survivor, _, _ := env.playerSession(t, "player-1")
+			duplicate, session, token := env.playerSession(t, "player-2")
+			tt.failed(t, env, survivor, duplicate)
+
+			env.now = env.now.Add(time.Minute)
+			identity, err := env.svc.Merge(ctx, survivor.PlatformUserID, duplicate.PlatformUserID, "backoffice", "duplicate account")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Merge error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if identity.PlatformUserID != survivor.PlatformUserID {
+				t.Errorf("Merge returned %s, want the survivor %s", identity.PlatformUserID, survivor.PlatformUserID)
+			}
+
+			got, err := env.sessions.Get(ctx, session.ID)
+			if err != nil {
+				t.Fatalf("get session: %v", err)
+			}
+			if got.RevokeReason != revokeReasonIdentityMerged {
+				t.Errorf("session revoke reason = %q, want %q", got.RevokeReason, revokeReasonIdentityMerged)
+			}
+			refresh, _ := env.refreshTokens.Consume(ctx, hashRefreshToken(token), env.now)
+			if refresh.RevokedAt.IsZero() {
+				t.Error("refresh token family of the merged user was not revoked")
+			}
+			entries, err := env.audit.ListByUser(ctx, survivor.PlatformUserID)
+			if err != nil {
+				t.Fatalf("list audit: %v", err)
+			}
+			if len(entries) != 1 || entries[0].Action != domain.AuditIdentityMerged ||
+				entries[0].Details["merged_platform_user_id"] != duplicate.PlatformUserID {
+				t.Errorf("audit entries = %+v, want the merge", entries)
+			}
+		})
+	}
+}
@@ -2,72 +2,405 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
 	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
 )
 
-const accessTokenTTL = 10 * time.Minute
-const backofficeTokenTTL = 10 * time.Minute
+// Session revoke reasons recorded by the auth service.
+const revokeReasonRefreshTokenReused = "refresh_token_reused"
+
+// Policies are the configurable rules the auth service applies.
+type Policies struct {
+	Scopes     domain.ScopePolicy
+	TTL        domain.TTLPolicy
+	Assertions domain.AssertionPolicy
+	Delegation domain.DelegationPolicy
+}
+
+// Deps are the ports the auth service works with. The identity ports are
+// usually all served by the one configured identity store.
+type Deps struct {
+	Resolver      interfaces.IdentityResolver
+	Linker        interfaces.IdentityLinker
+	Merger        interfaces.IdentityMerger
+	Eraser        interfaces.IdentityEraser
+	Profiles      interfaces.IdentityProfiles
+	Statuses      interfaces.IdentityStatuses
+	Lookup        interfaces.IdentityLookup
+	Audit         interfaces.AuditLog
+	Outbox        interfaces.Outbox
+	Issuer        interfaces.TokenIssuer
+	Verifier      interfaces.TokenVerifier
+	RefreshTokens interfaces.RefreshTokenStore
+	Sessions      interfaces.SessionStore
+	Providers     interfaces.ProviderStore
+	Assertions    interfaces.AssertionVerifier
+	Replay        interfaces.AssertionReplayCache
+}
 
 // Service implements the auth exchange use case.
 type Service struct {
-	resolver interfaces.IdentityResolver
-	lookup   interfaces.IdentityLookup
-	issuer   interfaces.TokenIssuer
+	resolver      interfaces.IdentityResolver
+	linker        interfaces.IdentityLinker
+	merger        interfaces.IdentityMerger
+	eraser        interfaces.IdentityEraser
+	profiles      interfaces.IdentityProfiles
+	statuses      interfaces.IdentityStatuses
+	lookup        interfaces.IdentityLookup
+	audit         interfaces.AuditLog
+	outbox        interfaces.Outbox
+	issuer        interfaces.TokenIssuer
+	verifier      interfaces.TokenVerifier
+	refreshTokens interfaces.RefreshTokenStore
+	sessions      interfaces.SessionStore
+	providers     interfaces.ProviderStore
+	assertions    interfaces.AssertionVerifier
+	replay        interfaces.AssertionReplayCache
+	scopes        domain.ScopePolicy
+	ttl           domain.TTLPolicy
+	assertionRule domain.AssertionPolicy
+	delegation    domain.DelegationPolicy
+	now           func() time.Time
 }
 
 // NewService creates an auth service with the given dependencies.
-func NewService(
-	resolver interfaces.IdentityResolver,
-	lookup interfaces.IdentityLookup,
-	issuer interfaces.TokenIssuer,
-) *Service {
+func NewService(deps Deps, policies Policies) *Service {
 	return &Service{
-		resolver: resolver,
-		lookup:   lookup,
-		issuer:   issuer,
+		resolver:      deps.Resolver,
+		linker:        deps.Linker,
+		merger:        deps.Merger,
+		eraser:        deps.Eraser,
+		profiles:      deps.Profiles,
+		statuses:      deps.Statuses,
+		lookup:        deps.Lookup,
+		audit:         deps.Audit,
+		outbox:        deps.Outbox,
+		issuer:        deps.Issuer,
+		verifier:      deps.Verifier,
+		refreshTokens: deps.RefreshTokens,
+		sessions:      deps.Sessions,
+		providers:     deps.Providers,
+		assertions:    deps.Assertions,
+		replay:        deps.Replay,
+		scopes:        policies.Scopes,
+		ttl:           policies.TTL,
+		assertionRule: policies.Assertions,
+		delegation:    policies.Delegation,
+		now:           time.Now,
 	}
 }
 
-// Exchange processes an external identity assertion from a customer backend.
-// It resolves or creates the platform identity and issues a short-lived access JWT.
-func (s *Service) Exchange(ctx context.Context, provider, externalUserID, tenant string) (*domain.TokenResult, error) {
-	if provider == "" || externalUserID == "" {
+// Exchange processes a signed identity assertion from a customer backend.
+// The assertion must verify against a key of its registered provider,
+// satisfy the assertion policy and not have been used before; otherwise an
+// error wrapping domain.ErrInvalidAssertion is returned. A disabled
+// provider returns domain.ErrProviderDisabled and a tenant outside the
+// provider's allowed tenants domain.ErrTenantNotAllowed. It then resolves
+// or creates the platform identity; an external identity linked to an
+// identity of another tenant returns domain.ErrTenantMismatch, and a
+// suspended or banned identity domain.ErrIdentitySuspended or
+// domain.ErrIdentityBanned. It starts a new session and issues a
+// short-lived access JWT together with the session's first refresh token,
+// with lifetimes from the provider's token settings. The session carries
+// the requested scopes, or every scope the policy grants when none are
+// requested; requesting an ungranted scope returns domain.ErrInvalidScope.
+// Profile values supplied with the exchange are recorded as of the
+// assertion's iat with source exchange; malformed ones return an error
+// wrapping domain.ErrInvalidProfile. The provider's profile claims are
+// copied into the access token. Every access token issued by the service
+// is announced with a token.issued event.
+func (s *Service) Exchange(ctx context.Context, rawAssertion string, requestedScopes []string, profile map[domain.ProfileField]string) (*domain.TokenResult, error) {
+	if rawAssertion == "" {
 		return nil, domain.ErrInvalidAssertion
 	}
+	now := s.now()
+
+	assertion, err := s.assertions.Verify(ctx, rawAssertion)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.assertionRule.Check(assertion, now); err != nil {
+		return nil, err
+	}
+	update, err := domain.ProfileUpdate{
+		Source: domain.ProfileSourceExchange,
+		At:     assertion.IssuedAt,
+		Set:    profile,
+	}.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	provider, err := s.providers.Get(ctx, assertion.Provider)
+	if err != nil {
+		if errors.Is(err, domain.ErrProviderNotFound) {
+			return nil, domain.ErrUnknownProvider
+		}
+		return nil, err
+	}
+	if !provider.Active() {
+		return nil, domain.ErrProviderDisabled
+	}
+	if !provider.AllowsTenant(assertion.Tenant) {
+		return nil, domain.ErrTenantNotAllowed
+	}
+	if err := s.replay.MarkUsed(ctx, assertion.Provider, assertion.ID, assertion.ExpiresAt.Add(s.assertionRule.Leeway)); err != nil {
+		return nil, err
+	}
 
-	identity, err := s.resolver.Resolve(ctx, provider, externalUserID, tenant)
+	identity, err := s.resolver.Resolve(ctx, assertion.Provider, assertion.ExternalUserID, assertion.Tenant)
 	if err != nil {
 		return nil, err
 	}
+	if identity.Tenant != assertion.Tenant {
+		return nil, domain.ErrTenantMismatch
+	}
+	if err := identity.Status.Check(now); err != nil {
+		return nil, err
+	}
+	if !update.Empty() {
+		if identity, err = s.profiles.UpdateProfile(ctx, identity.PlatformUserID, update); err != nil {
+			return nil, err
+		}
+	}
 
-	accessToken, err := s.issuer.Issue(ctx, identity.PlatformUserID, identity.Tenant, accessTokenTTL)
+	scopes, err := domain.SelectScopes(s.scopes.Granted(assertion.Provider, identity.Tenant, domain.SubjectTypePlayer), requestedScopes)
 	if err != nil {
 		return nil, err
 	}
 
-	return &domain.TokenResult{
-		AccessToken:    accessToken,
+	ttl := s.ttl.ForProvider(provider.Tokens)
+	session, err := s.startSession(ctx, domain.Session{
 		PlatformUserID: identity.PlatformUserID,
-		ExpiresIn:      int32(accessTokenTTL.Seconds()),
+		SubjectType:    domain.SubjectTypePlayer,
+		Tenant:         identity.Tenant,
+		Provider:       provider.ID,
+		Scopes:         scopes,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(ttl.Refresh),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.issuePlayerTokens(ctx, session, domain.GrantExchange, ttl, identity.Profile.Claims(provider.Tokens.ProfileClaims), now)
+}
+
+// Refresh exchanges a refresh token for a new access token and a rotated
+// refresh token. Presenting a token that was already rotated revokes its
+// whole family and session and returns domain.ErrRefreshTokenReused.
+// Sessions of providers that were deleted or disabled, or no longer allow
+// the session's tenant, and sessions of identities that are not active
+// cannot be refreshed.
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (*domain.TokenResult, error) {
+	if refreshToken == "" {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	now := s.now()
+
+	stored, err := s.refreshTokens.Consume(ctx, hashRefreshToken(refreshToken), now)
+	if errors.Is(err, domain.ErrRefreshTokenReused) {
+		if err := s.refreshTokens.RevokeFamily(ctx, stored.SessionID, now); err != nil {
+			return nil, err
+		}
+		if _, err := s.sessions.Revoke(ctx, stored.SessionID, revokeReasonRefreshTokenReused, now); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrRefreshTokenReused
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !stored.Usable(now) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	session, err := s.sessions.Get(ctx, stored.SessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	if !session.Active(now) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	provider, err := s.providers.Get(ctx, session.Provider)
+	if err != nil {
+		if errors.Is(err, domain.ErrProviderNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	if !provider.Active() || !provider.AllowsTenant(session.Tenant) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	identity, err := s.lookup.GetByPlatformUserID(ctx, stored.PlatformUserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrIdentityNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	if !identity.Status.ActiveAt(now) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	ttl := s.ttl.ForProvider(provider.Tokens)
+	if err := s.sessions.Extend(ctx, session.ID, now.Add(ttl.Refresh)); err != nil {
+		return nil, err
+	}
+	return s.issuePlayerTokens(ctx, session, domain.GrantRefresh, ttl, identity.Profile.Claims(provider.Tokens.ProfileClaims), now)
+}
+
+func (s *Service) issuePlayerTokens(ctx context.Context, session domain.Session, grant string, ttl domain.TTLPolicy, profile map[string]any, now time.Time) (*domain.TokenResult, error) {
+	accessTTL := ttl.AccessTTL("", session.Tenant, domain.SubjectTypePlayer)
+	accessToken, err := s.issuer.Issue(ctx, domain.AccessTokenClaims{
+		Subject:   session.PlatformUserID,
+		Tenant:    session.Tenant,
+		SessionID: session.ID,
+		Scopes:    session.Scopes,
+		Profile:   profile,
+		TTL:       accessTTL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	err = s.refreshTokens.Save(ctx, domain.RefreshToken{
+		TokenHash:      hashRefreshToken(refreshToken),
+		SessionID:      session.ID,
+		PlatformUserID: session.PlatformUserID,
+		Tenant:         session.Tenant,
+		IssuedAt:       now,
+		ExpiresAt:      now.Add(ttl.Refresh),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tokenIssued(ctx, session, grant, s.ttl.PlayerAudience, nil, now, accessTTL); err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenResult{
+		AccessToken:      accessToken,
+		PlatformUserID:   session.PlatformUserID,
+		ExpiresIn:        int32(accessTTL.Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: int32(ttl.Refresh.Seconds()),
+		Scopes:           session.Scopes,
 	}, nil
 }
 
-// IssueBackofficeToken issues a backoffice access token for a known platform user.
-func (s *Service) IssueBackofficeToken(ctx context.Context, userID, subjectType, tenant, audience string) (*domain.TokenResult, error) {
-	accessToken, err := s.issuer.IssueBackoffice(ctx, userID, subjectType, tenant, audience, backofficeTokenTTL)
+// IssueBackofficeToken issues a backoffice access token for a known platform
+// user. Every backoffice login gets its own session so it can be revoked.
+// Scopes are selected as in Exchange, matching rules without a provider.
+// Users with a suspended or banned platform identity are refused with
+// domain.ErrIdentitySuspended or domain.ErrIdentityBanned and users whose
+// identity was erased with domain.ErrIdentityErased; users without one are
+// not checked.
+func (s *Service) IssueBackofficeToken(ctx context.Context, userID, subjectType, tenant, audience string, requestedScopes []string) (*domain.TokenResult, error) {
+	if audience == "" {
+		audience = "backoffice"
+	}
+	now := s.now()
+	identity, err := s.lookup.GetByPlatformUserID(ctx, userID)
+	switch {
+	case err == nil:
+		if err := identity.Status.Check(now); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, domain.ErrIdentityErased), !errors.Is(err, domain.ErrIdentityNotFound):
+		return nil, err
+	}
+	scopes, err := domain.SelectScopes(s.scopes.Granted("", tenant, subjectType), requestedScopes)
+	if err != nil {
+		return nil, err
+	}
+
+	accessTTL := s.ttl.AccessTTL(audience, tenant, subjectType)
+	session, err := s.startSession(ctx, domain.Session{
+		PlatformUserID: userID,
+		SubjectType:    subjectType,
+		Tenant:         tenant,
+		Scopes:         scopes,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(accessTTL),
+	})
 	if err != nil {
 		return nil, err
 	}
+	accessToken, err := s.issuer.Issue(ctx, domain.AccessTokenClaims{
+		Subject:     userID,
+		Tenant:      tenant,
+		SubjectType: subjectType,
+		Audience:    audience,
+		SessionID:   session.ID,
+		Scopes:      session.Scopes,
+		TTL:         accessTTL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tokenIssued(ctx, session, domain.GrantBackoffice, audience, nil, now, accessTTL); err != nil {
+		return nil, err
+	}
 	return &domain.TokenResult{
 		AccessToken:    accessToken,
 		PlatformUserID: userID,
-		ExpiresIn:      int32(backofficeTokenTTL.Seconds()),
+		ExpiresIn:      int32(accessTTL.Seconds()),
+		Scopes:         session.Scopes,
 	}, nil
 }
 
+// tokenIssued adds the token.issued event of an access token issued for
+// session at now to the outbox. actors is the act chain of a delegated
+// token.
+func (s *Service) tokenIssued(ctx context.Context, session domain.Session, grant, audience string, actors []string, now time.Time, accessTTL time.Duration) error {
+	eventID, err := newEventID()
+	if err != nil {
+		return err
+	}
+	return s.outbox.Add(ctx, domain.Event{
+		ID:             eventID,
+		Type:           domain.EventTokenIssued,
+		At:             now,
+		PlatformUserID: session.PlatformUserID,
+		Payload: domain.TokenIssued{
+			PlatformUserID: session.PlatformUserID,
+			Tenant:         session.Tenant,
+			SubjectType:    session.SubjectType,
+			Grant:          grant,
+			SessionID:      session.ID,
+			Audience:       audience,
+			Scopes:         session.Scopes,
+			Actors:         actors,
+			IssuedAt:       now,
+			ExpiresAt:      now.Add(accessTTL),
+		},
+	})
+}
+
+// startSession assigns a new ID to session and stores it.
+func (s *Service) startSession(ctx context.Context, session domain.Session) (domain.Session, error) {
+	id, err := newSessionID()
+	if err != nil {
+		return domain.Session{}, err
+	}
+	session.ID = id
+	if err := s.sessions.Create(ctx, session); err != nil {
+		return domain.Session{}, err
+	}
+	return session, nil
+}
+
 // GetIdentity retrieves a platform identity by platform user ID.
 func (s *Service) GetIdentity(ctx context.Context, platformUserID string) (*domain.PlatformIdentity, error) {
 	identity, err := s.lookup.GetByPlatformUserID(ctx, platformUserID)
@@ -76,3 +409,41 @@ func (s *Service) GetIdentity(ctx context.Context, platformUserID string) (*doma
 	}
 	return &identity, nil
 }
+
+// GetIdentityInTenant is GetIdentity for callers restricted to one tenant.
+// Identities of other tenants return domain.ErrIdentityNotFound, so their
+// existence is not revealed.
+func (s *Service) GetIdentityInTenant(ctx context.Context, platformUserID, tenant string) (*domain.PlatformIdentity, error) {
+	identity, err := s.GetIdentity(ctx, platformUserID)
+	if err != nil {
+		return nil, err
+	}
+	if identity.Tenant != tenant {
+		return nil, domain.ErrIdentityNotFound
+	}
+	return identity, nil
+}
+
+// FindIdentity retrieves the platform identity an external identity is
+// linked to. Unlike Exchange it never creates one.
+func (s *Service) FindIdentity(ctx context.Context, provider, externalUserID string) (*domain.PlatformIdentity, error) {
+	identity, err := s.lookup.GetByLinkage(ctx, provider, externalUserID)
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// FindIdentityInTenant is FindIdentity restricted to one tenant: an
+// external identity linked to an identity of another tenant returns
+// domain.ErrIdentityNotFound.
+func (s *Service) FindIdentityInTenant(ctx context.Context, provider, externalUserID, tenant string) (*domain.PlatformIdentity, error) {
+	identity, err := s.FindIdentity(ctx, provider, externalUserID)
+	if err != nil {
+		return nil, err
+	}
+	if identity.Tenant != tenant {
+		return nil, domain.ErrIdentityNotFound
+	}
+	return identity, nil
+}
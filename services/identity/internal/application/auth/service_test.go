@@ -0,0 +1,454 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	authadapter "github.com/woffVienna/proteon-cursor/services/identity/internal/adapters/auth"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+const (
+	testProvider = "provider-a"
+	testTenant   = "tenant-a"
+)
+
+var testStart = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// stubIssuer records the claims of every token it issues.
+type stubIssuer struct {
+	issued []domain.AccessTokenClaims
+}
+
+func (i *stubIssuer) Issue(_ context.Context, claims domain.AccessTokenClaims) (string, error) {
+	i.issued = append(i.issued, claims)
+	return fmt.Sprintf("access-token-%d", len(i.issued)), nil
+}
+
+// stubVerifier accepts the tokens it knows.
+type stubVerifier map[string]domain.TokenClaims
+
+func (v stubVerifier) Verify(_ context.Context, token string) (domain.TokenClaims, error) {
+	claims, ok := v[token]
+	if !ok {
+		return domain.TokenClaims{}, errors.New("unknown token")
+	}
+	return claims, nil
+}
+
+type testEnv struct {
+	svc           *Service
+	identities    *authadapter.MemoryIdentityStore
+	sessions      *authadapter.MemorySessionStore
+	refreshTokens *authadapter.MemoryRefreshTokenStore
+	audit         *authadapter.MemoryAuditLog
+	providers     *authadapter.MemoryProviderStore
+	issuer        *stubIssuer
+	verifier      stubVerifier
+	now           time.Time
+}
+
+func newTestEnv(t *testing.T, policies Policies) *testEnv {
+	t.Helper()
+	if policies.TTL.PlayerAccess == 0 {
+		policies.TTL = domain.TTLPolicy{
+			PlayerAudience:   "proteon-players",
+			PlayerAccess:     15 * time.Minute,
+			BackofficeAccess: 15 * time.Minute,
+			Refresh:          24 * time.Hour,
+		}
+	}
+	var n int
+	env := &testEnv{
+		identities: authadapter.NewMemoryIdentityStore(func() string {
+			n++
+			return fmt.Sprintf("00000000-0000-0000-0000-%012d", n)
+		}),
+		sessions:      authadapter.NewMemorySessionStore(policies.TTL.MaxAccessTTL()),
+		refreshTokens: authadapter.NewMemoryRefreshTokenStore(),
+		audit:         authadapter.NewMemoryAuditLog(),
+		providers:     authadapter.NewMemoryProviderStore(),
+		issuer:        &stubIssuer{},
+		verifier:      stubVerifier{},
+		now:           testStart,
+	}
+	if err := env.providers.Create(context.Background(), domain.Provider{
+		ID:        testProvider,
+		Status:    domain.ProviderActive,
+		CreatedAt: testStart,
+		UpdatedAt: testStart,
+	}); err != nil {
+		t.Fatalf("create provider: %v", err)
+	}
+	env.svc = NewService(Deps{
+		Resolver:      env.identities,
+		Linker:        env.identities,
+		Merger:        env.identities,
+		Eraser:        env.identities,
+		Profiles:      env.identities,
+		Statuses:      env.identities,
+		Lookup:        env.identities,
+		Audit:         env.audit,
+		Outbox:        env.identities,
+		Issuer:        env.issuer,
+		Verifier:      env.verifier,
+		RefreshTokens: env.refreshTokens,
+		Sessions:      env.sessions,
+		Providers:     env.providers,
+		Assertions:    authadapter.NewAssertionVerifier(env.providers),
+		Replay:        authadapter.NewMemoryReplayCache(),
+	}, policies)
+	env.svc.now = func() time.Time { return env.now }
+	return env
+}
+
+// playerSession creates a player identity with a session and its first
+// refresh token, and returns the identity, the session and the token.
+func (env *testEnv) playerSession(t *testing.T, externalUserID string) (domain.PlatformIdentity, domain.Session, string) {
+	t.Helper()
+	ctx := context.Background()
+	identity, err := env.identities.Resolve(ctx, testProvider, externalUserID, testTenant)
+	if err != nil {
+		t.Fatalf("resolve identity: %v", err)
+	}
+	session := domain.Session{
+		ID:             "session-" + externalUserID,
+		PlatformUserID: identity.PlatformUserID,
+		SubjectType:    domain.SubjectTypePlayer,
+		Tenant:         testTenant,
+		Provider:       testProvider,
+		Scopes:         []string{"profile:read"},
+		CreatedAt:      env.now,
+		ExpiresAt:      env.now.Add(env.svc.ttl.Refresh),
+	}
+	if err := env.sessions.Create(ctx, session); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	token := "refresh-" + externalUserID
+	if err := env.refreshTokens.Save(ctx, domain.RefreshToken{
+		TokenHash:      hashRefreshToken(token),
+		SessionID:      session.ID,
+		PlatformUserID: identity.PlatformUserID,
+		Tenant:         testTenant,
+		IssuedAt:       env.now,
+		ExpiresAt:      env.now.Add(env.svc.ttl.Refresh),
+	}); err != nil {
+		t.Fatalf("save refresh token: %v", err)
+	}
+	return identity, session, token
+}
+
+func TestExchange(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwk := domain.PublicJWK{
+		Kty: "OKP",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+	}
+	policies := Policies{Assertions: domain.AssertionPolicy{
+		Audience:    "proteon",
+		MaxLifetime: 5 * time.Minute,
+		Leeway:      30 * time.Second,
+	}}
+
+	tests := []struct {
+		name     string
+		provider func(p *domain.Provider)
+		claims   func(c jwt.MapClaims)
+		// replay exchanges the same assertion twice.
+		replay  bool
+		wantErr error
+	}{
+		{name: "valid assertion"},
+		{name: "replayed assertion", replay: true, wantErr: domain.ErrAssertionReplayed},
+		{
+			name:    "expired",
+			claims:  func(c jwt.MapClaims) { c["exp"] = testStart.Add(-time.Minute).Unix() },
+			wantErr: domain.ErrAssertionExpired,
+		},
+		{
+			name:    "lifetime over the maximum",
+			claims:  func(c jwt.MapClaims) { c["exp"] = testStart.Add(time.Hour).Unix() },
+			wantErr: domain.ErrAssertionLifetime,
+		},
+		{
+			name:    "other audience",
+			claims:  func(c jwt.MapClaims) { c["aud"] = "someone-else" },
+			wantErr: domain.ErrAssertionAudience,
+		},
+		{
+			name:    "missing jti",
+			claims:  func(c jwt.MapClaims) { delete(c, "jti") },
+			wantErr: domain.ErrAssertionClaims,
+		},
+		{
+			name:     "disabled provider",
+			provider: func(p *domain.Provider) { p.Status = domain.ProviderDisabled },
+			wantErr:  domain.ErrProviderDisabled,
+		},
+		{
+			name:     "tenant not allowed",
+			provider: func(p *domain.Provider) { p.AllowedTenants = []string{"tenant-b"} },
+			wantErr:  domain.ErrTenantNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, policies)
+			ctx := context.Background()
+			provider, err := env.providers.Get(ctx, testProvider)
+			if err != nil {
+				t.Fatalf("get provider: %v", err)
+			}
+			provider.AssertionKeys = []domain.PublicJWK{jwk}
+			if tt.provider != nil {
+				tt.provider(&provider)
+			}
+			if err := env.providers.Update(ctx, provider); err != nil {
+				t.Fatalf("update provider: %v", err)
+			}
+
+			claims := jwt.MapClaims{
+				"iss":    testProvider,
+				"sub":    "external-1",
+				"tenant": testTenant,
+				"aud":    "proteon",
+				"jti":    "assertion-1",
+				"iat":    testStart.Unix(),
+				"exp":    testStart.Add(time.Minute).Unix(),
+			}
+			if tt.claims != nil {
+				tt.claims(claims)
+			}
+			assertion, err := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims).SignedString(key)
+			if err != nil {
+				t.Fatalf("sign assertion: %v", err)
+			}
+
+			_, err = env.svc.Exchange(ctx, assertion, nil, nil)
+			if tt.replay {
+				if err != nil {
+					t.Fatalf("first Exchange: %v", err)
+				}
+				_, err = env.svc.Exchange(ctx, assertion, nil, nil)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Exchange error = %v, want %v", err, tt.wantErr)
+			}
+			// Only a successful exchange issues an access token; a replay
+			// issues none beyond the first.
+			wantIssued := 1
+			if tt.wantErr != nil && !tt.replay {
+				wantIssued = 0
+			}
+			if len(env.issuer.issued) != wantIssued {
+				t.Errorf("issued %d access tokens, want %d", len(env.issuer.issued), wantIssued)
+			}
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	// Each step presents a refresh token: "first" is the session's first
+	// token, "rotated" the one the last successful refresh returned.
+	type step struct {
+		present string
+		advance time.Duration
+		wantErr error
+	}
+	tests := []struct {
+		name string
+		// prepare runs after the session is created.
+		prepare     func(t *testing.T, env *testEnv, session domain.Session)
+		steps       []step
+		wantRevoked string
+	}{
+		{
+			name:  "rotates the token",
+			steps: []step{{present: "first"}, {present: "rotated"}, {present: "rotated"}},
+		},
+		{
+			name: "reusing a rotated token revokes the family and session",
+			steps: []step{
+				{present: "first"},
+				{present: "first", wantErr: domain.ErrRefreshTokenReused},
+				{present: "rotated", wantErr: domain.ErrInvalidRefreshToken},
+			},
+			wantRevoked: revokeReasonRefreshTokenReused,
+		},
+		{
+			name: "reuse after several rotations revokes the newest token",
+			steps: []step{
+				{present: "first"},
+				{present: "rotated"},
+				{present: "first", wantErr: domain.ErrRefreshTokenReused},
+				{present: "rotated", wantErr: domain.ErrInvalidRefreshToken},
+			},
+			wantRevoked: revokeReasonRefreshTokenReused,
+		},
+		{
+			name:  "unknown token",
+			steps: []step{{present: "unknown", wantErr: domain.ErrInvalidRefreshToken}},
+		},
+		{
+			name:  "expired token",
+			steps: []step{{present: "first", advance: 25 * time.Hour, wantErr: domain.ErrInvalidRefreshToken}},
+		},
+		{
+			name: "revoked session",
+			prepare: func(t *testing.T, env *testEnv, session domain.Session) {
+				if _, err := env.sessions.Revoke(context.Background(), session.ID, "logout", env.now); err != nil {
+					t.Fatalf("revoke session: %v", err)
+				}
+			},
+			steps:       []step{{present: "first", wantErr: domain.ErrInvalidRefreshToken}},
+			wantRevoked: "logout",
+		},
+		{
+			name: "banned identity",
+			prepare: func(t *testing.T, env *testEnv, session domain.Session) {
+				if _, err := env.identities.SetStatus(context.Background(), session.PlatformUserID, domain.IdentityStatus{
+					State:     domain.IdentityBanned,
+					ChangedAt: env.now,
+				}); err != nil {
+					t.Fatalf("ban identity: %v", err)
+				}
+			},
+			steps: []step{{present: "first", wantErr: domain.ErrInvalidRefreshToken}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(t, Policies{})
+			_, session, first := env.playerSession(t, "player-1")
+			if tt.prepare != nil {
+				tt.prepare(t, env, session)
+			}
+
+			var rotated string
+			for i, st := range tt.steps {
+				env.now = env.now.Add(time.Minute + st.advance)
+				token := st.present
+				switch token {
+				case "first":
+					token = first
+				case "rotated":
+					token = rotated
+				}
+				result, err := env.svc.Refresh(ctx, token)
+				if !errors.Is(err, st.wantErr) {
+					t.Fatalf("step %d: Refresh error = %v, want %v", i, err, st.wantErr)
+				}
+				if err != nil {
+					continue
+				}
+				if result.RefreshToken == "" || result.RefreshToken == token {
+					t.Fatalf("step %d: refresh token was not rotated", i)
+				}
+				if result.PlatformUserID != session.PlatformUserID {
+					t.Errorf("step %d: platform user %s, want %s", i, result.PlatformUserID, session.PlatformUserID)
+				}
+				rotated = result.RefreshToken
+			}
+
+			got, err := env.sessions.Get(ctx, session.ID)
+			if err != nil {
+				t.Fatalf("get session: %v", err)
+			}
+			if got.RevokeReason != tt.wantRevoked {
+				t.Errorf("session revoke reason = %q, want %q", got.RevokeReason, tt.wantRevoked)
+			}
+		})
+	}
+}
+
+func TestRefreshExtendsSession(t *testing.T) {
+	env := newTestEnv(t, Policies{})
+	_, session, token := env.playerSession(t, "player-1")
+
+	env.now = env.now.Add(time.Hour)
+	if _, err := env.svc.Refresh(context.Background(), token); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	got, err := env.sessions.Get(context.Background(), session.ID)
+	if err != nil {
+		t.Fatalf("get session: %v", err)
+	}
+	if want := env.now.Add(env.svc.ttl.Refresh); !got.ExpiresAt.Equal(want) {
+		t.Errorf("session expires at %v, want %v", got.ExpiresAt, want)
+	}
+	if n := len(env.issuer.issued); n != 1 || env.issuer.issued[0].SessionID != session.ID {
+		t.Errorf("issued %+v, want one token for the session", env.issuer.issued)
+	}
+}
+
+func TestIssueBackofficeToken(t *testing.T) {
+	tests := []struct {
+		name string
+		// identity prepares the user's platform identity and returns its
+		// ID.
+		identity func(t *testing.T, env *testEnv) string
+		wantErr  error
+	}{
+		{
+			name:     "user without an identity",
+			identity: func(*testing.T, *testEnv) string { return "00000000-0000-0000-0000-999999999999" },
+		},
+		{
+			name: "active identity",
+			identity: func(t *testing.T, env *testEnv) string {
+				identity, _, _ := env.playerSession(t, "operator-1")
+				return identity.PlatformUserID
+			},
+		},
+		{
+			name: "banned identity",
+			identity: func(t *testing.T, env *testEnv) string {
+				identity, _, _ := env.playerSession(t, "operator-1")
+				status := domain.IdentityStatus{State: domain.IdentityBanned, Reason: domain.ReasonFraud}
+				if _, err := env.svc.SetStatus(context.Background(), identity.PlatformUserID, status, "backoffice", ""); err != nil {
+					t.Fatalf("set status: %v", err)
+				}
+				return identity.PlatformUserID
+			},
+			wantErr: domain.ErrIdentityBanned,
+		},
+		{
+			name: "erased identity",
+			identity: func(t *testing.T, env *testEnv) string {
+				identity, _, _ := env.playerSession(t, "operator-1")
+				if _, err := env.svc.Erase(context.Background(), identity.PlatformUserID, "backoffice", ""); err != nil {
+					t.Fatalf("erase: %v", err)
+				}
+				return identity.PlatformUserID
+			},
+			wantErr: domain.ErrIdentityErased,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, Policies{})
+			id := tt.identity(t, env)
+
+			_, err := env.svc.IssueBackofficeToken(context.Background(), id, "operator", testTenant, "", nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("IssueBackofficeToken error = %v, want %v", err, tt.wantErr)
+			}
+			if issued := len(env.issuer.issued) == 1; issued != (tt.wantErr == nil) {
+				t.Errorf("issued %d access tokens", len(env.issuer.issued))
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// revokeReasonIdentityBlocked is recorded on the sessions of a user that
+// was suspended or banned.
+const revokeReasonIdentityBlocked = "identity_blocked"
+
+// SetStatus suspends, bans or reactivates a platform identity on behalf of
+// actor and records the change in its audit log. Suspending or banning
+// revokes the user's sessions and refresh tokens; the identity gets no
+// tokens until it is active again, which a suspension is from its until
+// on. Invalid statuses return an error wrapping domain.ErrInvalidStatus and
+// the ID of a merged identity domain.ErrIdentityMerged.
+func (s *Service) SetStatus(ctx context.Context, platformUserID string, status domain.IdentityStatus, actor, note string) (*domain.PlatformIdentity, error) {
+	now := s.now()
+	status.ChangedAt = now
+	if err := status.Validate(now); err != nil {
+		return nil, err
+	}
+	if _, err := s.live(ctx, platformUserID); err != nil {
+		return nil, err
+	}
+
+	identity, err := s.statuses.SetStatus(ctx, platformUserID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	var revoked []domain.Session
+	if status.State != domain.IdentityActive {
+		revoked, err = s.sessions.RevokeAllForUser(ctx, platformUserID, revokeReasonIdentityBlocked, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, session := range revoked {
+			if err := s.refreshTokens.RevokeFamily(ctx, session.ID, now); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	entryID, err := newAuditEntryID()
+	if err != nil {
+		return nil, err
+	}
+	details := map[string]string{
+		"state":            string(status.State),
+		"revoked_sessions": strconv.Itoa(len(revoked)),
+	}
+	if status.Reason != "" {
+		details["reason"] = string(status.Reason)
+	}
+	if !status.Until.IsZero() {
+		details["until"] = status.Until.UTC().Format(time.RFC3339)
+	}
+	if note != "" {
+		details["note"] = note
+	}
+	err = s.audit.Append(ctx, domain.AuditEntry{
+		ID:             entryID,
+		PlatformUserID: platformUserID,
+		Action:         domain.AuditIdentityStatusChanged,
+		Actor:          actor,
+		Details:        details,
+		At:             now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+func TestSetStatus(t *testing.T) {
+	suspension := domain.IdentityStatus{
+		State:  domain.IdentitySuspended,
+		Reason: domain.ReasonAbuse,
+		Until:  testStart.Add(time.Hour),
+	}
+	ban := domain.IdentityStatus{State: domain.IdentityBanned, Reason: domain.ReasonFraud}
+
+	tests := []struct {
+		name   string
+		status domain.IdentityStatus
+		// merged sets the status of an ID merged into another identity.
+		merged      bool
+		wantErr     error
+		wantRevoked bool
+		// wantRefresh is the error refreshing the player's token returns
+		// afterwards, at an hour and a minute after the change.
+		wantRefresh error
+	}{
+		{name: "suspension", status: suspension, wantRevoked: true, wantRefresh: domain.ErrInvalidRefreshToken},
+		{name: "ban", status: ban, wantRevoked: true, wantRefresh: domain.ErrInvalidRefreshToken},
+		{name: "reactivation", status: domain.IdentityStatus{State: domain.IdentityActive}},
+		{
+			name:    "suspension without until",
+			status:  domain.IdentityStatus{State: domain.IdentitySuspended, Reason: domain.ReasonAbuse},
+			wantErr: domain.ErrInvalidStatus,
+		},
+		{
+			name:    "ban without a reason",
+			status:  domain.IdentityStatus{State: domain.IdentityBanned},
+			wantErr: domain.ErrInvalidStatus,
+		},
+		{
+			name:    "unknown reason",
+			status:  domain.IdentityStatus{State: domain.IdentityBanned, Reason: "bored"},
+			wantErr: domain.ErrInvalidStatus,
+		},
+		{name: "merged ID", status: ban, merged: true, wantErr: domain.ErrIdentityMerged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(t, Policies{})
+			player, session, token := env.playerSession(t, "player-1")
+			id := player.PlatformUserID
+			if tt.merged {
+				duplicate, _, _ := env.playerSession(t, "player-2")
+				if _, err := env.svc.Merge(ctx, id, duplicate.PlatformUserID, "backoffice", ""); err != nil {
+					t.Fatalf("merge: %v", err)
+				}
+				id = duplicate.PlatformUserID
+			}
+
+			identity, err := env.svc.SetStatus(ctx, id, tt.status, "backoffice", "ticket 42")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetStatus error = %v, want %v", err, tt.wantErr)
+			}
+			got, err := env.sessions.Get(ctx, session.ID)
+			if err != nil {
+				t.Fatalf("get session: %v", err)
+			}
+			if tt.wantErr != nil {
+				if !got.RevokedAt.IsZero() {
+					t.Errorf("refused status change revoked the session at %v", got.RevokedAt)
+				}
+				return
+			}
+
+			if identity.Status.State != tt.status.State || !identity.Status.ChangedAt.Equal(testStart) {
+				t.Errorf("status = %+v, want %s changed at %v", identity.Status, tt.status.State, testStart)
+			}
+			if revoked := got.RevokeReason == revokeReasonIdentityBlocked; revoked != tt.wantRevoked {
+				t.Errorf("session revoke reason = %q, want revoked %v", got.RevokeReason, tt.wantRevoked)
+			}
+			entries, err := env.audit.ListByUser(ctx, id)
+			if err != nil {
+				t.Fatalf("list audit: %v", err)
+			}
+			last := entries[len(entries)-1]
+			if last.Action != domain.AuditIdentityStatusChanged || last.Details["state"] != string(tt.status.State) ||
+				last.Details["reason"] != string(tt.status.Reason) || last.Details["note"] != "ticket 42" {
+				t.Errorf("last audit entry = %+v, want the change to %s", last, tt.status.State)
+			}
+
+			env.now = env.now.Add(time.Hour + time.Minute)
+			if _, err := env.svc.Refresh(ctx, token); !errors.Is(err, tt.wantRefresh) {
+				t.Errorf("Refresh after the change = %v, want %v", err, tt.wantRefresh)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// ExchangeToken implements the RFC 8693 token exchange: an internal service
+// presents an access token it received and gets a token for another
+// audience to call that service on behalf of the token's subject. The
+// subject token must verify and carry a session, which must exist and not
+// be revoked or expired; otherwise domain.ErrInvalidSubjectToken is returned. The
+// delegation policy must allow the caller to act for the subject at the
+// audience, given the act chain the subject token already carries, or
+// domain.ErrDelegationNotAllowed is returned. The new token keeps the
+// subject, tenant, subject type and session of the subject token, carries
+// the requested scopes out of those the subject token and the policy
+// allow (domain.ErrInvalidScope for others), and names the caller in its
+// act claim, nesting the subject token's act claim. It never outlives the
+// subject token. Subjects with a suspended or banned platform identity are
+// refused as in IssueBackofficeToken.
+func (s *Service) ExchangeToken(ctx context.Context, req domain.TokenExchange) (*domain.TokenResult, error) {
+	if req.SubjectToken == "" {
+		return nil, domain.ErrInvalidSubjectToken
+	}
+	now := s.now()
+
+	subject, err := s.verifier.Verify(ctx, req.SubjectToken)
+	if err != nil {
+		return nil, domain.ErrInvalidSubjectToken
+	}
+	// Without a session the subject token could not be revoked.
+	if subject.SessionID == "" {
+		return nil, domain.ErrInvalidSubjectToken
+	}
+	subjectSession, err := s.sessions.Get(ctx, subject.SessionID)
+	if errors.Is(err, domain.ErrSessionNotFound) {
+		return nil, domain.ErrInvalidSubjectToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !subjectSession.Active(now) {
+		return nil, domain.ErrInvalidSubjectToken
+	}
+
+	allowed, err := s.delegation.Allowed(req.Actor, subject, req.Audience)
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := domain.SelectScopes(allowed, req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.lookup.GetByPlatformUserID(ctx, subject.Subject)
+	switch {
+	case err == nil:
+		if err := identity.Status.Check(now); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, domain.ErrIdentityNotFound):
+		return nil, err
+	}
+
+	accessTTL := s.ttl.AccessTTL(req.Audience, subject.Tenant, subject.SubjectType)
+	if remaining := subject.ExpiresAt.Sub(now).Truncate(time.Second); !subject.ExpiresAt.IsZero() && remaining < accessTTL {
+		accessTTL = remaining
+	}
+	if accessTTL < time.Second {
+		return nil, domain.ErrInvalidSubjectToken
+	}
+
+	actor := &domain.Actor{Subject: req.Actor, Actor: subject.Actor}
+	accessToken, err := s.issuer.Issue(ctx, domain.AccessTokenClaims{
+		Subject:     subject.Subject,
+		Tenant:      subject.Tenant,
+		SubjectType: subject.SubjectType,
+		Audience:    req.Audience,
+		SessionID:   subject.SessionID,
+		Scopes:      scopes,
+		Actor:       actor,
+		TTL:         accessTTL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	session := domain.Session{
+		ID:             subject.SessionID,
+		PlatformUserID: subject.Subject,
+		SubjectType:    subject.SubjectType,
+		Tenant:         subject.Tenant,
+		Scopes:         scopes,
+	}
+	if err := s.tokenIssued(ctx, session, domain.GrantTokenExchange, req.Audience, actor.Chain(), now, accessTTL); err != nil {
+		return nil, err
+	}
+	return &domain.TokenResult{
+		AccessToken:    accessToken,
+		PlatformUserID: subject.Subject,
+		ExpiresIn:      int32(accessTTL.Seconds()),
+		Scopes:         scopes,
+	}, nil
+}
@@ -0,0 +1,226 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+var testDelegation = domain.DelegationPolicy{
+	MaxActorChain: 2,
+	Rules: []domain.DelegationRule{
+		{Actor: "game-service", SubjectType: domain.SubjectTypePlayer, Audiences: []string{"wallet-service"}, Scopes: []string{"wallet:read"}},
+		{Actor: "wallet-service", Audiences: []string{"ledger-service"}, Via: []string{"game-service"}},
+		{Actor: "ledger-service", Audiences: []string{"audit-service"}, Via: []string{"wallet-service"}},
+	},
+}
+
+func TestExchangeToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		actor    string
+		audience string
+		scopes   []string
+		// claims adjusts the subject token's claims.
+		claims func(c *domain.TokenClaims)
+		// prepare runs after the subject's session is created.
+		prepare    func(t *testing.T, env *testEnv, session domain.Session)
+		token      string
+		wantErr    error
+		wantScopes []string
+		wantChain  []string
+	}{
+		{
+			name:       "allowed",
+			actor:      "game-service",
+			audience:   "wallet-service",
+			wantScopes: []string{"wallet:read"},
+			wantChain:  []string{"game-service"},
+		},
+		{
+			name:       "delegated token via an allowed actor",
+			actor:      "wallet-service",
+			audience:   "ledger-service",
+			claims:     func(c *domain.TokenClaims) { c.Actor = &domain.Actor{Subject: "game-service"} },
+			wantScopes: []string{"profile:read", "wallet:read"},
+			wantChain:  []string{"wallet-service", "game-service"},
+		},
+		{
+			name:     "no caller",
+			audience: "wallet-service",
+			wantErr:  domain.ErrDelegationNotAllowed,
+		},
+		{
+			name:     "caller without a rule",
+			actor:    "shop-service",
+			audience: "wallet-service",
+			wantErr:  domain.ErrDelegationNotAllowed,
+		},
+		{
+			name:     "audience not in the rule",
+			actor:    "game-service",
+			audience: "ledger-service",
+			wantErr:  domain.ErrDelegationNotAllowed,
+		},
+		{
+			name:     "subject type not in the rule",
+			actor:    "game-service",
+			audience: "wallet-service",
+			claims:   func(c *domain.TokenClaims) { c.SubjectType = "operator" },
+			wantErr:  domain.ErrDelegationNotAllowed,
+		},
+		{
+			name:     "delegated token without a via rule",
+			actor:    "game-service",
+			audience: "wallet-service",
+			claims:   func(c *domain.TokenClaims) { c.Actor = &domain.Actor{Subject: "shop-service"} },
+			wantErr:  domain.ErrDelegationNotAllowed,
+		},
+		{
+			name:     "delegated token via another actor",
+			actor:    "wallet-service",
+			audience: "ledger-service",
+			claims:   func(c *domain.TokenClaims) { c.Actor = &domain.Actor{Subject: "shop-service"} },
+			wantErr:  domain.ErrDelegationNotAllowed,
+		},
+		{
+			name:     "caller already in the act chain",
+			actor:    "game-service",
+			audience: "wallet-service",
+			claims:   func(c *domain.TokenClaims) { c.Actor = &domain.Actor{Subject: "game-service"} },
+			wantErr:  domain.ErrDelegationNotAllowed,
+		},
+		{
+			name:     "act chain too long",
+			actor:    "ledger-service",
+			audience: "audit-service",
+			claims: func(c *domain.TokenClaims) {
+				c.Actor = &domain.Actor{Subject: "wallet-service", Actor: &domain.Actor{Subject: "game-service"}}
+			},
+			wantErr: domain.ErrDelegationNotAllowed,
+		},
+		{
+			name:     "scope the rule does not allow",
+			actor:    "game-service",
+			audience: "wallet-service",
+			scopes:   []string{"profile:read"},
+			wantErr:  domain.ErrInvalidScope,
+		},
+		{
+			name:     "unverified subject token",
+			actor:    "game-service",
+			audience: "wallet-service",
+			token:    "forged",
+			wantErr:  domain.ErrInvalidSubjectToken,
+		},
+		{
+			name:     "subject token without a session",
+			actor:    "game-service",
+			audience: "wallet-service",
+			claims:   func(c *domain.TokenClaims) { c.SessionID = "" },
+			wantErr:  domain.ErrInvalidSubjectToken,
+		},
+		{
+			name:     "unknown session",
+			actor:    "game-service",
+			audience: "wallet-service",
+			claims:   func(c *domain.TokenClaims) { c.SessionID = "session-unknown" },
+			wantErr:  domain.ErrInvalidSubjectToken,
+		},
+		{
+			name:     "revoked session",
+			actor:    "game-service",
+			audience: "wallet-service",
+			prepare: func(t *testing.T, env *testEnv, session domain.Session) {
+				if _, err := env.sessions.Revoke(context.Background(), session.ID, "logout", env.now); err != nil {
+					t.Fatalf("revoke session: %v", err)
+				}
+			},
+			wantErr: domain.ErrInvalidSubjectToken,
+		},
+		{
+			name:     "subject token about to expire",
+			actor:    "game-service",
+			audience: "wallet-service",
+			claims:   func(c *domain.TokenClaims) { c.ExpiresAt = c.IssuedAt.Add(500 * time.Millisecond) },
+			wantErr:  domain.ErrInvalidSubjectToken,
+		},
+		{
+			name:     "banned identity",
+			actor:    "game-service",
+			audience: "wallet-service",
+			prepare: func(t *testing.T, env *testEnv, session domain.Session) {
+				if _, err := env.identities.SetStatus(context.Background(), session.PlatformUserID, domain.IdentityStatus{
+					State:     domain.IdentityBanned,
+					ChangedAt: env.now,
+				}); err != nil {
+					t.Fatalf("ban identity: %v", err)
+				}
+			},
+			wantErr: domain.ErrIdentityBanned,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, Policies{Delegation: testDelegation})
+			identity, session, _ := env.playerSession(t, "player-1")
+			if tt.prepare != nil {
+				tt.prepare(t, env, session)
+			}
+			claims := domain.TokenClaims{
+				Subject:     identity.PlatformUserID,
+				Tenant:      testTenant,
+				SubjectType: domain.SubjectTypePlayer,
+				Audience:    []string{"proteon-players"},
+				Scopes:      []string{"profile:read", "wallet:read"},
+				SessionID:   session.ID,
+				IssuedAt:    env.now,
+				ExpiresAt:   env.now.Add(10 * time.Minute),
+			}
+			if tt.claims != nil {
+				tt.claims(&claims)
+			}
+			env.verifier["subject-token"] = claims
+			token := tt.token
+			if token == "" {
+				token = "subject-token"
+			}
+
+			result, err := env.svc.ExchangeToken(context.Background(), domain.TokenExchange{
+				SubjectToken: token,
+				Actor:        tt.actor,
+				Audience:     tt.audience,
+				Scopes:       tt.scopes,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ExchangeToken error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(env.issuer.issued) != 0 {
+					t.Errorf("issued %+v for a refused exchange", env.issuer.issued)
+				}
+				return
+			}
+			if !slices.Equal(result.Scopes, tt.wantScopes) {
+				t.Errorf("scopes = %v, want %v", result.Scopes, tt.wantScopes)
+			}
+			if len(env.issuer.issued) != 1 {
+				t.Fatalf("issued %d tokens, want 1", len(env.issuer.issued))
+			}
+			issued := env.issuer.issued[0]
+			if issued.Subject != claims.Subject || issued.SessionID != session.ID || issued.Audience != tt.audience {
+				t.Errorf("issued %+v for the wrong subject, session or audience", issued)
+			}
+			if chain := issued.Actor.Chain(); !slices.Equal(chain, tt.wantChain) {
+				t.Errorf("act chain = %v, want %v", chain, tt.wantChain)
+			}
+			if remaining := claims.ExpiresAt.Sub(env.now); issued.TTL > remaining {
+				t.Errorf("TTL %s outlives the subject token (%s left)", issued.TTL, remaining)
+			}
+		})
+	}
+}
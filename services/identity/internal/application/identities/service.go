@@ -0,0 +1,78 @@
+package identities
+
+import (
+	"context"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// Service implements identity listing and profile use cases.
+type Service struct {
+	query    interfaces.IdentityQuery
+	profiles interfaces.IdentityProfiles
+	now      func() time.Time
+}
+
+// NewService creates an identity service.
+func NewService(query interfaces.IdentityQuery, profiles interfaces.IdentityProfiles) *Service {
+	return &Service{query: query, profiles: profiles, now: time.Now}
+}
+
+// Page is one page of a listing. NextCursor is empty on the last page.
+type Page struct {
+	Identities []domain.PlatformIdentity
+	NextCursor string
+}
+
+// List returns the identities matching filter that follow cursor (empty for
+// the first page). A limit of 0 selects domain.DefaultIdentityPageSize;
+// larger limits are capped at domain.MaxIdentityPageSize. Returns
+// domain.ErrInvalidFilter or domain.ErrInvalidCursor for bad input.
+func (s *Service) List(ctx context.Context, filter domain.IdentityFilter, cursor string, limit int) (Page, error) {
+	if err := filter.Validate(); err != nil {
+		return Page{}, err
+	}
+	var after *domain.IdentityCursor
+	if cursor != "" {
+		c, err := domain.ParseIdentityCursor(cursor)
+		if err != nil {
+			return Page{}, err
+		}
+		after = &c
+	}
+	switch {
+	case limit <= 0:
+		limit = domain.DefaultIdentityPageSize
+	case limit > domain.MaxIdentityPageSize:
+		limit = domain.MaxIdentityPageSize
+	}
+
+	page, err := s.query.List(ctx, filter, after, limit)
+	if err != nil {
+		return Page{}, err
+	}
+	out := Page{Identities: page.Identities}
+	if page.Next != nil {
+		out.NextCursor = page.Next.Encode()
+	}
+	return out, nil
+}
+
+// UpdateProfile sets and clears profile attributes of a platform user with
+// source api. API values take precedence over values supplied with later
+// exchanges until they are cleared. Malformed values return an error
+// wrapping domain.ErrInvalidProfile.
+func (s *Service) UpdateProfile(ctx context.Context, platformUserID string, set map[domain.ProfileField]string, clear []domain.ProfileField) (domain.PlatformIdentity, error) {
+	update, err := domain.ProfileUpdate{
+		Source: domain.ProfileSourceAPI,
+		At:     s.now(),
+		Set:    set,
+		Clear:  clear,
+	}.Normalize()
+	if err != nil {
+		return domain.PlatformIdentity{}, err
+	}
+	return s.profiles.UpdateProfile(ctx, platformUserID, update)
+}
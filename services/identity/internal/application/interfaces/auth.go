@@ -2,7 +2,6 @@ package interfaces
 
 import (
 	"context"
-	"crypto/ed25519"
 	"time"
 
 	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
@@ -14,17 +13,213 @@ type IdentityResolver interface {
 	Resolve(ctx context.Context, provider, externalUserID, tenant string) (domain.PlatformIdentity, error)
 }
 
-// IdentityLookup retrieves an existing platform identity by platform user ID.
-// Implemented by adapters (e.g. in-memory, Postgres).
+// ProviderStore persists the provider registry. Get, Update and Delete
+// return domain.ErrProviderNotFound for unknown IDs; Create returns
+// domain.ErrProviderExists for a taken ID.
+type ProviderStore interface {
+	Create(ctx context.Context, provider domain.Provider) error
+	Get(ctx context.Context, id string) (domain.Provider, error)
+	// List returns every provider ordered by ID.
+	List(ctx context.Context) ([]domain.Provider, error)
+	Update(ctx context.Context, provider domain.Provider) error
+	Delete(ctx context.Context, id string) error
+}
+
+// AssertionVerifier parses a compact JWS identity assertion and verifies its
+// signature with a key of the provider named in iss. Claim rules are
+// checked by the caller. Failures wrap domain.ErrInvalidAssertion.
+type AssertionVerifier interface {
+	Verify(ctx context.Context, assertion string) (domain.Assertion, error)
+}
+
+// AssertionReplayCache remembers used assertion IDs until they expire.
+type AssertionReplayCache interface {
+	// MarkUsed records a jti of a provider. Returns
+	// domain.ErrAssertionReplayed if it was already recorded.
+	MarkUsed(ctx context.Context, provider, jti string, expiresAt time.Time) error
+}
+
+// IdentityLinker attaches external identities to platform identities and
+// detaches them. Both return domain.ErrIdentityNotFound for unknown
+// platform users and must be atomic with Resolve.
+type IdentityLinker interface {
+	// Link adds a linkage and returns the updated identity. Linking an
+	// external identity the user already has is a no-op; one linked to
+	// another user returns domain.ErrLinkageConflict.
+	Link(ctx context.Context, platformUserID string, linkage domain.Linkage) (domain.PlatformIdentity, error)
+	// Unlink removes a linkage and returns the updated identity. It returns
+	// domain.ErrLinkageNotFound if the user has no such linkage and
+	// domain.ErrLastLinkage for the only remaining one.
+	Unlink(ctx context.Context, platformUserID, provider, externalUserID string) (domain.PlatformIdentity, error)
+}
+
+// IdentityLookup retrieves an existing platform identity by platform user ID
+// or by a linked external identity, without creating one. The ID of a
+// merged identity returns the identity it was merged into. Implemented by
+// adapters (e.g. in-memory, Postgres).
 type IdentityLookup interface {
 	GetByPlatformUserID(ctx context.Context, platformUserID string) (domain.PlatformIdentity, error)
+	// GetByLinkage returns the identity the external identity is linked
+	// to, or domain.ErrIdentityNotFound.
+	GetByLinkage(ctx context.Context, provider, externalUserID string) (domain.PlatformIdentity, error)
+}
+
+// IdentityQuery lists platform identities. Merged identities are not
+// listed. Implemented by adapters (e.g. in-memory, Postgres).
+type IdentityQuery interface {
+	// List returns up to limit (> 0) identities matching filter, ordered by
+	// domain.IdentityCursor, that come after the cursor (nil for the first
+	// page). Next is set if more identities may follow. A cursor the store
+	// cannot place returns domain.ErrInvalidCursor.
+	List(ctx context.Context, filter domain.IdentityFilter, after *domain.IdentityCursor, limit int) (domain.IdentityPage, error)
+}
+
+// IdentityMerger folds one platform identity into another. It must be
+// atomic with Resolve and IdentityLinker.
+type IdentityMerger interface {
+	// Merge moves every linkage of mergedID to survivorID, appended after
+	// the survivor's own with LinkedAt set to at, and leaves a tombstone
+	// for mergedID: lookups of mergedID return the survivor from then on,
+	// including for IDs merged into mergedID before. The survivor adopts
+	// the profile attributes of mergedID it does not have and keeps its
+	// own status. It returns
+	// domain.ErrIdentityNotFound if either identity does not exist or was
+	// merged already, and the updated survivor otherwise.
+	Merge(ctx context.Context, survivorID, mergedID string, at time.Time) (domain.PlatformIdentity, error)
+}
+
+// IdentityEraser removes platform identities for data erasure requests.
+// It must be atomic with Resolve, IdentityLinker and IdentityMerger.
+type IdentityEraser interface {
+	// Erase deletes the identity and its linkages and leaves a tombstone
+	// holding only platformUserID and at; the IDs merged into it are
+	// erased with it. Lookups of any of them return
+	// domain.ErrIdentityErased from then on. Erasing an erased ID again
+	// returns the recorded erasure. Pending outbox events of the erased
+	// IDs are dropped. Unknown or merged IDs return
+	// domain.ErrIdentityNotFound.
+	Erase(ctx context.Context, platformUserID string, at time.Time) (domain.Erasure, error)
+}
+
+// IdentityProfiles updates the profile attributes of platform identities.
+// It must be atomic with IdentityMerger and IdentityEraser.
+type IdentityProfiles interface {
+	// UpdateProfile applies a normalized update with domain.Profile.Apply
+	// and returns the updated identity. The ID of a merged identity updates
+	// the survivor. Erased IDs return domain.ErrIdentityErased and unknown
+	// ones domain.ErrIdentityNotFound.
+	UpdateProfile(ctx context.Context, platformUserID string, update domain.ProfileUpdate) (domain.PlatformIdentity, error)
+}
+
+// IdentityStatuses changes the lifecycle status of platform identities. It
+// must be atomic with IdentityMerger and IdentityEraser.
+type IdentityStatuses interface {
+	// SetStatus replaces the status of an identity and returns the updated
+	// identity. Unknown or merged IDs return domain.ErrIdentityNotFound and
+	// erased ones domain.ErrIdentityErased.
+	SetStatus(ctx context.Context, platformUserID string, status domain.IdentityStatus) (domain.PlatformIdentity, error)
+}
+
+// Outbox holds events until they are published, next to the identity
+// store: Resolve, Link, SetStatus and Erase add the events of their changes
+// atomically with them. Implemented by the identity store adapters.
+type Outbox interface {
+	// Add adds an event about a change stored elsewhere.
+	Add(ctx context.Context, event domain.Event) error
+	// Pending returns up to limit (> 0) unpublished messages, oldest first.
+	Pending(ctx context.Context, limit int) ([]domain.OutboxMessage, error)
+	// MarkPublished removes published messages. Unknown IDs are ignored.
+	MarkPublished(ctx context.Context, ids []string) error
+}
+
+// EventPublisher publishes outbox messages to other services. A message
+// must be durable with the broker when Publish returns.
+type EventPublisher interface {
+	Publish(ctx context.Context, msg domain.OutboxMessage) error
+}
+
+// AuditLog records administrative changes to platform identities.
+// Implemented by adapters (e.g. in-memory, Postgres).
+type AuditLog interface {
+	Append(ctx context.Context, entry domain.AuditEntry) error
+	// ListByUser returns the entries of a user, oldest first.
+	ListByUser(ctx context.Context, platformUserID string) ([]domain.AuditEntry, error)
+	// ReplaceDetails replaces the details of a user's entries, keyed by
+	// entry ID, e.g. to redact them. IDs of no entry of the user are
+	// ignored.
+	ReplaceDetails(ctx context.Context, platformUserID string, details map[string]map[string]string) error
 }
 
 // TokenIssuer issues signed access tokens (JWTs).
 // Implemented by adapters (e.g. Ed25519 JWT issuer).
 type TokenIssuer interface {
-	Issue(ctx context.Context, platformUserID, tenant string, ttl time.Duration) (string, error)
-	IssueBackoffice(ctx context.Context, userID, subjectType, tenant, audience string, ttl time.Duration) (string, error)
-	PublicKey() ed25519.PublicKey
-	Kid() string
+	Issue(ctx context.Context, claims domain.AccessTokenClaims) (string, error)
+}
+
+// RefreshTokenStore persists refresh token state keyed by token hash.
+// Implemented by adapters (e.g. in-memory, Postgres).
+type RefreshTokenStore interface {
+	Save(ctx context.Context, token domain.RefreshToken) error
+	// Consume marks the token as used and returns its prior state. It must be
+	// atomic: if the token was already used, it returns the token together
+	// with domain.ErrRefreshTokenReused. Unknown hashes return
+	// domain.ErrInvalidRefreshToken.
+	Consume(ctx context.Context, tokenHash string, at time.Time) (domain.RefreshToken, error)
+	// RevokeFamily revokes every token issued for a session.
+	RevokeFamily(ctx context.Context, sessionID string, at time.Time) error
+}
+
+// SessionStore persists sessions and their revocation state.
+// Implemented by adapters (e.g. in-memory, Postgres).
+type SessionStore interface {
+	Create(ctx context.Context, session domain.Session) error
+	// Get returns domain.ErrSessionNotFound for unknown sessions.
+	Get(ctx context.Context, sessionID string) (domain.Session, error)
+	ListByUser(ctx context.Context, platformUserID string) ([]domain.Session, error)
+	// Extend moves the expiry of an active session.
+	Extend(ctx context.Context, sessionID string, expiresAt time.Time) error
+	// Revoke revokes a session. Revoking a revoked session is a no-op that
+	// returns the session unchanged.
+	Revoke(ctx context.Context, sessionID, reason string, at time.Time) (domain.Session, error)
+	// RevokeAllForUser revokes every unrevoked session of a user and returns them.
+	RevokeAllForUser(ctx context.Context, platformUserID, reason string, at time.Time) ([]domain.Session, error)
+	// RevokedSince returns sessions revoked at or after since.
+	RevokedSince(ctx context.Context, since time.Time) ([]domain.Session, error)
+}
+
+// SigningKeyRing holds the token signing keys and their lifecycle state.
+// Implemented by adapters (e.g. in-memory or file-backed key ring).
+type SigningKeyRing interface {
+	// Keys returns every key in the ring, including retired ones.
+	Keys(ctx context.Context) ([]domain.SigningKeyInfo, error)
+	// PublishedKeys returns the next, active and retiring public keys.
+	PublishedKeys(ctx context.Context) ([]domain.VerificationKey, error)
+	// Rotate promotes the next key of every algorithm to active once it has
+	// been published for at least minNextAge, demotes the active keys to
+	// retiring and creates new next keys. Returns the promoted keys, or
+	// domain.ErrRotationTooSoon.
+	Rotate(ctx context.Context, now time.Time, minNextAge time.Duration) ([]domain.SigningKeyInfo, error)
+	// ActivatePending makes the next key of an algorithm without an active
+	// key active once it has been published for at least minNextAge, and
+	// returns the activated keys.
+	ActivatePending(ctx context.Context, now time.Time, minNextAge time.Duration) ([]domain.SigningKeyInfo, error)
+	// RetireExpired retires keys that have been retiring for at least
+	// retireAfter and removes keys retired for that long.
+	RetireExpired(ctx context.Context, now time.Time, retireAfter time.Duration) ([]domain.SigningKeyInfo, error)
+	// Reload picks up changes other replicas made to a shared ring. The
+	// changing methods return domain.ErrKeyRingChanged, having reloaded
+	// the ring, when another replica changed it first.
+	Reload(ctx context.Context) error
+}
+
+// TokenVerifier verifies access tokens issued by this service with the
+// same rules the gateways apply. Any error means the token is not active.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (domain.TokenClaims, error)
+}
+
+// ClientAuthenticator authenticates internal API clients.
+// Returns domain.ErrInvalidClient for unknown clients or wrong secrets.
+type ClientAuthenticator interface {
+	Authenticate(ctx context.Context, clientID, clientSecret string) error
 }
@@ -0,0 +1,69 @@
+package introspection
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// Service implements RFC 7662 token introspection for clients that cannot
+// verify access tokens themselves.
+type Service struct {
+	clients  interfaces.ClientAuthenticator
+	verifier interfaces.TokenVerifier
+	sessions interfaces.SessionStore
+	now      func() time.Time
+}
+
+// NewService creates an introspection service with the given dependencies.
+func NewService(clients interfaces.ClientAuthenticator, verifier interfaces.TokenVerifier, sessions interfaces.SessionStore) *Service {
+	return &Service{
+		clients:  clients,
+		verifier: verifier,
+		sessions: sessions,
+		now:      time.Now,
+	}
+}
+
+// Introspect authenticates the calling client and reports whether token is
+// active. Returns domain.ErrInvalidClient if client authentication fails.
+// Tokens of revoked sessions are inactive.
+func (s *Service) Introspect(ctx context.Context, clientID, clientSecret, token string) (domain.Introspection, error) {
+	if err := s.clients.Authenticate(ctx, clientID, clientSecret); err != nil {
+		return domain.Introspection{}, err
+	}
+
+	claims, err := s.verifier.Verify(ctx, token)
+	if err != nil {
+		return domain.Introspection{Active: false}, nil
+	}
+
+	status, err := s.sessionStatus(ctx, claims.SessionID)
+	if err != nil {
+		return domain.Introspection{}, err
+	}
+	if status == domain.SessionStatusRevoked {
+		return domain.Introspection{Active: false, SessionStatus: status}, nil
+	}
+	return domain.Introspection{Active: true, Claims: claims, SessionStatus: status}, nil
+}
+
+func (s *Service) sessionStatus(ctx context.Context, sessionID string) (domain.SessionStatus, error) {
+	if sessionID == "" {
+		return domain.SessionStatusUnknown, nil
+	}
+	session, err := s.sessions.Get(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return domain.SessionStatusUnknown, nil
+		}
+		return "", err
+	}
+	if !session.RevokedAt.IsZero() {
+		return domain.SessionStatusRevoked, nil
+	}
+	return domain.SessionStatusActive, nil
+}
@@ -0,0 +1,77 @@
+// Package outbox relays the events written to the outbox to the broker.
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+)
+
+// batchSize is the number of messages read from the outbox at a time.
+const batchSize = 100
+
+// Relay publishes outbox messages in write order and removes them once the
+// broker has them. A message is removed only after it was published, so
+// after a crash or a failed removal it is published again: delivery is at
+// least once, and consumers dedupe on the event ID. With several replicas
+// sharing an outbox, each relays it and the broker's deduplication on the
+// event ID catches most duplicates.
+type Relay struct {
+	outbox    interfaces.Outbox
+	publisher interfaces.EventPublisher
+	interval  time.Duration
+}
+
+// NewRelay creates a relay that polls the outbox every interval.
+func NewRelay(outbox interfaces.Outbox, publisher interfaces.EventPublisher, interval time.Duration) *Relay {
+	return &Relay{outbox: outbox, publisher: publisher, interval: interval}
+}
+
+// Run relays until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Flush(ctx); err != nil {
+				log.Printf("event relay: %v", err)
+			}
+		}
+	}
+}
+
+// Flush publishes pending messages until the outbox is empty and returns
+// how many were published. It stops at the first message the publisher
+// refuses, so later messages do not overtake it.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	published := 0
+	for {
+		msgs, err := r.outbox.Pending(ctx, batchSize)
+		if err != nil || len(msgs) == 0 {
+			return published, err
+		}
+		ids := make([]string, 0, len(msgs))
+		var publishErr error
+		for _, msg := range msgs {
+			if err := r.publisher.Publish(ctx, msg); err != nil {
+				publishErr = fmt.Errorf("publish %s %s: %w", msg.Type, msg.ID, err)
+				break
+			}
+			ids = append(ids, msg.ID)
+		}
+		if err := r.outbox.MarkPublished(ctx, ids); err != nil {
+			return published, err
+		}
+		published += len(ids)
+		if publishErr != nil {
+			return published, publishErr
+		}
+	}
+}
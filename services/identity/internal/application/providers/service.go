@@ -0,0 +1,107 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// minAccessTTL is the shortest access token lifetime a provider may set.
+const minAccessTTL = time.Minute
+
+// Service implements the provider registry admin use cases.
+type Service struct {
+	providers interfaces.ProviderStore
+	// ttl bounds provider token settings: a provider may shorten the
+	// configured lifetimes but never exceed what signing key retirement
+	// and the revocation window are sized for.
+	ttl domain.TTLPolicy
+	now func() time.Time
+}
+
+// NewService creates a provider registry service.
+func NewService(providers interfaces.ProviderStore, ttl domain.TTLPolicy) *Service {
+	return &Service{
+		providers: providers,
+		ttl:       ttl,
+		now:       time.Now,
+	}
+}
+
+// List returns every registered provider ordered by ID.
+func (s *Service) List(ctx context.Context) ([]domain.Provider, error) {
+	return s.providers.List(ctx)
+}
+
+// Get returns a provider or domain.ErrProviderNotFound.
+func (s *Service) Get(ctx context.Context, id string) (domain.Provider, error) {
+	return s.providers.Get(ctx, id)
+}
+
+// Create registers a provider. An empty status means active. Returns an
+// error wrapping domain.ErrInvalidProvider for invalid fields and
+// domain.ErrProviderExists for a taken ID.
+func (s *Service) Create(ctx context.Context, provider domain.Provider) (domain.Provider, error) {
+	if provider.Status == "" {
+		provider.Status = domain.ProviderActive
+	}
+	if err := s.validate(provider); err != nil {
+		return domain.Provider{}, err
+	}
+
+	now := s.now()
+	provider.CreatedAt = now
+	provider.UpdatedAt = now
+	if err := s.providers.Create(ctx, provider); err != nil {
+		return domain.Provider{}, err
+	}
+	return provider, nil
+}
+
+// Update replaces every mutable field of an existing provider. Disabling a
+// provider stops new exchanges and refreshes of its sessions; access tokens
+// already issued stay valid until they expire.
+func (s *Service) Update(ctx context.Context, provider domain.Provider) (domain.Provider, error) {
+	existing, err := s.providers.Get(ctx, provider.ID)
+	if err != nil {
+		return domain.Provider{}, err
+	}
+	if provider.Status == "" {
+		provider.Status = existing.Status
+	}
+	if err := s.validate(provider); err != nil {
+		return domain.Provider{}, err
+	}
+
+	provider.CreatedAt = existing.CreatedAt
+	provider.UpdatedAt = s.now()
+	if err := s.providers.Update(ctx, provider); err != nil {
+		return domain.Provider{}, err
+	}
+	return provider, nil
+}
+
+// Delete removes a provider. Identities it resolved are kept, but no
+// further assertions of the provider are accepted.
+func (s *Service) Delete(ctx context.Context, id string) error {
+	return s.providers.Delete(ctx, id)
+}
+
+func (s *Service) validate(provider domain.Provider) error {
+	if err := provider.Validate(); err != nil {
+		return err
+	}
+
+	tokens := provider.Tokens
+	if maxAccess := s.ttl.MaxAccessTTL(); tokens.AccessTTL != 0 && (tokens.AccessTTL < minAccessTTL || tokens.AccessTTL > maxAccess) {
+		return fmt.Errorf("%w: access token TTL must be between %s and %s", domain.ErrInvalidProvider, minAccessTTL, maxAccess)
+	}
+	ttl := s.ttl.ForProvider(tokens)
+	if tokens.RefreshTTL != 0 && (tokens.RefreshTTL <= ttl.PlayerAccess || tokens.RefreshTTL > s.ttl.Refresh) {
+		return fmt.Errorf("%w: refresh token TTL must exceed the access token TTL (%s) and be at most %s", domain.ErrInvalidProvider, ttl.PlayerAccess, s.ttl.Refresh)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package sessions
+
+import (
+	"context"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// Service implements session listing and revocation use cases.
+type Service struct {
+	sessions      interfaces.SessionStore
+	refreshTokens interfaces.RefreshTokenStore
+	// window is how long a revocation stays relevant to verifiers: the
+	// longest access token lifetime.
+	window time.Duration
+	now    func() time.Time
+}
+
+// NewService creates a session service. revocationWindow must be at least
+// the longest access token lifetime.
+func NewService(sessions interfaces.SessionStore, refreshTokens interfaces.RefreshTokenStore, revocationWindow time.Duration) *Service {
+	return &Service{
+		sessions:      sessions,
+		refreshTokens: refreshTokens,
+		window:        revocationWindow,
+		now:           time.Now,
+	}
+}
+
+// List returns every session of a user, including revoked and expired ones.
+func (s *Service) List(ctx context.Context, platformUserID string) ([]domain.Session, error) {
+	return s.sessions.ListByUser(ctx, platformUserID)
+}
+
+// Revoke revokes one session of a user together with its refresh tokens.
+// Returns domain.ErrSessionNotFound if the session does not belong to the user.
+func (s *Service) Revoke(ctx context.Context, platformUserID, sessionID, reason string) (domain.Session, error) {
+	session, err := s.sessions.Get(ctx, sessionID)
+	if err != nil {
+		return domain.Session{}, err
+	}
+	if session.PlatformUserID != platformUserID {
+		return domain.Session{}, domain.ErrSessionNotFound
+	}
+
+	now := s.now()
+	session, err = s.sessions.Revoke(ctx, sessionID, reason, now)
+	if err != nil {
+		return domain.Session{}, err
+	}
+	if err := s.refreshTokens.RevokeFamily(ctx, sessionID, now); err != nil {
+		return domain.Session{}, err
+	}
+	return session, nil
+}
+
+// RevokeAll revokes every session of a user together with their refresh
+// tokens and returns the sessions that were revoked.
+func (s *Service) RevokeAll(ctx context.Context, platformUserID, reason string) ([]domain.Session, error) {
+	now := s.now()
+	revoked, err := s.sessions.RevokeAllForUser(ctx, platformUserID, reason, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range revoked {
+		if err := s.refreshTokens.RevokeFamily(ctx, session.ID, now); err != nil {
+			return nil, err
+		}
+	}
+	return revoked, nil
+}
+
+// Revocations returns the revocations verifiers must still enforce that
+// were made at or after since, and the time the list was taken. Callers
+// poll with the returned time as the next since.
+func (s *Service) Revocations(ctx context.Context, since time.Time) ([]domain.Revocation, time.Time, error) {
+	now := s.now()
+	if earliest := now.Add(-s.window); since.Before(earliest) {
+		since = earliest
+	}
+
+	revoked, err := s.sessions.RevokedSince(ctx, since)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	out := make([]domain.Revocation, 0, len(revoked))
+	for _, session := range revoked {
+		expiresAt := session.RevokedAt.Add(s.window)
+		if session.ExpiresAt.Before(expiresAt) {
+			expiresAt = session.ExpiresAt
+		}
+		if !expiresAt.After(now) {
+			continue
+		}
+		out = append(out, domain.Revocation{
+			SessionID:      session.ID,
+			PlatformUserID: session.PlatformUserID,
+			RevokedAt:      session.RevokedAt,
+			ExpiresAt:      expiresAt,
+		})
+	}
+	return out, now, nil
+}
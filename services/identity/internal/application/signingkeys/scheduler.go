@@ -0,0 +1,35 @@
+package signingkeys
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Scheduler periodically drives key retirement and scheduled rotation.
+type Scheduler struct {
+	svc      *Service
+	interval time.Duration
+}
+
+// NewScheduler creates a scheduler that ticks the service every interval.
+func NewScheduler(svc *Service, interval time.Duration) *Scheduler {
+	return &Scheduler{svc: svc, interval: interval}
+}
+
+// Run ticks until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.svc.Tick(ctx); err != nil {
+				log.Printf("signing key tick failed: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package signingkeys
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/application/interfaces"
+	"github.com/woffVienna/proteon-cursor/services/identity/internal/domain"
+)
+
+// Policy controls when signing keys rotate and retire.
+type Policy struct {
+	// RotationInterval is how long a key stays active before scheduled
+	// rotation. Zero disables scheduled rotation (API-triggered only).
+	RotationInterval time.Duration
+	// PublishLead is the minimum time a next key must be published before it
+	// may become active. Must cover the JWKS cache max-age so that every
+	// verifier knows the key before it signs anything.
+	PublishLead time.Duration
+	// RetireAfter is how long a retiring key stays published. Must cover the
+	// longest token lifetime plus the JWKS cache max-age.
+	RetireAfter time.Duration
+}
+
+// Service implements signing key lifecycle use cases.
+type Service struct {
+	ring   interfaces.SigningKeyRing
+	policy Policy
+	now    func() time.Time
+}
+
+// NewService creates a signing key service with the given dependencies.
+func NewService(ring interfaces.SigningKeyRing, policy Policy) *Service {
+	return &Service{
+		ring:   ring,
+		policy: policy,
+		now:    time.Now,
+	}
+}
+
+// List returns every key in the ring.
+func (s *Service) List(ctx context.Context) ([]domain.SigningKeyInfo, error) {
+	if err := s.ring.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return s.ring.Keys(ctx)
+}
+
+// PublishedKeys returns the public keys that belong in the JWKS.
+func (s *Service) PublishedKeys(ctx context.Context) ([]domain.VerificationKey, error) {
+	return s.ring.PublishedKeys(ctx)
+}
+
+// Rotate promotes the next keys to active. Returns domain.ErrRotationTooSoon
+// if a next key has not been published for at least Policy.PublishLead,
+// or domain.ErrKeyRingChanged if another replica changed the ring meanwhile.
+func (s *Service) Rotate(ctx context.Context) ([]domain.SigningKeyInfo, error) {
+	if err := s.ring.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return s.ring.Rotate(ctx, s.now(), s.policy.PublishLead)
+}
+
+// Tick picks up changes other replicas made to the ring, activates keys of
+// added algorithms once they have been published for Policy.PublishLead,
+// retires expired keys and performs scheduled rotation when due.
+func (s *Service) Tick(ctx context.Context) error {
+	if err := s.ring.Reload(ctx); err != nil {
+		return err
+	}
+	// Another replica changing the ring first made the same change or
+	// needs none; the ring is reloaded and the next tick looks again.
+	if err := s.tick(ctx); err != nil && !errors.Is(err, domain.ErrKeyRingChanged) {
+		return err
+	}
+	return nil
+}
+
+func (s *Service) tick(ctx context.Context) error {
+	now := s.now()
+	if _, err := s.ring.ActivatePending(ctx, now, s.policy.PublishLead); err != nil {
+		return err
+	}
+	if _, err := s.ring.RetireExpired(ctx, now, s.policy.RetireAfter); err != nil {
+		return err
+	}
+	if s.policy.RotationInterval <= 0 {
+		return nil
+	}
+
+	keys, err := s.ring.Keys(ctx)
+	if err != nil {
+		return err
+	}
+	// One rotation promotes the next keys of every algorithm.
+	for _, k := range keys {
+		if k.State != domain.SigningKeyActive || now.Sub(k.ActivatedAt) < s.policy.RotationInterval {
+			continue
+		}
+		if _, err := s.ring.Rotate(ctx, now, s.policy.PublishLead); err != nil && !errors.Is(err, domain.ErrRotationTooSoon) {
+			return err
+		}
+		return nil
+	}
+	return nil
+}
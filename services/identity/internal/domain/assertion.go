@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"crypto"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Assertion failures. All of them wrap ErrInvalidAssertion.
+var (
+	ErrAssertionSignature = fmt.Errorf("%w: bad signature or unknown key", ErrInvalidAssertion)
+	ErrAssertionClaims    = fmt.Errorf("%w: missing or malformed claims", ErrInvalidAssertion)
+	ErrAssertionAudience  = fmt.Errorf("%w: audience mismatch", ErrInvalidAssertion)
+	ErrAssertionExpired   = fmt.Errorf("%w: expired or not yet valid", ErrInvalidAssertion)
+	ErrAssertionLifetime  = fmt.Errorf("%w: lifetime too long", ErrInvalidAssertion)
+	ErrAssertionReplayed  = fmt.Errorf("%w: jti already used", ErrInvalidAssertion)
+)
+
+// Assertion is a signed identity assertion from a customer backend, after
+// signature verification. iss is the provider, sub the external user ID.
+type Assertion struct {
+	Provider       string
+	ExternalUserID string
+	Tenant         string
+	Audience       []string
+	ID             string
+	IssuedAt       time.Time
+	ExpiresAt      time.Time
+}
+
+// AssertionKey is a public key a provider signs assertions with.
+type AssertionKey struct {
+	Kid       string
+	Algorithm string
+	PublicKey crypto.PublicKey
+}
+
+// AssertionPolicy are the rules a verified assertion must satisfy.
+type AssertionPolicy struct {
+	// Audience must be contained in the assertion's aud.
+	Audience string
+	// MaxLifetime bounds exp - iat.
+	MaxLifetime time.Duration
+	// Leeway tolerates clock skew on iat and exp.
+	Leeway time.Duration
+}
+
+// Check validates the claims of a verified assertion at now.
+func (p AssertionPolicy) Check(a Assertion, now time.Time) error {
+	if a.Provider == "" || a.ExternalUserID == "" || a.ID == "" || a.IssuedAt.IsZero() || a.ExpiresAt.IsZero() {
+		return ErrAssertionClaims
+	}
+	if !slices.Contains(a.Audience, p.Audience) {
+		return ErrAssertionAudience
+	}
+	if a.ExpiresAt.Sub(a.IssuedAt) > p.MaxLifetime {
+		return ErrAssertionLifetime
+	}
+	if now.After(a.ExpiresAt.Add(p.Leeway)) || now.Add(p.Leeway).Before(a.IssuedAt) {
+		return ErrAssertionExpired
+	}
+	return nil
+}
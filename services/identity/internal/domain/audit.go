@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"maps"
+	"time"
+)
+
+// AuditAction names an administrative change recorded in the audit log.
+type AuditAction string
+
+// AuditIdentityMerged records that another platform identity was merged
+// into the entry's user.
+const AuditIdentityMerged AuditAction = "identity.merged"
+
+// AuditEntry records an administrative change to a platform identity.
+type AuditEntry struct {
+	ID             string
+	PlatformUserID string
+	Action         AuditAction
+	// Actor is the internal caller that made the change; empty when the
+	// caller is not authenticated (dev only).
+	Actor   string
+	Details map[string]string
+	At      time.Time
+}
+
+// AuditRedacted replaces operator free text in the details of an erased
+// user's entries.
+const AuditRedacted = "[redacted]"
+
+// auditFreeText names the details of each action that hold operator free
+// text, which may mention the person behind the identity.
+var auditFreeText = map[AuditAction][]string{
+	AuditIdentityMerged:        {"reason"},
+	AuditIdentityStatusChanged: {"note"},
+	AuditIdentityErased:        {"reason"},
+}
+
+// RedactedDetails returns the entry's details with operator free text
+// replaced by AuditRedacted, and whether anything was replaced.
+func (e AuditEntry) RedactedDetails() (map[string]string, bool) {
+	var redacted map[string]string
+	for _, key := range auditFreeText[e.Action] {
+		if v, ok := e.Details[key]; !ok || v == AuditRedacted {
+			continue
+		}
+		if redacted == nil {
+			redacted = maps.Clone(e.Details)
+		}
+		redacted[key] = AuditRedacted
+	}
+	return redacted, redacted != nil
+}
@@ -2,27 +2,115 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
 var (
 	ErrIdentityNotFound = errors.New("platform identity not found")
 	ErrInvalidAssertion = errors.New("invalid external identity assertion")
+	ErrInvalidLinkage   = errors.New("invalid linkage")
+	ErrLinkageNotFound  = errors.New("linkage not found")
+	// ErrLinkageConflict is returned when an external identity is already
+	// linked to another platform user; a linkage belongs to one user only.
+	ErrLinkageConflict = errors.New("external identity is linked to another platform user")
+	ErrLastLinkage     = errors.New("cannot remove the last linkage of a platform identity")
+	ErrMergeSelf       = errors.New("cannot merge a platform identity into itself")
+	// ErrIdentityMerged is returned for a platform user ID that was merged
+	// into another identity where the survivor cannot stand in for it.
+	ErrIdentityMerged = errors.New("platform identity was merged into another")
+	// ErrMergeTenantMismatch is returned when merging identities of
+	// different tenants.
+	ErrMergeTenantMismatch = errors.New("platform identities belong to different tenants")
+	// ErrMergeInactive is returned when merging a suspended or banned
+	// identity: its linkages would move to the survivor and escape the
+	// block.
+	ErrMergeInactive = errors.New("cannot merge a suspended or banned platform identity")
+	// ErrTenantMismatch is returned when an external identity is used in
+	// another tenant than the one its platform identity belongs to. An
+	// identity never moves between tenants.
+	ErrTenantMismatch = errors.New("platform identity belongs to another tenant")
 )
 
 // PlatformIdentity represents a reduced Proteon platform identity.
-// It maps an external provider + external user ID to a stable platform user ID.
+// It maps one or more external identities (provider + external user ID) to
+// a stable platform user ID.
 type PlatformIdentity struct {
 	PlatformUserID string
+	// Tenant is the tenant the identity was created in. It never changes:
+	// exchanges and linkages for other tenants are rejected.
+	Tenant string
+	// Linkages are the linked external identities in the order they were
+	// linked; the first is the one the identity was created from. An
+	// identity always has at least one.
+	Linkages []Linkage
+	// Profile holds the optional profile attributes; nil when none are set.
+	Profile Profile
+	// Status is the lifecycle status; see IdentityStatus.At.
+	Status    IdentityStatus
+	CreatedAt time.Time
+}
+
+// Linkage ties an external identity to a platform identity.
+type Linkage struct {
 	Provider       string
 	ExternalUserID string
-	Tenant         string
-	CreatedAt      time.Time
+	// Tenant is the tenant asserted when the linkage was made.
+	Tenant   string
+	LinkedAt time.Time
+}
+
+// Validate checks that the linkage names an external identity.
+func (l Linkage) Validate() error {
+	if l.Provider == "" || l.ExternalUserID == "" {
+		return fmt.Errorf("%w: provider and external user ID are required", ErrInvalidLinkage)
+	}
+	return nil
+}
+
+// Primary returns the linkage the identity was created from, or the oldest
+// remaining one.
+func (p PlatformIdentity) Primary() Linkage {
+	if len(p.Linkages) == 0 {
+		return Linkage{}
+	}
+	return p.Linkages[0]
+}
+
+// Linkage returns the linkage of an external identity.
+func (p PlatformIdentity) Linkage(provider, externalUserID string) (Linkage, bool) {
+	for _, l := range p.Linkages {
+		if l.Provider == provider && l.ExternalUserID == externalUserID {
+			return l, true
+		}
+	}
+	return Linkage{}, false
+}
+
+// AccessTokenClaims describes an access token to issue.
+type AccessTokenClaims struct {
+	Subject string
+	Tenant  string
+	// SubjectType is empty for player tokens.
+	SubjectType string
+	// Audience is empty for the issuer's default audience.
+	Audience  string
+	SessionID string
+	Scopes    []string
+	// Actor becomes the act claim of a delegated token.
+	Actor *Actor
+	// Profile are profile attribute claims keyed by claim name.
+	Profile map[string]any
+	TTL     time.Duration
 }
 
 // TokenResult is the result of a successful auth exchange.
+// Refresh token fields are empty for tokens that cannot be refreshed.
 type TokenResult struct {
-	AccessToken    string
-	PlatformUserID string
-	ExpiresIn      int32
+	AccessToken      string
+	PlatformUserID   string
+	ExpiresIn        int32
+	RefreshToken     string
+	RefreshExpiresIn int32
+	Scopes           []string
 }
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+)
+
+var (
+	// ErrInvalidSubjectToken is returned when the subject token of a token
+	// exchange is not an active access token of this issuer.
+	ErrInvalidSubjectToken = errors.New("invalid subject token")
+	// ErrDelegationNotAllowed is returned when no delegation rule lets the
+	// caller act for the subject token at the requested audience.
+	ErrDelegationNotAllowed = errors.New("delegation not allowed")
+)
+
+// Token types of RFC 8693 accepted as subject token and issued by a token
+// exchange. Proteon access tokens are JWTs, so both name the same tokens.
+const (
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeJWT         = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// DefaultMaxActorChain is the act chain length a delegation policy allows
+// when it sets none.
+const DefaultMaxActorChain = 3
+
+// Actor is the act claim of a delegated token (RFC 8693 §4.1): the service
+// acting for the subject, and the actor it got the token from, if any.
+type Actor struct {
+	Subject string
+	Actor   *Actor
+}
+
+// Chain returns the actors from the current one to the first.
+func (a *Actor) Chain() []string {
+	var chain []string
+	for ; a != nil; a = a.Actor {
+		chain = append(chain, a.Subject)
+	}
+	return chain
+}
+
+// TokenExchange is an RFC 8693 token exchange request. Actor is the
+// authenticated service calling on behalf of the subject token's subject.
+type TokenExchange struct {
+	SubjectToken string
+	Actor        string
+	Audience     string
+	// Scopes are the requested scopes; empty requests every scope the
+	// delegation allows.
+	Scopes []string
+}
+
+// DelegationRule lets an actor exchange tokens whose tenant and subject type
+// match for tokens of the given audiences. An empty match field matches
+// any value.
+type DelegationRule struct {
+	// Actor is the service name of the caller.
+	Actor       string
+	Tenant      string
+	SubjectType string
+	Audiences   []string
+	// Scopes caps the scopes of the new token; empty keeps every scope of
+	// the subject token.
+	Scopes []string
+	// Via are the actors whose delegated tokens the rule applies to: the
+	// subject token's current actor must be one of them. A rule without
+	// Via applies only to tokens without an act claim.
+	Via []string
+}
+
+func (r DelegationRule) matches(actor string, subject TokenClaims, audience string, chain []string) bool {
+	if r.Actor != actor || !slices.Contains(r.Audiences, audience) {
+		return false
+	}
+	if len(chain) == 0 {
+		if len(r.Via) > 0 {
+			return false
+		}
+	} else if !slices.Contains(r.Via, chain[0]) {
+		return false
+	}
+	return (r.Tenant == "" || r.Tenant == subject.Tenant) &&
+		(r.SubjectType == "" || r.SubjectType == subject.SubjectType)
+}
+
+// DelegationPolicy decides which services may exchange a token to act for
+// its subject. The zero policy allows no delegation.
+type DelegationPolicy struct {
+	Rules []DelegationRule
+	// MaxActorChain caps the number of actors in the act claim of an
+	// exchanged token.
+	MaxActorChain int
+}
+
+// Allowed returns the sorted scopes actor may carry when it exchanges
+// subject for a token of audience, or ErrDelegationNotAllowed. The subject
+// token's act chain must be shorter than MaxActorChain and must not contain
+// actor already, and its current actor must be in Via of a matching rule.
+func (p DelegationPolicy) Allowed(actor string, subject TokenClaims, audience string) ([]string, error) {
+	chain := subject.Actor.Chain()
+	if actor == "" || len(chain) >= p.MaxActorChain || slices.Contains(chain, actor) {
+		return nil, ErrDelegationNotAllowed
+	}
+
+	var (
+		matched bool
+		allowed []string
+	)
+	for _, rule := range p.Rules {
+		if !rule.matches(actor, subject, audience, chain) {
+			continue
+		}
+		matched = true
+		if len(rule.Scopes) == 0 {
+			allowed = append(allowed, subject.Scopes...)
+			continue
+		}
+		for _, scope := range rule.Scopes {
+			if slices.Contains(subject.Scopes, scope) {
+				allowed = append(allowed, scope)
+			}
+		}
+	}
+	if !matched {
+		return nil, ErrDelegationNotAllowed
+	}
+	slices.Sort(allowed)
+	return slices.Compact(allowed), nil
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrIdentityErased is returned for the platform user ID of an erased
+// identity. It wraps ErrIdentityNotFound: to everything but the erasure
+// flow an erased identity does not exist.
+var ErrIdentityErased = fmt.Errorf("%w: erased", ErrIdentityNotFound)
+
+// AuditIdentityErased records that the entry's user was erased.
+const AuditIdentityErased AuditAction = "identity.erased"
+
+// Erasure is the tombstone an erased platform identity leaves: only its
+// platform user ID and the erasure time remain. The platform user IDs
+// that had been merged into it are erased with it.
+type Erasure struct {
+	PlatformUserID string
+	MergedUserIDs  []string
+	ErasedAt       time.Time
+}
+
+// IdentityExport is everything identity holds about a platform user, for
+// data access requests. MergedUserIDs are the platform user IDs merged
+// into the identity; their sessions and audit entries are included.
+type IdentityExport struct {
+	Identity      PlatformIdentity
+	MergedUserIDs []string
+	Sessions      []Session
+	Audit         []AuditEntry
+	ExportedAt    time.Time
+}
@@ -0,0 +1,166 @@
+package domain
+
+import "time"
+
+// EventType names a fact identity publishes to other services.
+type EventType string
+
+const (
+	// EventIdentityCreated announces a new platform identity with its first
+	// linkage.
+	EventIdentityCreated EventType = "identity.created"
+	// EventIdentityLinked announces an external identity linked to an
+	// existing platform identity.
+	EventIdentityLinked EventType = "identity.linked"
+	// EventIdentityStatusChanged announces a suspension, ban or
+	// reactivation.
+	EventIdentityStatusChanged EventType = "identity.status_changed"
+	// EventIdentityErased announces that a platform identity was erased, so
+	// other services can erase what they hold about its platform user IDs.
+	EventIdentityErased EventType = "identity.erased"
+	// EventTokenIssued announces an access token issued to a player or a
+	// backoffice user.
+	EventTokenIssued EventType = "token.issued"
+)
+
+// Event is a fact published to other services once the change it describes
+// is durable. Payload holds the payload type matching Type. Consumers may
+// see an event more than once and must dedupe on ID.
+type Event struct {
+	ID   string
+	Type EventType
+	At   time.Time
+	// PlatformUserID is the user the event is about.
+	PlatformUserID string
+	Payload        any
+}
+
+// OutboxMessage is an event encoded for the broker, waiting in the outbox
+// until it has been published.
+type OutboxMessage struct {
+	ID             string
+	Type           EventType
+	PlatformUserID string
+	// Data is the event in its contract envelope.
+	Data      []byte
+	CreatedAt time.Time
+}
+
+// IdentityCreated is the payload of EventIdentityCreated.
+type IdentityCreated struct {
+	PlatformUserID string
+	Tenant         string
+	Linkage        Linkage
+	CreatedAt      time.Time
+}
+
+// IdentityLinked is the payload of EventIdentityLinked.
+type IdentityLinked struct {
+	PlatformUserID string
+	Tenant         string
+	Linkage        Linkage
+}
+
+// IdentityStatusChanged is the payload of EventIdentityStatusChanged.
+type IdentityStatusChanged struct {
+	PlatformUserID string
+	Tenant         string
+	Status         IdentityStatus
+}
+
+// IdentityErased is the payload of EventIdentityErased.
+type IdentityErased struct {
+	PlatformUserID string
+	MergedUserIDs  []string
+	ErasedAt       time.Time
+}
+
+// TokenIssued is the payload of EventTokenIssued.
+type TokenIssued struct {
+	PlatformUserID string
+	Tenant         string
+	// SubjectType is SubjectTypePlayer or the backoffice subject type.
+	SubjectType string
+	// Grant is how the token was obtained: exchange, refresh, backoffice or
+	// token_exchange.
+	Grant     string
+	SessionID string
+	Audience  string
+	Scopes    []string
+	// Actors is the act chain of a delegated token, current actor first.
+	Actors    []string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// Grants recorded on EventTokenIssued.
+const (
+	GrantExchange   = "exchange"
+	GrantRefresh    = "refresh"
+	GrantBackoffice = "backoffice"
+	// GrantTokenExchange is an RFC 8693 exchange of an access token for
+	// a delegated one.
+	GrantTokenExchange = "token_exchange"
+)
+
+// NewIdentityCreatedEvent returns the event of a newly created identity.
+func NewIdentityCreatedEvent(id string, identity PlatformIdentity) Event {
+	return Event{
+		ID:             id,
+		Type:           EventIdentityCreated,
+		At:             identity.CreatedAt,
+		PlatformUserID: identity.PlatformUserID,
+		Payload: IdentityCreated{
+			PlatformUserID: identity.PlatformUserID,
+			Tenant:         identity.Tenant,
+			Linkage:        identity.Linkages[0],
+			CreatedAt:      identity.CreatedAt,
+		},
+	}
+}
+
+// NewIdentityLinkedEvent returns the event of linkage added to identity.
+func NewIdentityLinkedEvent(id string, identity PlatformIdentity, linkage Linkage) Event {
+	return Event{
+		ID:             id,
+		Type:           EventIdentityLinked,
+		At:             linkage.LinkedAt,
+		PlatformUserID: identity.PlatformUserID,
+		Payload: IdentityLinked{
+			PlatformUserID: identity.PlatformUserID,
+			Tenant:         identity.Tenant,
+			Linkage:        linkage,
+		},
+	}
+}
+
+// NewIdentityStatusChangedEvent returns the event of the status identity
+// was given.
+func NewIdentityStatusChangedEvent(id string, identity PlatformIdentity) Event {
+	return Event{
+		ID:             id,
+		Type:           EventIdentityStatusChanged,
+		At:             identity.Status.ChangedAt,
+		PlatformUserID: identity.PlatformUserID,
+		Payload: IdentityStatusChanged{
+			PlatformUserID: identity.PlatformUserID,
+			Tenant:         identity.Tenant,
+			Status:         identity.Status,
+		},
+	}
+}
+
+// NewIdentityErasedEvent returns the event of an erasure.
+func NewIdentityErasedEvent(id string, erasure Erasure) Event {
+	return Event{
+		ID:             id,
+		Type:           EventIdentityErased,
+		At:             erasure.ErasedAt,
+		PlatformUserID: erasure.PlatformUserID,
+		Payload: IdentityErased{
+			PlatformUserID: erasure.PlatformUserID,
+			MergedUserIDs:  erasure.MergedUserIDs,
+			ErasedAt:       erasure.ErasedAt,
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Page sizes of identity listings.
+const (
+	DefaultIdentityPageSize = 50
+	MaxIdentityPageSize     = 200
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid page cursor")
+	ErrInvalidFilter = errors.New("invalid identity filter")
+)
+
+// IdentityFilter selects platform identities in a listing. Empty fields
+// match anything. Provider and ExternalUserIDPrefix must match the same
+// linkage.
+type IdentityFilter struct {
+	Provider             string
+	Tenant               string
+	ExternalUserIDPrefix string
+	// CreatedFrom is inclusive, CreatedTo exclusive.
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+// Validate checks that the creation time range is not inverted.
+func (f IdentityFilter) Validate() error {
+	if !f.CreatedFrom.IsZero() && !f.CreatedTo.IsZero() && !f.CreatedFrom.Before(f.CreatedTo) {
+		return fmt.Errorf("%w: created_from must be before created_to", ErrInvalidFilter)
+	}
+	return nil
+}
+
+// Matches reports whether an identity passes the filter.
+func (f IdentityFilter) Matches(identity PlatformIdentity) bool {
+	if f.Tenant != "" && identity.Tenant != f.Tenant {
+		return false
+	}
+	if !f.CreatedFrom.IsZero() && identity.CreatedAt.Before(f.CreatedFrom) {
+		return false
+	}
+	if !f.CreatedTo.IsZero() && !identity.CreatedAt.Before(f.CreatedTo) {
+		return false
+	}
+	if f.Provider == "" && f.ExternalUserIDPrefix == "" {
+		return true
+	}
+	for _, l := range identity.Linkages {
+		if (f.Provider == "" || l.Provider == f.Provider) && strings.HasPrefix(l.ExternalUserID, f.ExternalUserIDPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IdentityCursor is the position of an identity in a listing. Listings are
+// ordered by creation time, then platform user ID, so the order is stable
+// while identities are added.
+type IdentityCursor struct {
+	CreatedAt      time.Time
+	PlatformUserID string
+}
+
+// CursorOf returns the position of an identity.
+func CursorOf(identity PlatformIdentity) IdentityCursor {
+	return IdentityCursor{CreatedAt: identity.CreatedAt, PlatformUserID: identity.PlatformUserID}
+}
+
+// Before reports whether the identity at c is listed before the one at o.
+func (c IdentityCursor) Before(o IdentityCursor) bool {
+	if !c.CreatedAt.Equal(o.CreatedAt) {
+		return c.CreatedAt.Before(o.CreatedAt)
+	}
+	return c.PlatformUserID < o.PlatformUserID
+}
+
+// Encode returns the cursor as an opaque token for clients.
+func (c IdentityCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + " " + c.PlatformUserID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseIdentityCursor decodes a token from IdentityCursor.Encode.
+func ParseIdentityCursor(token string) (IdentityCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return IdentityCursor{}, ErrInvalidCursor
+	}
+	at, id, ok := strings.Cut(string(raw), " ")
+	if !ok || id == "" {
+		return IdentityCursor{}, ErrInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return IdentityCursor{}, ErrInvalidCursor
+	}
+	return IdentityCursor{CreatedAt: createdAt, PlatformUserID: id}, nil
+}
+
+// IdentityPage is one page of a listing. Next is the position of its last
+// identity when more may follow, nil on the last page.
+type IdentityPage struct {
+	Identities []PlatformIdentity
+	Next       *IdentityCursor
+}
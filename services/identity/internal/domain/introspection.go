@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidClient = errors.New("invalid client credentials")
+
+// TokenClaims are the verified claims of an access token.
+type TokenClaims struct {
+	Subject     string
+	Tenant      string
+	SubjectType string
+	Audience    []string
+	Scopes      []string
+	SessionID   string
+	// Actor is the act claim of a delegated token; nil otherwise.
+	Actor     *Actor
+	ExpiresAt time.Time
+	IssuedAt  time.Time
+}
+
+// SessionStatus is the revocation state of the session a token belongs to.
+type SessionStatus string
+
+const (
+	SessionStatusActive  SessionStatus = "active"
+	SessionStatusRevoked SessionStatus = "revoked"
+	// SessionStatusUnknown is reported for tokens without sid or whose
+	// session is not known to this instance.
+	SessionStatusUnknown SessionStatus = "unknown"
+)
+
+// Introspection is the result of introspecting a token (RFC 7662). Claims
+// are only set for active tokens.
+type Introspection struct {
+	Active        bool
+	Claims        TokenClaims
+	SessionStatus SessionStatus
+}
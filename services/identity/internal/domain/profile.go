@@ -0,0 +1,236 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInvalidProfile is returned for profile updates with unknown fields or
+// malformed values.
+var ErrInvalidProfile = errors.New("invalid profile")
+
+// ProfileField names an optional profile attribute of a platform identity.
+type ProfileField string
+
+const (
+	ProfileDisplayName ProfileField = "display_name"
+	// ProfileLocale is a BCP 47 language tag, e.g. de-AT.
+	ProfileLocale ProfileField = "locale"
+	// ProfileCountry is the ISO 3166-1 alpha-2 country or market, e.g. AT.
+	ProfileCountry   ProfileField = "country"
+	ProfileBirthYear ProfileField = "birth_year"
+)
+
+// ProfileFields are the known profile fields in display order.
+var ProfileFields = []ProfileField{ProfileDisplayName, ProfileLocale, ProfileCountry, ProfileBirthYear}
+
+const (
+	maxDisplayNameLength = 64
+	minBirthYear         = 1900
+)
+
+var localePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// Known reports whether f is a known profile field.
+func (f ProfileField) Known() bool {
+	switch f {
+	case ProfileDisplayName, ProfileLocale, ProfileCountry, ProfileBirthYear:
+		return true
+	}
+	return false
+}
+
+// Claim returns the access token claim the field is mapped to.
+func (f ProfileField) Claim() string {
+	if f == ProfileDisplayName {
+		return "name"
+	}
+	return string(f)
+}
+
+// ProfileSource is where the value of a profile attribute came from.
+type ProfileSource string
+
+const (
+	// ProfileSourceExchange values are supplied by the customer backend
+	// with an auth exchange. They are a copy of the customer's own data as
+	// of the assertion.
+	ProfileSourceExchange ProfileSource = "exchange"
+	// ProfileSourceAPI values are set explicitly through the identity API.
+	ProfileSourceAPI ProfileSource = "api"
+)
+
+// ProfileAttribute is the value of a profile field with its provenance.
+type ProfileAttribute struct {
+	Value     string
+	Source    ProfileSource
+	UpdatedAt time.Time
+}
+
+// Profile holds the profile attributes of a platform identity by field.
+type Profile map[ProfileField]ProfileAttribute
+
+// ProfileUpdate sets and clears profile attributes. All attributes it
+// touches get Source and At.
+type ProfileUpdate struct {
+	Source ProfileSource
+	At     time.Time
+	// Set maps fields to new values. Birth years are decimal.
+	Set map[ProfileField]string
+	// Clear lists fields to remove.
+	Clear []ProfileField
+}
+
+// Empty reports whether the update changes no field.
+func (u ProfileUpdate) Empty() bool {
+	return len(u.Set) == 0 && len(u.Clear) == 0
+}
+
+// Normalize validates the update and returns it with canonical values:
+// trimmed display names, upper-case countries and birth years without
+// leading zeros. Errors wrap ErrInvalidProfile.
+func (u ProfileUpdate) Normalize() (ProfileUpdate, error) {
+	if u.Source != ProfileSourceExchange && u.Source != ProfileSourceAPI {
+		return ProfileUpdate{}, fmt.Errorf("%w: unknown source %q", ErrInvalidProfile, u.Source)
+	}
+	if u.At.IsZero() {
+		return ProfileUpdate{}, fmt.Errorf("%w: missing update time", ErrInvalidProfile)
+	}
+	out := ProfileUpdate{Source: u.Source, At: u.At, Set: make(map[ProfileField]string, len(u.Set))}
+	for field, value := range u.Set {
+		normalized, err := normalizeProfileValue(field, value, u.At)
+		if err != nil {
+			return ProfileUpdate{}, err
+		}
+		out.Set[field] = normalized
+	}
+	for _, field := range u.Clear {
+		if !field.Known() {
+			return ProfileUpdate{}, fmt.Errorf("%w: unknown field %q", ErrInvalidProfile, field)
+		}
+		if _, ok := u.Set[field]; ok {
+			return ProfileUpdate{}, fmt.Errorf("%w: %s is both set and cleared", ErrInvalidProfile, field)
+		}
+		out.Clear = append(out.Clear, field)
+	}
+	return out, nil
+}
+
+func normalizeProfileValue(field ProfileField, value string, at time.Time) (string, error) {
+	switch field {
+	case ProfileDisplayName:
+		value = strings.TrimSpace(value)
+		if value == "" || !utf8.ValidString(value) || utf8.RuneCountInString(value) > maxDisplayNameLength {
+			return "", fmt.Errorf("%w: display_name must be 1 to %d characters", ErrInvalidProfile, maxDisplayNameLength)
+		}
+		if strings.ContainsFunc(value, unicode.IsControl) {
+			return "", fmt.Errorf("%w: display_name must not contain control characters", ErrInvalidProfile)
+		}
+		return value, nil
+	case ProfileLocale:
+		if !localePattern.MatchString(value) {
+			return "", fmt.Errorf("%w: locale must be a BCP 47 language tag", ErrInvalidProfile)
+		}
+		return value, nil
+	case ProfileCountry:
+		value = strings.ToUpper(value)
+		if len(value) != 2 || value[0] < 'A' || value[0] > 'Z' || value[1] < 'A' || value[1] > 'Z' {
+			return "", fmt.Errorf("%w: country must be an ISO 3166-1 alpha-2 code", ErrInvalidProfile)
+		}
+		return value, nil
+	case ProfileBirthYear:
+		year, err := strconv.Atoi(value)
+		if err != nil || year < minBirthYear || year > at.Year() {
+			return "", fmt.Errorf("%w: birth_year must be between %d and %d", ErrInvalidProfile, minBirthYear, at.Year())
+		}
+		return strconv.Itoa(year), nil
+	default:
+		return "", fmt.Errorf("%w: unknown field %q", ErrInvalidProfile, field)
+	}
+}
+
+// Apply returns the profile with a normalized update applied, and whether
+// any attribute was written. A field is only changed if the update is not older
+// than its current value, and values set through the API are never
+// replaced by exchange values: the customer backend may keep sending an
+// outdated copy. Clearing a field removes it, so exchanges can fill it
+// again.
+func (p Profile) Apply(u ProfileUpdate) (Profile, bool) {
+	out := maps.Clone(p)
+	if out == nil {
+		out = make(Profile)
+	}
+	changed := false
+	for field, value := range u.Set {
+		current, ok := out[field]
+		if ok && !u.accepts(current) {
+			continue
+		}
+		out[field] = ProfileAttribute{Value: value, Source: u.Source, UpdatedAt: u.At}
+		changed = true
+	}
+	for _, field := range u.Clear {
+		if current, ok := out[field]; ok && u.accepts(current) {
+			delete(out, field)
+			changed = true
+		}
+	}
+	if len(out) == 0 {
+		out = nil
+	}
+	return out, changed
+}
+
+// accepts reports whether the update may replace attribute a.
+func (u ProfileUpdate) accepts(a ProfileAttribute) bool {
+	if u.At.Before(a.UpdatedAt) {
+		return false
+	}
+	return u.Source == ProfileSourceAPI || a.Source == ProfileSourceExchange
+}
+
+// Adopt returns the profile completed with the attributes of other for
+// fields the profile does not have, e.g. when other is merged into it.
+func (p Profile) Adopt(other Profile) Profile {
+	out := maps.Clone(p)
+	for field, a := range other {
+		if _, ok := out[field]; ok {
+			continue
+		}
+		if out == nil {
+			out = make(Profile)
+		}
+		out[field] = a
+	}
+	return out
+}
+
+// Claims returns the access token claims for the selected fields that have
+// a value. Birth years are numbers, every other claim is a string.
+func (p Profile) Claims(fields []ProfileField) map[string]any {
+	var out map[string]any
+	for _, field := range fields {
+		a, ok := p[field]
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]any, len(fields))
+		}
+		if field == ProfileBirthYear {
+			if year, err := strconv.Atoi(a.Value); err == nil {
+				out[field.Claim()] = year
+			}
+			continue
+		}
+		out[field.Claim()] = a.Value
+	}
+	return out
+}
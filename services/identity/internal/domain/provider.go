@@ -0,0 +1,206 @@
+package domain
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"time"
+)
+
+var (
+	ErrProviderNotFound = errors.New("provider not found")
+	ErrProviderExists   = errors.New("provider already exists")
+	ErrInvalidProvider  = errors.New("invalid provider")
+	ErrProviderDisabled = errors.New("provider is disabled")
+	ErrTenantNotAllowed = errors.New("tenant not allowed for provider")
+	// ErrUnknownProvider is returned for assertions whose iss is not a
+	// registered provider.
+	ErrUnknownProvider = fmt.Errorf("%w: unknown provider", ErrInvalidAssertion)
+)
+
+// ProviderStatus controls whether a provider may exchange assertions.
+type ProviderStatus string
+
+const (
+	ProviderActive   ProviderStatus = "active"
+	ProviderDisabled ProviderStatus = "disabled"
+)
+
+const minRSAKeyBits = 2048
+
+var providerIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,127}$`)
+
+// Provider is a customer platform or auth provider allowed to exchange
+// identity assertions. Its ID is the assertion's iss and the provider of
+// the identities it resolves.
+type Provider struct {
+	ID          string
+	DisplayName string
+	Status      ProviderStatus
+	// AllowedTenants restricts the tenant claim of assertions. Empty allows
+	// any tenant.
+	AllowedTenants []string
+	// AssertionKeys are the public keys the provider signs assertions with.
+	AssertionKeys []PublicJWK
+	Tokens        ProviderTokenSettings
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ProviderTokenSettings replace the default player token lifetimes for
+// tokens of the provider. Zero keeps the default.
+type ProviderTokenSettings struct {
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+	// ProfileClaims are the profile fields copied into the provider's
+	// player access tokens.
+	ProfileClaims []ProfileField
+}
+
+// Active reports whether the provider may exchange assertions.
+func (p Provider) Active() bool {
+	return p.Status == ProviderActive
+}
+
+// AllowsTenant reports whether assertions of the provider may carry tenant.
+func (p Provider) AllowsTenant(tenant string) bool {
+	return len(p.AllowedTenants) == 0 || slices.Contains(p.AllowedTenants, tenant)
+}
+
+// Keys parses the provider's assertion keys.
+func (p Provider) Keys() ([]AssertionKey, error) {
+	keys := make([]AssertionKey, 0, len(p.AssertionKeys))
+	for i, jwk := range p.AssertionKeys {
+		key, err := jwk.AssertionKey()
+		if err != nil {
+			return nil, fmt.Errorf("assertion key %d: %w", i, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Validate checks the provider's fields. Errors wrap ErrInvalidProvider.
+func (p Provider) Validate() error {
+	if !providerIDPattern.MatchString(p.ID) {
+		return fmt.Errorf("%w: id must match %s", ErrInvalidProvider, providerIDPattern)
+	}
+	if p.Status != ProviderActive && p.Status != ProviderDisabled {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidProvider, p.Status)
+	}
+	for _, t := range p.AllowedTenants {
+		if t == "" {
+			return fmt.Errorf("%w: empty allowed tenant", ErrInvalidProvider)
+		}
+	}
+	keys, err := p.Keys()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidProvider, err)
+	}
+	kids := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		if kids[k.Kid] {
+			return fmt.Errorf("%w: duplicate assertion key kid %q", ErrInvalidProvider, k.Kid)
+		}
+		kids[k.Kid] = true
+	}
+	if len(keys) > 1 && kids[""] {
+		return fmt.Errorf("%w: assertion keys need a kid when a provider has several", ErrInvalidProvider)
+	}
+	if p.Tokens.AccessTTL < 0 || p.Tokens.RefreshTTL < 0 {
+		return fmt.Errorf("%w: token lifetimes must not be negative", ErrInvalidProvider)
+	}
+	for i, f := range p.Tokens.ProfileClaims {
+		if !f.Known() {
+			return fmt.Errorf("%w: unknown profile claim %q", ErrInvalidProvider, f)
+		}
+		if slices.Contains(p.Tokens.ProfileClaims[:i], f) {
+			return fmt.Errorf("%w: duplicate profile claim %q", ErrInvalidProvider, f)
+		}
+	}
+	return nil
+}
+
+// PublicJWK is a public JSON Web Key (RFC 7517) an assertion is verified
+// with. Ed25519 (EdDSA), P-256 (ES256) and RSA (RS256) keys are supported.
+type PublicJWK struct {
+	Kty string
+	Kid string
+	Alg string
+	Crv string
+	X   string
+	Y   string
+	N   string
+	E   string
+}
+
+// AssertionKey decodes the JWK into a verification key.
+func (j PublicJWK) AssertionKey() (AssertionKey, error) {
+	var (
+		alg string
+		pub any
+	)
+	switch {
+	case j.Kty == "OKP" && j.Crv == "Ed25519":
+		x, err := decodeJWKField(j.X, ed25519.PublicKeySize)
+		if err != nil {
+			return AssertionKey{}, fmt.Errorf("x: %v", err)
+		}
+		alg, pub = "EdDSA", ed25519.PublicKey(x)
+	case j.Kty == "EC" && j.Crv == "P-256":
+		x, err := decodeJWKField(j.X, 32)
+		if err != nil {
+			return AssertionKey{}, fmt.Errorf("x: %v", err)
+		}
+		y, err := decodeJWKField(j.Y, 32)
+		if err != nil {
+			return AssertionKey{}, fmt.Errorf("y: %v", err)
+		}
+		ecKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !ecKey.Curve.IsOnCurve(ecKey.X, ecKey.Y) {
+			return AssertionKey{}, errors.New("point not on P-256")
+		}
+		alg, pub = "ES256", ecKey
+	case j.Kty == "RSA":
+		n, err := decodeJWKField(j.N, 0)
+		if err != nil {
+			return AssertionKey{}, fmt.Errorf("n: %v", err)
+		}
+		e, err := decodeJWKField(j.E, 0)
+		if err != nil || len(e) > 4 {
+			return AssertionKey{}, errors.New("invalid e")
+		}
+		rsaKey := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		if rsaKey.N.BitLen() < minRSAKeyBits {
+			return AssertionKey{}, fmt.Errorf("RSA key shorter than %d bits", minRSAKeyBits)
+		}
+		alg, pub = "RS256", rsaKey
+	default:
+		return AssertionKey{}, fmt.Errorf("unsupported key kty=%q crv=%q", j.Kty, j.Crv)
+	}
+
+	if j.Alg != "" && j.Alg != alg {
+		return AssertionKey{}, fmt.Errorf("alg %q does not match key type (want %s)", j.Alg, alg)
+	}
+	return AssertionKey{Kid: j.Kid, Algorithm: alg, PublicKey: pub}, nil
+}
+
+// decodeJWKField decodes a base64url JWK member. A non-zero size requires
+// exactly that many bytes.
+func decodeJWKField(v string, size int) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 || (size > 0 && len(b) != size) {
+		return nil, fmt.Errorf("invalid length %d", len(b))
+	}
+	return b, nil
+}
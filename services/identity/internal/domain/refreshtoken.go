@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+)
+
+// RefreshToken is the stored state of an opaque refresh token.
+// Only a hash of the token is stored; the token itself is returned to the
+// client once. Every refresh rotates the token within the same family, so
+// presenting an already used token reveals theft and revokes the family.
+// The family is the session the tokens were issued for.
+type RefreshToken struct {
+	TokenHash      string
+	SessionID      string
+	PlatformUserID string
+	Tenant         string
+	IssuedAt       time.Time
+	ExpiresAt      time.Time
+	UsedAt         time.Time
+	RevokedAt      time.Time
+}
+
+// Usable reports whether the token may be exchanged at the given time.
+func (t RefreshToken) Usable(now time.Time) bool {
+	return t.RevokedAt.IsZero() && now.Before(t.ExpiresAt)
+}
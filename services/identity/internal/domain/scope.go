@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidScope = errors.New("requested scope not granted")
+
+// ScopeRule grants scopes to tokens whose provider, tenant and subject type
+// match. An empty match field matches any value. Backoffice tokens have no
+// provider, so only rules without a provider apply to them.
+type ScopeRule struct {
+	Provider    string
+	Tenant      string
+	SubjectType string
+	Scopes      []string
+}
+
+func (r ScopeRule) matches(provider, tenant, subjectType string) bool {
+	return (r.Provider == "" || r.Provider == provider) &&
+		(r.Tenant == "" || r.Tenant == tenant) &&
+		(r.SubjectType == "" || r.SubjectType == subjectType)
+}
+
+// ScopePolicy decides which scopes a token may carry. The zero policy
+// grants no scopes.
+type ScopePolicy struct {
+	Rules []ScopeRule
+}
+
+// Granted returns the sorted union of the scopes of every matching rule.
+func (p ScopePolicy) Granted(provider, tenant, subjectType string) []string {
+	var granted []string
+	for _, rule := range p.Rules {
+		if rule.matches(provider, tenant, subjectType) {
+			granted = append(granted, rule.Scopes...)
+		}
+	}
+	slices.Sort(granted)
+	return slices.Compact(granted)
+}
+
+// SelectScopes narrows granted scopes to the requested ones. An empty
+// request selects every granted scope; requesting a scope that is not
+// granted returns ErrInvalidScope.
+func SelectScopes(granted, requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return granted, nil
+	}
+	selected := make([]string, 0, len(requested))
+	for _, scope := range requested {
+		if !slices.Contains(granted, scope) {
+			return nil, ErrInvalidScope
+		}
+		selected = append(selected, scope)
+	}
+	slices.Sort(selected)
+	return slices.Compact(selected), nil
+}
+
+// ParseScope splits a space-separated scope string (RFC 6749 §3.3).
+func ParseScope(scope string) []string {
+	return strings.Fields(scope)
+}
+
+// FormatScope joins scopes into a space-separated scope string.
+func FormatScope(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// SubjectTypePlayer is the session subject type for tokens from the player
+// auth exchange. Backoffice sessions use the backoffice subject type
+// (operator, tenant_user).
+const SubjectTypePlayer = "player"
+
+// Session groups the tokens issued for one auth exchange or backoffice
+// login. Access tokens carry the session ID as "sid"; for players the
+// session is also the refresh token family. Revoking a session invalidates
+// both before they expire.
+type Session struct {
+	ID             string
+	PlatformUserID string
+	SubjectType    string
+	Tenant         string
+	// Provider is the provider of a player session; empty for backoffice
+	// sessions.
+	Provider string
+	// Scopes are granted at session start and carried by every token of
+	// the session.
+	Scopes    []string
+	CreatedAt time.Time
+	// ExpiresAt is when the last token of the session expires. Refreshing
+	// extends it.
+	ExpiresAt    time.Time
+	RevokedAt    time.Time
+	RevokeReason string
+}
+
+// Active reports whether the session is neither revoked nor expired.
+func (s Session) Active(now time.Time) bool {
+	return s.RevokedAt.IsZero() && now.Before(s.ExpiresAt)
+}
+
+// Revocation tells token verifiers to reject a session's access tokens.
+// It is only relevant until ExpiresAt, after which every access token of
+// the session has expired on its own.
+type Revocation struct {
+	SessionID      string
+	PlatformUserID string
+	RevokedAt      time.Time
+	ExpiresAt      time.Time
+}
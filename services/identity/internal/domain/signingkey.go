@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"crypto"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNoActiveSigningKey = errors.New("no active signing key")
+	ErrRotationTooSoon    = errors.New("next signing key has not been published long enough")
+	ErrKeyRingChanged     = errors.New("signing key ring was changed concurrently")
+)
+
+// SigningKeyState is the lifecycle state of a token signing key.
+//
+//	next -> active -> retiring -> retired
+//
+// Next keys are published before they sign anything so that verifiers
+// already know them when they become active. Retiring keys no longer sign
+// but stay published until every token they signed has expired.
+type SigningKeyState string
+
+const (
+	SigningKeyNext     SigningKeyState = "next"
+	SigningKeyActive   SigningKeyState = "active"
+	SigningKeyRetiring SigningKeyState = "retiring"
+	SigningKeyRetired  SigningKeyState = "retired"
+)
+
+// Published reports whether keys in this state belong in the JWKS.
+func (s SigningKeyState) Published() bool {
+	return s == SigningKeyNext || s == SigningKeyActive || s == SigningKeyRetiring
+}
+
+// SigningKeyInfo describes a key in the signing key ring without exposing
+// private key material.
+type SigningKeyInfo struct {
+	Kid         string
+	Algorithm   string
+	State       SigningKeyState
+	CreatedAt   time.Time
+	ActivatedAt time.Time
+	RetiringAt  time.Time
+	RetiredAt   time.Time
+}
+
+// VerificationKey is a published public key used to verify access tokens:
+// an ed25519.PublicKey for EdDSA, an *rsa.PublicKey for RS256 or an
+// *ecdsa.PublicKey for ES256.
+type VerificationKey struct {
+	Kid       string
+	Algorithm string
+	PublicKey crypto.PublicKey
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrIdentityInactive is returned when tokens are requested for a
+	// suspended or banned platform identity.
+	ErrIdentityInactive  = errors.New("platform identity is not active")
+	ErrIdentitySuspended = fmt.Errorf("%w: suspended", ErrIdentityInactive)
+	ErrIdentityBanned    = fmt.Errorf("%w: banned", ErrIdentityInactive)
+	ErrInvalidStatus     = errors.New("invalid identity status")
+)
+
+// AuditIdentityStatusChanged records a status change of the entry's user.
+const AuditIdentityStatusChanged AuditAction = "identity.status_changed"
+
+// IdentityState is the lifecycle state of a platform identity.
+type IdentityState string
+
+const (
+	IdentityActive IdentityState = "active"
+	// IdentitySuspended identities are blocked until IdentityStatus.Until.
+	IdentitySuspended IdentityState = "suspended"
+	// IdentityBanned identities are blocked until reactivated.
+	IdentityBanned IdentityState = "banned"
+)
+
+// StatusReason is the reason code of a status change.
+type StatusReason string
+
+const (
+	ReasonCheating       StatusReason = "cheating"
+	ReasonAbuse          StatusReason = "abuse"
+	ReasonFraud          StatusReason = "fraud"
+	ReasonChargeback     StatusReason = "chargeback"
+	ReasonTermsViolation StatusReason = "terms_violation"
+	ReasonUnderage       StatusReason = "underage"
+	// ReasonAppealGranted reactivates an identity after a successful appeal.
+	ReasonAppealGranted StatusReason = "appeal_granted"
+	ReasonOther         StatusReason = "other"
+)
+
+// Known reports whether r is a known reason code.
+func (r StatusReason) Known() bool {
+	switch r {
+	case ReasonCheating, ReasonAbuse, ReasonFraud, ReasonChargeback, ReasonTermsViolation,
+		ReasonUnderage, ReasonAppealGranted, ReasonOther:
+		return true
+	}
+	return false
+}
+
+// IdentityStatus is the lifecycle status of a platform identity. The zero
+// value is the status of an identity that was never suspended or banned.
+type IdentityStatus struct {
+	// State is empty for identities that were never suspended or banned.
+	State  IdentityState
+	Reason StatusReason
+	// Until ends a suspension; zero for other states.
+	Until     time.Time
+	ChangedAt time.Time
+}
+
+// Validate checks a status to be set at now. Suspensions and bans need a
+// reason code, and suspensions an end in the future; reactivations may
+// name a reason. Errors wrap ErrInvalidStatus.
+func (s IdentityStatus) Validate(now time.Time) error {
+	if s.Reason != "" && !s.Reason.Known() {
+		return fmt.Errorf("%w: unknown reason %q", ErrInvalidStatus, s.Reason)
+	}
+	switch s.State {
+	case IdentityActive:
+		if !s.Until.IsZero() {
+			return fmt.Errorf("%w: until is only allowed for suspensions", ErrInvalidStatus)
+		}
+	case IdentitySuspended:
+		if s.Reason == "" {
+			return fmt.Errorf("%w: suspensions need a reason", ErrInvalidStatus)
+		}
+		if !s.Until.After(now) {
+			return fmt.Errorf("%w: suspensions need an until in the future", ErrInvalidStatus)
+		}
+	case IdentityBanned:
+		if s.Reason == "" {
+			return fmt.Errorf("%w: bans need a reason", ErrInvalidStatus)
+		}
+		if !s.Until.IsZero() {
+			return fmt.Errorf("%w: until is only allowed for suspensions", ErrInvalidStatus)
+		}
+	default:
+		return fmt.Errorf("%w: unknown state %q", ErrInvalidStatus, s.State)
+	}
+	return nil
+}
+
+// At returns the status in effect at now: a suspension that has ended
+// reads as active from its end on.
+func (s IdentityStatus) At(now time.Time) IdentityStatus {
+	switch {
+	case s.State == "":
+		return IdentityStatus{State: IdentityActive}
+	case s.State == IdentitySuspended && !now.Before(s.Until):
+		return IdentityStatus{State: IdentityActive, ChangedAt: s.Until}
+	}
+	return s
+}
+
+// ActiveAt reports whether the identity may get tokens at now.
+func (s IdentityStatus) ActiveAt(now time.Time) bool {
+	return s.At(now).State == IdentityActive
+}
+
+// Check returns ErrIdentitySuspended or ErrIdentityBanned if the identity
+// may not get tokens at now.
+func (s IdentityStatus) Check(now time.Time) error {
+	switch s.At(now).State {
+	case IdentitySuspended:
+		return ErrIdentitySuspended
+	case IdentityBanned:
+		return ErrIdentityBanned
+	}
+	return nil
+}
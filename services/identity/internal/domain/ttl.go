@@ -0,0 +1,85 @@
+package domain
+
+import "time"
+
+// TTLOverride replaces the default access token lifetime for tokens whose
+// audience, tenant and subject type match. An empty match field matches any
+// value.
+type TTLOverride struct {
+	Audience    string
+	Tenant      string
+	SubjectType string
+	TTL         time.Duration
+}
+
+func (o TTLOverride) matches(audience, tenant, subjectType string) bool {
+	return (o.Audience == "" || o.Audience == audience) &&
+		(o.Tenant == "" || o.Tenant == tenant) &&
+		(o.SubjectType == "" || o.SubjectType == subjectType)
+}
+
+func (o TTLOverride) specificity() int {
+	n := 0
+	for _, f := range []string{o.Audience, o.Tenant, o.SubjectType} {
+		if f != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// TTLPolicy decides token lifetimes.
+type TTLPolicy struct {
+	// PlayerAudience is the audience of player tokens, used to match
+	// overrides against tokens issued with the issuer's default audience.
+	PlayerAudience   string
+	PlayerAccess     time.Duration
+	BackofficeAccess time.Duration
+	Refresh          time.Duration
+	// Overrides are applied most specific first (most match fields set);
+	// among equally specific matches the first one wins.
+	Overrides []TTLOverride
+}
+
+// AccessTTL returns the access token lifetime for a token. An empty
+// audience means the player audience.
+func (p TTLPolicy) AccessTTL(audience, tenant, subjectType string) time.Duration {
+	if audience == "" {
+		audience = p.PlayerAudience
+	}
+	ttl := p.BackofficeAccess
+	if subjectType == SubjectTypePlayer {
+		ttl = p.PlayerAccess
+	}
+
+	best := -1
+	for _, o := range p.Overrides {
+		if o.matches(audience, tenant, subjectType) && o.specificity() > best {
+			ttl = o.TTL
+			best = o.specificity()
+		}
+	}
+	return ttl
+}
+
+// ForProvider returns the policy with the provider's player token lifetimes
+// replacing the defaults. Overrides still apply on top.
+func (p TTLPolicy) ForProvider(settings ProviderTokenSettings) TTLPolicy {
+	if settings.AccessTTL > 0 {
+		p.PlayerAccess = settings.AccessTTL
+	}
+	if settings.RefreshTTL > 0 {
+		p.Refresh = settings.RefreshTTL
+	}
+	return p
+}
+
+// MaxAccessTTL returns the longest access token lifetime the policy can
+// produce.
+func (p TTLPolicy) MaxAccessTTL() time.Duration {
+	ttl := max(p.PlayerAccess, p.BackofficeAccess)
+	for _, o := range p.Overrides {
+		ttl = max(ttl, o.TTL)
+	}
+	return ttl
+}
@@ -1,18 +1,203 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	platformconfig "github.com/woffVienna/proteon-cursor/libs/platform/config"
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/jwtverifier"
+	"github.com/woffVienna/proteon-cursor/libs/platform/security/serviceauth"
 )
 
 type Config = platformconfig.Config[ServiceConfig]
 
+// Bounds enforced on configured token lifetimes.
+const (
+	minAccessTokenTTL  = time.Minute
+	maxAccessTokenTTL  = 24 * time.Hour
+	maxRefreshTokenTTL = 90 * 24 * time.Hour
+	maxAssertionLife   = 10 * time.Minute
+)
+
 type ServiceConfig struct {
-	JWT JWTConfig
+	Store         StoreConfig
+	Events        EventsConfig
+	JWT           JWTConfig
+	Tokens        TokenConfig
+	Scopes        ScopeConfig
+	Delegation    DelegationConfig
+	Providers     ProviderConfig
+	Assertions    AssertionConfig
+	Introspection IntrospectionConfig
+	OAuth         OAuthConfig
+	Internal      InternalConfig
+}
+
+// Identity store backends.
+const (
+	StoreMemory   = "memory"
+	StoreFile     = "file"
+	StorePostgres = "postgres"
+)
+
+type StoreConfig struct {
+	// Backend selects where platform identities, sessions and refresh
+	// tokens live: StoreMemory (lost on restart, ENV=dev only), StoreFile
+	// (durable, single replica) or StorePostgres.
+	Backend string
+	// DSN is the Postgres connection string, required for StorePostgres.
+	DSN string
+	// Dir holds the log and snapshot of StoreFile.
+	Dir string
+	// Fsync is the StoreFile fsync policy: always, interval or never.
+	Fsync         string
+	FsyncInterval time.Duration
+	// SnapshotEvery compacts the StoreFile log after that many records.
+	SnapshotEvery int
+}
+
+type EventsConfig struct {
+	// NATSURL is the NATS server events are published to with JetStream.
+	// Empty writes events to the service log.
+	NATSURL string
+	// Stream is the JetStream stream of identity's events, created on
+	// startup if it does not exist.
+	Stream string
+	// RelayInterval is how often the outbox is polled for new events.
+	RelayInterval time.Duration
 }
 
 type JWTConfig struct {
 	Issuer   string
 	Audience string
+	// SigningAlg is the JWS algorithm of access tokens (EdDSA, RS256 or
+	// ES256) unless AudienceAlgs names one for their audience.
+	SigningAlg string
+	// AudienceAlgs maps audience -> JWS algorithm, for consumers that can
+	// only verify RSA or ECDSA signatures.
+	AudienceAlgs map[string]string
+	// SigningKeyFile is the path to a PEM (PKCS#8) encoded Ed25519, RSA or
+	// P-256 private key, or a JWK encoded Ed25519 private key.
+	SigningKeyFile string
+	// SigningKey holds the PEM or JWK encoded key inline (e.g. injected from a secret).
+	SigningKey string
+	// KeyRingFile persists the signing key ring. When the file exists it is
+	// authoritative; otherwise the ring is seeded from the configured key.
+	// With StorePostgres the ring is kept in the database instead and the
+	// file is only imported into an empty one.
+	KeyRingFile string
+	// KeyRotationInterval enables scheduled rotation. Zero means rotation is
+	// only triggered through the internal API.
+	KeyRotationInterval time.Duration
+	// KeyRetireAfter is how long a retiring key stays published in the JWKS.
+	KeyRetireAfter time.Duration
+	// JWKSMaxAge is the Cache-Control max-age of the JWKS response and the
+	// minimum time a next key is published before it may become active.
+	JWKSMaxAge time.Duration
+}
+
+type TokenConfig struct {
+	// AccessTTL is the default player access token lifetime.
+	AccessTTL time.Duration
+	// BackofficeTTL is the default backoffice access token lifetime.
+	BackofficeTTL time.Duration
+	// RefreshTTL is the player refresh token lifetime; refreshing extends
+	// the session by this much.
+	RefreshTTL time.Duration
+	// Overrides replace the default access token lifetime for matching
+	// audience, tenant and subject type.
+	Overrides []TTLOverride
+}
+
+// TTLOverride is one entry of TOKEN_TTL_OVERRIDES, e.g.
+// {"subject_type":"operator","ttl":"8h"}. Omitted match fields match any value.
+type TTLOverride struct {
+	Audience    string
+	Tenant      string
+	SubjectType string
+	TTL         time.Duration
+}
+
+// MaxAccessTTL returns the longest configured access token lifetime.
+func (t TokenConfig) MaxAccessTTL() time.Duration {
+	ttl := max(t.AccessTTL, t.BackofficeTTL)
+	for _, o := range t.Overrides {
+		ttl = max(ttl, o.TTL)
+	}
+	return ttl
+}
+
+type ScopeConfig struct {
+	// PolicyFile is the path to a JSON scope policy.
+	PolicyFile string
+	// Policy holds the JSON scope policy inline. Without a policy no scopes
+	// are granted.
+	Policy string
+}
+
+type DelegationConfig struct {
+	// PolicyFile is the path to a JSON delegation policy.
+	PolicyFile string
+	// Policy holds the JSON delegation policy inline. Without a policy no
+	// token exchange is allowed.
+	Policy string
+}
+
+type ProviderConfig struct {
+	// StoreFile persists the provider registry as JSON. Empty keeps
+	// providers in memory only. With StorePostgres the registry is kept in
+	// the database instead and providers of the file it lacks are imported.
+	StoreFile string
+}
+
+type AssertionConfig struct {
+	// Audience is the aud value assertions must carry.
+	Audience string
+	// MaxLifetime caps exp - iat of an assertion.
+	MaxLifetime time.Duration
+	// Leeway is the clock skew tolerated on iat and exp.
+	Leeway time.Duration
+}
+
+type IntrospectionConfig struct {
+	// Clients maps client ID -> secret of the clients allowed to call
+	// POST /internal/v1/introspect (HTTP Basic).
+	Clients map[string]string
+}
+
+// OAuthConfig holds the public URLs of the backoffice authorization code
+// flow, which the auth service serves. They are advertised in the
+// discovery document; empty omits them.
+type OAuthConfig struct {
+	AuthorizationEndpoint string
+	TokenEndpoint         string
+}
+
+// InternalConfig controls who may call the /internal routes. Callers
+// authenticate with a service token (Authorization: Bearer) or, on the
+// internal TLS listener, a client certificate whose Common Name names them.
+type InternalConfig struct {
+	// Callers maps caller name -> Ed25519 public key of its service tokens.
+	Callers map[string]ed25519.PublicKey
+	// TokenAudience is the aud service tokens must carry.
+	TokenAudience string
+	// Allow maps an operation ID (or "*" for every internal operation) to
+	// the callers allowed to invoke it.
+	Allow map[string][]string
+	// TLSPort enables the internal TLS listener.
+	TLSPort      string
+	TLSCertFile  string
+	TLSKeyFile   string
+	ClientCAFile string
+}
+
+// Authenticated reports whether any caller authentication is configured.
+func (c InternalConfig) Authenticated() bool {
+	return len(c.Callers) > 0 || c.ClientCAFile != ""
 }
 
 func Load() (Config, error) {
@@ -25,12 +210,422 @@ func Load() (Config, error) {
 		DefaultPort:        "8081",
 	})
 
-	return loader.Load(func(env platformconfig.Env) (ServiceConfig, error) {
+	cfg, err := loader.Load(func(env platformconfig.Env) (ServiceConfig, error) {
+		storeFsyncInterval, err := env.Duration("IDENTITY_STORE_FSYNC_INTERVAL", time.Second)
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+		storeSnapshotEvery, err := strconv.Atoi(env.String("IDENTITY_STORE_SNAPSHOT_EVERY", "10000"))
+		if err != nil {
+			return ServiceConfig{}, fmt.Errorf("IDENTITY_STORE_SNAPSHOT_EVERY: %w", err)
+		}
+
+		relayInterval, err := env.Duration("EVENTS_RELAY_INTERVAL", time.Second)
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+
+		rotationInterval, err := env.Duration("JWT_KEY_ROTATION_INTERVAL", 0)
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+		retireAfter, err := env.Duration("JWT_KEY_RETIRE_AFTER", time.Hour)
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+		jwksMaxAge, err := env.Duration("JWKS_CACHE_MAX_AGE", 5*time.Minute)
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+
+		accessTTL, err := env.Duration("ACCESS_TOKEN_TTL", 10*time.Minute)
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+		backofficeTTL, err := env.Duration("BACKOFFICE_TOKEN_TTL", 10*time.Minute)
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+		refreshTTL, err := env.Duration("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+		ttlOverrides, err := parseTTLOverrides(env.String("TOKEN_TTL_OVERRIDES", ""))
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+
+		assertionMaxLifetime, err := env.Duration("ASSERTION_MAX_LIFETIME", 5*time.Minute)
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+		assertionLeeway, err := env.Duration("ASSERTION_LEEWAY", 30*time.Second)
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+
+		audienceAlgs, err := parseAudienceAlgs(env.String("JWT_AUDIENCE_ALGS", ""))
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+
+		issuer := env.String("JWT_ISSUER", "proteon.identity")
+		introspectionClients, err := parsePairs("INTROSPECTION_CLIENTS", env.String("INTROSPECTION_CLIENTS", ""))
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+
+		serviceCallers, err := parsePairs("SERVICE_CALLERS", env.String("SERVICE_CALLERS", ""))
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+		callerKeys := make(map[string]ed25519.PublicKey, len(serviceCallers))
+		for caller, key := range serviceCallers {
+			if callerKeys[caller], err = serviceauth.ParsePublicKey(key); err != nil {
+				return ServiceConfig{}, fmt.Errorf("invalid SERVICE_CALLERS entry %q: %w", caller, err)
+			}
+		}
+		internalAllow, err := parseAllowlist(env.String("INTERNAL_ALLOWLIST", ""))
+		if err != nil {
+			return ServiceConfig{}, err
+		}
+
 		return ServiceConfig{
 			JWT: JWTConfig{
-				Issuer:   env.String("JWT_ISSUER", "proteon.identity"),
-				Audience: env.String("JWT_AUDIENCE", "proteon-api"),
+				Issuer:              issuer,
+				Audience:            env.String("JWT_AUDIENCE", "proteon-api"),
+				SigningAlg:          env.String("JWT_SIGNING_ALG", jwtverifier.AlgEdDSA),
+				AudienceAlgs:        audienceAlgs,
+				SigningKeyFile:      env.String("JWT_SIGNING_KEY_FILE", ""),
+				SigningKey:          env.String("JWT_SIGNING_KEY", ""),
+				KeyRingFile:         env.String("JWT_KEY_RING_FILE", ""),
+				KeyRotationInterval: rotationInterval,
+				KeyRetireAfter:      retireAfter,
+				JWKSMaxAge:          jwksMaxAge,
+			},
+			Tokens: TokenConfig{
+				AccessTTL:     accessTTL,
+				BackofficeTTL: backofficeTTL,
+				RefreshTTL:    refreshTTL,
+				Overrides:     ttlOverrides,
+			},
+			Store: StoreConfig{
+				Backend:       env.String("IDENTITY_STORE", StoreMemory),
+				DSN:           env.String("DB_DSN", ""),
+				Dir:           env.String("IDENTITY_STORE_DIR", ""),
+				Fsync:         env.String("IDENTITY_STORE_FSYNC", "always"),
+				FsyncInterval: storeFsyncInterval,
+				SnapshotEvery: storeSnapshotEvery,
+			},
+			Events: EventsConfig{
+				NATSURL:       env.String("NATS_URL", ""),
+				Stream:        env.String("NATS_STREAM", "IDENTITY"),
+				RelayInterval: relayInterval,
+			},
+			Scopes: ScopeConfig{
+				PolicyFile: env.String("SCOPE_POLICY_FILE", ""),
+				Policy:     env.String("SCOPE_POLICY", ""),
+			},
+			Delegation: DelegationConfig{
+				PolicyFile: env.String("DELEGATION_POLICY_FILE", ""),
+				Policy:     env.String("DELEGATION_POLICY", ""),
+			},
+			Providers: ProviderConfig{
+				StoreFile: env.String("PROVIDER_STORE_FILE", ""),
+			},
+			Assertions: AssertionConfig{
+				Audience:    env.String("ASSERTION_AUDIENCE", issuer),
+				MaxLifetime: assertionMaxLifetime,
+				Leeway:      assertionLeeway,
+			},
+			Introspection: IntrospectionConfig{
+				Clients: introspectionClients,
+			},
+			OAuth: OAuthConfig{
+				AuthorizationEndpoint: env.String("OAUTH_AUTHORIZATION_ENDPOINT", ""),
+				TokenEndpoint:         env.String("OAUTH_TOKEN_ENDPOINT", ""),
+			},
+			Internal: InternalConfig{
+				Callers:       callerKeys,
+				TokenAudience: env.String("SERVICE_TOKEN_AUDIENCE", "identity-service"),
+				Allow:         internalAllow,
+				TLSPort:       env.String("INTERNAL_TLS_PORT", ""),
+				TLSCertFile:   env.String("INTERNAL_TLS_CERT_FILE", ""),
+				TLSKeyFile:    env.String("INTERNAL_TLS_KEY_FILE", ""),
+				ClientCAFile:  env.String("INTERNAL_TLS_CLIENT_CA_FILE", ""),
 			},
 		}, nil
 	})
+	if err != nil {
+		return Config{}, err
+	}
+
+	if err := validateStore(cfg.Environment, cfg.Service.Store); err != nil {
+		return Config{}, err
+	}
+	if err := validateEvents(cfg.Service.Events); err != nil {
+		return Config{}, err
+	}
+	if err := validateJWT(cfg.Environment, cfg.Service.JWT, cfg.Service.Store.Backend); err != nil {
+		return Config{}, err
+	}
+	if err := validateTokens(cfg.Service.Tokens, cfg.Service.JWT); err != nil {
+		return Config{}, err
+	}
+	if cfg.Service.Scopes.PolicyFile != "" && cfg.Service.Scopes.Policy != "" {
+		return Config{}, fmt.Errorf("set only one of SCOPE_POLICY_FILE and SCOPE_POLICY")
+	}
+	if cfg.Service.Delegation.PolicyFile != "" && cfg.Service.Delegation.Policy != "" {
+		return Config{}, fmt.Errorf("set only one of DELEGATION_POLICY_FILE and DELEGATION_POLICY")
+	}
+	if err := validateAssertions(cfg.Service.Assertions); err != nil {
+		return Config{}, err
+	}
+	if (cfg.Service.OAuth.AuthorizationEndpoint == "") != (cfg.Service.OAuth.TokenEndpoint == "") {
+		return Config{}, fmt.Errorf("set both or neither of OAUTH_AUTHORIZATION_ENDPOINT and OAUTH_TOKEN_ENDPOINT")
+	}
+	if err := validateInternal(cfg.Environment, cfg.Service.Internal); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// validateStore enforces that only ENV=dev may keep identities, sessions
+// and refresh tokens in memory.
+func validateStore(environment string, store StoreConfig) error {
+	switch store.Backend {
+	case StoreMemory:
+		if environment != "dev" {
+			return fmt.Errorf("IDENTITY_STORE=%s is only allowed when ENV=dev, got ENV=%s", StoreMemory, environment)
+		}
+		return nil
+	case StoreFile:
+		if store.Dir == "" {
+			return fmt.Errorf("IDENTITY_STORE_DIR is required with IDENTITY_STORE=%s", StoreFile)
+		}
+		switch store.Fsync {
+		case "always", "never":
+		case "interval":
+			if store.FsyncInterval <= 0 {
+				return fmt.Errorf("IDENTITY_STORE_FSYNC_INTERVAL must be positive")
+			}
+		default:
+			return fmt.Errorf("IDENTITY_STORE_FSYNC must be always, interval or never, got %q", store.Fsync)
+		}
+		if store.SnapshotEvery < 0 {
+			return fmt.Errorf("IDENTITY_STORE_SNAPSHOT_EVERY must not be negative")
+		}
+		return nil
+	case StorePostgres:
+		if store.DSN == "" {
+			return fmt.Errorf("DB_DSN is required with IDENTITY_STORE=%s", StorePostgres)
+		}
+		return nil
+	default:
+		return fmt.Errorf("IDENTITY_STORE must be %q, %q or %q, got %q", StoreMemory, StoreFile, StorePostgres, store.Backend)
+	}
+}
+
+func validateEvents(events EventsConfig) error {
+	if events.NATSURL != "" && events.Stream == "" {
+		return fmt.Errorf("NATS_STREAM must not be empty with NATS_URL")
+	}
+	if events.RelayInterval <= 0 {
+		return fmt.Errorf("EVENTS_RELAY_INTERVAL must be positive")
+	}
+	return nil
+}
+
+// validateInternal enforces that only ENV=dev may leave the /internal
+// routes unauthenticated.
+func validateInternal(environment string, internal InternalConfig) error {
+	tlsSet := internal.TLSCertFile != "" || internal.TLSKeyFile != "" || internal.ClientCAFile != ""
+	if internal.TLSPort == "" && tlsSet {
+		return fmt.Errorf("INTERNAL_TLS_PORT is required with INTERNAL_TLS_CERT_FILE, INTERNAL_TLS_KEY_FILE or INTERNAL_TLS_CLIENT_CA_FILE")
+	}
+	if internal.TLSPort != "" && (internal.TLSCertFile == "" || internal.TLSKeyFile == "" || internal.ClientCAFile == "") {
+		return fmt.Errorf("INTERNAL_TLS_PORT requires INTERNAL_TLS_CERT_FILE, INTERNAL_TLS_KEY_FILE and INTERNAL_TLS_CLIENT_CA_FILE")
+	}
+	if environment != "dev" && !internal.Authenticated() {
+		return fmt.Errorf("SERVICE_CALLERS or INTERNAL_TLS_CLIENT_CA_FILE is required when ENV=%s", environment)
+	}
+	if internal.TokenAudience == "" {
+		return fmt.Errorf("SERVICE_TOKEN_AUDIENCE must not be empty")
+	}
+	return nil
+}
+
+// validateAssertions keeps identity assertions short-lived so the replay
+// cache only has to remember a few minutes of jtis.
+func validateAssertions(a AssertionConfig) error {
+	if a.Audience == "" {
+		return fmt.Errorf("ASSERTION_AUDIENCE must not be empty")
+	}
+	if a.MaxLifetime <= 0 || a.MaxLifetime > maxAssertionLife {
+		return fmt.Errorf("ASSERTION_MAX_LIFETIME (%s) must be positive and at most %s", a.MaxLifetime, maxAssertionLife)
+	}
+	if a.Leeway < 0 || a.Leeway > time.Minute {
+		return fmt.Errorf("ASSERTION_LEEWAY (%s) must be between 0s and 1m", a.Leeway)
+	}
+	return nil
+}
+
+// validateJWT enforces that only ENV=dev may run with ephemeral signing keys
+// and that rotation timings leave no window where verifiers miss a key.
+func validateJWT(environment string, jwt JWTConfig, storeBackend string) error {
+	if jwt.SigningKeyFile != "" && jwt.SigningKey != "" {
+		return fmt.Errorf("set only one of JWT_SIGNING_KEY_FILE and JWT_SIGNING_KEY")
+	}
+	hasKey := jwt.SigningKeyFile != "" || jwt.SigningKey != ""
+	// With IDENTITY_STORE=postgres the ring is kept in the database.
+	persisted := jwt.KeyRingFile != "" || storeBackend == StorePostgres
+	if environment != "dev" && !hasKey && !persisted {
+		return fmt.Errorf("JWT_SIGNING_KEY_FILE, JWT_SIGNING_KEY or JWT_KEY_RING_FILE is required when ENV=%s", environment)
+	}
+	if !jwtverifier.Supported(jwt.SigningAlg) {
+		return fmt.Errorf("JWT_SIGNING_ALG must be %s, %s or %s, got %q", jwtverifier.AlgEdDSA, jwtverifier.AlgRS256, jwtverifier.AlgES256, jwt.SigningAlg)
+	}
+	for audience, alg := range jwt.AudienceAlgs {
+		if !jwtverifier.Supported(alg) {
+			return fmt.Errorf("invalid JWT_AUDIENCE_ALGS entry %q: algorithm must be %s, %s or %s, got %q", audience, jwtverifier.AlgEdDSA, jwtverifier.AlgRS256, jwtverifier.AlgES256, alg)
+		}
+	}
+	if environment != "dev" && jwt.KeyRotationInterval > 0 && !persisted {
+		return fmt.Errorf("JWT_KEY_RING_FILE is required for scheduled key rotation when ENV=%s", environment)
+	}
+	if jwt.JWKSMaxAge <= 0 {
+		return fmt.Errorf("JWKS_CACHE_MAX_AGE must be positive")
+	}
+	if jwt.KeyRotationInterval < 0 {
+		return fmt.Errorf("JWT_KEY_ROTATION_INTERVAL must not be negative")
+	}
+	if jwt.KeyRotationInterval > 0 && jwt.KeyRotationInterval < jwt.JWKSMaxAge {
+		return fmt.Errorf("JWT_KEY_ROTATION_INTERVAL (%s) must be at least JWKS_CACHE_MAX_AGE (%s)", jwt.KeyRotationInterval, jwt.JWKSMaxAge)
+	}
+	if jwt.KeyRetireAfter <= jwt.JWKSMaxAge {
+		return fmt.Errorf("JWT_KEY_RETIRE_AFTER (%s) must exceed JWKS_CACHE_MAX_AGE (%s)", jwt.KeyRetireAfter, jwt.JWKSMaxAge)
+	}
+	return nil
+}
+
+// validateTokens enforces lifetime bounds and that retiring signing keys
+// stay published until every token they signed has expired.
+func validateTokens(tokens TokenConfig, jwt JWTConfig) error {
+	accessTTLs := map[string]time.Duration{
+		"ACCESS_TOKEN_TTL":     tokens.AccessTTL,
+		"BACKOFFICE_TOKEN_TTL": tokens.BackofficeTTL,
+	}
+	for i, o := range tokens.Overrides {
+		accessTTLs[fmt.Sprintf("TOKEN_TTL_OVERRIDES[%d]", i)] = o.TTL
+	}
+	for key, ttl := range accessTTLs {
+		if ttl < minAccessTokenTTL || ttl > maxAccessTokenTTL {
+			return fmt.Errorf("%s (%s) must be between %s and %s", key, ttl, minAccessTokenTTL, maxAccessTokenTTL)
+		}
+	}
+
+	maxAccess := tokens.MaxAccessTTL()
+	if tokens.RefreshTTL <= maxAccess || tokens.RefreshTTL > maxRefreshTokenTTL {
+		return fmt.Errorf("REFRESH_TOKEN_TTL (%s) must exceed the longest access token TTL (%s) and be at most %s", tokens.RefreshTTL, maxAccess, maxRefreshTokenTTL)
+	}
+	if jwt.KeyRetireAfter < maxAccess+jwt.JWKSMaxAge {
+		return fmt.Errorf("JWT_KEY_RETIRE_AFTER (%s) must cover the longest access token TTL (%s) plus JWKS_CACHE_MAX_AGE (%s)", jwt.KeyRetireAfter, maxAccess, jwt.JWKSMaxAge)
+	}
+	return nil
+}
+
+// parseTTLOverrides parses the JSON array of TOKEN_TTL_OVERRIDES.
+func parseTTLOverrides(value string) ([]TTLOverride, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var raw []struct {
+		Audience    string `json:"audience"`
+		Tenant      string `json:"tenant"`
+		SubjectType string `json:"subject_type"`
+		TTL         string `json:"ttl"`
+	}
+	if err := json.Unmarshal([]byte(value), &raw); err != nil {
+		return nil, fmt.Errorf("invalid TOKEN_TTL_OVERRIDES: %w", err)
+	}
+
+	overrides := make([]TTLOverride, 0, len(raw))
+	for i, r := range raw {
+		if r.Audience == "" && r.Tenant == "" && r.SubjectType == "" {
+			return nil, fmt.Errorf("invalid TOKEN_TTL_OVERRIDES[%d]: set at least one of audience, tenant, subject_type", i)
+		}
+		ttl, err := time.ParseDuration(r.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TOKEN_TTL_OVERRIDES[%d] ttl %q: %w", i, r.TTL, err)
+		}
+		overrides = append(overrides, TTLOverride{
+			Audience:    r.Audience,
+			Tenant:      r.Tenant,
+			SubjectType: r.SubjectType,
+			TTL:         ttl,
+		})
+	}
+	return overrides, nil
+}
+
+// parseAllowlist parses the JSON object of INTERNAL_ALLOWLIST, e.g.
+// {"postInternalV1BackofficeTokens":["auth-service"]}.
+func parseAllowlist(value string) (map[string][]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var allow map[string][]string
+	if err := json.Unmarshal([]byte(value), &allow); err != nil {
+		return nil, fmt.Errorf("invalid INTERNAL_ALLOWLIST: %w", err)
+	}
+	for op, callers := range allow {
+		if op == "" || len(callers) == 0 {
+			return nil, fmt.Errorf("invalid INTERNAL_ALLOWLIST: operation %q needs at least one caller", op)
+		}
+	}
+	return allow, nil
+}
+
+// parseAudienceAlgs parses JWT_AUDIENCE_ALGS, a comma-separated list of
+// audience:alg pairs. Audiences may contain colons (e.g. URNs), so the pair
+// is split at the last one.
+func parseAudienceAlgs(value string) (map[string]string, error) {
+	algs := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		i := strings.LastIndex(pair, ":")
+		if i <= 0 || i == len(pair)-1 {
+			return nil, fmt.Errorf("invalid JWT_AUDIENCE_ALGS entry %q: want audience:alg", pair)
+		}
+		audience, alg := pair[:i], pair[i+1:]
+		if _, dup := algs[audience]; dup {
+			return nil, fmt.Errorf("invalid JWT_AUDIENCE_ALGS: duplicate audience %q", audience)
+		}
+		algs[audience] = alg
+	}
+	return algs, nil
+}
+
+// parsePairs parses a comma-separated list of name:value pairs, such as
+// client_id:secret or caller:public_key.
+func parsePairs(key, value string) (map[string]string, error) {
+	pairs := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, v, ok := strings.Cut(pair, ":")
+		if !ok || name == "" || v == "" {
+			return nil, fmt.Errorf("invalid %s entry %q: want name:value", key, name)
+		}
+		if _, dup := pairs[name]; dup {
+			return nil, fmt.Errorf("invalid %s: duplicate entry %q", key, name)
+		}
+		pairs[name] = v
+	}
+	return pairs, nil
 }